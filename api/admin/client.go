@@ -9,6 +9,7 @@ import (
 	"github.com/shubhamdubey02/cryftgo/api"
 	"github.com/shubhamdubey02/cryftgo/database/rpcdb"
 	"github.com/shubhamdubey02/cryftgo/ids"
+	"github.com/shubhamdubey02/cryftgo/network"
 	"github.com/shubhamdubey02/cryftgo/utils/formatting"
 	"github.com/shubhamdubey02/cryftgo/utils/logging"
 	"github.com/shubhamdubey02/cryftgo/utils/rpc"
@@ -31,6 +32,9 @@ type Client interface {
 	GetLoggerLevel(ctx context.Context, loggerName string, options ...rpc.Option) (map[string]LogAndDisplayLevels, error)
 	GetConfig(ctx context.Context, options ...rpc.Option) (interface{}, error)
 	DBGet(ctx context.Context, key []byte, options ...rpc.Option) ([]byte, error)
+	CreateBackup(ctx context.Context, path string, options ...rpc.Option) (*CreateBackupReply, error)
+	SetConnectionPolicy(ctx context.Context, policy network.ConnectionPolicyConfig, options ...rpc.Option) (network.ConnectionPolicyConfig, error)
+	GetConnectionPolicy(ctx context.Context, options ...rpc.Option) (network.ConnectionPolicyConfig, error)
 }
 
 // Client implementation for the Avalanche Platform Info API Endpoint
@@ -163,3 +167,25 @@ func (c *client) DBGet(ctx context.Context, key []byte, options ...rpc.Option) (
 	}
 	return formatting.Decode(formatting.HexNC, res.Value)
 }
+
+func (c *client) CreateBackup(ctx context.Context, path string, options ...rpc.Option) (*CreateBackupReply, error) {
+	res := &CreateBackupReply{}
+	err := c.requester.SendRequest(ctx, "admin.createBackup", &CreateBackupArgs{
+		Path: path,
+	}, res, options...)
+	return res, err
+}
+
+func (c *client) SetConnectionPolicy(ctx context.Context, policy network.ConnectionPolicyConfig, options ...rpc.Option) (network.ConnectionPolicyConfig, error) {
+	res := &ConnectionPolicyReply{}
+	err := c.requester.SendRequest(ctx, "admin.setConnectionPolicy", &ConnectionPolicyArgs{
+		Policy: policy,
+	}, res, options...)
+	return res.Policy, err
+}
+
+func (c *client) GetConnectionPolicy(ctx context.Context, options ...rpc.Option) (network.ConnectionPolicyConfig, error) {
+	res := &ConnectionPolicyReply{}
+	err := c.requester.SendRequest(ctx, "admin.getConnectionPolicy", struct{}{}, res, options...)
+	return res.Policy, err
+}
@@ -12,6 +12,7 @@ import (
 
 	"github.com/shubhamdubey02/cryftgo/api"
 	"github.com/shubhamdubey02/cryftgo/ids"
+	"github.com/shubhamdubey02/cryftgo/network"
 	"github.com/shubhamdubey02/cryftgo/utils/logging"
 	"github.com/shubhamdubey02/cryftgo/utils/rpc"
 )
@@ -65,6 +66,12 @@ func (mc *mockClient) SendRequest(_ context.Context, _ string, _ interface{}, re
 	case *LoggerLevelReply:
 		response := mc.response.(*LoggerLevelReply)
 		*p = *response
+	case *CreateBackupReply:
+		response := mc.response.(*CreateBackupReply)
+		*p = *response
+	case *ConnectionPolicyReply:
+		response := mc.response.(*ConnectionPolicyReply)
+		*p = *response
 	case *interface{}:
 		response := mc.response.(*interface{})
 		*p = *response
@@ -356,3 +363,57 @@ func TestGetConfig(t *testing.T) {
 		})
 	}
 }
+
+func TestCreateBackup(t *testing.T) {
+	t.Run("successful", func(t *testing.T) {
+		require := require.New(t)
+
+		expectedReply := &CreateBackupReply{
+			NumKeys:  1,
+			NumBytes: 10,
+			Checksum: ids.GenerateTestID(),
+		}
+		mockClient := client{requester: NewMockClient(expectedReply, nil)}
+		reply, err := mockClient.CreateBackup(context.Background(), "db.bak")
+		require.NoError(err)
+		require.Equal(expectedReply, reply)
+	})
+
+	t.Run("failure", func(t *testing.T) {
+		mockClient := client{requester: NewMockClient(&CreateBackupReply{}, errTest)}
+		_, err := mockClient.CreateBackup(context.Background(), "db.bak")
+		require.ErrorIs(t, err, errTest)
+	})
+}
+
+func TestConnectionPolicy(t *testing.T) {
+	policy := network.ConnectionPolicyConfig{
+		DeniedNodeIDs:         []ids.NodeID{ids.GenerateTestNodeID()},
+		AllowedCIDRs:          []string{"10.0.0.0/8"},
+		ValidatorsOnlyInbound: true,
+	}
+
+	t.Run("set", func(t *testing.T) {
+		require := require.New(t)
+
+		mockClient := client{requester: NewMockClient(&ConnectionPolicyReply{Policy: policy}, nil)}
+		reply, err := mockClient.SetConnectionPolicy(context.Background(), policy)
+		require.NoError(err)
+		require.Equal(policy, reply)
+	})
+
+	t.Run("get", func(t *testing.T) {
+		require := require.New(t)
+
+		mockClient := client{requester: NewMockClient(&ConnectionPolicyReply{Policy: policy}, nil)}
+		reply, err := mockClient.GetConnectionPolicy(context.Background())
+		require.NoError(err)
+		require.Equal(policy, reply)
+	})
+
+	t.Run("failure", func(t *testing.T) {
+		mockClient := client{requester: NewMockClient(&ConnectionPolicyReply{}, errTest)}
+		_, err := mockClient.SetConnectionPolicy(context.Background(), policy)
+		require.ErrorIs(t, err, errTest)
+	})
+}
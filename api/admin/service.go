@@ -5,6 +5,7 @@ package admin
 
 import (
 	"errors"
+	"fmt"
 	"net/http"
 	"path"
 	"sync"
@@ -16,8 +17,11 @@ import (
 	"github.com/shubhamdubey02/cryftgo/api/server"
 	"github.com/shubhamdubey02/cryftgo/chains"
 	"github.com/shubhamdubey02/cryftgo/database"
+	"github.com/shubhamdubey02/cryftgo/database/backup"
 	"github.com/shubhamdubey02/cryftgo/database/rpcdb"
 	"github.com/shubhamdubey02/cryftgo/ids"
+	"github.com/shubhamdubey02/cryftgo/network"
+	"github.com/shubhamdubey02/cryftgo/snow/engine/snowman/polltrace"
 	"github.com/shubhamdubey02/cryftgo/utils"
 	"github.com/shubhamdubey02/cryftgo/utils/constants"
 	"github.com/shubhamdubey02/cryftgo/utils/formatting"
@@ -41,6 +45,8 @@ const (
 var (
 	errAliasTooLong = errors.New("alias length is too long")
 	errNoLogLevel   = errors.New("need to specify either displayLevel or logLevel")
+	errNoPath       = errors.New("need to specify a path")
+	errNoTrace      = errors.New("no consensus trace for block")
 )
 
 type Config struct {
@@ -53,6 +59,9 @@ type Config struct {
 	HTTPServer   server.PathAdderWithReadLock
 	VMRegistry   registry.VMRegistry
 	VMManager    vms.Manager
+	Network      network.Network
+	// Records the polls applied to the blocks of each chain
+	ConsensusTraces *polltrace.Manager
 }
 
 // Admin is the API service for node admin management
@@ -60,6 +69,10 @@ type Admin struct {
 	Config
 	lock     sync.RWMutex
 	profiler profiler.Profiler
+
+	// backupLock prevents concurrent backups without blocking the rest of
+	// the API while a backup is being written.
+	backupLock sync.Mutex
 }
 
 // NewService returns a new admin API service.
@@ -413,3 +426,135 @@ func (a *Admin) DbGet(_ *http.Request, args *DBGetArgs, reply *DBGetReply) error
 	reply.Value, err = formatting.Encode(formatting.HexNC, value)
 	return err
 }
+
+type CreateBackupArgs struct {
+	Path string `json:"path"`
+}
+
+type CreateBackupReply struct {
+	NumKeys  json.Uint64 `json:"numKeys"`
+	NumBytes json.Uint64 `json:"numBytes"`
+	Checksum ids.ID      `json:"checksum"`
+}
+
+// CreateBackup writes a consistent copy of the node's database to a new file
+// at [args.Path]. The node continues to serve requests while the backup is
+// written.
+func (a *Admin) CreateBackup(_ *http.Request, args *CreateBackupArgs, reply *CreateBackupReply) error {
+	a.Log.Debug("API called",
+		zap.String("service", "admin"),
+		zap.String("method", "createBackup"),
+		logging.UserString("path", args.Path),
+	)
+
+	if len(args.Path) == 0 {
+		return errNoPath
+	}
+
+	a.backupLock.Lock()
+	defer a.backupLock.Unlock()
+
+	snapshot, err := database.NewSnapshot(a.DB)
+	if err != nil {
+		return err
+	}
+	defer snapshot.Release()
+
+	info, err := backup.Create(snapshot, args.Path)
+	if err != nil {
+		return err
+	}
+
+	a.Log.Info("created database backup",
+		zap.String("path", args.Path),
+		zap.Uint64("numKeys", info.NumKeys),
+		zap.Uint64("numBytes", info.NumBytes),
+		zap.Stringer("checksum", info.Checksum),
+	)
+
+	reply.NumKeys = json.Uint64(info.NumKeys)
+	reply.NumBytes = json.Uint64(info.NumBytes)
+	reply.Checksum = info.Checksum
+	return nil
+}
+
+type ConnectionPolicyArgs struct {
+	Policy network.ConnectionPolicyConfig `json:"policy"`
+}
+
+type ConnectionPolicyReply struct {
+	Policy network.ConnectionPolicyConfig `json:"policy"`
+}
+
+// SetConnectionPolicy replaces the node's peer connection policy. Connected
+// peers that are no longer allowed by the new policy are disconnected.
+func (a *Admin) SetConnectionPolicy(_ *http.Request, args *ConnectionPolicyArgs, reply *ConnectionPolicyReply) error {
+	a.Log.Debug("API called",
+		zap.String("service", "admin"),
+		zap.String("method", "setConnectionPolicy"),
+		zap.Int("numAllowedNodeIDs", len(args.Policy.AllowedNodeIDs)),
+		zap.Int("numDeniedNodeIDs", len(args.Policy.DeniedNodeIDs)),
+		zap.Int("numAllowedCIDRs", len(args.Policy.AllowedCIDRs)),
+		zap.Int("numDeniedCIDRs", len(args.Policy.DeniedCIDRs)),
+		zap.Bool("validatorsOnlyInbound", args.Policy.ValidatorsOnlyInbound),
+	)
+
+	a.lock.Lock()
+	defer a.lock.Unlock()
+
+	if err := a.Network.SetConnectionPolicy(args.Policy); err != nil {
+		return err
+	}
+
+	a.Log.Info("updated connection policy",
+		zap.Int("numAllowedNodeIDs", len(args.Policy.AllowedNodeIDs)),
+		zap.Int("numDeniedNodeIDs", len(args.Policy.DeniedNodeIDs)),
+		zap.Int("numAllowedCIDRs", len(args.Policy.AllowedCIDRs)),
+		zap.Int("numDeniedCIDRs", len(args.Policy.DeniedCIDRs)),
+		zap.Bool("validatorsOnlyInbound", args.Policy.ValidatorsOnlyInbound),
+	)
+
+	reply.Policy = a.Network.ConnectionPolicy()
+	return nil
+}
+
+// GetConnectionPolicy returns the node's current peer connection policy.
+func (a *Admin) GetConnectionPolicy(_ *http.Request, _ *struct{}, reply *ConnectionPolicyReply) error {
+	a.Log.Debug("API called",
+		zap.String("service", "admin"),
+		zap.String("method", "getConnectionPolicy"),
+	)
+
+	a.lock.RLock()
+	defer a.lock.RUnlock()
+
+	reply.Policy = a.Network.ConnectionPolicy()
+	return nil
+}
+
+type GetConsensusTraceArgs struct {
+	BlockID ids.ID `json:"blockID"`
+}
+
+type GetConsensusTraceReply struct {
+	ChainID ids.ID          `json:"chainID"`
+	Trace   polltrace.Trace `json:"trace"`
+}
+
+// GetConsensusTrace returns the polls that were applied to a processing or
+// recently decided block, to explain how long it took to be decided.
+func (a *Admin) GetConsensusTrace(_ *http.Request, args *GetConsensusTraceArgs, reply *GetConsensusTraceReply) error {
+	a.Log.Debug("API called",
+		zap.String("service", "admin"),
+		zap.String("method", "getConsensusTrace"),
+		zap.Stringer("blockID", args.BlockID),
+	)
+
+	chainID, trace, ok := a.ConsensusTraces.Trace(args.BlockID)
+	if !ok {
+		return fmt.Errorf("%w %s", errNoTrace, args.BlockID)
+	}
+	reply.ChainID = chainID
+	reply.Trace = trace
+	return nil
+}
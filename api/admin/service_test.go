@@ -5,14 +5,22 @@ package admin
 
 import (
 	"net/http"
+	"path/filepath"
 	"testing"
 
 	"github.com/stretchr/testify/require"
 	"go.uber.org/mock/gomock"
 
+	"github.com/shubhamdubey02/cryftgo/database"
+	"github.com/shubhamdubey02/cryftgo/database/backup"
+	"github.com/shubhamdubey02/cryftgo/database/corruptabledb"
 	"github.com/shubhamdubey02/cryftgo/database/memdb"
 	"github.com/shubhamdubey02/cryftgo/ids"
+	"github.com/shubhamdubey02/cryftgo/network"
+	"github.com/shubhamdubey02/cryftgo/snow/consensus/snowball"
+	"github.com/shubhamdubey02/cryftgo/snow/engine/snowman/polltrace"
 	"github.com/shubhamdubey02/cryftgo/utils/formatting"
+	"github.com/shubhamdubey02/cryftgo/utils/json"
 	"github.com/shubhamdubey02/cryftgo/utils/logging"
 	"github.com/shubhamdubey02/cryftgo/vms"
 	"github.com/shubhamdubey02/cryftgo/vms/registry"
@@ -167,3 +175,131 @@ func TestServiceDBGet(t *testing.T) {
 		})
 	}
 }
+
+func TestServiceCreateBackup(t *testing.T) {
+	require := require.New(t)
+
+	a := &Admin{Config: Config{
+		Log: logging.NoLog{},
+		DB:  memdb.New(),
+	}}
+	require.NoError(a.DB.Put([]byte("hello"), []byte("world")))
+
+	path := filepath.Join(t.TempDir(), "db.bak")
+	reply := &CreateBackupReply{}
+	require.NoError(a.CreateBackup(
+		nil,
+		&CreateBackupArgs{
+			Path: path,
+		},
+		reply,
+	))
+	require.Equal(json.Uint64(1), reply.NumKeys)
+	require.Equal(json.Uint64(10), reply.NumBytes)
+
+	restored := memdb.New()
+	info, err := backup.Restore(path, restored, backup.DefaultBatchSize)
+	require.NoError(err)
+	require.Equal(reply.Checksum, info.Checksum)
+
+	value, err := restored.Get([]byte("hello"))
+	require.NoError(err)
+	require.Equal([]byte("world"), value)
+
+	// Backups should never overwrite an existing file.
+	err = a.CreateBackup(nil, &CreateBackupArgs{Path: path}, &CreateBackupReply{})
+	require.ErrorIs(err, backup.ErrFileExists)
+
+	err = a.CreateBackup(nil, &CreateBackupArgs{}, &CreateBackupReply{})
+	require.ErrorIs(err, errNoPath)
+}
+
+func TestServiceCreateBackupNotSupported(t *testing.T) {
+	a := &Admin{Config: Config{
+		Log: logging.NoLog{},
+		DB:  corruptabledb.New(memdb.New()),
+	}}
+
+	err := a.CreateBackup(
+		nil,
+		&CreateBackupArgs{
+			Path: filepath.Join(t.TempDir(), "db.bak"),
+		},
+		&CreateBackupReply{},
+	)
+	require.ErrorIs(t, err, database.ErrSnapshotNotSupported)
+}
+
+type testNetwork struct {
+	network.Network
+
+	policy network.ConnectionPolicyConfig
+	err    error
+}
+
+func (n *testNetwork) ConnectionPolicy() network.ConnectionPolicyConfig {
+	return n.policy
+}
+
+func (n *testNetwork) SetConnectionPolicy(policy network.ConnectionPolicyConfig) error {
+	if n.err != nil {
+		return n.err
+	}
+	n.policy = policy
+	return nil
+}
+
+func TestServiceConnectionPolicy(t *testing.T) {
+	require := require.New(t)
+
+	net := &testNetwork{}
+	a := &Admin{Config: Config{
+		Log:     logging.NoLog{},
+		Network: net,
+	}}
+
+	policy := network.ConnectionPolicyConfig{
+		AllowedNodeIDs: []ids.NodeID{ids.GenerateTestNodeID()},
+		DeniedCIDRs:    []string{"192.168.0.0/16"},
+	}
+	setReply := &ConnectionPolicyReply{}
+	require.NoError(a.SetConnectionPolicy(nil, &ConnectionPolicyArgs{Policy: policy}, setReply))
+	require.Equal(policy, setReply.Policy)
+	require.Equal(policy, net.policy)
+
+	getReply := &ConnectionPolicyReply{}
+	require.NoError(a.GetConnectionPolicy(nil, nil, getReply))
+	require.Equal(policy, getReply.Policy)
+
+	// A rejected policy should not replace the current policy.
+	net.err = errTest
+	err := a.SetConnectionPolicy(nil, &ConnectionPolicyArgs{}, &ConnectionPolicyReply{})
+	require.ErrorIs(err, errTest)
+	require.Equal(policy, net.policy)
+}
+
+func TestServiceGetConsensusTrace(t *testing.T) {
+	require := require.New(t)
+
+	traces := polltrace.NewManager(1)
+	a := &Admin{Config: Config{
+		Log:             logging.NoLog{},
+		ConsensusTraces: traces,
+	}}
+
+	chainID := ids.GenerateTestID()
+	blkID := ids.GenerateTestID()
+
+	err := a.GetConsensusTrace(nil, &GetConsensusTraceArgs{BlockID: blkID}, &GetConsensusTraceReply{})
+	require.ErrorIs(err, errNoTrace)
+
+	tracer, err := traces.NewTracer(chainID, snowball.DefaultParameters)
+	require.NoError(err)
+	tracer.Issued(blkID, ids.Empty, 1)
+
+	reply := &GetConsensusTraceReply{}
+	require.NoError(a.GetConsensusTrace(nil, &GetConsensusTraceArgs{BlockID: blkID}, reply))
+	require.Equal(chainID, reply.ChainID)
+	require.Equal(blkID, reply.Trace.BlkID)
+	require.Equal(json.Uint64(1), reply.Trace.Height)
+}
@@ -7,6 +7,8 @@ import (
 	"errors"
 	"fmt"
 	"net/http"
+	"slices"
+	"time"
 
 	"github.com/gorilla/rpc/v2"
 	"go.uber.org/zap"
@@ -16,6 +18,7 @@ import (
 	"github.com/shubhamdubey02/cryftgo/network"
 	"github.com/shubhamdubey02/cryftgo/network/peer"
 	"github.com/shubhamdubey02/cryftgo/snow/networking/benchlist"
+	"github.com/shubhamdubey02/cryftgo/snow/networking/reputation"
 	"github.com/shubhamdubey02/cryftgo/snow/validators"
 	"github.com/shubhamdubey02/cryftgo/utils/constants"
 	"github.com/shubhamdubey02/cryftgo/utils/ips"
@@ -42,6 +45,7 @@ type Info struct {
 	chainManager chains.Manager
 	vmManager    vms.Manager
 	benchlist    benchlist.Manager
+	reputation   reputation.Tracker
 }
 
 type Parameters struct {
@@ -70,6 +74,7 @@ func NewService(
 	myIP ips.DynamicIPPort,
 	network network.Network,
 	benchlist benchlist.Manager,
+	reputation reputation.Tracker,
 ) (http.Handler, error) {
 	server := rpc.NewServer()
 	codec := json.NewCodec()
@@ -85,6 +90,7 @@ func NewService(
 			myIP:         myIP,
 			networking:   network,
 			benchlist:    benchlist,
+			reputation:   reputation,
 		},
 		"info",
 	)
@@ -215,7 +221,14 @@ type PeersArgs struct {
 type Peer struct {
 	peer.Info
 
-	Benched []string `json:"benched"`
+	Benched         []string `json:"benched"`
+	ReputationScore float64  `json:"reputationScore"`
+}
+
+// BannedPeer is a peer that is banned because of its misbehavior
+type BannedPeer struct {
+	NodeID ids.NodeID `json:"nodeID"`
+	Until  time.Time  `json:"until"`
 }
 
 // PeersReply are the results from calling Peers
@@ -224,6 +237,11 @@ type PeersReply struct {
 	NumPeers json.Uint64 `json:"numPeers"`
 	// Each element is a peer
 	Peers []Peer `json:"peers"`
+	// Peers whose most recent connection was rejected by the connection
+	// policy, newest first
+	RejectedPeers []network.RejectedPeer `json:"rejectedPeers,omitempty"`
+	// Peers that are currently banned, sorted by NodeID
+	BannedPeers []BannedPeer `json:"bannedPeers,omitempty"`
 }
 
 // Peers returns the list of current validators
@@ -246,13 +264,32 @@ func (i *Info) Peers(_ *http.Request, args *PeersArgs, reply *PeersReply) error
 			benchedAliases[idx] = alias
 		}
 		peerInfo[index] = Peer{
-			Info:    peer,
-			Benched: benchedAliases,
+			Info:            peer,
+			Benched:         benchedAliases,
+			ReputationScore: i.reputation.Score(peer.ID),
 		}
 	}
 
 	reply.Peers = peerInfo
 	reply.NumPeers = json.Uint64(len(reply.Peers))
+
+	nodeIDs := set.Of(args.NodeIDs...)
+	for _, rejected := range i.networking.RejectedPeers() {
+		if nodeIDs.Len() == 0 || nodeIDs.Contains(rejected.NodeID) {
+			reply.RejectedPeers = append(reply.RejectedPeers, rejected)
+		}
+	}
+	for nodeID, until := range i.reputation.Bans() {
+		if nodeIDs.Len() == 0 || nodeIDs.Contains(nodeID) {
+			reply.BannedPeers = append(reply.BannedPeers, BannedPeer{
+				NodeID: nodeID,
+				Until:  until,
+			})
+		}
+	}
+	slices.SortFunc(reply.BannedPeers, func(a, b BannedPeer) int {
+		return a.NodeID.Compare(b.NodeID)
+	})
 	return nil
 }
 
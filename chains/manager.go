@@ -35,8 +35,10 @@ import (
 	"github.com/shubhamdubey02/cryftgo/snow/engine/common"
 	"github.com/shubhamdubey02/cryftgo/snow/engine/common/tracker"
 	"github.com/shubhamdubey02/cryftgo/snow/engine/snowman/block"
+	"github.com/shubhamdubey02/cryftgo/snow/engine/snowman/polltrace"
 	"github.com/shubhamdubey02/cryftgo/snow/engine/snowman/syncer"
 	"github.com/shubhamdubey02/cryftgo/snow/networking/handler"
+	"github.com/shubhamdubey02/cryftgo/snow/networking/reputation"
 	"github.com/shubhamdubey02/cryftgo/snow/networking/router"
 	"github.com/shubhamdubey02/cryftgo/snow/networking/sender"
 	"github.com/shubhamdubey02/cryftgo/snow/networking/timeout"
@@ -59,6 +61,7 @@ import (
 	"github.com/shubhamdubey02/cryftgo/vms/platformvm/warp"
 	"github.com/shubhamdubey02/cryftgo/vms/propertyfx"
 	"github.com/shubhamdubey02/cryftgo/vms/proposervm"
+	"github.com/shubhamdubey02/cryftgo/vms/proposervm/proposer"
 	"github.com/shubhamdubey02/cryftgo/vms/secp256k1fx"
 	"github.com/shubhamdubey02/cryftgo/vms/tracedvm"
 
@@ -227,6 +230,20 @@ type ManagerConfig struct {
 	// Tracks CPU/disk usage caused by each peer.
 	ResourceTracker timetracker.ResourceTracker
 
+	// Reports misbehavior of peers
+	Reputation reputation.Reporter
+
+	// Reports the uptimes of validators to the uptime proposer policy. The
+	// uptimes must be the same on every node, so the uptimes measured by this
+	// node can't be used. If nil, the uptime proposer policy can't be used.
+	ProposerUptimes proposer.UptimeState
+
+	// Records the polls applied to the blocks of each snowman engine
+	ConsensusTraces *polltrace.Manager
+
+	// Tracks how long peers take to respond to queries
+	LatencyTracker timetracker.LatencyTracker
+
 	StateSyncBeacons []ids.NodeID
 
 	ChainDataDir string
@@ -685,16 +702,24 @@ func (m *manager) createAvalancheChain(
 	var (
 		minBlockDelay       = proposervm.DefaultMinBlockDelay
 		numHistoricalBlocks = proposervm.DefaultNumHistoricalBlocks
+		proposerPolicy      proposer.Policy
+		proposerPolicyName  string
 	)
 	if subnetCfg, ok := m.SubnetConfigs[ctx.SubnetID]; ok {
 		minBlockDelay = subnetCfg.ProposerMinBlockDelay
 		numHistoricalBlocks = subnetCfg.ProposerNumHistoricalBlocks
+		proposerPolicy, err = subnetCfg.Policy(m.ProposerUptimes)
+		if err != nil {
+			return nil, fmt.Errorf("error while parsing proposer policy: %w", err)
+		}
+		proposerPolicyName = subnetCfg.ProposerPolicy
 	}
 	m.Log.Info("creating proposervm wrapper",
 		zap.Time("activationTime", m.ApricotPhase4Time),
 		zap.Uint64("minPChainHeight", m.ApricotPhase4MinPChainHeight),
 		zap.Duration("minBlockDelay", minBlockDelay),
 		zap.Uint64("numHistoricalBlocks", numHistoricalBlocks),
+		zap.String("proposerPolicy", proposerPolicyName),
 	)
 
 	chainAlias := m.PrimaryAliasOrDefault(ctx.ChainID)
@@ -719,6 +744,7 @@ func (m *manager) createAvalancheChain(
 			NumHistoricalBlocks: numHistoricalBlocks,
 			StakingLeafSigner:   m.StakingTLSSigner,
 			StakingCertLeaf:     m.StakingTLSCert,
+			ProposerPolicy:      proposerPolicy,
 		},
 	)
 
@@ -813,6 +839,11 @@ func (m *manager) createAvalancheChain(
 		snowmanConsensus = smcon.Trace(snowmanConsensus, m.Tracer)
 	}
 
+	pollTracer, err := m.ConsensusTraces.NewTracer(ctx.ChainID, consensusParams)
+	if err != nil {
+		return nil, fmt.Errorf("couldn't initialize poll tracer: %w", err)
+	}
+
 	// Create engine, bootstrapper and state-syncer in this order,
 	// to make sure start callbacks are duly initialized
 	snowmanEngineConfig := smeng.Config{
@@ -824,6 +855,9 @@ func (m *manager) createAvalancheChain(
 		ConnectedValidators: connectedValidators,
 		Params:              consensusParams,
 		Consensus:           snowmanConsensus,
+		Reputation:          m.Reputation,
+		PollTracer:          pollTracer,
+		LatencyTracker:      m.LatencyTracker,
 	}
 	var snowmanEngine common.Engine
 	snowmanEngine, err = smeng.New(snowmanEngineConfig)
@@ -1037,16 +1071,24 @@ func (m *manager) createSnowmanChain(
 	var (
 		minBlockDelay       = proposervm.DefaultMinBlockDelay
 		numHistoricalBlocks = proposervm.DefaultNumHistoricalBlocks
+		proposerPolicy      proposer.Policy
+		proposerPolicyName  string
 	)
 	if subnetCfg, ok := m.SubnetConfigs[ctx.SubnetID]; ok {
 		minBlockDelay = subnetCfg.ProposerMinBlockDelay
 		numHistoricalBlocks = subnetCfg.ProposerNumHistoricalBlocks
+		proposerPolicy, err = subnetCfg.Policy(m.ProposerUptimes)
+		if err != nil {
+			return nil, fmt.Errorf("error while parsing proposer policy: %w", err)
+		}
+		proposerPolicyName = subnetCfg.ProposerPolicy
 	}
 	m.Log.Info("creating proposervm wrapper",
 		zap.Time("activationTime", m.ApricotPhase4Time),
 		zap.Uint64("minPChainHeight", m.ApricotPhase4MinPChainHeight),
 		zap.Duration("minBlockDelay", minBlockDelay),
 		zap.Uint64("numHistoricalBlocks", numHistoricalBlocks),
+		zap.String("proposerPolicy", proposerPolicyName),
 	)
 
 	chainAlias := m.PrimaryAliasOrDefault(ctx.ChainID)
@@ -1064,6 +1106,7 @@ func (m *manager) createSnowmanChain(
 			NumHistoricalBlocks: numHistoricalBlocks,
 			StakingLeafSigner:   m.StakingTLSSigner,
 			StakingCertLeaf:     m.StakingTLSCert,
+			ProposerPolicy:      proposerPolicy,
 		},
 	)
 
@@ -1158,6 +1201,11 @@ func (m *manager) createSnowmanChain(
 		consensus = smcon.Trace(consensus, m.Tracer)
 	}
 
+	pollTracer, err := m.ConsensusTraces.NewTracer(ctx.ChainID, consensusParams)
+	if err != nil {
+		return nil, fmt.Errorf("couldn't initialize poll tracer: %w", err)
+	}
+
 	// Create engine, bootstrapper and state-syncer in this order,
 	// to make sure start callbacks are duly initialized
 	engineConfig := smeng.Config{
@@ -1169,6 +1217,9 @@ func (m *manager) createSnowmanChain(
 		ConnectedValidators: connectedValidators,
 		Params:              consensusParams,
 		Consensus:           consensus,
+		Reputation:          m.Reputation,
+		PollTracer:          pollTracer,
+		LatencyTracker:      m.LatencyTracker,
 		PartialSync:         m.PartialSyncPrimaryNetwork && ctx.ChainID == constants.PlatformChainID,
 	}
 	var engine common.Engine
@@ -11,6 +11,7 @@ import (
 	"fmt"
 	"io/fs"
 	"math"
+	"net"
 	"os"
 	"path/filepath"
 	"strings"
@@ -24,10 +25,12 @@ import (
 	"github.com/shubhamdubey02/cryftgo/ids"
 	"github.com/shubhamdubey02/cryftgo/network"
 	"github.com/shubhamdubey02/cryftgo/network/dialer"
+	"github.com/shubhamdubey02/cryftgo/network/peer"
 	"github.com/shubhamdubey02/cryftgo/network/throttling"
 	"github.com/shubhamdubey02/cryftgo/node"
 	"github.com/shubhamdubey02/cryftgo/snow/consensus/snowball"
 	"github.com/shubhamdubey02/cryftgo/snow/networking/benchlist"
+	"github.com/shubhamdubey02/cryftgo/snow/networking/reputation"
 	"github.com/shubhamdubey02/cryftgo/snow/networking/router"
 	"github.com/shubhamdubey02/cryftgo/snow/networking/tracker"
 	"github.com/shubhamdubey02/cryftgo/staking"
@@ -294,6 +297,11 @@ func getNetworkConfig(
 	supportedACPs.Difference(constants.ActivatedACPs)
 	objectedACPs.Difference(constants.ActivatedACPs)
 
+	connectionPolicyConfig, err := getConnectionPolicyConfig(v)
+	if err != nil {
+		return network.Config{}, err
+	}
+
 	config := network.Config{
 		ThrottlerConfig: network.ThrottlerConfig{
 			MaxInboundConnsPerSec: maxInboundConnsPerSec,
@@ -348,6 +356,13 @@ func getNetworkConfig(
 
 		TLSKeyLogFile: v.GetString(NetworkTLSKeyLogFileKey),
 
+		RecorderEnabled: v.GetBool(NetworkRecorderEnabledKey),
+		RecorderConfig: peer.RecorderConfig{
+			Directory: GetExpandedArg(v, NetworkRecorderDirKey),
+			MaxSize:   int(v.GetUint(NetworkRecorderMaxSizeKey)),
+			MaxFiles:  int(v.GetUint(NetworkRecorderMaxFilesKey)),
+		},
+
 		TimeoutConfig: network.TimeoutConfig{
 			PingPongTimeout:      v.GetDuration(NetworkPingTimeoutKey),
 			ReadHandshakeTimeout: v.GetDuration(NetworkReadHandshakeTimeoutKey),
@@ -374,6 +389,7 @@ func getNetworkConfig(
 		SupportedACPs: supportedACPs,
 		ObjectedACPs:  objectedACPs,
 
+		ConnectionPolicyConfig:    connectionPolicyConfig,
 		RequireValidatorToConnect: v.GetBool(NetworkRequireValidatorToConnectKey),
 		PeerReadBufferSize:        int(v.GetUint(NetworkPeerReadBufferSizeKey)),
 		PeerWriteBufferSize:       int(v.GetUint(NetworkPeerWriteBufferSizeKey)),
@@ -410,6 +426,8 @@ func getNetworkConfig(
 		return network.Config{}, fmt.Errorf("%s must be >= 0", NetworkPingFrequencyKey)
 	case config.PingPongTimeout <= config.PingFrequency:
 		return network.Config{}, fmt.Errorf("%s must be > %s", NetworkPingTimeoutKey, NetworkPingFrequencyKey)
+	case config.RecorderEnabled && config.RecorderConfig.MaxSize <= 0:
+		return network.Config{}, fmt.Errorf("%s must be > 0", NetworkRecorderMaxSizeKey)
 	case config.ReadHandshakeTimeout < 0:
 		return network.Config{}, fmt.Errorf("%s must be >= 0", NetworkReadHandshakeTimeoutKey)
 	case config.MaxClockDifference < 0:
@@ -418,6 +436,48 @@ func getNetworkConfig(
 	return config, nil
 }
 
+func getConnectionPolicyConfig(v *viper.Viper) (network.ConnectionPolicyConfig, error) {
+	config := network.ConnectionPolicyConfig{
+		AllowedCIDRs:          v.GetStringSlice(NetworkAllowedCIDRsKey),
+		DeniedCIDRs:           v.GetStringSlice(NetworkDeniedCIDRsKey),
+		ValidatorsOnlyInbound: v.GetBool(NetworkValidatorsOnlyInboundKey),
+	}
+
+	var err error
+	config.AllowedNodeIDs, err = parseNodeIDs(v.GetStringSlice(NetworkAllowedNodeIDsKey))
+	if err != nil {
+		return network.ConnectionPolicyConfig{}, fmt.Errorf("couldn't parse %s: %w", NetworkAllowedNodeIDsKey, err)
+	}
+	config.DeniedNodeIDs, err = parseNodeIDs(v.GetStringSlice(NetworkDeniedNodeIDsKey))
+	if err != nil {
+		return network.ConnectionPolicyConfig{}, fmt.Errorf("couldn't parse %s: %w", NetworkDeniedNodeIDsKey, err)
+	}
+
+	for _, cidr := range config.AllowedCIDRs {
+		if _, _, err := net.ParseCIDR(cidr); err != nil {
+			return network.ConnectionPolicyConfig{}, fmt.Errorf("couldn't parse %s: %w", NetworkAllowedCIDRsKey, err)
+		}
+	}
+	for _, cidr := range config.DeniedCIDRs {
+		if _, _, err := net.ParseCIDR(cidr); err != nil {
+			return network.ConnectionPolicyConfig{}, fmt.Errorf("couldn't parse %s: %w", NetworkDeniedCIDRsKey, err)
+		}
+	}
+	return config, nil
+}
+
+func parseNodeIDs(nodeIDStrs []string) ([]ids.NodeID, error) {
+	nodeIDs := make([]ids.NodeID, len(nodeIDStrs))
+	for i, nodeIDStr := range nodeIDStrs {
+		nodeID, err := ids.NodeIDFromString(nodeIDStr)
+		if err != nil {
+			return nil, err
+		}
+		nodeIDs[i] = nodeID
+	}
+	return nodeIDs, nil
+}
+
 func getBenchlistConfig(v *viper.Viper, consensusParameters snowball.Parameters) (benchlist.Config, error) {
 	// AlphaConfidence is used here to ensure that benching can't cause a
 	// liveness failure. If AlphaPreference were used, the benchlist may grow to
@@ -439,6 +499,23 @@ func getBenchlistConfig(v *viper.Viper, consensusParameters snowball.Parameters)
 	return config, nil
 }
 
+func getReputationConfig(v *viper.Viper) (reputation.Config, error) {
+	config := reputation.Config{
+		InvalidMessagePenalty:    v.GetFloat64(ReputationInvalidMessagePenaltyKey),
+		InvalidBlockPenalty:      v.GetFloat64(ReputationInvalidBlockPenaltyKey),
+		InvalidSignaturePenalty:  v.GetFloat64(ReputationInvalidSignaturePenaltyKey),
+		InvalidAppRequestPenalty: v.GetFloat64(ReputationInvalidAppRequestPenaltyKey),
+		Halflife:                 v.GetDuration(ReputationHalflifeKey),
+		DisconnectThreshold:      v.GetFloat64(ReputationDisconnectThresholdKey),
+		BanThreshold:             v.GetFloat64(ReputationBanThresholdKey),
+		BanDuration:              v.GetDuration(ReputationBanDurationKey),
+	}
+	if err := config.Verify(); err != nil {
+		return reputation.Config{}, fmt.Errorf("invalid reputation config: %w", err)
+	}
+	return config, nil
+}
+
 func getStateSyncConfig(v *viper.Viper) (node.StateSyncConfig, error) {
 	var (
 		config       = node.StateSyncConfig{}
@@ -845,7 +922,8 @@ func getDatabaseConfig(v *viper.Viper, networkID uint32) (node.DatabaseConfig, e
 			GetExpandedArg(v, DBPathKey),
 			constants.NetworkName(networkID),
 		),
-		Config: configBytes,
+		Config:      configBytes,
+		RestorePath: GetExpandedArg(v, DBRestoreFromKey),
 	}, nil
 }
 
@@ -984,6 +1062,24 @@ func readChainConfigPath(chainConfigPath string) (map[string]chains.ChainConfig,
 	return chainConfigMap, nil
 }
 
+// getSubnetMsgByteQuotas returns the inbound and outbound message byte quotas
+// of the subnets in [subnetConfigs] that set them.
+func getSubnetMsgByteQuotas(subnetConfigs map[ids.ID]subnets.Config) (map[ids.ID]uint64, map[ids.ID]uint64) {
+	var (
+		inboundQuotas  = make(map[ids.ID]uint64)
+		outboundQuotas = make(map[ids.ID]uint64)
+	)
+	for subnetID, config := range subnetConfigs {
+		if config.InboundMsgByteQuota != 0 {
+			inboundQuotas[subnetID] = config.InboundMsgByteQuota
+		}
+		if config.OutboundMsgByteQuota != 0 {
+			outboundQuotas[subnetID] = config.OutboundMsgByteQuota
+		}
+	}
+	return inboundQuotas, outboundQuotas
+}
+
 // getSubnetConfigs reads subnet configs from the correct place
 // (flag or file) and returns a non-nil map.
 func getSubnetConfigs(v *viper.Viper, subnetIDs []ids.ID) (map[ids.ID]subnets.Config, error) {
@@ -1220,6 +1316,9 @@ func GetNodeConfig(v *viper.Viper) (node.Config, error) {
 		return node.Config{}, fmt.Errorf("%s must be >= 0", ConsensusFrontierPollFrequencyKey)
 	}
 
+	// Consensus tracing
+	nodeConfig.ConsensusTraceSize = int(v.GetUint(ConsensusTraceSizeKey))
+
 	// App handling
 	nodeConfig.ConsensusAppConcurrency = int(v.GetUint(ConsensusAppConcurrencyKey))
 	if nodeConfig.ConsensusAppConcurrency <= 0 {
@@ -1321,12 +1420,22 @@ func GetNodeConfig(v *viper.Viper) (node.Config, error) {
 
 	nodeConfig.SubnetConfigs = subnetConfigs
 
+	// Subnet Message Byte Quotas
+	throttlerConfig := &nodeConfig.NetworkConfig.ThrottlerConfig
+	throttlerConfig.InboundMsgThrottlerConfig.SubnetQuotas, throttlerConfig.OutboundMsgThrottlerConfig.SubnetQuotas = getSubnetMsgByteQuotas(subnetConfigs)
+
 	// Benchlist
 	nodeConfig.BenchlistConfig, err = getBenchlistConfig(v, primaryNetworkConfig.ConsensusParameters)
 	if err != nil {
 		return node.Config{}, err
 	}
 
+	// Reputation
+	nodeConfig.ReputationConfig, err = getReputationConfig(v)
+	if err != nil {
+		return node.Config{}, err
+	}
+
 	// File Descriptor Limit
 	nodeConfig.FdLimit = v.GetUint64(FdLimitKey)
 
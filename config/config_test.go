@@ -421,6 +421,23 @@ func TestGetSubnetConfigsFromFile(t *testing.T) {
 			},
 			expectedErr: nil,
 		},
+		"message byte quotas": {
+			fileName:  "2Ctt6eGAeo4MLqTmGa7AdRecuVMPGWEX9wSsCLBYrLhX4a394i.json",
+			givenJSON: `{"inboundMsgByteQuota": 1024}`,
+			testF: func(require *require.Assertions, given map[ids.ID]subnets.Config) {
+				id, _ := ids.FromString("2Ctt6eGAeo4MLqTmGa7AdRecuVMPGWEX9wSsCLBYrLhX4a394i")
+				config, ok := given[id]
+				require.True(ok)
+
+				require.Equal(uint64(1024), config.InboundMsgByteQuota)
+				require.Zero(config.OutboundMsgByteQuota)
+
+				inboundQuotas, outboundQuotas := getSubnetMsgByteQuotas(given)
+				require.Equal(map[ids.ID]uint64{id: 1024}, inboundQuotas)
+				require.Empty(outboundQuotas)
+			},
+			expectedErr: nil,
+		},
 	}
 
 	for name, test := range tests {
@@ -42,6 +42,7 @@ var (
 	defaultDBDir                = filepath.Join(defaultUnexpandedDataDir, "db")
 	defaultLogDir               = filepath.Join(defaultUnexpandedDataDir, "logs")
 	defaultProfileDir           = filepath.Join(defaultUnexpandedDataDir, "profiles")
+	defaultRecorderDir          = filepath.Join(defaultUnexpandedDataDir, "recordings")
 	defaultStakingPath          = filepath.Join(defaultUnexpandedDataDir, "staking")
 	defaultStakingTLSKeyPath    = filepath.Join(defaultStakingPath, "staker.key")
 	defaultStakingCertPath      = filepath.Join(defaultStakingPath, "staker.crt")
@@ -114,6 +115,7 @@ func addNodeFlags(fs *pflag.FlagSet) {
 	fs.String(DBPathKey, defaultDBDir, "Path to database directory")
 	fs.String(DBConfigFileKey, "", fmt.Sprintf("Path to database config file. Ignored if %s is specified", DBConfigContentKey))
 	fs.String(DBConfigContentKey, "", "Specifies base64 encoded database config content")
+	fs.String(DBRestoreFromKey, "", "Path to a database backup to restore from on startup. Ignored if the database is not empty")
 
 	// Logging
 	fs.String(LogsDirKey, defaultLogDir, "Logging directory for Avalanche")
@@ -161,6 +163,11 @@ func addNodeFlags(fs *pflag.FlagSet) {
 	// based on the networkID.
 	fs.Bool(NetworkAllowPrivateIPsKey, false, fmt.Sprintf("Allows the node to initiate outbound connection attempts to peers with private IPs. If the provided --%s is one of [%s, %s] the default is false. Oterhwise, the default is true", NetworkNameKey, constants.MainnetName, constants.MustangName))
 	fs.Bool(NetworkRequireValidatorToConnectKey, constants.DefaultNetworkRequireValidatorToConnect, "If true, this node will only maintain a connection with another node if this node is a validator, the other node is a validator, or the other node is a beacon")
+	fs.StringSlice(NetworkAllowedNodeIDsKey, nil, "If non-empty, this node will only connect to peers with one of these NodeIDs or with an IP in one of --"+NetworkAllowedCIDRsKey)
+	fs.StringSlice(NetworkDeniedNodeIDsKey, nil, "This node will never connect to peers with one of these NodeIDs")
+	fs.StringSlice(NetworkAllowedCIDRsKey, nil, "If non-empty, this node will only connect to peers with an IP in one of these CIDR ranges or with one of --"+NetworkAllowedNodeIDsKey)
+	fs.StringSlice(NetworkDeniedCIDRsKey, nil, "This node will never connect to peers with an IP in one of these CIDR ranges")
+	fs.Bool(NetworkValidatorsOnlyInboundKey, false, "If true, this node will only accept inbound connections from beacons, allowed NodeIDs, and validators of the primary network or of a tracked subnet")
 	fs.Uint(NetworkPeerReadBufferSizeKey, constants.DefaultNetworkPeerReadBufferSize, "Size, in bytes, of the buffer that we read peer messages into (there is one buffer per peer)")
 	fs.Uint(NetworkPeerWriteBufferSizeKey, constants.DefaultNetworkPeerWriteBufferSize, "Size, in bytes, of the buffer that we write peer messages into (there is one buffer per peer)")
 
@@ -174,15 +181,31 @@ func addNodeFlags(fs *pflag.FlagSet) {
 
 	fs.String(NetworkTLSKeyLogFileKey, "", "TLS key log file path. Should only be specified for debugging")
 
+	fs.Bool(NetworkRecorderEnabledKey, false, "If true, every message sent to and received from peers is recorded. Should only be enabled for debugging")
+	fs.String(NetworkRecorderDirKey, defaultRecorderDir, "Directory the recorded messages are written to")
+	fs.Uint(NetworkRecorderMaxSizeKey, constants.DefaultNetworkRecorderMaxSize, "Size, in megabytes, of a recording file before it is rotated")
+	fs.Uint(NetworkRecorderMaxFilesKey, constants.DefaultNetworkRecorderMaxFiles, "Number of rotated recording files to keep. If 0, all rotated files are kept")
+
 	// Benchlist
 	fs.Int(BenchlistFailThresholdKey, constants.DefaultBenchlistFailThreshold, "Number of consecutive failed queries before benchlisting a node")
 	fs.Duration(BenchlistDurationKey, constants.DefaultBenchlistDuration, "Max amount of time a peer is benchlisted after surpassing the threshold")
 	fs.Duration(BenchlistMinFailingDurationKey, constants.DefaultBenchlistMinFailingDuration, "Minimum amount of time messages to a peer must be failing before the peer is benched")
 
+	// Reputation
+	fs.Float64(ReputationInvalidMessagePenaltyKey, constants.DefaultReputationInvalidMessagePenalty, "Amount added to the reputation score of a peer when it sends a message that can't be parsed")
+	fs.Float64(ReputationInvalidBlockPenaltyKey, constants.DefaultReputationInvalidBlockPenalty, "Amount added to the reputation score of a peer when it sends a block that can't be parsed")
+	fs.Float64(ReputationInvalidSignaturePenaltyKey, constants.DefaultReputationInvalidSignaturePenalty, "Amount added to the reputation score of a peer when it sends an invalid signature")
+	fs.Float64(ReputationInvalidAppRequestPenaltyKey, constants.DefaultReputationInvalidAppRequestPenalty, "Amount added to the reputation score of a peer when a p2p handler fails to handle its request")
+	fs.Duration(ReputationHalflifeKey, constants.DefaultReputationHalflife, "Halflife of the reputation score of a peer")
+	fs.Float64(ReputationDisconnectThresholdKey, constants.DefaultReputationDisconnectThreshold, "Reputation score at which a peer is disconnected. If 0, peers are never disconnected because of their score")
+	fs.Float64(ReputationBanThresholdKey, constants.DefaultReputationBanThreshold, "Reputation score at which a peer is banned. If 0, peers are never banned")
+	fs.Duration(ReputationBanDurationKey, constants.DefaultReputationBanDuration, "Amount of time a peer is banned after reaching the ban threshold")
+
 	// Router
 	fs.Uint(ConsensusAppConcurrencyKey, constants.DefaultConsensusAppConcurrency, "Maximum number of goroutines to use when handling App messages on a chain")
 	fs.Duration(ConsensusShutdownTimeoutKey, constants.DefaultConsensusShutdownTimeout, "Timeout before killing an unresponsive chain")
 	fs.Duration(ConsensusFrontierPollFrequencyKey, constants.DefaultFrontierPollFrequency, "Frequency of polling for new consensus frontiers")
+	fs.Uint(ConsensusTraceSizeKey, constants.DefaultConsensusTraceSize, "Number of recently decided blocks of each chain whose consensus polls are kept for admin.getConsensusTrace. If 0, consensus polls aren't traced")
 
 	// Inbound Throttling
 	fs.Uint64(InboundThrottlerAtLargeAllocSizeKey, constants.DefaultInboundThrottlerAtLargeAllocSize, "Size, in bytes, of at-large byte allocation in inbound message throttler")
@@ -43,6 +43,7 @@ const (
 	DBPathKey                        = "db-dir"
 	DBConfigFileKey                  = "db-config-file"
 	DBConfigContentKey               = "db-config-file-content"
+	DBRestoreFromKey                 = "db-restore-from"
 	PublicIPKey                      = "public-ip"
 	PublicIPResolutionFreqKey        = "public-ip-resolution-frequency"
 	PublicIPResolutionServiceKey     = "public-ip-resolution-service"
@@ -102,11 +103,20 @@ const (
 	NetworkMaxClockDifferenceKey                       = "network-max-clock-difference"
 	NetworkAllowPrivateIPsKey                          = "network-allow-private-ips"
 	NetworkRequireValidatorToConnectKey                = "network-require-validator-to-connect"
+	NetworkAllowedNodeIDsKey                           = "network-allowed-node-ids"
+	NetworkDeniedNodeIDsKey                            = "network-denied-node-ids"
+	NetworkAllowedCIDRsKey                             = "network-allowed-cidrs"
+	NetworkDeniedCIDRsKey                              = "network-denied-cidrs"
+	NetworkValidatorsOnlyInboundKey                    = "network-validators-only-inbound"
 	NetworkPeerReadBufferSizeKey                       = "network-peer-read-buffer-size"
 	NetworkPeerWriteBufferSizeKey                      = "network-peer-write-buffer-size"
 	NetworkTCPProxyEnabledKey                          = "network-tcp-proxy-enabled"
 	NetworkTCPProxyReadTimeoutKey                      = "network-tcp-proxy-read-timeout"
 	NetworkTLSKeyLogFileKey                            = "network-tls-key-log-file-unsafe"
+	NetworkRecorderEnabledKey                          = "network-recorder-enabled"
+	NetworkRecorderDirKey                              = "network-recorder-dir"
+	NetworkRecorderMaxSizeKey                          = "network-recorder-max-size"
+	NetworkRecorderMaxFilesKey                         = "network-recorder-max-files"
 	NetworkInboundConnUpgradeThrottlerCooldownKey      = "network-inbound-connection-throttling-cooldown"
 	NetworkInboundThrottlerMaxConnsPerSecKey           = "network-inbound-connection-throttling-max-conns-per-sec"
 	NetworkOutboundConnectionThrottlingRpsKey          = "network-outbound-connection-throttling-rps"
@@ -114,6 +124,14 @@ const (
 	BenchlistFailThresholdKey                          = "benchlist-fail-threshold"
 	BenchlistDurationKey                               = "benchlist-duration"
 	BenchlistMinFailingDurationKey                     = "benchlist-min-failing-duration"
+	ReputationInvalidMessagePenaltyKey                 = "reputation-invalid-message-penalty"
+	ReputationInvalidBlockPenaltyKey                   = "reputation-invalid-block-penalty"
+	ReputationInvalidSignaturePenaltyKey               = "reputation-invalid-signature-penalty"
+	ReputationInvalidAppRequestPenaltyKey              = "reputation-invalid-app-request-penalty"
+	ReputationHalflifeKey                              = "reputation-halflife"
+	ReputationDisconnectThresholdKey                   = "reputation-disconnect-threshold"
+	ReputationBanThresholdKey                          = "reputation-ban-threshold"
+	ReputationBanDurationKey                           = "reputation-ban-duration"
 	LogsDirKey                                         = "log-dir"
 	LogLevelKey                                        = "log-level"
 	LogDisplayLevelKey                                 = "log-display-level"
@@ -143,6 +161,7 @@ const (
 	ConsensusAppConcurrencyKey                         = "consensus-app-concurrency"
 	ConsensusShutdownTimeoutKey                        = "consensus-shutdown-timeout"
 	ConsensusFrontierPollFrequencyKey                  = "consensus-frontier-poll-frequency"
+	ConsensusTraceSizeKey                              = "consensus-trace-size"
 	ProposerVMUseCurrentHeightKey                      = "proposervm-use-current-height"
 	FdLimitKey                                         = "fd-limit"
 	IndexEnabledKey                                    = "index-enabled"
@@ -0,0 +1,401 @@
+// Copyright (C) 2019-2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+// Package backup writes consistent, checksummed copies of a database to a
+// single file and restores databases from them.
+//
+// A backup file is laid out as:
+//
+//	magic (8 bytes) | version (4 bytes)
+//	repeated: 0x01 | uvarint(len(key)) | key | uvarint(len(value)) | value
+//	0x00 | numKeys (8 bytes) | sha256 of all preceding bytes (32 bytes)
+package backup
+
+import (
+	"bufio"
+	"bytes"
+	"crypto/sha256"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"hash"
+	"io"
+	"os"
+	"path/filepath"
+
+	"github.com/shubhamdubey02/cryftgo/database"
+	"github.com/shubhamdubey02/cryftgo/ids"
+	"github.com/shubhamdubey02/cryftgo/utils/perms"
+	"github.com/shubhamdubey02/cryftgo/utils/units"
+)
+
+const (
+	version uint32 = 0
+
+	recordEnd   byte = 0x00
+	recordEntry byte = 0x01
+
+	// maxEntryLen bounds the size of keys and values read from a backup to
+	// avoid large allocations when reading a corrupted file.
+	maxEntryLen = 256 * units.MiB
+
+	// DefaultBatchSize is the number of bytes written to a database before
+	// the batch is flushed during a restore.
+	DefaultBatchSize = 4 * units.MiB
+
+	tmpSuffix = ".tmp"
+)
+
+var (
+	magic = [8]byte{'c', 'r', 'y', 'f', 't', 'b', 'a', 'k'}
+
+	// restoringKey is written to a database before any entries are restored
+	// into it and is deleted along with the final batch of entries. If the
+	// key is present, the restore was interrupted.
+	restoringKey = []byte("backupRestoreInProgress")
+
+	ErrInvalidChecksum = errors.New("invalid checksum")
+	ErrNotEmpty        = errors.New("database is not empty")
+	ErrInterrupted     = errors.New("database restore was interrupted")
+	ErrFileExists      = errors.New("file already exists")
+
+	errInvalidMagic       = errors.New("invalid magic")
+	errUnsupportedVersion = errors.New("unsupported version")
+	errInvalidRecord      = errors.New("invalid record type")
+	errInvalidLength      = errors.New("invalid length")
+	errWrongNumKeys       = errors.New("wrong number of keys")
+)
+
+// Info describes the contents of a backup.
+type Info struct {
+	NumKeys  uint64 `json:"numKeys"`
+	NumBytes uint64 `json:"numBytes"`
+	Checksum ids.ID `json:"checksum"`
+}
+
+// Create writes the contents of [snapshot] to a new file at [path].
+//
+// The backup is written to a temporary file and moved to [path] once it has
+// been fully written and synced, so [path] never contains a partial backup.
+func Create(snapshot database.Iteratee, path string) (Info, error) {
+	if _, err := os.Stat(path); err == nil {
+		return Info{}, fmt.Errorf("%w: %s", ErrFileExists, path)
+	} else if !errors.Is(err, os.ErrNotExist) {
+		return Info{}, err
+	}
+
+	if err := os.MkdirAll(filepath.Dir(path), perms.ReadWriteExecute); err != nil {
+		return Info{}, fmt.Errorf("couldn't create backup directory: %w", err)
+	}
+
+	tmpPath := path + tmpSuffix
+	file, err := os.OpenFile(tmpPath, os.O_CREATE|os.O_EXCL|os.O_WRONLY, perms.ReadWrite)
+	if err != nil {
+		return Info{}, fmt.Errorf("couldn't create backup file: %w", err)
+	}
+
+	info, err := write(snapshot, file)
+	if err == nil {
+		err = file.Sync()
+	}
+	if closeErr := file.Close(); err == nil {
+		err = closeErr
+	}
+	if err == nil {
+		err = os.Rename(tmpPath, path)
+	}
+	if err != nil {
+		_ = os.Remove(tmpPath)
+		return Info{}, err
+	}
+	return info, nil
+}
+
+func write(snapshot database.Iteratee, file io.Writer) (Info, error) {
+	var (
+		hasher   = sha256.New()
+		w        = bufio.NewWriter(io.MultiWriter(file, hasher))
+		info     Info
+		lenBytes [binary.MaxVarintLen64]byte
+	)
+	if _, err := w.Write(magic[:]); err != nil {
+		return Info{}, err
+	}
+	if err := binary.Write(w, binary.BigEndian, version); err != nil {
+		return Info{}, err
+	}
+
+	it := snapshot.NewIterator()
+	defer it.Release()
+
+	for it.Next() {
+		key := it.Key()
+		value := it.Value()
+		if err := w.WriteByte(recordEntry); err != nil {
+			return Info{}, err
+		}
+		n := binary.PutUvarint(lenBytes[:], uint64(len(key)))
+		if _, err := w.Write(lenBytes[:n]); err != nil {
+			return Info{}, err
+		}
+		if _, err := w.Write(key); err != nil {
+			return Info{}, err
+		}
+		n = binary.PutUvarint(lenBytes[:], uint64(len(value)))
+		if _, err := w.Write(lenBytes[:n]); err != nil {
+			return Info{}, err
+		}
+		if _, err := w.Write(value); err != nil {
+			return Info{}, err
+		}
+		info.NumKeys++
+		info.NumBytes += uint64(len(key) + len(value))
+	}
+	if err := it.Error(); err != nil {
+		return Info{}, err
+	}
+
+	if err := w.WriteByte(recordEnd); err != nil {
+		return Info{}, err
+	}
+	if err := binary.Write(w, binary.BigEndian, info.NumKeys); err != nil {
+		return Info{}, err
+	}
+	if err := w.Flush(); err != nil {
+		return Info{}, err
+	}
+
+	// The checksum is written directly to the file so that it isn't included
+	// in the hash.
+	copy(info.Checksum[:], hasher.Sum(nil))
+	_, err := file.Write(info.Checksum[:])
+	return info, err
+}
+
+// Verify reads the backup at [path] and returns an error if it is malformed
+// or its checksum doesn't match its contents.
+func Verify(path string) (Info, error) {
+	return read(path, func([]byte, []byte) error { return nil })
+}
+
+// Restore verifies the backup at [path] and then writes its contents into
+// [db]. [db] must be empty.
+//
+// If the restore doesn't complete, [Interrupted] reports it until [db] is
+// cleared with [ClearInterrupted].
+func Restore(path string, db database.Database, batchSize int) (Info, error) {
+	isEmpty, err := database.IsEmpty(db)
+	if err != nil {
+		return Info{}, err
+	}
+	if !isEmpty {
+		return Info{}, ErrNotEmpty
+	}
+
+	// Verify the backup before writing anything so that a corrupted backup
+	// can't partially populate the database.
+	if _, err := Verify(path); err != nil {
+		return Info{}, err
+	}
+
+	if err := db.Put(restoringKey, nil); err != nil {
+		return Info{}, err
+	}
+
+	batch := db.NewBatch()
+	info, err := read(path, func(key, value []byte) error {
+		if err := batch.Put(key, value); err != nil {
+			return err
+		}
+		if batch.Size() < batchSize {
+			return nil
+		}
+		if err := batch.Write(); err != nil {
+			return err
+		}
+		batch.Reset()
+		return nil
+	})
+	if err != nil {
+		return Info{}, err
+	}
+	if err := batch.Delete(restoringKey); err != nil {
+		return Info{}, err
+	}
+	return info, batch.Write()
+}
+
+// Interrupted returns true if a restore into [db] was started but didn't
+// complete. Such a database only contains part of the backup.
+func Interrupted(db database.KeyValueReader) (bool, error) {
+	return db.Has(restoringKey)
+}
+
+// ClearInterrupted removes all the contents of [db] if a restore into it was
+// interrupted, so that the restore can be retried. Returns true if [db] was
+// cleared.
+func ClearInterrupted(db database.Database, batchSize int) (bool, error) {
+	interrupted, err := Interrupted(db)
+	if err != nil || !interrupted {
+		return false, err
+	}
+
+	batch := db.NewBatch()
+	it := db.NewIterator()
+	// Defer the release of the iterator inside a closure to guarantee that the
+	// latest, not the first, iterator is released on return.
+	defer func() {
+		it.Release()
+	}()
+
+	for it.Next() {
+		key := it.Key()
+		// The marker is deleted last so that the database is cleared again
+		// if this is interrupted.
+		if bytes.Equal(key, restoringKey) {
+			continue
+		}
+		if err := batch.Delete(key); err != nil {
+			return false, err
+		}
+		if batch.Size() < batchSize {
+			continue
+		}
+		if err := batch.Write(); err != nil {
+			return false, err
+		}
+		batch.Reset()
+
+		// Reset the iterator to release references to now deleted keys.
+		if err := it.Error(); err != nil {
+			return false, err
+		}
+		it.Release()
+		it = db.NewIterator()
+	}
+	if err := it.Error(); err != nil {
+		return false, err
+	}
+	if err := batch.Delete(restoringKey); err != nil {
+		return false, err
+	}
+	return true, batch.Write()
+}
+
+// read calls [onEntry] with every key/value pair in the backup at [path]. The
+// checksum is only verified after all entries have been read.
+func read(path string, onEntry func(key, value []byte) error) (Info, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return Info{}, err
+	}
+	defer file.Close()
+
+	var (
+		buffered = bufio.NewReader(file)
+		r        = &hashingReader{
+			reader: buffered,
+			hasher: sha256.New(),
+		}
+		info Info
+	)
+
+	var fileMagic [len(magic)]byte
+	if _, err := io.ReadFull(r, fileMagic[:]); err != nil {
+		return Info{}, err
+	}
+	if fileMagic != magic {
+		return Info{}, errInvalidMagic
+	}
+	var fileVersion uint32
+	if err := binary.Read(r, binary.BigEndian, &fileVersion); err != nil {
+		return Info{}, err
+	}
+	if fileVersion != version {
+		return Info{}, fmt.Errorf("%w: %d", errUnsupportedVersion, fileVersion)
+	}
+
+	for {
+		recordType, err := r.ReadByte()
+		if err != nil {
+			return Info{}, err
+		}
+		if recordType == recordEnd {
+			break
+		}
+		if recordType != recordEntry {
+			return Info{}, fmt.Errorf("%w: %d", errInvalidRecord, recordType)
+		}
+
+		key, err := readBytes(r)
+		if err != nil {
+			return Info{}, err
+		}
+		value, err := readBytes(r)
+		if err != nil {
+			return Info{}, err
+		}
+		if err := onEntry(key, value); err != nil {
+			return Info{}, err
+		}
+		info.NumKeys++
+		info.NumBytes += uint64(len(key) + len(value))
+	}
+
+	var numKeys uint64
+	if err := binary.Read(r, binary.BigEndian, &numKeys); err != nil {
+		return Info{}, err
+	}
+	if numKeys != info.NumKeys {
+		return Info{}, fmt.Errorf("%w: expected %d but read %d", errWrongNumKeys, numKeys, info.NumKeys)
+	}
+
+	copy(info.Checksum[:], r.hasher.Sum(nil))
+	var expectedChecksum ids.ID
+	if _, err := io.ReadFull(buffered, expectedChecksum[:]); err != nil {
+		return Info{}, err
+	}
+	if expectedChecksum != info.Checksum {
+		return Info{}, fmt.Errorf("%w: expected %s but calculated %s", ErrInvalidChecksum, expectedChecksum, info.Checksum)
+	}
+
+	// Make sure there isn't any trailing data.
+	if _, err := buffered.ReadByte(); err != io.EOF {
+		return Info{}, ErrInvalidChecksum
+	}
+	return info, nil
+}
+
+func readBytes(r *hashingReader) ([]byte, error) {
+	length, err := binary.ReadUvarint(r)
+	if err != nil {
+		return nil, err
+	}
+	if length > maxEntryLen {
+		return nil, fmt.Errorf("%w: %d", errInvalidLength, length)
+	}
+	b := make([]byte, length)
+	_, err = io.ReadFull(r, b)
+	return b, err
+}
+
+// hashingReader hashes all the bytes read from [reader].
+type hashingReader struct {
+	reader *bufio.Reader
+	hasher hash.Hash
+}
+
+func (r *hashingReader) Read(p []byte) (int, error) {
+	n, err := r.reader.Read(p)
+	_, _ = r.hasher.Write(p[:n])
+	return n, err
+}
+
+func (r *hashingReader) ReadByte() (byte, error) {
+	b, err := r.reader.ReadByte()
+	if err != nil {
+		return 0, err
+	}
+	_, _ = r.hasher.Write([]byte{b})
+	return b, nil
+}
@@ -0,0 +1,222 @@
+// Copyright (C) 2019-2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package backup
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/shubhamdubey02/cryftgo/database"
+	"github.com/shubhamdubey02/cryftgo/database/memdb"
+	"github.com/shubhamdubey02/cryftgo/utils/perms"
+)
+
+var errTest = errors.New("non-nil error")
+
+func newPopulatedDB(t *testing.T, numKeys int) *memdb.Database {
+	db := memdb.New()
+	for i := 0; i < numKeys; i++ {
+		key := []byte{byte(i >> 8), byte(i)}
+		require.NoError(t, db.Put(key, append(key, key...)))
+	}
+	return db
+}
+
+func TestCreateRestore(t *testing.T) {
+	require := require.New(t)
+
+	src := newPopulatedDB(t, 1000)
+	snapshot, err := src.NewSnapshot()
+	require.NoError(err)
+	defer snapshot.Release()
+
+	// Writes after the snapshot shouldn't be included in the backup.
+	require.NoError(src.Put([]byte("after"), nil))
+
+	path := filepath.Join(t.TempDir(), "backups", "db.bak")
+	info, err := Create(snapshot, path)
+	require.NoError(err)
+	require.Equal(uint64(1000), info.NumKeys)
+	require.Equal(uint64(6000), info.NumBytes)
+
+	verifiedInfo, err := Verify(path)
+	require.NoError(err)
+	require.Equal(info, verifiedInfo)
+
+	dst := memdb.New()
+	restoredInfo, err := Restore(path, dst, 64)
+	require.NoError(err)
+	require.Equal(info, restoredInfo)
+
+	require.NoError(src.Delete([]byte("after")))
+	srcCount, err := database.Count(src)
+	require.NoError(err)
+	dstCount, err := database.Count(dst)
+	require.NoError(err)
+	require.Equal(srcCount, dstCount)
+
+	it := src.NewIterator()
+	defer it.Release()
+	for it.Next() {
+		value, err := dst.Get(it.Key())
+		require.NoError(err)
+		require.Equal(it.Value(), value)
+	}
+	require.NoError(it.Error())
+}
+
+func TestCreateEmpty(t *testing.T) {
+	require := require.New(t)
+
+	path := filepath.Join(t.TempDir(), "db.bak")
+	info, err := Create(memdb.New(), path)
+	require.NoError(err)
+	require.Zero(info.NumKeys)
+
+	dst := memdb.New()
+	_, err = Restore(path, dst, DefaultBatchSize)
+	require.NoError(err)
+
+	isEmpty, err := database.IsEmpty(dst)
+	require.NoError(err)
+	require.True(isEmpty)
+}
+
+func TestCreateFileExists(t *testing.T) {
+	require := require.New(t)
+
+	path := filepath.Join(t.TempDir(), "db.bak")
+	_, err := Create(memdb.New(), path)
+	require.NoError(err)
+
+	_, err = Create(memdb.New(), path)
+	require.ErrorIs(err, ErrFileExists)
+}
+
+func TestRestoreNotEmpty(t *testing.T) {
+	require := require.New(t)
+
+	path := filepath.Join(t.TempDir(), "db.bak")
+	_, err := Create(newPopulatedDB(t, 10), path)
+	require.NoError(err)
+
+	_, err = Restore(path, newPopulatedDB(t, 1), DefaultBatchSize)
+	require.ErrorIs(err, ErrNotEmpty)
+}
+
+// failingBatchDB fails to write batches after [numWrites] batches have been
+// written.
+type failingBatchDB struct {
+	database.Database
+	numWrites int
+}
+
+func (db *failingBatchDB) NewBatch() database.Batch {
+	return &failingBatch{
+		Batch: db.Database.NewBatch(),
+		db:    db,
+	}
+}
+
+type failingBatch struct {
+	database.Batch
+	db *failingBatchDB
+}
+
+func (b *failingBatch) Write() error {
+	if b.db.numWrites == 0 {
+		return errTest
+	}
+	b.db.numWrites--
+	return b.Batch.Write()
+}
+
+func TestRestoreInterrupted(t *testing.T) {
+	require := require.New(t)
+
+	src := newPopulatedDB(t, 1000)
+	path := filepath.Join(t.TempDir(), "db.bak")
+	_, err := Create(src, path)
+	require.NoError(err)
+
+	// Interrupt the restore after the first batch was written.
+	dst := memdb.New()
+	_, err = Restore(path, &failingBatchDB{Database: dst, numWrites: 1}, 64)
+	require.ErrorIs(err, errTest)
+
+	interrupted, err := Interrupted(dst)
+	require.NoError(err)
+	require.True(interrupted)
+
+	// The partially restored database must be cleared before retrying.
+	_, err = Restore(path, dst, 64)
+	require.ErrorIs(err, ErrNotEmpty)
+
+	cleared, err := ClearInterrupted(dst, 64)
+	require.NoError(err)
+	require.True(cleared)
+
+	isEmpty, err := database.IsEmpty(dst)
+	require.NoError(err)
+	require.True(isEmpty)
+
+	_, err = Restore(path, dst, 64)
+	require.NoError(err)
+
+	interrupted, err = Interrupted(dst)
+	require.NoError(err)
+	require.False(interrupted)
+
+	// A completed restore must not be cleared.
+	cleared, err = ClearInterrupted(dst, 64)
+	require.NoError(err)
+	require.False(cleared)
+
+	srcCount, err := database.Count(src)
+	require.NoError(err)
+	dstCount, err := database.Count(dst)
+	require.NoError(err)
+	require.Equal(srcCount, dstCount)
+}
+
+func TestRestoreCorrupted(t *testing.T) {
+	require := require.New(t)
+
+	path := filepath.Join(t.TempDir(), "db.bak")
+	_, err := Create(newPopulatedDB(t, 10), path)
+	require.NoError(err)
+
+	backupBytes, err := os.ReadFile(path)
+	require.NoError(err)
+
+	// Modify a byte of a value in the first entry.
+	backupBytes[len(magic)+4+5] ^= 0xff
+	require.NoError(os.WriteFile(path, backupBytes, perms.ReadWrite))
+
+	_, err = Verify(path)
+	require.ErrorIs(err, ErrInvalidChecksum)
+
+	dst := memdb.New()
+	_, err = Restore(path, dst, DefaultBatchSize)
+	require.ErrorIs(err, ErrInvalidChecksum)
+
+	// Nothing should have been written.
+	isEmpty, err := database.IsEmpty(dst)
+	require.NoError(err)
+	require.True(isEmpty)
+}
+
+func TestVerifyInvalidMagic(t *testing.T) {
+	require := require.New(t)
+
+	path := filepath.Join(t.TempDir(), "db.bak")
+	require.NoError(os.WriteFile(path, []byte("not a backup file"), perms.ReadWrite))
+
+	_, err := Verify(path)
+	require.ErrorIs(err, errInvalidMagic)
+}
@@ -5,6 +5,7 @@ package corruptabledb
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"sync"
 
@@ -12,8 +13,10 @@ import (
 )
 
 var (
-	_ database.Database = (*Database)(nil)
-	_ database.Batch    = (*batch)(nil)
+	_ database.Database     = (*Database)(nil)
+	_ database.RangeDeleter = (*Database)(nil)
+	_ database.Ingester     = (*Database)(nil)
+	_ database.Batch        = (*batch)(nil)
 )
 
 // CorruptableDB is a wrapper around Database
@@ -67,6 +70,27 @@ func (db *Database) Delete(key []byte) error {
 	return db.handleError(db.Database.Delete(key))
 }
 
+// DeleteRange removes all keys in [start, limit) from the database
+func (db *Database) DeleteRange(start, limit []byte) error {
+	if err := db.corrupted(); err != nil {
+		return err
+	}
+	return db.handleError(database.DeleteRange(db.Database, start, limit))
+}
+
+// Ingest writes all the pairs provided by [it] into the database
+func (db *Database) Ingest(it database.Iterator) error {
+	if err := db.corrupted(); err != nil {
+		return err
+	}
+	err := database.Ingest(db.Database, it)
+	if errors.Is(err, database.ErrUnsortedKeys) {
+		// Unsorted input is a caller error and doesn't indicate corruption.
+		return err
+	}
+	return db.handleError(err)
+}
+
 func (db *Database) Compact(start []byte, limit []byte) error {
 	return db.handleError(db.Database.Compact(start, limit))
 }
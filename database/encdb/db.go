@@ -4,9 +4,13 @@
 package encdb
 
 import (
+	"bytes"
 	"context"
 	"crypto/cipher"
 	"crypto/rand"
+	"encoding/binary"
+	"errors"
+	"fmt"
 	"slices"
 	"sync"
 
@@ -20,17 +24,69 @@ var (
 	_ database.Database = (*Database)(nil)
 	_ database.Batch    = (*batch)(nil)
 	_ database.Iterator = (*iterator)(nil)
+	_ database.Iterator = (*maskedIterator)(nil)
+
+	errLegacy         = errors.New("operation not supported by unversioned databases")
+	errInvalidNonce   = errors.New("invalid nonce")
+	errInvalidValue   = errors.New("invalid value")
+	errPrefixTooShort = errors.New("prefix is shorter than the preserved key prefix")
 )
 
+// Config configures a versioned database.
+type Config struct {
+	// KDF is used to derive the key encryption key when the database is
+	// created or its password is changed. Existing databases use the
+	// parameters recorded in their header.
+	KDF KDFParams
+	// EncryptKeys specifies whether keys, in addition to values, are
+	// encrypted when the database is created. Existing databases use the
+	// setting recorded in their header.
+	//
+	// Encrypted keys aren't ordered, so every iterator over a database with
+	// encrypted keys reads all matching entries into memory. If false, keys
+	// are stored in plaintext.
+	EncryptKeys bool
+	// KeyPrefixLen is the length of the key prefixes that are preserved when
+	// keys are encrypted. Iterating over a prefix at least this long only
+	// reads the keys that share its first KeyPrefixLen bytes, while shorter,
+	// non-empty prefixes can't be iterated over. Encrypted keys reveal which
+	// keys share their first KeyPrefixLen bytes. Existing databases use the
+	// length recorded in their header.
+	KeyPrefixLen uint16
+}
+
+// DefaultConfig returns the config used to create new versioned databases.
+func DefaultConfig() Config {
+	return Config{
+		KDF: DefaultKDFParams,
+		// Matches the length of the hashed prefixes used by prefixdb.
+		KeyPrefixLen: 32,
+	}
+}
+
 // Database encrypts all values that are provided
 type Database struct {
 	lock   sync.RWMutex
 	cipher cipher.AEAD
 	db     database.Database
 	closed bool
+
+	// The following fields are only populated for versioned databases.
+	header  *header
+	kek     cipher.AEAD
+	keys    *keys
+	ciphers map[uint32]cipher.AEAD
+	masker  *keyMasker
+
+	// rotateLock ensures only one key rotation happens at a time.
+	rotateLock sync.Mutex
 }
 
 // New returns a new encrypted database
+//
+// Values are encrypted with a key derived directly from [password]. Databases
+// created with New can't have their password changed and don't encrypt keys.
+// Use Open for new databases.
 func New(password []byte, db database.Database) (*Database, error) {
 	h := hashing.ComputeHash256(password)
 	aead, err := chacha20poly1305.NewX(h)
@@ -40,6 +96,148 @@ func New(password []byte, db database.Database) (*Database, error) {
 	}, err
 }
 
+// Open returns a new versioned encrypted database.
+//
+// If [db] is empty, a new header is written using [config]. Otherwise, the
+// header is read from [db] and its keys are decrypted with [password].
+//
+// Returns ErrIncorrectPassword if [password] doesn't match the header.
+// Returns ErrUnversioned if [db] contains data but doesn't have a header, as is
+// the case for databases created with New.
+func Open(password []byte, db database.Database, config Config) (*Database, error) {
+	headerBytes, err := db.Get(headerKey)
+	switch {
+	case err == nil:
+		h, err := parseHeader(headerBytes)
+		if err != nil {
+			return nil, err
+		}
+		kek, err := h.kek(password)
+		if err != nil {
+			return nil, err
+		}
+		k, err := h.unwrap(kek)
+		if err != nil {
+			return nil, err
+		}
+		return newVersioned(db, h, kek, k)
+	case err != database.ErrNotFound:
+		return nil, err
+	}
+
+	isEmpty, err := database.IsEmpty(db)
+	if err != nil {
+		return nil, err
+	}
+	if !isEmpty {
+		return nil, ErrUnversioned
+	}
+
+	dataKey, err := randomBytes(keyLen)
+	if err != nil {
+		return nil, err
+	}
+	k := &keys{
+		data: map[uint32][]byte{
+			0: dataKey,
+		},
+	}
+	if config.EncryptKeys {
+		k.mask, err = randomBytes(keyLen)
+		if err != nil {
+			return nil, err
+		}
+	}
+	h := &header{
+		Version:     headerVersion,
+		KDF:         config.KDF,
+		EncryptKeys: config.EncryptKeys,
+	}
+	if config.EncryptKeys {
+		h.KeyPrefixLen = config.KeyPrefixLen
+	}
+	kek, err := h.setPassword(password)
+	if err != nil {
+		return nil, err
+	}
+	if err := h.wrap(kek, k); err != nil {
+		return nil, err
+	}
+	if err := writeHeader(db, h); err != nil {
+		return nil, err
+	}
+	return newVersioned(db, h, kek, k)
+}
+
+func newVersioned(db database.Database, h *header, kek cipher.AEAD, k *keys) (*Database, error) {
+	encDB := &Database{
+		db:     db,
+		header: h,
+		kek:    kek,
+		keys:   k,
+	}
+	if err := encDB.initCiphers(); err != nil {
+		return nil, err
+	}
+	if h.EncryptKeys {
+		masker, err := newKeyMasker(k.mask, int(h.KeyPrefixLen))
+		if err != nil {
+			return nil, err
+		}
+		encDB.masker = masker
+	}
+	return encDB, nil
+}
+
+func (db *Database) initCiphers() error {
+	db.ciphers = make(map[uint32]cipher.AEAD, len(db.keys.data))
+	for epoch, key := range db.keys.data {
+		aead, err := chacha20poly1305.NewX(key)
+		if err != nil {
+			return err
+		}
+		db.ciphers[epoch] = aead
+	}
+	return nil
+}
+
+func writeHeader(db database.KeyValueWriter, h *header) error {
+	headerBytes, err := h.bytes()
+	if err != nil {
+		return err
+	}
+	return db.Put(headerKey, headerBytes)
+}
+
+// isVersioned returns true if the database was created with Open.
+func (db *Database) isVersioned() bool {
+	return db.header != nil
+}
+
+// dbKey returns the key that [key] is stored under in the underlying
+// database.
+func (db *Database) dbKey(key []byte) []byte {
+	if !db.isVersioned() {
+		return key
+	}
+	if db.masker != nil {
+		key = db.masker.mask(key)
+	}
+	return append(slices.Clone(dataPrefix), key...)
+}
+
+// userKey is the inverse of dbKey.
+func (db *Database) userKey(dbKey []byte) ([]byte, error) {
+	if !db.isVersioned() {
+		return dbKey, nil
+	}
+	key := dbKey[len(dataPrefix):]
+	if db.masker != nil {
+		return db.masker.unmask(key)
+	}
+	return key, nil
+}
+
 func (db *Database) Has(key []byte) (bool, error) {
 	db.lock.RLock()
 	defer db.lock.RUnlock()
@@ -47,7 +245,7 @@ func (db *Database) Has(key []byte) (bool, error) {
 	if db.closed {
 		return false, database.ErrClosed
 	}
-	return db.db.Has(key)
+	return db.db.Has(db.dbKey(key))
 }
 
 func (db *Database) Get(key []byte) ([]byte, error) {
@@ -57,11 +255,11 @@ func (db *Database) Get(key []byte) ([]byte, error) {
 	if db.closed {
 		return nil, database.ErrClosed
 	}
-	encVal, err := db.db.Get(key)
+	encVal, err := db.db.Get(db.dbKey(key))
 	if err != nil {
 		return nil, err
 	}
-	return db.decrypt(encVal)
+	return db.decrypt(key, encVal)
 }
 
 func (db *Database) Put(key, value []byte) error {
@@ -72,11 +270,11 @@ func (db *Database) Put(key, value []byte) error {
 		return database.ErrClosed
 	}
 
-	encValue, err := db.encrypt(value)
+	encValue, err := db.encrypt(key, value)
 	if err != nil {
 		return err
 	}
-	return db.db.Put(key, encValue)
+	return db.db.Put(db.dbKey(key), encValue)
 }
 
 func (db *Database) Delete(key []byte) error {
@@ -86,7 +284,7 @@ func (db *Database) Delete(key []byte) error {
 	if db.closed {
 		return database.ErrClosed
 	}
-	return db.db.Delete(key)
+	return db.db.Delete(db.dbKey(key))
 }
 
 func (db *Database) NewBatch() database.Batch {
@@ -117,12 +315,71 @@ func (db *Database) NewIteratorWithStartAndPrefix(start, prefix []byte) database
 			Err: database.ErrClosed,
 		}
 	}
+	if db.masker != nil {
+		return db.newMaskedIterator(start, prefix)
+	}
+	if db.isVersioned() {
+		start = db.dbKey(start)
+		prefix = db.dbKey(prefix)
+	}
 	return &iterator{
 		Iterator: db.db.NewIteratorWithStartAndPrefix(start, prefix),
 		db:       db,
 	}
 }
 
+// newMaskedIterator returns an iterator over a database with masked keys.
+// Because masking doesn't preserve the order of keys, all matching entries are
+// read into memory and sorted when the iterator is created.
+//
+// If [prefix] is at least as long as the preserved key prefixes, only the keys
+// sharing its preserved prefix are read. If [prefix] is empty, every key in the
+// database is read. Other prefixes aren't supported.
+//
+// Assumes the lock is held.
+func (db *Database) newMaskedIterator(start, prefix []byte) database.Iterator {
+	scanPrefix := dataPrefix
+	if tag, ok := db.masker.prefixTag(prefix); ok {
+		scanPrefix = append(slices.Clone(dataPrefix), tag...)
+	} else if len(prefix) != 0 {
+		return &database.IteratorError{
+			Err: fmt.Errorf("%w: %d < %d", errPrefixTooShort, len(prefix), db.header.KeyPrefixLen),
+		}
+	}
+
+	it := db.db.NewIteratorWithPrefix(scanPrefix)
+	defer it.Release()
+
+	var entries []maskedEntry
+	for it.Next() {
+		key, err := db.userKey(it.Key())
+		if err != nil {
+			return &database.IteratorError{
+				Err: err,
+			}
+		}
+		if !bytes.HasPrefix(key, prefix) || bytes.Compare(key, start) < 0 {
+			continue
+		}
+		entries = append(entries, maskedEntry{
+			key:    key,
+			encVal: slices.Clone(it.Value()),
+		})
+	}
+	if err := it.Error(); err != nil {
+		return &database.IteratorError{
+			Err: err,
+		}
+	}
+	slices.SortFunc(entries, func(a, b maskedEntry) int {
+		return bytes.Compare(a.key, b.key)
+	})
+	return &maskedIterator{
+		db:      db,
+		entries: entries,
+	}
+}
+
 func (db *Database) Compact(start, limit []byte) error {
 	db.lock.Lock()
 	defer db.lock.Unlock()
@@ -130,6 +387,18 @@ func (db *Database) Compact(start, limit []byte) error {
 	if db.closed {
 		return database.ErrClosed
 	}
+	if db.masker != nil {
+		// Masked keys aren't ordered, so the whole data range is compacted.
+		return db.db.Compact(dataPrefix, dataLimit)
+	}
+	if db.isVersioned() {
+		start = db.dbKey(start)
+		if limit == nil {
+			limit = dataLimit
+		} else {
+			limit = db.dbKey(limit)
+		}
+	}
 	return db.db.Compact(start, limit)
 }
 
@@ -166,6 +435,10 @@ type batch struct {
 
 	db  *Database
 	ops []database.BatchOp
+
+	// epoch is the epoch of the data key that the values in the batch were
+	// encrypted with.
+	epoch uint32
 }
 
 func (b *batch) Put(key, value []byte) error {
@@ -173,11 +446,26 @@ func (b *batch) Put(key, value []byte) error {
 		Key:   slices.Clone(key),
 		Value: slices.Clone(value),
 	})
-	encValue, err := b.db.encrypt(value)
+
+	b.db.lock.RLock()
+	defer b.db.lock.RUnlock()
+
+	if b.db.isVersioned() && b.epoch != b.db.header.Epoch {
+		// The data key was rotated since the batch was last written to, so
+		// everything is re-encrypted with the current data key, including
+		// the op that was just added.
+		return b.reencrypt()
+	}
+	return b.put(key, value)
+}
+
+// Assumes the database lock is held.
+func (b *batch) put(key, value []byte) error {
+	encValue, err := b.db.encryptWithEpoch(key, value, b.epoch)
 	if err != nil {
 		return err
 	}
-	return b.Batch.Put(key, encValue)
+	return b.Batch.Put(b.db.dbKey(key), encValue)
 }
 
 func (b *batch) Delete(key []byte) error {
@@ -185,7 +473,11 @@ func (b *batch) Delete(key []byte) error {
 		Key:    slices.Clone(key),
 		Delete: true,
 	})
-	return b.Batch.Delete(key)
+
+	b.db.lock.RLock()
+	defer b.db.lock.RUnlock()
+
+	return b.Batch.Delete(b.db.dbKey(key))
 }
 
 func (b *batch) Write() error {
@@ -196,9 +488,33 @@ func (b *batch) Write() error {
 		return database.ErrClosed
 	}
 
+	// If the data key was rotated after the batch was populated, the batch
+	// must be re-encrypted, because the old data key may be removed before
+	// this batch is written.
+	if b.db.isVersioned() && b.epoch != b.db.header.Epoch {
+		if err := b.reencrypt(); err != nil {
+			return err
+		}
+	}
 	return b.Batch.Write()
 }
 
+// Assumes the database lock is held.
+func (b *batch) reencrypt() error {
+	b.Batch.Reset()
+	b.epoch = b.db.header.Epoch
+	for _, op := range b.ops {
+		if op.Delete {
+			if err := b.Batch.Delete(b.db.dbKey(op.Key)); err != nil {
+				return err
+			}
+		} else if err := b.put(op.Key, op.Value); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
 // Reset resets the batch for reuse.
 func (b *batch) Reset() {
 	if cap(b.ops) > len(b.ops)*database.MaxExcessCapacityFactor {
@@ -242,14 +558,21 @@ func (it *iterator) Next() bool {
 
 	next := it.Iterator.Next()
 	if next {
+		key, err := it.db.userKey(it.Iterator.Key())
+		if err != nil {
+			it.val = nil
+			it.key = nil
+			it.err = err
+			return false
+		}
 		encVal := it.Iterator.Value()
-		val, err := it.db.decrypt(encVal)
+		val, err := it.db.decryptLocked(key, encVal)
 		if err != nil {
 			it.err = err
 			return false
 		}
 		it.val = val
-		it.key = it.Iterator.Key()
+		it.key = key
 	} else {
 		it.val = nil
 		it.key = nil
@@ -272,27 +595,153 @@ func (it *iterator) Value() []byte {
 	return it.val
 }
 
+type maskedEntry struct {
+	key    []byte
+	encVal []byte
+}
+
+type maskedIterator struct {
+	db      *Database
+	entries []maskedEntry
+
+	initialized bool
+	val, key    []byte
+	err         error
+}
+
+func (it *maskedIterator) Next() bool {
+	// Short-circuit and set an error if the underlying database has been closed.
+	if it.db.isClosed() {
+		it.val = nil
+		it.key = nil
+		it.err = database.ErrClosed
+		return false
+	}
+
+	if it.initialized && len(it.entries) > 0 {
+		it.entries = it.entries[1:]
+	}
+	it.initialized = true
+	if len(it.entries) == 0 {
+		it.val = nil
+		it.key = nil
+		return false
+	}
+
+	entry := it.entries[0]
+	val, err := it.db.decryptLocked(entry.key, entry.encVal)
+	if err != nil {
+		it.val = nil
+		it.key = nil
+		it.err = err
+		return false
+	}
+	it.val = val
+	it.key = entry.key
+	return true
+}
+
+func (it *maskedIterator) Error() error {
+	return it.err
+}
+
+func (it *maskedIterator) Key() []byte {
+	return it.key
+}
+
+func (it *maskedIterator) Value() []byte {
+	return it.val
+}
+
+func (it *maskedIterator) Release() {
+	it.entries = nil
+}
+
 type encryptedValue struct {
 	Ciphertext []byte `serialize:"true"`
 	Nonce      []byte `serialize:"true"`
 }
 
-func (db *Database) encrypt(plaintext []byte) ([]byte, error) {
+// envelope is the encoding of values in versioned databases. [Epoch]
+// identifies the data key that encrypted the value.
+type envelope struct {
+	Epoch      uint32 `serialize:"true"`
+	Nonce      []byte `serialize:"true"`
+	Ciphertext []byte `serialize:"true"`
+}
+
+// Assumes the lock is held.
+func (db *Database) encrypt(key, plaintext []byte) ([]byte, error) {
+	var epoch uint32
+	if db.isVersioned() {
+		epoch = db.header.Epoch
+	}
+	return db.encryptWithEpoch(key, plaintext, epoch)
+}
+
+// Assumes the lock is held.
+func (db *Database) encryptWithEpoch(key, plaintext []byte, epoch uint32) ([]byte, error) {
 	nonce := make([]byte, chacha20poly1305.NonceSizeX)
 	if _, err := rand.Read(nonce); err != nil {
 		return nil, err
 	}
-	ciphertext := db.cipher.Seal(nil, nonce, plaintext, nil)
-	return Codec.Marshal(CodecVersion, &encryptedValue{
-		Ciphertext: ciphertext,
+	if !db.isVersioned() {
+		ciphertext := db.cipher.Seal(nil, nonce, plaintext, nil)
+		return Codec.Marshal(CodecVersion, &encryptedValue{
+			Ciphertext: ciphertext,
+			Nonce:      nonce,
+		})
+	}
+
+	aead, ok := db.ciphers[epoch]
+	if !ok {
+		return nil, errUnknownEpoch
+	}
+	return Codec.Marshal(CodecVersion, &envelope{
+		Epoch:      epoch,
 		Nonce:      nonce,
+		Ciphertext: aead.Seal(nil, nonce, plaintext, valueAD(key, epoch)),
 	})
 }
 
-func (db *Database) decrypt(ciphertext []byte) ([]byte, error) {
-	val := encryptedValue{}
-	if _, err := Codec.Unmarshal(ciphertext, &val); err != nil {
+// decryptLocked grabs the lock and decrypts [ciphertext].
+func (db *Database) decryptLocked(key, ciphertext []byte) ([]byte, error) {
+	db.lock.RLock()
+	defer db.lock.RUnlock()
+
+	return db.decrypt(key, ciphertext)
+}
+
+// Assumes the lock is held.
+func (db *Database) decrypt(key, ciphertext []byte) ([]byte, error) {
+	if !db.isVersioned() {
+		val := encryptedValue{}
+		if _, err := Codec.Unmarshal(ciphertext, &val); err != nil {
+			return nil, err
+		}
+		return db.cipher.Open(nil, val.Nonce, val.Ciphertext, nil)
+	}
+
+	env := envelope{}
+	if _, err := Codec.Unmarshal(ciphertext, &env); err != nil {
 		return nil, err
 	}
-	return db.cipher.Open(nil, val.Nonce, val.Ciphertext, nil)
+	aead, ok := db.ciphers[env.Epoch]
+	if !ok {
+		return nil, fmt.Errorf("%w: %d", errUnknownEpoch, env.Epoch)
+	}
+	if len(env.Nonce) != aead.NonceSize() {
+		return nil, errInvalidNonce
+	}
+	value, err := aead.Open(nil, env.Nonce, env.Ciphertext, valueAD(key, env.Epoch))
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", errInvalidValue, err)
+	}
+	return value, nil
+}
+
+// valueAD binds a value to the key it is stored under, so that values can't be
+// moved between keys without detection.
+func valueAD(key []byte, epoch uint32) []byte {
+	return binary.BigEndian.AppendUint32(slices.Clone(key), epoch)
 }
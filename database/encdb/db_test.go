@@ -4,7 +4,9 @@
 package encdb
 
 import (
+	"crypto/aes"
 	"fmt"
+	"slices"
 	"testing"
 
 	"github.com/stretchr/testify/require"
@@ -15,6 +17,13 @@ import (
 
 const testPassword = "lol totally a secure password" //nolint:gosec
 
+// testKDFParams are much cheaper than the defaults to keep the tests fast.
+var testKDFParams = KDFParams{
+	Time:    1,
+	Memory:  64,
+	Threads: 1,
+}
+
 func TestInterface(t *testing.T) {
 	for name, test := range database.Tests {
 		t.Run(name, func(t *testing.T) {
@@ -27,6 +36,307 @@ func TestInterface(t *testing.T) {
 	}
 }
 
+func TestVersionedInterface(t *testing.T) {
+	for _, encryptKeys := range []bool{false, true} {
+		for name, test := range database.Tests {
+			t.Run(fmt.Sprintf("encryptKeys_%t_%s", encryptKeys, name), func(t *testing.T) {
+				test(t, newVersionedDB(t, memdb.New(), encryptKeys))
+			})
+		}
+	}
+}
+
+func newVersionedDB(t testing.TB, db database.Database, encryptKeys bool) *Database {
+	encDB, err := Open([]byte(testPassword), db, Config{
+		KDF:         testKDFParams,
+		EncryptKeys: encryptKeys,
+		// The database tests iterate over single byte prefixes.
+		KeyPrefixLen: 1,
+	})
+	require.NoError(t, err)
+	return encDB
+}
+
+func TestOpenExisting(t *testing.T) {
+	require := require.New(t)
+
+	baseDB := memdb.New()
+	db := newVersionedDB(t, baseDB, true)
+	require.NoError(db.Put([]byte("key"), []byte("value")))
+
+	// The header, not the config, determines how an existing database is
+	// opened.
+	db, err := Open([]byte(testPassword), baseDB, DefaultConfig())
+	require.NoError(err)
+	require.NotNil(db.masker)
+
+	value, err := db.Get([]byte("key"))
+	require.NoError(err)
+	require.Equal([]byte("value"), value)
+}
+
+func TestOpenIncorrectPassword(t *testing.T) {
+	require := require.New(t)
+
+	baseDB := memdb.New()
+	_ = newVersionedDB(t, baseDB, false)
+
+	_, err := Open([]byte("wrong password"), baseDB, DefaultConfig())
+	require.ErrorIs(err, ErrIncorrectPassword)
+}
+
+func TestOpenUnversioned(t *testing.T) {
+	require := require.New(t)
+
+	baseDB := memdb.New()
+	db, err := New([]byte(testPassword), baseDB)
+	require.NoError(err)
+	require.NoError(db.Put([]byte("key"), []byte("value")))
+
+	_, err = Open([]byte(testPassword), baseDB, DefaultConfig())
+	require.ErrorIs(err, ErrUnversioned)
+}
+
+func TestEncryptKeys(t *testing.T) {
+	require := require.New(t)
+
+	baseDB := memdb.New()
+	db := newVersionedDB(t, baseDB, true)
+
+	key := []byte("a plaintext key")
+	require.NoError(db.Put(key, []byte("value")))
+
+	it := baseDB.NewIteratorWithPrefix(dataPrefix)
+	defer it.Release()
+
+	require.True(it.Next())
+	require.NotContains(string(it.Key()), string(key))
+	require.Len(it.Key(), len(dataPrefix)+prefixTagLen+aes.BlockSize+len(key))
+	require.False(it.Next())
+	require.NoError(it.Error())
+}
+
+func TestKeyMasker(t *testing.T) {
+	require := require.New(t)
+
+	m, err := newKeyMasker([]byte("mask key"), len("prefix/"))
+	require.NoError(err)
+
+	key := []byte("prefix/suffix")
+	masked := m.mask(key)
+	require.NotContains(string(masked), string(key))
+	require.Equal(masked, m.mask(key))
+
+	unmasked, err := m.unmask(masked)
+	require.NoError(err)
+	require.Equal(key, unmasked)
+
+	// Masking only reveals shared prefixes of the preserved length.
+	other := m.mask([]byte("prefix/other"))
+	require.Equal(masked[:prefixTagLen], other[:prefixTagLen])
+	end := prefixTagLen + aes.BlockSize + len("prefix/")
+	require.NotEqual(masked[prefixTagLen:end], other[prefixTagLen:end])
+
+	tag, ok := m.prefixTag([]byte("prefix/s"))
+	require.True(ok)
+	require.Equal(masked[:prefixTagLen], tag)
+
+	_, ok = m.prefixTag([]byte("prefix"))
+	require.False(ok)
+
+	different := m.mask([]byte("prefiX/suffix"))
+	require.NotEqual(masked[:prefixTagLen], different[:prefixTagLen])
+
+	// Tampered keys are rejected.
+	tampered := slices.Clone(masked)
+	tampered[len(tampered)-1] ^= 1
+	_, err = m.unmask(tampered)
+	require.ErrorIs(err, errInvalidKey)
+
+	tampered = slices.Clone(masked)
+	tampered[0] ^= 1
+	_, err = m.unmask(tampered)
+	require.ErrorIs(err, errInvalidKey)
+
+	_, err = m.unmask(masked[:prefixTagLen+aes.BlockSize-1])
+	require.ErrorIs(err, errInvalidKey)
+}
+
+func TestMaskedIteratorWithPrefix(t *testing.T) {
+	require := require.New(t)
+
+	baseDB := memdb.New()
+	db, err := Open([]byte(testPassword), baseDB, Config{
+		KDF:          testKDFParams,
+		EncryptKeys:  true,
+		KeyPrefixLen: 4,
+	})
+	require.NoError(err)
+
+	for _, key := range []string{"abcd/2", "abcd/1", "abcd", "abce/1", "ab", "zzzz/1"} {
+		require.NoError(db.Put([]byte(key), []byte(key)))
+	}
+
+	// Only the keys sharing the preserved prefix are stored under its tag.
+	tag, ok := db.masker.prefixTag([]byte("abcd"))
+	require.True(ok)
+	scanned := 0
+	it := baseDB.NewIteratorWithPrefix(append(slices.Clone(dataPrefix), tag...))
+	for it.Next() {
+		scanned++
+	}
+	require.NoError(it.Error())
+	it.Release()
+	require.Equal(3, scanned)
+
+	it = db.NewIteratorWithStartAndPrefix([]byte("abcd/1"), []byte("abcd/"))
+	var keys []string
+	for it.Next() {
+		require.Equal(it.Key(), it.Value())
+		keys = append(keys, string(it.Key()))
+	}
+	require.NoError(it.Error())
+	it.Release()
+	require.Equal([]string{"abcd/1", "abcd/2"}, keys)
+
+	// Prefixes shorter than the preserved prefix can't be scanned.
+	it = db.NewIteratorWithPrefix([]byte("abc"))
+	require.False(it.Next())
+	require.ErrorIs(it.Error(), errPrefixTooShort)
+	it.Release()
+
+	// Without a prefix, the whole database is iterated over.
+	it = db.NewIterator()
+	keys = nil
+	for it.Next() {
+		keys = append(keys, string(it.Key()))
+	}
+	require.NoError(it.Error())
+	it.Release()
+	require.Equal([]string{"ab", "abcd", "abcd/1", "abcd/2", "abce/1", "zzzz/1"}, keys)
+}
+
+func TestValueBoundToKey(t *testing.T) {
+	require := require.New(t)
+
+	baseDB := memdb.New()
+	db := newVersionedDB(t, baseDB, false)
+	require.NoError(db.Put([]byte("key1"), []byte("value1")))
+	require.NoError(db.Put([]byte("key2"), []byte("value2")))
+
+	// Swap the encrypted values in the underlying database.
+	encVal1, err := baseDB.Get(db.dbKey([]byte("key1")))
+	require.NoError(err)
+	require.NoError(baseDB.Put(db.dbKey([]byte("key2")), encVal1))
+
+	_, err = db.Get([]byte("key2"))
+	require.ErrorIs(err, errInvalidValue)
+}
+
+func TestChangePassword(t *testing.T) {
+	require := require.New(t)
+
+	baseDB := memdb.New()
+	db := newVersionedDB(t, baseDB, true)
+	require.NoError(db.Put([]byte("key"), []byte("value")))
+
+	newPassword := []byte("new password")
+	require.NoError(db.ChangePassword(newPassword))
+
+	// The database remains usable after changing the password.
+	value, err := db.Get([]byte("key"))
+	require.NoError(err)
+	require.Equal([]byte("value"), value)
+
+	_, err = Open([]byte(testPassword), baseDB, DefaultConfig())
+	require.ErrorIs(err, ErrIncorrectPassword)
+
+	db, err = Open(newPassword, baseDB, DefaultConfig())
+	require.NoError(err)
+	value, err = db.Get([]byte("key"))
+	require.NoError(err)
+	require.Equal([]byte("value"), value)
+}
+
+func TestUnversionedUnsupported(t *testing.T) {
+	require := require.New(t)
+
+	db, err := New([]byte(testPassword), memdb.New())
+	require.NoError(err)
+
+	require.ErrorIs(db.ChangePassword([]byte("new password")), errLegacy)
+	require.ErrorIs(db.RotateKey(1), errLegacy)
+}
+
+func TestRotateKey(t *testing.T) {
+	for _, encryptKeys := range []bool{false, true} {
+		t.Run(fmt.Sprintf("encryptKeys_%t", encryptKeys), func(t *testing.T) {
+			require := require.New(t)
+
+			baseDB := memdb.New()
+			db := newVersionedDB(t, baseDB, encryptKeys)
+
+			const numKeys = 100
+			for i := 0; i < numKeys; i++ {
+				require.NoError(db.Put([]byte{byte(i)}, []byte{byte(i), byte(i)}))
+			}
+
+			// A batch populated before the rotation is written after it.
+			batch := db.NewBatch()
+			require.NoError(batch.Put([]byte("batch"), []byte("value")))
+
+			require.NoError(db.RotateKey(64))
+			require.Equal(uint32(1), db.header.Epoch)
+			require.Len(db.header.DataKeys, 1)
+			require.NoError(batch.Write())
+
+			// Every value must be readable with only the new data key.
+			db, err := Open([]byte(testPassword), baseDB, DefaultConfig())
+			require.NoError(err)
+			for i := 0; i < numKeys; i++ {
+				value, err := db.Get([]byte{byte(i)})
+				require.NoError(err)
+				require.Equal([]byte{byte(i), byte(i)}, value)
+			}
+			value, err := db.Get([]byte("batch"))
+			require.NoError(err)
+			require.Equal([]byte("value"), value)
+		})
+	}
+}
+
+func TestRotateKeyResume(t *testing.T) {
+	require := require.New(t)
+
+	baseDB := memdb.New()
+	db := newVersionedDB(t, baseDB, false)
+	require.NoError(db.Put([]byte("old"), []byte("value")))
+
+	// Simulate a rotation that was interrupted before any values were
+	// re-encrypted.
+	require.NoError(db.startRotation())
+	require.NoError(db.Put([]byte("new"), []byte("value")))
+
+	db, err := Open([]byte(testPassword), baseDB, DefaultConfig())
+	require.NoError(err)
+	require.Len(db.header.DataKeys, 2)
+
+	// Both data keys are available while the rotation is in progress.
+	for _, key := range []string{"old", "new"} {
+		value, err := db.Get([]byte(key))
+		require.NoError(err)
+		require.Equal([]byte("value"), value)
+	}
+
+	require.NoError(db.RotateKey(1))
+	require.Equal(uint32(1), db.header.Epoch)
+	require.Len(db.header.DataKeys, 1)
+
+	value, err := db.Get([]byte("old"))
+	require.NoError(err)
+	require.Equal([]byte("value"), value)
+}
+
 func newDB(t testing.TB) database.Database {
 	unencryptedDB := memdb.New()
 	db, err := New([]byte(testPassword), unencryptedDB)
@@ -0,0 +1,231 @@
+// Copyright (C) 2019-2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package encdb
+
+import (
+	"cmp"
+	"crypto/cipher"
+	"crypto/rand"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"slices"
+
+	"golang.org/x/crypto/argon2"
+	"golang.org/x/crypto/chacha20poly1305"
+)
+
+const (
+	headerVersion uint16 = 0
+
+	saltLen = 16
+	keyLen  = chacha20poly1305.KeySize
+)
+
+var (
+	// headerKey is where the header of a versioned database is stored in the
+	// underlying database. All user data is stored under [dataPrefix], so the
+	// header can never collide with a user key.
+	headerKey  = []byte{0x00}
+	dataPrefix = []byte{0x01}
+	dataLimit  = []byte{0x02}
+
+	dataKeyAD = []byte("encdb data key")
+	maskKeyAD = []byte("encdb mask key")
+
+	// DefaultKDFParams are the argon2id parameters used to derive the key
+	// encryption key from a password. They match the parameters used to hash
+	// passwords in utils/password.
+	DefaultKDFParams = KDFParams{
+		Time:    1,
+		Memory:  64 * 1024,
+		Threads: 4,
+	}
+
+	ErrIncorrectPassword = errors.New("incorrect password")
+	ErrUnversioned       = errors.New("database contains unversioned data")
+
+	errUnsupportedHeaderVersion = errors.New("unsupported header version")
+	errUnknownEpoch             = errors.New("unknown key epoch")
+	errInvalidKDFParams         = errors.New("invalid KDF params")
+)
+
+// KDFParams are the argon2id parameters used to derive the key encryption key
+// from a password.
+type KDFParams struct {
+	Time uint32 `serialize:"true"`
+	// Memory is the amount of memory used by the KDF in KiB.
+	Memory  uint32 `serialize:"true"`
+	Threads uint8  `serialize:"true"`
+}
+
+func (p KDFParams) verify() error {
+	if p.Time == 0 || p.Memory == 0 || p.Threads == 0 {
+		return fmt.Errorf("%w: %+v", errInvalidKDFParams, p)
+	}
+	return nil
+}
+
+// wrappedKey is a key encrypted with the key encryption key.
+type wrappedKey struct {
+	Epoch      uint32 `serialize:"true"`
+	Nonce      []byte `serialize:"true"`
+	Ciphertext []byte `serialize:"true"`
+}
+
+// header is stored at [headerKey] of a versioned database.
+//
+// Values are encrypted with data encryption keys that are randomly generated
+// and never change. Each data key is identified by its epoch, which is stored
+// alongside every value it encrypted. The data keys are stored in the header,
+// encrypted with a key encryption key derived from the password. This allows
+// the password to be changed without re-encrypting any values, and allows the
+// data key to be rotated by re-encrypting values in the background.
+type header struct {
+	Version uint16    `serialize:"true"`
+	KDF     KDFParams `serialize:"true"`
+	Salt    []byte    `serialize:"true"`
+	// Epoch is the epoch of the data key used to encrypt new values. Other
+	// data keys are only present while a rotation is in progress.
+	Epoch    uint32       `serialize:"true"`
+	DataKeys []wrappedKey `serialize:"true"`
+	// MaskKey and KeyPrefixLen are only populated if keys are encrypted.
+	EncryptKeys  bool       `serialize:"true"`
+	MaskKey      wrappedKey `serialize:"true"`
+	KeyPrefixLen uint16     `serialize:"true"`
+}
+
+// keys are the unwrapped contents of a header.
+type keys struct {
+	data map[uint32][]byte
+	mask []byte
+}
+
+func parseHeader(headerBytes []byte) (*header, error) {
+	h := &header{}
+	if _, err := Codec.Unmarshal(headerBytes, h); err != nil {
+		return nil, fmt.Errorf("couldn't parse header: %w", err)
+	}
+	if h.Version != headerVersion {
+		return nil, fmt.Errorf("%w: %d", errUnsupportedHeaderVersion, h.Version)
+	}
+	return h, nil
+}
+
+func (h *header) bytes() ([]byte, error) {
+	return Codec.Marshal(CodecVersion, h)
+}
+
+// kek derives the key encryption key of the header from [password].
+func (h *header) kek(password []byte) (cipher.AEAD, error) {
+	if err := h.KDF.verify(); err != nil {
+		return nil, err
+	}
+	key := argon2.IDKey(password, h.Salt, h.KDF.Time, h.KDF.Memory, h.KDF.Threads, keyLen)
+	return chacha20poly1305.NewX(key)
+}
+
+// setPassword generates a new salt and returns the key encryption key derived
+// from it and [password]. The keys must be re-wrapped afterwards.
+func (h *header) setPassword(password []byte) (cipher.AEAD, error) {
+	salt, err := randomBytes(saltLen)
+	if err != nil {
+		return nil, err
+	}
+	h.Salt = salt
+	return h.kek(password)
+}
+
+// unwrap decrypts the keys in the header using [kek].
+//
+// Returns ErrIncorrectPassword if [kek] wasn't derived from the password the
+// keys were wrapped with.
+func (h *header) unwrap(kek cipher.AEAD) (*keys, error) {
+	var err error
+	k := &keys{
+		data: make(map[uint32][]byte, len(h.DataKeys)),
+	}
+	for _, wrapped := range h.DataKeys {
+		key, err := unwrapKey(kek, wrapped, dataKeyAD)
+		if err != nil {
+			return nil, err
+		}
+		k.data[wrapped.Epoch] = key
+	}
+	if _, ok := k.data[h.Epoch]; !ok {
+		return nil, fmt.Errorf("%w: %d", errUnknownEpoch, h.Epoch)
+	}
+	if h.EncryptKeys {
+		k.mask, err = unwrapKey(kek, h.MaskKey, maskKeyAD)
+		if err != nil {
+			return nil, err
+		}
+	}
+	return k, nil
+}
+
+// wrap replaces the wrapped keys in the header with [k] encrypted by [kek].
+func (h *header) wrap(kek cipher.AEAD, k *keys) error {
+	dataKeys := make([]wrappedKey, 0, len(k.data))
+	for epoch, key := range k.data {
+		wrapped, err := wrapKey(kek, epoch, key, dataKeyAD)
+		if err != nil {
+			return err
+		}
+		dataKeys = append(dataKeys, wrapped)
+	}
+	slices.SortFunc(dataKeys, func(a, b wrappedKey) int {
+		return cmp.Compare(a.Epoch, b.Epoch)
+	})
+
+	var (
+		maskKey wrappedKey
+		err     error
+	)
+	if h.EncryptKeys {
+		maskKey, err = wrapKey(kek, 0, k.mask, maskKeyAD)
+		if err != nil {
+			return err
+		}
+	}
+
+	h.DataKeys = dataKeys
+	h.MaskKey = maskKey
+	return nil
+}
+
+func wrapKey(kek cipher.AEAD, epoch uint32, key []byte, ad []byte) (wrappedKey, error) {
+	nonce, err := randomBytes(chacha20poly1305.NonceSizeX)
+	if err != nil {
+		return wrappedKey{}, err
+	}
+	return wrappedKey{
+		Epoch:      epoch,
+		Nonce:      nonce,
+		Ciphertext: kek.Seal(nil, nonce, key, epochAD(ad, epoch)),
+	}, nil
+}
+
+func unwrapKey(kek cipher.AEAD, wrapped wrappedKey, ad []byte) ([]byte, error) {
+	if len(wrapped.Nonce) != chacha20poly1305.NonceSizeX {
+		return nil, ErrIncorrectPassword
+	}
+	key, err := kek.Open(nil, wrapped.Nonce, wrapped.Ciphertext, epochAD(ad, wrapped.Epoch))
+	if err != nil {
+		return nil, ErrIncorrectPassword
+	}
+	return key, nil
+}
+
+// epochAD binds a wrapped key to its purpose and epoch so that wrapped keys
+// can't be swapped within the header.
+func epochAD(ad []byte, epoch uint32) []byte {
+	return binary.BigEndian.AppendUint32(append([]byte{}, ad...), epoch)
+}
+
+func randomBytes(n int) ([]byte, error) {
+	b := make([]byte, n)
+	_, err := rand.Read(b)
+	return b, err
+}
@@ -0,0 +1,119 @@
+// Copyright (C) 2019-2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package encdb
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/hmac"
+	"crypto/sha256"
+	"errors"
+)
+
+// prefixTagLen is the length of the tag that groups masked keys by their
+// prefix.
+const prefixTagLen = 16
+
+var (
+	keyMACLabel    = []byte("encdb key mac")
+	keyEncLabel    = []byte("encdb key encryption")
+	prefixTagLabel = []byte("encdb key prefix tag")
+
+	errInvalidKey = errors.New("invalid encrypted key")
+)
+
+// keyMasker deterministically encrypts keys, so that a key can be looked up by
+// its encryption.
+//
+// Keys are encrypted with a synthetic IV construction, using subkeys derived
+// from the mask key:
+//
+//	tag = HMAC-SHA256(tagKey, key[:prefixLen])[:16]
+//	iv = HMAC-SHA256(macKey, key)[:16]
+//	masked = tag || iv || AES-256-CTR(encKey, iv, key)
+//
+// The tag only depends on the first [prefixLen] bytes of the key (or the whole
+// key, if it is shorter), so all keys sharing a prefix of at least [prefixLen]
+// bytes are stored contiguously in the underlying database. This allows
+// iterating over such a prefix without reading the rest of the database.
+//
+// Masked keys reveal whether two keys are equal, whether they share their
+// first [prefixLen] bytes, and the length of the keys. They don't preserve the
+// ordering of keys, nor any other shared prefixes.
+type keyMasker struct {
+	prefixLen int
+	tagKey    []byte
+	macKey    []byte
+	block     cipher.Block
+}
+
+func newKeyMasker(maskKey []byte, prefixLen int) (*keyMasker, error) {
+	block, err := aes.NewCipher(deriveKey(maskKey, keyEncLabel))
+	if err != nil {
+		return nil, err
+	}
+	return &keyMasker{
+		prefixLen: prefixLen,
+		tagKey:    deriveKey(maskKey, prefixTagLabel),
+		macKey:    deriveKey(maskKey, keyMACLabel),
+		block:     block,
+	}, nil
+}
+
+// mask returns the encryption of [key].
+func (m *keyMasker) mask(key []byte) []byte {
+	masked := make([]byte, prefixTagLen+aes.BlockSize+len(key))
+	copy(masked, m.tag(key))
+	iv := m.iv(key)
+	copy(masked[prefixTagLen:], iv)
+	cipher.NewCTR(m.block, iv).XORKeyStream(masked[prefixTagLen+aes.BlockSize:], key)
+	return masked
+}
+
+// unmask returns the key encrypted in [masked].
+//
+// Returns errInvalidKey if [masked] wasn't returned by mask.
+func (m *keyMasker) unmask(masked []byte) ([]byte, error) {
+	if len(masked) < prefixTagLen+aes.BlockSize {
+		return nil, errInvalidKey
+	}
+	tag := masked[:prefixTagLen]
+	iv := masked[prefixTagLen : prefixTagLen+aes.BlockSize]
+	key := make([]byte, len(masked)-prefixTagLen-aes.BlockSize)
+	cipher.NewCTR(m.block, iv).XORKeyStream(key, masked[prefixTagLen+aes.BlockSize:])
+	if !hmac.Equal(iv, m.iv(key)) || !hmac.Equal(tag, m.tag(key)) {
+		return nil, errInvalidKey
+	}
+	return key, nil
+}
+
+// prefixTag returns the tag shared by all keys starting with [prefix].
+//
+// Returns false if [prefix] is too short to determine the tag, in which case
+// keys starting with [prefix] can have any tag.
+func (m *keyMasker) prefixTag(prefix []byte) ([]byte, bool) {
+	if len(prefix) < m.prefixLen {
+		return nil, false
+	}
+	return m.tag(prefix), true
+}
+
+func (m *keyMasker) tag(key []byte) []byte {
+	mac := hmac.New(sha256.New, m.tagKey)
+	_, _ = mac.Write(key[:min(len(key), m.prefixLen)])
+	return mac.Sum(nil)[:prefixTagLen]
+}
+
+func (m *keyMasker) iv(key []byte) []byte {
+	mac := hmac.New(sha256.New, m.macKey)
+	_, _ = mac.Write(key)
+	return mac.Sum(nil)[:aes.BlockSize]
+}
+
+// deriveKey returns a subkey of [key] for the purpose described by [label].
+func deriveKey(key, label []byte) []byte {
+	mac := hmac.New(sha256.New, key)
+	_, _ = mac.Write(label)
+	return mac.Sum(nil)
+}
@@ -0,0 +1,214 @@
+// Copyright (C) 2019-2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package encdb
+
+import (
+	"maps"
+	"slices"
+
+	"github.com/shubhamdubey02/cryftgo/database"
+)
+
+// ChangePassword re-encrypts the data keys with a key derived from [password].
+// Values are not re-encrypted, so this is cheap regardless of the size of the
+// database. The new header is written atomically, so if this fails the old
+// password remains valid.
+func (db *Database) ChangePassword(password []byte) error {
+	db.lock.Lock()
+	defer db.lock.Unlock()
+
+	switch {
+	case db.closed:
+		return database.ErrClosed
+	case !db.isVersioned():
+		return errLegacy
+	}
+
+	h := *db.header
+	kek, err := h.setPassword(password)
+	if err != nil {
+		return err
+	}
+	if err := h.wrap(kek, db.keys); err != nil {
+		return err
+	}
+	if err := writeHeader(db.db, &h); err != nil {
+		return err
+	}
+	db.header = &h
+	db.kek = kek
+	return nil
+}
+
+// RotateKey generates a new data key and re-encrypts every value with it.
+// Values are re-encrypted in batches of approximately [batchSize] bytes while
+// the database remains available for reads and writes. Once every value has
+// been re-encrypted, the old data keys are removed from the header.
+//
+// If RotateKey is interrupted, calling it again resumes the rotation rather
+// than starting a new one.
+func (db *Database) RotateKey(batchSize int) error {
+	db.rotateLock.Lock()
+	defer db.rotateLock.Unlock()
+
+	if err := db.startRotation(); err != nil {
+		return err
+	}
+
+	// The iterator is only used to find the keys to re-encrypt. Each value is
+	// re-read under the lock before being re-encrypted so that concurrent
+	// writes aren't overwritten.
+	it := db.db.NewIteratorWithPrefix(dataPrefix)
+	defer it.Release()
+
+	var (
+		dbKeys [][]byte
+		size   int
+	)
+	for it.Next() {
+		if db.isCurrentEpoch(it.Value()) {
+			continue
+		}
+		dbKeys = append(dbKeys, slices.Clone(it.Key()))
+		size += len(it.Key()) + len(it.Value())
+		if size < batchSize {
+			continue
+		}
+		if err := db.reencrypt(dbKeys); err != nil {
+			return err
+		}
+		dbKeys = dbKeys[:0]
+		size = 0
+	}
+	if err := it.Error(); err != nil {
+		return err
+	}
+	if err := db.reencrypt(dbKeys); err != nil {
+		return err
+	}
+	return db.finishRotation()
+}
+
+// startRotation adds a new data key to the header, unless a previous rotation
+// was interrupted.
+func (db *Database) startRotation() error {
+	db.lock.Lock()
+	defer db.lock.Unlock()
+
+	switch {
+	case db.closed:
+		return database.ErrClosed
+	case !db.isVersioned():
+		return errLegacy
+	case len(db.keys.data) > 1:
+		// A previous rotation was interrupted.
+		return nil
+	}
+
+	dataKey, err := randomBytes(keyLen)
+	if err != nil {
+		return err
+	}
+	k := &keys{
+		data: maps.Clone(db.keys.data),
+		mask: db.keys.mask,
+	}
+	h := *db.header
+	h.Epoch++
+	k.data[h.Epoch] = dataKey
+	return db.updateKeys(&h, k)
+}
+
+// finishRotation removes all data keys other than the current one from the
+// header.
+func (db *Database) finishRotation() error {
+	db.lock.Lock()
+	defer db.lock.Unlock()
+
+	if db.closed {
+		return database.ErrClosed
+	}
+
+	h := *db.header
+	k := &keys{
+		data: map[uint32][]byte{
+			h.Epoch: db.keys.data[h.Epoch],
+		},
+		mask: db.keys.mask,
+	}
+	return db.updateKeys(&h, k)
+}
+
+// updateKeys wraps [k] into [h] with the current key encryption key, writes
+// [h] to disk and starts using it.
+//
+// Assumes the lock is held.
+func (db *Database) updateKeys(h *header, k *keys) error {
+	if err := h.wrap(db.kek, k); err != nil {
+		return err
+	}
+	if err := writeHeader(db.db, h); err != nil {
+		return err
+	}
+	db.header = h
+	db.keys = k
+	return db.initCiphers()
+}
+
+// isCurrentEpoch returns true if [encVal] was encrypted with the current data
+// key.
+func (db *Database) isCurrentEpoch(encVal []byte) bool {
+	db.lock.RLock()
+	defer db.lock.RUnlock()
+
+	env := envelope{}
+	if _, err := Codec.Unmarshal(encVal, &env); err != nil {
+		return false
+	}
+	return env.Epoch == db.header.Epoch
+}
+
+// reencrypt re-encrypts the values stored at [dbKeys] with the current data
+// key.
+func (db *Database) reencrypt(dbKeys [][]byte) error {
+	if len(dbKeys) == 0 {
+		return nil
+	}
+
+	db.lock.Lock()
+	defer db.lock.Unlock()
+
+	if db.closed {
+		return database.ErrClosed
+	}
+
+	batch := db.db.NewBatch()
+	for _, dbKey := range dbKeys {
+		encVal, err := db.db.Get(dbKey)
+		if err == database.ErrNotFound {
+			// The key was deleted since it was iterated over.
+			continue
+		}
+		if err != nil {
+			return err
+		}
+
+		key, err := db.userKey(dbKey)
+		if err != nil {
+			return err
+		}
+		value, err := db.decrypt(key, encVal)
+		if err != nil {
+			return err
+		}
+		newEncVal, err := db.encrypt(key, value)
+		if err != nil {
+			return err
+		}
+		if err := batch.Put(dbKey, newEncVal); err != nil {
+			return err
+		}
+	}
+	return batch.Write()
+}
@@ -49,3 +49,18 @@ func TestSortednessUint32(t *testing.T) {
 	}
 	require.True(t, utils.IsSortedBytes(intBytes))
 }
+
+func TestPrefixLimit(t *testing.T) {
+	tests := []struct {
+		prefix []byte
+		limit  []byte
+	}{
+		{prefix: nil, limit: nil},
+		{prefix: []byte{0x01}, limit: []byte{0x02}},
+		{prefix: []byte{0x01, 0xff}, limit: []byte{0x02}},
+		{prefix: []byte{0xff, 0xff}, limit: nil},
+	}
+	for _, test := range tests {
+		require.Equal(t, test.limit, PrefixLimit(test.prefix))
+	}
+}
@@ -0,0 +1,80 @@
+// Copyright (C) 2019-2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package leveldb
+
+import (
+	"bytes"
+
+	"github.com/syndtr/goleveldb/leveldb"
+	"github.com/syndtr/goleveldb/leveldb/util"
+
+	"github.com/shubhamdubey02/cryftgo/database"
+)
+
+var (
+	_ database.Snapshotter = (*Database)(nil)
+	_ database.Snapshot    = (*snapshot)(nil)
+)
+
+// snapshot is a wrapper around a levelDB snapshot.
+type snapshot struct {
+	db       *Database
+	snapshot *leveldb.Snapshot
+}
+
+// NewSnapshot returns a consistent view of the current state of the database
+func (db *Database) NewSnapshot() (database.Snapshot, error) {
+	if db.closed.Get() {
+		return nil, database.ErrClosed
+	}
+	s, err := db.DB.GetSnapshot()
+	if err != nil {
+		return nil, updateError(err)
+	}
+	return &snapshot{
+		db:       db,
+		snapshot: s,
+	}, nil
+}
+
+func (s *snapshot) Has(key []byte) (bool, error) {
+	has, err := s.snapshot.Has(key, nil)
+	return has, updateError(err)
+}
+
+func (s *snapshot) Get(key []byte) ([]byte, error) {
+	value, err := s.snapshot.Get(key, nil)
+	return value, updateError(err)
+}
+
+func (s *snapshot) NewIterator() database.Iterator {
+	return s.newIterator(new(util.Range))
+}
+
+func (s *snapshot) NewIteratorWithStart(start []byte) database.Iterator {
+	return s.newIterator(&util.Range{Start: start})
+}
+
+func (s *snapshot) NewIteratorWithPrefix(prefix []byte) database.Iterator {
+	return s.newIterator(util.BytesPrefix(prefix))
+}
+
+func (s *snapshot) NewIteratorWithStartAndPrefix(start, prefix []byte) database.Iterator {
+	iterRange := util.BytesPrefix(prefix)
+	if bytes.Compare(start, prefix) == 1 {
+		iterRange.Start = start
+	}
+	return s.newIterator(iterRange)
+}
+
+func (s *snapshot) newIterator(iterRange *util.Range) database.Iterator {
+	return &iter{
+		db:       s.db,
+		Iterator: s.snapshot.NewIterator(iterRange, nil),
+	}
+}
+
+func (s *snapshot) Release() {
+	s.snapshot.Release()
+}
@@ -21,9 +21,11 @@ const (
 )
 
 var (
-	_ database.Database = (*Database)(nil)
-	_ database.Batch    = (*batch)(nil)
-	_ database.Iterator = (*iterator)(nil)
+	_ database.Database     = (*Database)(nil)
+	_ database.RangeDeleter = (*Database)(nil)
+	_ database.Ingester     = (*Database)(nil)
+	_ database.Batch        = (*batch)(nil)
+	_ database.Iterator     = (*iterator)(nil)
 )
 
 // Database is an ephemeral key-value store that implements the Database
@@ -108,6 +110,59 @@ func (db *Database) Delete(key []byte) error {
 	return nil
 }
 
+func (db *Database) DeleteRange(start, limit []byte) error {
+	db.lock.Lock()
+	defer db.lock.Unlock()
+
+	if db.db == nil {
+		return database.ErrClosed
+	}
+	for key := range db.db {
+		if key < string(start) {
+			continue
+		}
+		if limit != nil && key >= string(limit) {
+			continue
+		}
+		delete(db.db, key)
+	}
+	return nil
+}
+
+// Ingest reads all the pairs from [it] before writing any of them, so the
+// pairs are applied atomically.
+func (db *Database) Ingest(it database.Iterator) error {
+	var (
+		ops     []database.BatchOp
+		lastKey []byte
+	)
+	for it.Next() {
+		key := slices.Clone(it.Key())
+		if err := database.VerifySorted(lastKey, key); err != nil {
+			return err
+		}
+		ops = append(ops, database.BatchOp{
+			Key:   key,
+			Value: slices.Clone(it.Value()),
+		})
+		lastKey = key
+	}
+	if err := it.Error(); err != nil {
+		return err
+	}
+
+	db.lock.Lock()
+	defer db.lock.Unlock()
+
+	if db.db == nil {
+		return database.ErrClosed
+	}
+	for _, op := range ops {
+		db.db[string(op.Key)] = op.Value
+	}
+	return nil
+}
+
 func (db *Database) NewBatch() database.Batch {
 	return &batch{db: db}
 }
@@ -0,0 +1,39 @@
+// Copyright (C) 2019-2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package memdb
+
+import (
+	"maps"
+
+	"github.com/shubhamdubey02/cryftgo/database"
+)
+
+var (
+	_ database.Snapshotter = (*Database)(nil)
+	_ database.Snapshot    = (*snapshot)(nil)
+)
+
+// snapshot is a copy of the database at the time the snapshot was taken.
+// Values are never modified in place, so only the map needs to be copied.
+type snapshot struct {
+	*Database
+}
+
+func (db *Database) NewSnapshot() (database.Snapshot, error) {
+	db.lock.RLock()
+	defer db.lock.RUnlock()
+
+	if db.db == nil {
+		return nil, database.ErrClosed
+	}
+	return &snapshot{
+		Database: &Database{
+			db: maps.Clone(db.db),
+		},
+	}, nil
+}
+
+func (s *snapshot) Release() {
+	_ = s.Database.Close()
+}
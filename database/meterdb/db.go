@@ -16,9 +16,12 @@ import (
 const methodLabel = "method"
 
 var (
-	_ database.Database = (*Database)(nil)
-	_ database.Batch    = (*batch)(nil)
-	_ database.Iterator = (*iterator)(nil)
+	_ database.Database     = (*Database)(nil)
+	_ database.Snapshotter  = (*Database)(nil)
+	_ database.RangeDeleter = (*Database)(nil)
+	_ database.Ingester     = (*Database)(nil)
+	_ database.Batch        = (*batch)(nil)
+	_ database.Iterator     = (*iterator)(nil)
 
 	methodLabels = []string{methodLabel}
 	hasLabel     = prometheus.Labels{
@@ -33,12 +36,21 @@ var (
 	deleteLabel = prometheus.Labels{
 		methodLabel: "delete",
 	}
+	deleteRangeLabel = prometheus.Labels{
+		methodLabel: "delete_range",
+	}
+	ingestLabel = prometheus.Labels{
+		methodLabel: "ingest",
+	}
 	newBatchLabel = prometheus.Labels{
 		methodLabel: "new_batch",
 	}
 	newIteratorLabel = prometheus.Labels{
 		methodLabel: "new_iterator",
 	}
+	newSnapshotLabel = prometheus.Labels{
+		methodLabel: "new_snapshot",
+	}
 	compactLabel = prometheus.Labels{
 		methodLabel: "compact",
 	}
@@ -180,6 +192,29 @@ func (db *Database) Delete(key []byte) error {
 	return err
 }
 
+func (db *Database) DeleteRange(start, limit []byte) error {
+	startTime := time.Now()
+	err := database.DeleteRange(db.db, start, limit)
+	duration := time.Since(startTime)
+
+	db.calls.With(deleteRangeLabel).Inc()
+	db.duration.With(deleteRangeLabel).Add(float64(duration))
+	db.size.With(deleteRangeLabel).Add(float64(len(start) + len(limit)))
+	return err
+}
+
+func (db *Database) Ingest(it database.Iterator) error {
+	sizeIt := &sizeIterator{Iterator: it}
+	start := time.Now()
+	err := database.Ingest(db.db, sizeIt)
+	duration := time.Since(start)
+
+	db.calls.With(ingestLabel).Inc()
+	db.duration.With(ingestLabel).Add(float64(duration))
+	db.size.With(ingestLabel).Add(float64(sizeIt.size))
+	return err
+}
+
 func (db *Database) NewBatch() database.Batch {
 	start := time.Now()
 	b := &batch{
@@ -221,6 +256,18 @@ func (db *Database) NewIteratorWithStartAndPrefix(
 	return it
 }
 
+// NewSnapshot returns a snapshot of the underlying database. Reads from the
+// snapshot are not metered.
+func (db *Database) NewSnapshot() (database.Snapshot, error) {
+	start := time.Now()
+	snapshot, err := database.NewSnapshot(db.db)
+	duration := time.Since(start)
+
+	db.calls.With(newSnapshotLabel).Inc()
+	db.duration.With(newSnapshotLabel).Add(float64(duration))
+	return snapshot, err
+}
+
 func (db *Database) Compact(start, limit []byte) error {
 	startTime := time.Now()
 	err := db.db.Compact(start, limit)
@@ -384,3 +431,17 @@ func (it *iterator) Release() {
 	it.db.calls.With(iteratorReleaseLabel).Inc()
 	it.db.duration.With(iteratorReleaseLabel).Add(float64(duration))
 }
+
+// sizeIterator tracks the number of bytes read from the wrapped iterator.
+type sizeIterator struct {
+	database.Iterator
+	size int
+}
+
+func (it *sizeIterator) Next() bool {
+	hasNext := it.Iterator.Next()
+	if hasNext {
+		it.size += len(it.Iterator.Key()) + len(it.Iterator.Value())
+	}
+	return hasNext
+}
@@ -0,0 +1,132 @@
+// Copyright (C) 2019-2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package migrate
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"hash"
+
+	"go.uber.org/zap"
+
+	"github.com/shubhamdubey02/cryftgo/database"
+	"github.com/shubhamdubey02/cryftgo/utils/formatting"
+)
+
+var ErrChecksumMismatch = errors.New("checksum mismatch")
+
+// PrefixChecksum is the checksum of all the key/value pairs sharing a prefix.
+type PrefixChecksum struct {
+	Prefix   []byte
+	NumKeys  uint64
+	Checksum [sha256.Size]byte
+}
+
+// Checksums calculates a checksum for each distinct [prefixLen] byte key
+// prefix in [db]. Keys shorter than [prefixLen] are grouped by the entire key.
+//
+// The returned checksums are sorted by prefix.
+func Checksums(db database.Iteratee, prefixLen int) ([]PrefixChecksum, error) {
+	it := db.NewIterator()
+	defer it.Release()
+
+	var (
+		checksums []PrefixChecksum
+		current   *PrefixChecksum
+		hasher    hash.Hash
+		lenBytes  [binary.MaxVarintLen64]byte
+	)
+	finish := func() {
+		if current == nil {
+			return
+		}
+		copy(current.Checksum[:], hasher.Sum(nil))
+		checksums = append(checksums, *current)
+	}
+	for it.Next() {
+		key := it.Key()
+		prefix := key[:min(prefixLen, len(key))]
+		if current == nil || !bytes.Equal(current.Prefix, prefix) {
+			finish()
+			current = &PrefixChecksum{
+				Prefix: bytes.Clone(prefix),
+			}
+			hasher = sha256.New()
+		}
+
+		// Length prefixing the key and value guarantees that the hash input
+		// can't be ambiguous.
+		value := it.Value()
+		n := binary.PutUvarint(lenBytes[:], uint64(len(key)))
+		_, _ = hasher.Write(lenBytes[:n])
+		_, _ = hasher.Write(key)
+		n = binary.PutUvarint(lenBytes[:], uint64(len(value)))
+		_, _ = hasher.Write(lenBytes[:n])
+		_, _ = hasher.Write(value)
+		current.NumKeys++
+	}
+	if err := it.Error(); err != nil {
+		return nil, err
+	}
+	finish()
+	return checksums, nil
+}
+
+// Verify reports an error if the contents of the source and destination
+// databases differ. The databases are compared by prefix so that a mismatch
+// can be located.
+func (m *Migrator) Verify() error {
+	m.log.Info("verifying migration",
+		zap.Int("prefixLen", m.config.PrefixLen),
+	)
+
+	srcChecksums, err := Checksums(m.src, m.config.PrefixLen)
+	if err != nil {
+		return fmt.Errorf("failed to calculate source checksums: %w", err)
+	}
+	dstChecksums, err := Checksums(m.dst, m.config.PrefixLen)
+	if err != nil {
+		return fmt.Errorf("failed to calculate destination checksums: %w", err)
+	}
+
+	var mismatched []string
+	for len(srcChecksums) > 0 || len(dstChecksums) > 0 {
+		switch {
+		case len(dstChecksums) == 0 ||
+			(len(srcChecksums) > 0 && bytes.Compare(srcChecksums[0].Prefix, dstChecksums[0].Prefix) < 0):
+			mismatched = append(mismatched, m.logMismatch(srcChecksums[0].Prefix, srcChecksums[0].NumKeys, 0))
+			srcChecksums = srcChecksums[1:]
+		case len(srcChecksums) == 0 ||
+			bytes.Compare(srcChecksums[0].Prefix, dstChecksums[0].Prefix) > 0:
+			mismatched = append(mismatched, m.logMismatch(dstChecksums[0].Prefix, 0, dstChecksums[0].NumKeys))
+			dstChecksums = dstChecksums[1:]
+		default:
+			srcChecksum, dstChecksum := srcChecksums[0], dstChecksums[0]
+			if srcChecksum.Checksum != dstChecksum.Checksum {
+				mismatched = append(mismatched, m.logMismatch(srcChecksum.Prefix, srcChecksum.NumKeys, dstChecksum.NumKeys))
+			}
+			srcChecksums = srcChecksums[1:]
+			dstChecksums = dstChecksums[1:]
+		}
+	}
+	if len(mismatched) > 0 {
+		return fmt.Errorf("%w for prefixes %v", ErrChecksumMismatch, mismatched)
+	}
+
+	m.log.Info("verified migration")
+	return nil
+}
+
+func (m *Migrator) logMismatch(prefix []byte, srcKeys, dstKeys uint64) string {
+	prefixStr, _ := formatting.Encode(formatting.HexNC, prefix)
+	m.log.Warn("checksum mismatch",
+		zap.String("prefix", prefixStr),
+		zap.Uint64("srcKeys", srcKeys),
+		zap.Uint64("dstKeys", dstKeys),
+	)
+	return prefixStr
+}
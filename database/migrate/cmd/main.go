@@ -0,0 +1,161 @@
+// Copyright (C) 2019-2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"os/signal"
+	"syscall"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/spf13/cobra"
+
+	"github.com/shubhamdubey02/cryftgo/database"
+	"github.com/shubhamdubey02/cryftgo/database/leveldb"
+	"github.com/shubhamdubey02/cryftgo/database/migrate"
+	"github.com/shubhamdubey02/cryftgo/database/pebble"
+	"github.com/shubhamdubey02/cryftgo/utils/logging"
+	"github.com/shubhamdubey02/cryftgo/utils/wrappers"
+)
+
+var (
+	errSrcDirRequired        = errors.New("--src-db-dir is required")
+	errDstDirRequired        = errors.New("--dst-db-dir is required")
+	errCheckpointDirRequired = errors.New("--checkpoint-dir is required")
+)
+
+// This migrates the contents of a node's database between on-disk backends.
+//
+// The database directories must point at the directory containing the
+// database files. For a node, leveldb files are located at
+// [db-dir]/[network]/v1.4.5 and pebble files at [db-dir]/[network]/pebble.
+//
+// The node must not be running while the migration is in progress.
+func main() {
+	var (
+		srcType       string
+		srcDir        string
+		dstType       string
+		dstDir        string
+		checkpointDir string
+		config        = migrate.DefaultConfig()
+		skipVerify    bool
+		restart       bool
+	)
+	rootCmd := &cobra.Command{
+		Use:   "dbmigrate",
+		Short: "Migrate a database between leveldb and pebble",
+		RunE: func(*cobra.Command, []string) error {
+			switch {
+			case len(srcDir) == 0:
+				return errSrcDirRequired
+			case len(dstDir) == 0:
+				return errDstDirRequired
+			case len(checkpointDir) == 0:
+				return errCheckpointDirRequired
+			}
+
+			log := logging.NewLogger(
+				"",
+				logging.NewWrappedCore(
+					logging.Info,
+					os.Stdout,
+					logging.Colors.ConsoleEncoder(),
+				),
+			)
+
+			src, err := openDB(srcType, srcDir, log)
+			if err != nil {
+				return fmt.Errorf("couldn't open source database: %w", err)
+			}
+			dst, err := openDB(dstType, dstDir, log)
+			if err != nil {
+				_ = src.Close()
+				return fmt.Errorf("couldn't open destination database: %w", err)
+			}
+			checkpoints, err := leveldb.New(checkpointDir, nil, log, "", prometheus.NewRegistry())
+			if err != nil {
+				_ = src.Close()
+				_ = dst.Close()
+				return fmt.Errorf("couldn't open checkpoint database: %w", err)
+			}
+
+			err = run(log, config, src, dst, checkpoints, skipVerify, restart)
+			errs := wrappers.Errs{}
+			errs.Add(
+				err,
+				src.Close(),
+				dst.Close(),
+				checkpoints.Close(),
+			)
+			return errs.Err
+		},
+	}
+	rootCmd.Flags().StringVar(&srcType, "src-db-type", leveldb.Name, fmt.Sprintf("Type of the source database. Must be one of {%s, %s}", leveldb.Name, pebble.Name))
+	rootCmd.Flags().StringVar(&srcDir, "src-db-dir", "", "Directory of the source database")
+	rootCmd.Flags().StringVar(&dstType, "dst-db-type", pebble.Name, fmt.Sprintf("Type of the destination database. Must be one of {%s, %s}", leveldb.Name, pebble.Name))
+	rootCmd.Flags().StringVar(&dstDir, "dst-db-dir", "", "Directory of the destination database")
+	rootCmd.Flags().StringVar(&checkpointDir, "checkpoint-dir", "", "Directory used to record the migration progress. Re-using the same directory resumes an interrupted migration")
+	rootCmd.Flags().IntVar(&config.BatchSize, "batch-size", config.BatchSize, "Number of bytes written to the destination between checkpoints")
+	rootCmd.Flags().IntVar(&config.PrefixLen, "prefix-len", config.PrefixLen, "Number of leading key bytes used to group checksums during verification")
+	rootCmd.Flags().DurationVar(&config.LogFrequency, "log-frequency", config.LogFrequency, "Minimum duration between progress logs")
+	rootCmd.Flags().BoolVar(&skipVerify, "skip-verify", false, "If true, the destination won't be compared against the source after migrating")
+	rootCmd.Flags().BoolVar(&restart, "restart", false, "If true, any recorded progress is discarded and the migration starts from the beginning")
+
+	if err := rootCmd.Execute(); err != nil {
+		fmt.Fprintf(os.Stderr, "dbmigrate failed: %v\n", err)
+		os.Exit(1)
+	}
+	os.Exit(0)
+}
+
+func run(
+	log logging.Logger,
+	config migrate.Config,
+	src database.Database,
+	dst database.Database,
+	checkpoints database.Database,
+	skipVerify bool,
+	restart bool,
+) error {
+	m, err := migrate.New(log, config, src, dst, checkpoints)
+	if err != nil {
+		return err
+	}
+	if restart {
+		if err := m.Reset(); err != nil {
+			return fmt.Errorf("couldn't reset checkpoint: %w", err)
+		}
+	}
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	if _, err := m.Migrate(ctx); err != nil {
+		return err
+	}
+	if skipVerify {
+		return nil
+	}
+	return m.Verify()
+}
+
+func openDB(dbType string, dir string, log logging.Logger) (database.Database, error) {
+	switch dbType {
+	case leveldb.Name:
+		return leveldb.New(dir, nil, log, "", prometheus.NewRegistry())
+	case pebble.Name:
+		return pebble.New(dir, nil, log, "", prometheus.NewRegistry())
+	default:
+		return nil, fmt.Errorf(
+			"db-type was %q but should have been one of {%s, %s}",
+			dbType,
+			leveldb.Name,
+			pebble.Name,
+		)
+	}
+}
@@ -0,0 +1,318 @@
+// Copyright (C) 2019-2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+// Package migrate copies the full contents of one database.Database into
+// another. Progress is checkpointed so that an interrupted migration can be
+// resumed, and the result can be verified with per-prefix checksums.
+package migrate
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"time"
+
+	"go.uber.org/zap"
+
+	"github.com/shubhamdubey02/cryftgo/database"
+	"github.com/shubhamdubey02/cryftgo/utils/logging"
+	"github.com/shubhamdubey02/cryftgo/utils/units"
+)
+
+const (
+	DefaultBatchSize    = 4 * units.MiB
+	DefaultPrefixLen    = 1
+	DefaultLogFrequency = 30 * time.Second
+)
+
+var (
+	lastKeyKey  = []byte("lastKey")
+	numKeysKey  = []byte("numKeys")
+	numBytesKey = []byte("numBytes")
+	doneKey     = []byte("done")
+
+	errInvalidBatchSize = errors.New("batch size must be positive")
+	errInvalidPrefixLen = errors.New("prefix length must be non-negative")
+)
+
+type Config struct {
+	// BatchSize is the number of bytes buffered before the batch is written
+	// to the destination and a checkpoint is recorded.
+	BatchSize int
+	// PrefixLen is the number of leading key bytes used to group keys when
+	// calculating checksums during verification.
+	PrefixLen int
+	// LogFrequency is the minimum amount of time between progress logs.
+	LogFrequency time.Duration
+}
+
+func DefaultConfig() Config {
+	return Config{
+		BatchSize:    DefaultBatchSize,
+		PrefixLen:    DefaultPrefixLen,
+		LogFrequency: DefaultLogFrequency,
+	}
+}
+
+// Destination is the database that the migration writes into. It must be
+// iterable so that the migration can be verified.
+type Destination interface {
+	database.Batcher
+	database.Iteratee
+}
+
+// Checkpoints is where the progress of a migration is recorded. Each
+// checkpoint is written in a single batch, so that it is never partially
+// recorded.
+type Checkpoints interface {
+	database.KeyValueReaderWriterDeleter
+	database.Batcher
+}
+
+// Stats describes the amount of data migrated so far.
+type Stats struct {
+	NumKeys  uint64 `json:"numKeys"`
+	NumBytes uint64 `json:"numBytes"`
+	// Resumed is true if a checkpoint has been recorded.
+	Resumed bool `json:"resumed"`
+	// Done is true once every key in the source has been written.
+	Done bool `json:"done"`
+}
+
+// Migrator streams every key/value pair from [src] into [dst].
+//
+// After each batch is written to [dst], the last migrated key is recorded in
+// [checkpoints]. Because re-writing a key/value pair is idempotent, it's safe
+// for the migration to crash between writing a batch and recording the
+// checkpoint.
+type Migrator struct {
+	log         logging.Logger
+	config      Config
+	src         database.Iteratee
+	dst         Destination
+	checkpoints Checkpoints
+}
+
+func New(
+	log logging.Logger,
+	config Config,
+	src database.Iteratee,
+	dst Destination,
+	checkpoints Checkpoints,
+) (*Migrator, error) {
+	if config.BatchSize <= 0 {
+		return nil, errInvalidBatchSize
+	}
+	if config.PrefixLen < 0 {
+		return nil, errInvalidPrefixLen
+	}
+	return &Migrator{
+		log:         log,
+		config:      config,
+		src:         src,
+		dst:         dst,
+		checkpoints: checkpoints,
+	}, nil
+}
+
+// Stats returns the progress recorded in the checkpoint store.
+func (m *Migrator) Stats() (Stats, error) {
+	var (
+		stats Stats
+		err   error
+	)
+	stats.Done, err = getBool(m.checkpoints, doneKey)
+	if err != nil {
+		return Stats{}, err
+	}
+	stats.NumKeys, err = getUInt64(m.checkpoints, numKeysKey)
+	if err != nil {
+		return Stats{}, err
+	}
+	stats.NumBytes, err = getUInt64(m.checkpoints, numBytesKey)
+	if err != nil {
+		return Stats{}, err
+	}
+	_, err = m.checkpoints.Get(lastKeyKey)
+	switch err {
+	case nil:
+		stats.Resumed = true
+	case database.ErrNotFound:
+	default:
+		return Stats{}, err
+	}
+	return stats, nil
+}
+
+// Migrate copies all the key/value pairs that haven't been migrated yet. If a
+// checkpoint exists, the migration continues after the last checkpointed key.
+func (m *Migrator) Migrate(ctx context.Context) (Stats, error) {
+	stats, err := m.Stats()
+	if err != nil {
+		return Stats{}, fmt.Errorf("failed to read checkpoint: %w", err)
+	}
+	if stats.Done {
+		m.log.Info("migration already completed",
+			zap.Uint64("numKeys", stats.NumKeys),
+			zap.Uint64("numBytes", stats.NumBytes),
+		)
+		return stats, nil
+	}
+
+	lastKey, err := m.checkpoints.Get(lastKeyKey)
+	skipLastKey := err == nil
+	switch err {
+	case nil:
+		m.log.Info("resuming migration",
+			zap.Binary("lastKey", lastKey),
+			zap.Uint64("numKeys", stats.NumKeys),
+			zap.Uint64("numBytes", stats.NumBytes),
+		)
+	case database.ErrNotFound:
+		m.log.Info("starting migration")
+	default:
+		return Stats{}, fmt.Errorf("failed to read checkpoint: %w", err)
+	}
+
+	it := m.src.NewIteratorWithStart(lastKey)
+	defer it.Release()
+
+	var (
+		batch       = m.dst.NewBatch()
+		batchKeys   uint64
+		batchBytes  uint64
+		batchLast   []byte
+		startTime   = time.Now()
+		lastLogTime = startTime
+	)
+	for it.Next() {
+		key := it.Key()
+		// The iterator starts at [lastKey], which was already migrated.
+		if skipLastKey {
+			skipLastKey = false
+			if bytes.Equal(key, lastKey) {
+				continue
+			}
+		}
+
+		value := it.Value()
+		if err := batch.Put(key, value); err != nil {
+			return stats, err
+		}
+		batchKeys++
+		batchBytes += uint64(len(key) + len(value))
+		batchLast = slices.Clone(key)
+
+		if batch.Size() < m.config.BatchSize {
+			continue
+		}
+
+		if err := ctx.Err(); err != nil {
+			return stats, err
+		}
+
+		if err := m.flush(batch, batchLast, &stats, batchKeys, batchBytes); err != nil {
+			return stats, err
+		}
+		batchKeys = 0
+		batchBytes = 0
+
+		if now := time.Now(); now.Sub(lastLogTime) >= m.config.LogFrequency {
+			lastLogTime = now
+			m.log.Info("migrating database",
+				zap.Binary("lastKey", batchLast),
+				zap.Uint64("numKeys", stats.NumKeys),
+				zap.Uint64("numBytes", stats.NumBytes),
+				zap.Duration("duration", now.Sub(startTime)),
+			)
+		}
+	}
+	if err := it.Error(); err != nil {
+		return stats, err
+	}
+
+	if batchKeys > 0 {
+		if err := m.flush(batch, batchLast, &stats, batchKeys, batchBytes); err != nil {
+			return stats, err
+		}
+	}
+
+	if err := database.PutBool(m.checkpoints, doneKey, true); err != nil {
+		return stats, fmt.Errorf("failed to record completion: %w", err)
+	}
+	stats.Done = true
+
+	m.log.Info("finished migrating database",
+		zap.Uint64("numKeys", stats.NumKeys),
+		zap.Uint64("numBytes", stats.NumBytes),
+		zap.Duration("duration", time.Since(startTime)),
+	)
+	return stats, nil
+}
+
+// flush writes [batch] to the destination and then records a checkpoint at
+// [lastKey].
+func (m *Migrator) flush(
+	batch database.Batch,
+	lastKey []byte,
+	stats *Stats,
+	numKeys uint64,
+	numBytes uint64,
+) error {
+	if err := batch.Write(); err != nil {
+		return fmt.Errorf("failed to write batch: %w", err)
+	}
+	batch.Reset()
+
+	// The checkpoint is written after the batch so that a crash can only
+	// cause keys to be migrated twice, never skipped. Its fields are written
+	// together so that the recorded stats always match [lastKey].
+	checkpoint := m.checkpoints.NewBatch()
+	if err := database.PutUInt64(checkpoint, numKeysKey, stats.NumKeys+numKeys); err != nil {
+		return fmt.Errorf("failed to record checkpoint: %w", err)
+	}
+	if err := database.PutUInt64(checkpoint, numBytesKey, stats.NumBytes+numBytes); err != nil {
+		return fmt.Errorf("failed to record checkpoint: %w", err)
+	}
+	if err := checkpoint.Put(lastKeyKey, lastKey); err != nil {
+		return fmt.Errorf("failed to record checkpoint: %w", err)
+	}
+	if err := checkpoint.Write(); err != nil {
+		return fmt.Errorf("failed to record checkpoint: %w", err)
+	}
+
+	stats.NumKeys += numKeys
+	stats.NumBytes += numBytes
+	stats.Resumed = true
+	return nil
+}
+
+// Reset removes all recorded progress so that the next call to Migrate starts
+// from the beginning of the source database.
+func (m *Migrator) Reset() error {
+	batch := m.checkpoints.NewBatch()
+	for _, key := range [][]byte{lastKeyKey, numKeysKey, numBytesKey, doneKey} {
+		if err := batch.Delete(key); err != nil {
+			return err
+		}
+	}
+	return batch.Write()
+}
+
+func getBool(db database.KeyValueReader, key []byte) (bool, error) {
+	value, err := database.GetBool(db, key)
+	if err == database.ErrNotFound {
+		return false, nil
+	}
+	return value, err
+}
+
+func getUInt64(db database.KeyValueReader, key []byte) (uint64, error) {
+	value, err := database.GetUInt64(db, key)
+	if err == database.ErrNotFound {
+		return 0, nil
+	}
+	return value, err
+}
@@ -0,0 +1,285 @@
+// Copyright (C) 2019-2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package migrate
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/shubhamdubey02/cryftgo/database"
+	"github.com/shubhamdubey02/cryftgo/database/memdb"
+	"github.com/shubhamdubey02/cryftgo/utils/logging"
+)
+
+var errTest = errors.New("non-nil error")
+
+func populate(t *testing.T, db database.KeyValueWriter, numKeys int) {
+	for i := 0; i < numKeys; i++ {
+		key := []byte{byte(i % 4), byte(i >> 8), byte(i)}
+		require.NoError(t, db.Put(key, key))
+	}
+}
+
+func testConfig() Config {
+	config := DefaultConfig()
+	config.BatchSize = 64
+	return config
+}
+
+func TestMigrate(t *testing.T) {
+	require := require.New(t)
+
+	src := memdb.New()
+	dst := memdb.New()
+	populate(t, src, 1000)
+
+	m, err := New(logging.NoLog{}, testConfig(), src, dst, memdb.New())
+	require.NoError(err)
+
+	stats, err := m.Migrate(context.Background())
+	require.NoError(err)
+	require.True(stats.Done)
+	require.Equal(uint64(1000), stats.NumKeys)
+	require.Equal(uint64(6000), stats.NumBytes)
+	require.NoError(m.Verify())
+
+	// Migrating again should be a noop.
+	stats2, err := m.Migrate(context.Background())
+	require.NoError(err)
+	require.Equal(stats, stats2)
+}
+
+func TestMigrateEmpty(t *testing.T) {
+	require := require.New(t)
+
+	m, err := New(logging.NoLog{}, testConfig(), memdb.New(), memdb.New(), memdb.New())
+	require.NoError(err)
+
+	stats, err := m.Migrate(context.Background())
+	require.NoError(err)
+	require.True(stats.Done)
+	require.Zero(stats.NumKeys)
+	require.NoError(m.Verify())
+}
+
+// failingBatcher fails to write batches once [remaining] writes have occurred.
+type failingBatcher struct {
+	*memdb.Database
+	remaining int
+}
+
+func (f *failingBatcher) NewBatch() database.Batch {
+	return &failingBatch{
+		Batch:   f.Database.NewBatch(),
+		batcher: f,
+	}
+}
+
+type failingBatch struct {
+	database.Batch
+	batcher *failingBatcher
+}
+
+func (b *failingBatch) Write() error {
+	if b.batcher.remaining == 0 {
+		return errTest
+	}
+	b.batcher.remaining--
+	return b.Batch.Write()
+}
+
+func TestMigrateResume(t *testing.T) {
+	require := require.New(t)
+
+	src := memdb.New()
+	populate(t, src, 1000)
+
+	dst := &failingBatcher{
+		Database:  memdb.New(),
+		remaining: 10,
+	}
+	checkpoints := memdb.New()
+
+	m, err := New(logging.NoLog{}, testConfig(), src, dst, checkpoints)
+	require.NoError(err)
+
+	_, err = m.Migrate(context.Background())
+	require.ErrorIs(err, errTest)
+
+	stats, err := m.Stats()
+	require.NoError(err)
+	require.True(stats.Resumed)
+	require.False(stats.Done)
+	require.Positive(stats.NumKeys)
+	require.Less(stats.NumKeys, uint64(1000))
+	require.ErrorIs(m.Verify(), ErrChecksumMismatch)
+
+	dst.remaining = -1
+	m, err = New(logging.NoLog{}, testConfig(), src, dst, checkpoints)
+	require.NoError(err)
+
+	stats, err = m.Migrate(context.Background())
+	require.NoError(err)
+	require.True(stats.Done)
+	require.Equal(uint64(1000), stats.NumKeys)
+	require.NoError(m.Verify())
+}
+
+func TestMigrateCheckpointMatchesLastKey(t *testing.T) {
+	require := require.New(t)
+
+	src := memdb.New()
+	populate(t, src, 1000)
+
+	checkpoints := &failingBatcher{
+		Database:  memdb.New(),
+		remaining: 3,
+	}
+
+	m, err := New(logging.NoLog{}, testConfig(), src, memdb.New(), checkpoints)
+	require.NoError(err)
+
+	_, err = m.Migrate(context.Background())
+	require.ErrorIs(err, errTest)
+
+	stats, err := m.Stats()
+	require.NoError(err)
+	require.True(stats.Resumed)
+
+	lastKey, err := checkpoints.Get(lastKeyKey)
+	require.NoError(err)
+
+	// The recorded stats must describe exactly the keys up to [lastKey].
+	var (
+		numKeys  uint64
+		numBytes uint64
+		it       = src.NewIterator()
+	)
+	defer it.Release()
+	for it.Next() && bytes.Compare(it.Key(), lastKey) <= 0 {
+		numKeys++
+		numBytes += uint64(len(it.Key()) + len(it.Value()))
+	}
+	require.Equal(numKeys, stats.NumKeys)
+	require.Equal(numBytes, stats.NumBytes)
+}
+
+func TestMigrateCanceled(t *testing.T) {
+	require := require.New(t)
+
+	src := memdb.New()
+	populate(t, src, 1000)
+
+	m, err := New(logging.NoLog{}, testConfig(), src, memdb.New(), memdb.New())
+	require.NoError(err)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	_, err = m.Migrate(ctx)
+	require.ErrorIs(err, context.Canceled)
+
+	stats, err := m.Stats()
+	require.NoError(err)
+	require.False(stats.Done)
+}
+
+func TestMigrateReset(t *testing.T) {
+	require := require.New(t)
+
+	src := memdb.New()
+	populate(t, src, 10)
+
+	m, err := New(logging.NoLog{}, testConfig(), src, memdb.New(), memdb.New())
+	require.NoError(err)
+
+	_, err = m.Migrate(context.Background())
+	require.NoError(err)
+	require.NoError(m.Reset())
+
+	stats, err := m.Stats()
+	require.NoError(err)
+	require.Equal(Stats{}, stats)
+}
+
+func TestVerifyMismatch(t *testing.T) {
+	tests := []struct {
+		name   string
+		modify func(db database.KeyValueWriterDeleter) error
+	}{
+		{
+			name: "modified value",
+			modify: func(db database.KeyValueWriterDeleter) error {
+				return db.Put([]byte{1, 0, 1}, []byte{0})
+			},
+		},
+		{
+			name: "missing key",
+			modify: func(db database.KeyValueWriterDeleter) error {
+				return db.Delete([]byte{1, 0, 1})
+			},
+		},
+		{
+			name: "extra prefix",
+			modify: func(db database.KeyValueWriterDeleter) error {
+				return db.Put([]byte{9}, nil)
+			},
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			require := require.New(t)
+
+			src := memdb.New()
+			dst := memdb.New()
+			populate(t, src, 100)
+
+			m, err := New(logging.NoLog{}, testConfig(), src, dst, memdb.New())
+			require.NoError(err)
+
+			_, err = m.Migrate(context.Background())
+			require.NoError(err)
+			require.NoError(m.Verify())
+
+			require.NoError(test.modify(dst))
+			require.ErrorIs(m.Verify(), ErrChecksumMismatch)
+		})
+	}
+}
+
+func TestChecksums(t *testing.T) {
+	require := require.New(t)
+
+	db := memdb.New()
+	populate(t, db, 100)
+	require.NoError(db.Put(nil, nil))
+
+	checksums, err := Checksums(db, 1)
+	require.NoError(err)
+	require.Len(checksums, 5)
+	require.Empty(checksums[0].Prefix)
+	require.Equal(uint64(1), checksums[0].NumKeys)
+	for i, checksum := range checksums[1:] {
+		require.Equal([]byte{byte(i)}, checksum.Prefix)
+		require.Equal(uint64(25), checksum.NumKeys)
+	}
+}
+
+func TestNewInvalidConfig(t *testing.T) {
+	require := require.New(t)
+
+	config := DefaultConfig()
+	config.BatchSize = 0
+	_, err := New(logging.NoLog{}, config, memdb.New(), memdb.New(), memdb.New())
+	require.ErrorIs(err, errInvalidBatchSize)
+
+	config = DefaultConfig()
+	config.PrefixLen = -1
+	_, err = New(logging.NoLog{}, config, memdb.New(), memdb.New(), memdb.New())
+	require.ErrorIs(err, errInvalidPrefixLen)
+}
@@ -48,9 +48,11 @@ var (
 
 type Database struct {
 	lock          sync.RWMutex
+	dir           string
 	pebbleDB      *pebble.DB
 	closed        bool
 	openIterators set.Set[*iter]
+	openSnapshots set.Set[*snapshot]
 }
 
 type Config struct {
@@ -91,8 +93,10 @@ func New(file string, configBytes []byte, log logging.Logger, _ string, _ promet
 
 	db, err := pebble.Open(file, opts)
 	return &Database{
+		dir:           file,
 		pebbleDB:      db,
 		openIterators: set.Set[*iter]{},
+		openSnapshots: set.Set[*snapshot]{},
 	}, err
 }
 
@@ -113,6 +117,11 @@ func (db *Database) Close() error {
 	}
 	db.openIterators.Clear()
 
+	for snapshot := range db.openSnapshots {
+		snapshot.release()
+	}
+	db.openSnapshots.Clear()
+
 	return updateError(db.pebbleDB.Close())
 }
 
@@ -242,7 +251,12 @@ func (db *Database) NewIteratorWithStartAndPrefix(start, prefix []byte) database
 		}
 	}
 
-	it, err := db.pebbleDB.NewIter(keyRange(start, prefix))
+	return db.newIterator(db.pebbleDB, start, prefix)
+}
+
+// Assumes [db.lock] is held and [db.closed] is false.
+func (db *Database) newIterator(reader pebble.Reader, start, prefix []byte) database.Iterator {
+	it, err := reader.NewIter(keyRange(start, prefix))
 	if err != nil {
 		return &iter{
 			db:     db,
@@ -0,0 +1,173 @@
+// Copyright (C) 2019-2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package pebble
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+
+	"github.com/cockroachdb/pebble"
+	"github.com/cockroachdb/pebble/objstorage/objstorageprovider"
+	"github.com/cockroachdb/pebble/sstable"
+	"github.com/cockroachdb/pebble/vfs"
+
+	"github.com/shubhamdubey02/cryftgo/database"
+	"github.com/shubhamdubey02/cryftgo/utils/units"
+)
+
+const (
+	// ingestDirPattern is the pattern of the temporary directory, within the
+	// database directory, that sstables are written to before being ingested.
+	ingestDirPattern = "ingest-*"
+
+	// targetSSTableSize is the size at which a new sstable is started during
+	// an ingestion.
+	targetSSTableSize = 128 * units.MiB
+)
+
+var (
+	_ database.RangeDeleter = (*Database)(nil)
+	_ database.Ingester     = (*Database)(nil)
+)
+
+// DeleteRange atomically removes all keys in [start, limit) using a range
+// tombstone.
+func (db *Database) DeleteRange(start, limit []byte) error {
+	if limit == nil {
+		// The greatest key in the database is looked up to bound the range, so
+		// writes must be excluded until the range is deleted. Otherwise, a key
+		// written after the greatest key would survive the deletion.
+		db.lock.Lock()
+		defer db.lock.Unlock()
+	} else {
+		db.lock.RLock()
+		defer db.lock.RUnlock()
+	}
+
+	if db.closed {
+		return database.ErrClosed
+	}
+
+	batch := db.pebbleDB.NewBatch()
+	defer batch.Close()
+
+	if limit == nil {
+		// The database.Database spec treats a nil [limit] as a key after all
+		// keys, but pebble requires an exclusive upper bound, and no key is
+		// greater than every other key. The greatest key in the database is
+		// used as the bound and deleted separately.
+		it, err := db.pebbleDB.NewIter(&pebble.IterOptions{})
+		if err != nil {
+			return updateError(err)
+		}
+		if !it.Last() {
+			// The database is empty.
+			return it.Close()
+		}
+		limit = slices.Clone(it.Key())
+		if err := it.Close(); err != nil {
+			return err
+		}
+		if pebble.DefaultComparer.Compare(start, limit) > 0 {
+			return nil
+		}
+		if err := batch.Delete(limit, nil); err != nil {
+			return err
+		}
+	}
+
+	if pebble.DefaultComparer.Compare(start, limit) < 0 {
+		if err := batch.DeleteRange(start, limit, nil); err != nil {
+			return err
+		}
+	}
+	return updateError(batch.Commit(pebble.Sync))
+}
+
+// Ingest writes the pairs provided by [it] into sstables which are then
+// atomically added to the database. This avoids writing the pairs to the WAL
+// and memtables.
+func (db *Database) Ingest(it database.Iterator) error {
+	db.lock.RLock()
+	defer db.lock.RUnlock()
+
+	if db.closed {
+		return database.ErrClosed
+	}
+
+	dir, err := os.MkdirTemp(db.dir, ingestDirPattern)
+	if err != nil {
+		return err
+	}
+	defer os.RemoveAll(dir)
+
+	paths, err := db.writeSSTables(dir, it)
+	if err != nil {
+		return err
+	}
+	if len(paths) == 0 {
+		return nil
+	}
+	return updateError(db.pebbleDB.Ingest(paths))
+}
+
+// writeSSTables writes the pairs provided by [it] into sstables in [dir] and
+// returns their paths.
+func (db *Database) writeSSTables(dir string, it database.Iterator) ([]string, error) {
+	var (
+		opts = sstable.WriterOptions{
+			Comparer:    pebble.DefaultComparer,
+			TableFormat: db.pebbleDB.FormatMajorVersion().MaxTableFormat(),
+		}
+		paths   []string
+		writer  *sstable.Writer
+		lastKey []byte
+	)
+	closeWriter := func() error {
+		if writer == nil {
+			return nil
+		}
+		err := writer.Close()
+		writer = nil
+		return err
+	}
+	defer func() {
+		_ = closeWriter()
+	}()
+
+	for it.Next() {
+		key := it.Key()
+		if err := database.VerifySorted(lastKey, key); err != nil {
+			return nil, err
+		}
+		if lastKey == nil {
+			lastKey = make([]byte, 0, len(key))
+		}
+		lastKey = append(lastKey[:0], key...)
+
+		if writer != nil && writer.EstimatedSize() >= targetSSTableSize {
+			if err := closeWriter(); err != nil {
+				return nil, err
+			}
+		}
+		if writer == nil {
+			path := filepath.Join(dir, fmt.Sprintf("%06d.sst", len(paths)))
+			file, err := vfs.Default.Create(path)
+			if err != nil {
+				return nil, err
+			}
+			writer = sstable.NewWriter(objstorageprovider.NewFileWritable(file), opts)
+			paths = append(paths, path)
+		}
+		if err := writer.Set(key, it.Value()); err != nil {
+			return nil, err
+		}
+	}
+	if err := it.Error(); err != nil {
+		return nil, err
+	}
+	return paths, closeWriter()
+}
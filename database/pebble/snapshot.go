@@ -0,0 +1,119 @@
+// Copyright (C) 2019-2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package pebble
+
+import (
+	"slices"
+
+	"github.com/cockroachdb/pebble"
+
+	"github.com/shubhamdubey02/cryftgo/database"
+)
+
+var (
+	_ database.Snapshotter = (*Database)(nil)
+	_ database.Snapshot    = (*snapshot)(nil)
+)
+
+type snapshot struct {
+	db       *Database
+	snapshot *pebble.Snapshot
+
+	// closed is protected by [db.lock].
+	closed bool
+}
+
+func (db *Database) NewSnapshot() (database.Snapshot, error) {
+	db.lock.Lock()
+	defer db.lock.Unlock()
+
+	if db.closed {
+		return nil, database.ErrClosed
+	}
+
+	s := &snapshot{
+		db:       db,
+		snapshot: db.pebbleDB.NewSnapshot(),
+	}
+	db.openSnapshots.Add(s)
+	return s, nil
+}
+
+func (s *snapshot) Has(key []byte) (bool, error) {
+	s.db.lock.RLock()
+	defer s.db.lock.RUnlock()
+
+	if s.db.closed || s.closed {
+		return false, database.ErrClosed
+	}
+
+	_, closer, err := s.snapshot.Get(key)
+	if err == pebble.ErrNotFound {
+		return false, nil
+	}
+	if err != nil {
+		return false, updateError(err)
+	}
+	return true, closer.Close()
+}
+
+func (s *snapshot) Get(key []byte) ([]byte, error) {
+	s.db.lock.RLock()
+	defer s.db.lock.RUnlock()
+
+	if s.db.closed || s.closed {
+		return nil, database.ErrClosed
+	}
+
+	data, closer, err := s.snapshot.Get(key)
+	if err != nil {
+		return nil, updateError(err)
+	}
+	return slices.Clone(data), closer.Close()
+}
+
+func (s *snapshot) NewIterator() database.Iterator {
+	return s.NewIteratorWithStartAndPrefix(nil, nil)
+}
+
+func (s *snapshot) NewIteratorWithStart(start []byte) database.Iterator {
+	return s.NewIteratorWithStartAndPrefix(start, nil)
+}
+
+func (s *snapshot) NewIteratorWithPrefix(prefix []byte) database.Iterator {
+	return s.NewIteratorWithStartAndPrefix(nil, prefix)
+}
+
+func (s *snapshot) NewIteratorWithStartAndPrefix(start, prefix []byte) database.Iterator {
+	s.db.lock.Lock()
+	defer s.db.lock.Unlock()
+
+	if s.db.closed || s.closed {
+		return &iter{
+			db:     s.db,
+			closed: true,
+			err:    database.ErrClosed,
+		}
+	}
+
+	return s.db.newIterator(s.snapshot, start, prefix)
+}
+
+func (s *snapshot) Release() {
+	s.db.lock.Lock()
+	defer s.db.lock.Unlock()
+
+	s.release()
+}
+
+// Assumes [s.db.lock] is held.
+func (s *snapshot) release() {
+	if s.closed {
+		return
+	}
+
+	s.closed = true
+	s.db.openSnapshots.Remove(s)
+	_ = s.snapshot.Close()
+}
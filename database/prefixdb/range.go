@@ -0,0 +1,73 @@
+// Copyright (C) 2019-2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package prefixdb
+
+import "github.com/shubhamdubey02/cryftgo/database"
+
+var (
+	_ database.RangeDeleter = (*Database)(nil)
+	_ database.Ingester     = (*Database)(nil)
+	_ database.Iterator     = (*prefixingIterator)(nil)
+)
+
+// DeleteRange deletes [start, limit) from the underlying database, restricted
+// to the keys prefixed with the database's prefix.
+func (db *Database) DeleteRange(start, limit []byte) error {
+	db.lock.RLock()
+	defer db.lock.RUnlock()
+
+	if db.closed {
+		return database.ErrClosed
+	}
+
+	prefixedStart := db.prefix(start)
+	defer db.bufferPool.Put(prefixedStart)
+
+	if limit == nil {
+		return database.DeleteRange(db.db, *prefixedStart, db.dbLimit)
+	}
+	prefixedLimit := db.prefix(limit)
+	defer db.bufferPool.Put(prefixedLimit)
+
+	return database.DeleteRange(db.db, *prefixedStart, *prefixedLimit)
+}
+
+// Ingest prefixes the keys provided by [it] and ingests them into the
+// underlying database. Prefixing the keys preserves their order.
+func (db *Database) Ingest(it database.Iterator) error {
+	db.lock.RLock()
+	defer db.lock.RUnlock()
+
+	if db.closed {
+		return database.ErrClosed
+	}
+	return database.Ingest(db.db, &prefixingIterator{
+		Iterator: it,
+		db:       db,
+	})
+}
+
+// prefixingIterator prepends the database's prefix to every key of the
+// wrapped iterator.
+type prefixingIterator struct {
+	database.Iterator
+	db *Database
+
+	key []byte
+}
+
+func (it *prefixingIterator) Next() bool {
+	hasNext := it.Iterator.Next()
+	if hasNext {
+		it.key = append(it.key[:0], it.db.dbPrefix...)
+		it.key = append(it.key, it.Iterator.Key()...)
+	} else {
+		it.key = nil
+	}
+	return hasNext
+}
+
+func (it *prefixingIterator) Key() []byte {
+	return it.key
+}
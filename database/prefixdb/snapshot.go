@@ -0,0 +1,90 @@
+// Copyright (C) 2019-2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package prefixdb
+
+import "github.com/shubhamdubey02/cryftgo/database"
+
+var (
+	_ database.Snapshotter = (*Database)(nil)
+	_ database.Snapshot    = (*snapshot)(nil)
+)
+
+// snapshot restricts a snapshot of the underlying database to the keys
+// prefixed with the database's prefix.
+type snapshot struct {
+	database.Snapshot
+	db *Database
+}
+
+// NewSnapshot returns a snapshot of the underlying database. Returns
+// [database.ErrSnapshotNotSupported] if the underlying database doesn't
+// support snapshots.
+func (db *Database) NewSnapshot() (database.Snapshot, error) {
+	db.lock.RLock()
+	defer db.lock.RUnlock()
+
+	if db.closed {
+		return nil, database.ErrClosed
+	}
+
+	s, err := database.NewSnapshot(db.db)
+	if err != nil {
+		return nil, err
+	}
+	return &snapshot{
+		Snapshot: s,
+		db:       db,
+	}, nil
+}
+
+func (s *snapshot) Has(key []byte) (bool, error) {
+	if s.db.isClosed() {
+		return false, database.ErrClosed
+	}
+	prefixedKey := s.db.prefix(key)
+	defer s.db.bufferPool.Put(prefixedKey)
+
+	return s.Snapshot.Has(*prefixedKey)
+}
+
+func (s *snapshot) Get(key []byte) ([]byte, error) {
+	if s.db.isClosed() {
+		return nil, database.ErrClosed
+	}
+	prefixedKey := s.db.prefix(key)
+	defer s.db.bufferPool.Put(prefixedKey)
+
+	return s.Snapshot.Get(*prefixedKey)
+}
+
+func (s *snapshot) NewIterator() database.Iterator {
+	return s.NewIteratorWithStartAndPrefix(nil, nil)
+}
+
+func (s *snapshot) NewIteratorWithStart(start []byte) database.Iterator {
+	return s.NewIteratorWithStartAndPrefix(start, nil)
+}
+
+func (s *snapshot) NewIteratorWithPrefix(prefix []byte) database.Iterator {
+	return s.NewIteratorWithStartAndPrefix(nil, prefix)
+}
+
+func (s *snapshot) NewIteratorWithStartAndPrefix(start, prefix []byte) database.Iterator {
+	if s.db.isClosed() {
+		return &database.IteratorError{
+			Err: database.ErrClosed,
+		}
+	}
+
+	prefixedStart := s.db.prefix(start)
+	defer s.db.bufferPool.Put(prefixedStart)
+
+	prefixedPrefix := s.db.prefix(prefix)
+	defer s.db.bufferPool.Put(prefixedPrefix)
+
+	return &iterator{
+		Iterator: s.Snapshot.NewIteratorWithStartAndPrefix(*prefixedStart, *prefixedPrefix),
+		db:       s.db,
+	}
+}
@@ -0,0 +1,204 @@
+// Copyright (C) 2019-2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package database
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+
+	"github.com/shubhamdubey02/cryftgo/utils/units"
+)
+
+// rangeWriteSize is the number of bytes written in each batch when a range
+// operation isn't natively supported by a database.
+const rangeWriteSize = 4 * units.MiB
+
+var ErrUnsortedKeys = errors.New("keys are not sorted")
+
+// RangeDeleter wraps the DeleteRange method of a backing data store.
+type RangeDeleter interface {
+	// DeleteRange removes all keys in the range [start, limit).
+	//
+	// A nil start is treated as a key before all keys in the DB.
+	// A nil limit is treated as a key after all keys in the DB.
+	//
+	// Note: [start] and [limit] are safe to modify and read after calling
+	// DeleteRange.
+	DeleteRange(start []byte, limit []byte) error
+}
+
+// Ingester wraps the Ingest method of a backing data store.
+type Ingester interface {
+	// Ingest writes all the key/value pairs provided by [it] into the data
+	// store, overwriting any existing values. Keys must be provided in
+	// strictly increasing order, otherwise ErrUnsortedKeys is returned.
+	//
+	// Ingest is intended for loading large amounts of data and may bypass the
+	// usual write path. [it] is not released by Ingest.
+	Ingest(it Iterator) error
+}
+
+// DeleteRange removes all keys in the range [start, limit) from [db].
+//
+// If [db] implements RangeDeleter, the range is deleted natively. Otherwise,
+// the keys are deleted in batches, so the deletion may be partially applied if
+// an error occurs.
+func DeleteRange(db Database, start, limit []byte) error {
+	if deleter, ok := db.(RangeDeleter); ok {
+		return deleter.DeleteRange(start, limit)
+	}
+	return deleteRange(db, start, limit)
+}
+
+// DeletePrefix removes all keys with the given [prefix] from [db].
+//
+// See DeleteRange for the atomicity guarantees.
+func DeletePrefix(db Database, prefix []byte) error {
+	return DeleteRange(db, prefix, PrefixLimit(prefix))
+}
+
+// PrefixLimit returns the smallest key that is greater than every key with the
+// given [prefix]. Returns nil if there is no such key, which is treated as a
+// key after all keys by DeleteRange and Compact.
+func PrefixLimit(prefix []byte) []byte {
+	limit := bytes.Clone(prefix)
+	for i := len(limit) - 1; i >= 0; i-- {
+		limit[i]++
+		if limit[i] != 0 {
+			return limit[:i+1]
+		}
+	}
+	return nil
+}
+
+func deleteRange(db Database, start, limit []byte) error {
+	it := db.NewIteratorWithStart(start)
+	defer it.Release()
+
+	b := db.NewBatch()
+	for it.Next() {
+		key := it.Key()
+		if limit != nil && bytes.Compare(key, limit) >= 0 {
+			break
+		}
+		if err := b.Delete(key); err != nil {
+			return err
+		}
+		if b.Size() < rangeWriteSize {
+			continue
+		}
+		if err := b.Write(); err != nil {
+			return err
+		}
+		b.Reset()
+	}
+	if err := it.Error(); err != nil {
+		return err
+	}
+	return b.Write()
+}
+
+// Ingest writes all the key/value pairs provided by [it] into [db]. Keys must
+// be provided in strictly increasing order.
+//
+// If [db] implements Ingester, the pairs are ingested natively. Otherwise, the
+// pairs are written in batches, so the ingestion may be partially applied if
+// an error occurs.
+func Ingest(db Database, it Iterator) error {
+	if ingester, ok := db.(Ingester); ok {
+		return ingester.Ingest(it)
+	}
+	return ingest(db, it)
+}
+
+func ingest(db Database, it Iterator) error {
+	var (
+		b       = db.NewBatch()
+		lastKey []byte
+	)
+	for it.Next() {
+		key := it.Key()
+		if err := VerifySorted(lastKey, key); err != nil {
+			return err
+		}
+		if lastKey == nil {
+			lastKey = make([]byte, 0, len(key))
+		}
+		lastKey = append(lastKey[:0], key...)
+
+		if err := b.Put(key, it.Value()); err != nil {
+			return err
+		}
+		if b.Size() < rangeWriteSize {
+			continue
+		}
+		if err := b.Write(); err != nil {
+			return err
+		}
+		b.Reset()
+	}
+	if err := it.Error(); err != nil {
+		return err
+	}
+	return b.Write()
+}
+
+// VerifySorted returns ErrUnsortedKeys if [key] doesn't follow [lastKey]. A nil
+// [lastKey] signals that [key] is the first key.
+func VerifySorted(lastKey, key []byte) error {
+	if lastKey != nil && bytes.Compare(lastKey, key) >= 0 {
+		return fmt.Errorf("%w: %x doesn't follow %x", ErrUnsortedKeys, key, lastKey)
+	}
+	return nil
+}
+
+// NewSliceIterator returns an iterator over [ops]. Deletes are skipped. It is
+// intended for providing in-memory data to Ingest.
+func NewSliceIterator(ops []BatchOp) Iterator {
+	puts := make([]BatchOp, 0, len(ops))
+	for _, op := range ops {
+		if !op.Delete {
+			puts = append(puts, op)
+		}
+	}
+	return &sliceIterator{
+		ops: puts,
+	}
+}
+
+type sliceIterator struct {
+	ops         []BatchOp
+	initialized bool
+}
+
+func (it *sliceIterator) Next() bool {
+	if it.initialized && len(it.ops) > 0 {
+		it.ops = it.ops[1:]
+	}
+	it.initialized = true
+	return len(it.ops) > 0
+}
+
+func (*sliceIterator) Error() error {
+	return nil
+}
+
+func (it *sliceIterator) Key() []byte {
+	if !it.initialized || len(it.ops) == 0 {
+		return nil
+	}
+	return it.ops[0].Key
+}
+
+func (it *sliceIterator) Value() []byte {
+	if !it.initialized || len(it.ops) == 0 {
+		return nil
+	}
+	return it.ops[0].Value
+}
+
+func (it *sliceIterator) Release() {
+	it.ops = nil
+}
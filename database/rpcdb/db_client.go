@@ -6,6 +6,8 @@ package rpcdb
 import (
 	"context"
 	"encoding/json"
+	"io"
+	"slices"
 	"sync"
 
 	"google.golang.org/protobuf/types/known/emptypb"
@@ -18,9 +20,11 @@ import (
 )
 
 var (
-	_ database.Database = (*DatabaseClient)(nil)
-	_ database.Batch    = (*batch)(nil)
-	_ database.Iterator = (*iterator)(nil)
+	_ database.Database     = (*DatabaseClient)(nil)
+	_ database.RangeDeleter = (*DatabaseClient)(nil)
+	_ database.Ingester     = (*DatabaseClient)(nil)
+	_ database.Batch        = (*batch)(nil)
+	_ database.Iterator     = (*iterator)(nil)
 )
 
 // DatabaseClient is an implementation of database that talks over RPC.
@@ -80,6 +84,81 @@ func (db *DatabaseClient) Delete(key []byte) error {
 	return ErrEnumToError[resp.Err]
 }
 
+// DeleteRange attempts to remove all keys in [start, limit)
+func (db *DatabaseClient) DeleteRange(start, limit []byte) error {
+	resp, err := db.client.DeleteRange(context.Background(), &rpcdbpb.DeleteRangeRequest{
+		Start: start,
+		Limit: limit,
+	})
+	if err != nil {
+		return err
+	}
+	return ErrEnumToError[resp.Err]
+}
+
+// Ingest streams the key-value pairs provided by [it] to the server
+func (db *DatabaseClient) Ingest(it database.Iterator) error {
+	// Cancelling the stream signals to the server that the ingestion should be
+	// aborted.
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	stream, err := db.client.Ingest(ctx)
+	if err != nil {
+		return err
+	}
+
+	var (
+		size    int
+		req     = &rpcdbpb.IngestRequest{}
+		lastKey []byte
+	)
+	for it.Next() {
+		// The server also verifies the order of the keys, but the error
+		// wouldn't be reported as [database.ErrUnsortedKeys].
+		key := slices.Clone(it.Key())
+		if err := database.VerifySorted(lastKey, key); err != nil {
+			return err
+		}
+		lastKey = key
+
+		value := slices.Clone(it.Value())
+		size += len(key) + len(value)
+		req.Puts = append(req.Puts, &rpcdbpb.PutRequest{
+			Key:   key,
+			Value: value,
+		})
+		if size < iterationBatchSize {
+			continue
+		}
+
+		if err := stream.Send(req); err != nil {
+			// If the server terminated the stream, the reason is reported
+			// by CloseAndRecv.
+			if err == io.EOF {
+				break
+			}
+			return err
+		}
+		size = 0
+		req = &rpcdbpb.IngestRequest{}
+	}
+	if err := it.Error(); err != nil {
+		return err
+	}
+	if len(req.Puts) > 0 {
+		if err := stream.Send(req); err != nil && err != io.EOF {
+			return err
+		}
+	}
+
+	resp, err := stream.CloseAndRecv()
+	if err != nil {
+		return err
+	}
+	return ErrEnumToError[resp.Err]
+}
+
 // NewBatch returns a new batch
 func (db *DatabaseClient) NewBatch() database.Batch {
 	return &batch{db: db}
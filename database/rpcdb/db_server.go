@@ -7,6 +7,7 @@ import (
 	"context"
 	"encoding/json"
 	"errors"
+	"io"
 	"sync"
 
 	"google.golang.org/protobuf/types/known/emptypb"
@@ -19,7 +20,11 @@ import (
 
 const iterationBatchSize = 128 * units.KiB
 
-var errUnknownIterator = errors.New("unknown iterator")
+var (
+	_ database.Iterator = (*streamIterator)(nil)
+
+	errUnknownIterator = errors.New("unknown iterator")
+)
 
 // DatabaseServer is a database that is managed over RPC.
 type DatabaseServer struct {
@@ -76,6 +81,23 @@ func (db *DatabaseServer) Delete(_ context.Context, req *rpcdbpb.DeleteRequest)
 	return &rpcdbpb.DeleteResponse{Err: ErrorToErrEnum[err]}, ErrorToRPCError(err)
 }
 
+// DeleteRange delegates the DeleteRange call to the managed database and
+// returns the result
+func (db *DatabaseServer) DeleteRange(_ context.Context, req *rpcdbpb.DeleteRangeRequest) (*rpcdbpb.DeleteRangeResponse, error) {
+	err := database.DeleteRange(db.db, req.Start, req.Limit)
+	return &rpcdbpb.DeleteRangeResponse{Err: ErrorToErrEnum[err]}, ErrorToRPCError(err)
+}
+
+// Ingest delegates the key-value pairs streamed by the client to the Ingest
+// call of the managed database and returns the result
+func (db *DatabaseServer) Ingest(stream rpcdbpb.Database_IngestServer) error {
+	err := database.Ingest(db.db, &streamIterator{stream: stream})
+	if err := ErrorToRPCError(err); err != nil {
+		return err
+	}
+	return stream.SendAndClose(&rpcdbpb.IngestResponse{Err: ErrorToErrEnum[err]})
+}
+
 // Compact delegates the Compact call to the managed database and returns the
 // result
 func (db *DatabaseServer) Compact(_ context.Context, req *rpcdbpb.CompactRequest) (*rpcdbpb.CompactResponse, error) {
@@ -195,3 +217,57 @@ func (db *DatabaseServer) IteratorRelease(_ context.Context, req *rpcdbpb.Iterat
 	it.Release()
 	return &rpcdbpb.IteratorReleaseResponse{Err: ErrorToErrEnum[err]}, ErrorToRPCError(err)
 }
+
+// streamIterator iterates over the key-value pairs sent by a client during an
+// ingestion.
+type streamIterator struct {
+	stream rpcdbpb.Database_IngestServer
+
+	data []*rpcdbpb.PutRequest
+	err  error
+}
+
+func (it *streamIterator) Next() bool {
+	if len(it.data) > 0 {
+		it.data[0] = nil
+		it.data = it.data[1:]
+	}
+	for len(it.data) == 0 {
+		if it.stream == nil {
+			return false
+		}
+		req, err := it.stream.Recv()
+		if err != nil {
+			if err != io.EOF {
+				it.err = err
+			}
+			it.stream = nil
+			return false
+		}
+		it.data = req.Puts
+	}
+	return true
+}
+
+func (it *streamIterator) Error() error {
+	return it.err
+}
+
+func (it *streamIterator) Key() []byte {
+	if len(it.data) == 0 {
+		return nil
+	}
+	return it.data[0].Key
+}
+
+func (it *streamIterator) Value() []byte {
+	if len(it.data) == 0 {
+		return nil
+	}
+	return it.data[0].Value
+}
+
+func (it *streamIterator) Release() {
+	it.data = nil
+	it.stream = nil
+}
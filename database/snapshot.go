@@ -0,0 +1,41 @@
+// Copyright (C) 2019-2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package database
+
+import "errors"
+
+var ErrSnapshotNotSupported = errors.New("snapshots not supported")
+
+// Snapshot is a read-only, point-in-time view of a database. Writes to the
+// database after the snapshot was taken are not visible through the snapshot.
+//
+// A snapshot must be released after use. Iterators created from the snapshot
+// must be released before the snapshot is released.
+type Snapshot interface {
+	KeyValueReader
+	Iteratee
+
+	// Release releases associated resources. Release should always succeed
+	// and can be called multiple times without causing error.
+	Release()
+}
+
+// Snapshotter wraps the NewSnapshot method of a backing data store.
+type Snapshotter interface {
+	// NewSnapshot returns a consistent view of the current contents of the
+	// data store.
+	//
+	// Returns ErrSnapshotNotSupported if the data store is unable to provide
+	// a snapshot.
+	NewSnapshot() (Snapshot, error)
+}
+
+// NewSnapshot returns a snapshot of [db] if it implements Snapshotter.
+func NewSnapshot(db interface{}) (Snapshot, error) {
+	snapshotter, ok := db.(Snapshotter)
+	if !ok {
+		return nil, ErrSnapshotNotSupported
+	}
+	return snapshotter.NewSnapshot()
+}
@@ -59,6 +59,13 @@ var Tests = map[string]func(t *testing.T, db Database){
 	"ConcurrentBatches":                TestConcurrentBatches,
 	"ManySmallConcurrentKVPairBatches": TestManySmallConcurrentKVPairBatches,
 	"PutGetEmpty":                      TestPutGetEmpty,
+	"Snapshot":                         TestSnapshot,
+	"SnapshotIterator":                 TestSnapshotIterator,
+	"SnapshotClosed":                   TestSnapshotClosed,
+	"DeleteRange":                      TestDeleteRange,
+	"DeleteRangeNilBounds":             TestDeleteRangeNilBounds,
+	"Ingest":                           TestIngest,
+	"IngestUnsorted":                   TestIngestUnsorted,
 }
 
 // TestSimpleKeyValue tests to make sure that simple Put + Get + Delete + Has
@@ -612,6 +619,223 @@ func TestIteratorSnapshot(t *testing.T, db Database) {
 	require.NoError(iterator.Error())
 }
 
+// newSnapshotOrSkip returns a snapshot of [db] or skips the test if [db]
+// doesn't support snapshots.
+// TestDeleteRange tests to make sure that DeleteRange only removes the keys in
+// [start, limit).
+func TestDeleteRange(t *testing.T, db Database) {
+	require := require.New(t)
+
+	keys := [][]byte{
+		[]byte("a"),
+		[]byte("b"),
+		[]byte("b1"),
+		[]byte("c"),
+		[]byte("d"),
+	}
+	for _, key := range keys {
+		require.NoError(db.Put(key, key))
+	}
+
+	require.NoError(DeleteRange(db, []byte("b"), []byte("c")))
+
+	for _, key := range keys {
+		has, err := db.Has(key)
+		require.NoError(err)
+		require.Equal(!bytes.HasPrefix(key, []byte("b")), has, "key %q", key)
+	}
+
+	// An empty range shouldn't delete anything.
+	require.NoError(DeleteRange(db, []byte("d"), []byte("a")))
+	count, err := Count(db)
+	require.NoError(err)
+	require.Equal(3, count)
+}
+
+// TestDeleteRangeNilBounds tests to make sure that DeleteRange treats a nil
+// start as a key before all keys and a nil limit as a key after all keys.
+func TestDeleteRangeNilBounds(t *testing.T, db Database) {
+	require := require.New(t)
+
+	keys := [][]byte{
+		{},
+		[]byte("a"),
+		[]byte("b"),
+		{0xff, 0xff},
+	}
+	for _, key := range keys {
+		require.NoError(db.Put(key, []byte("value")))
+	}
+
+	require.NoError(DeleteRange(db, []byte("b"), nil))
+
+	count, err := Count(db)
+	require.NoError(err)
+	require.Equal(2, count)
+
+	require.NoError(DeleteRange(db, nil, nil))
+
+	isEmpty, err := IsEmpty(db)
+	require.NoError(err)
+	require.True(isEmpty)
+}
+
+// TestIngest tests to make sure that ingested pairs are readable and overwrite
+// existing values.
+func TestIngest(t *testing.T, db Database) {
+	require := require.New(t)
+
+	require.NoError(db.Put([]byte("b"), []byte("old")))
+	require.NoError(db.Put([]byte("z"), []byte("untouched")))
+
+	ops := []BatchOp{
+		{Key: []byte("a"), Value: []byte("1")},
+		{Key: []byte("b"), Value: []byte("2")},
+		{Key: []byte("c"), Value: []byte("3")},
+	}
+	it := NewSliceIterator(ops)
+	defer it.Release()
+
+	require.NoError(Ingest(db, it))
+
+	for _, op := range ops {
+		value, err := db.Get(op.Key)
+		require.NoError(err)
+		require.Equal(op.Value, value)
+	}
+	value, err := db.Get([]byte("z"))
+	require.NoError(err)
+	require.Equal([]byte("untouched"), value)
+
+	// Ingesting nothing should succeed.
+	emptyIt := NewSliceIterator(nil)
+	defer emptyIt.Release()
+
+	require.NoError(Ingest(db, emptyIt))
+}
+
+// TestIngestUnsorted tests to make sure that ingesting unsorted keys fails.
+func TestIngestUnsorted(t *testing.T, db Database) {
+	it := NewSliceIterator([]BatchOp{
+		{Key: []byte("b"), Value: []byte("1")},
+		{Key: []byte("a"), Value: []byte("2")},
+	})
+	defer it.Release()
+
+	err := Ingest(db, it)
+	require.ErrorIs(t, err, ErrUnsortedKeys)
+}
+
+func newSnapshotOrSkip(t *testing.T, db Database) Snapshot {
+	snapshot, err := NewSnapshot(db)
+	if err == ErrSnapshotNotSupported {
+		t.Skip("snapshots not supported")
+	}
+	require.NoError(t, err)
+	return snapshot
+}
+
+// TestSnapshot tests to make sure that writes performed after a snapshot is
+// taken are not visible through the snapshot.
+func TestSnapshot(t *testing.T, db Database) {
+	require := require.New(t)
+
+	key1 := []byte("hello1")
+	value1 := []byte("world1")
+
+	key2 := []byte("hello2")
+	value2 := []byte("world2")
+
+	require.NoError(db.Put(key1, value1))
+
+	snapshot := newSnapshotOrSkip(t, db)
+	defer snapshot.Release()
+
+	require.NoError(db.Put(key2, value2))
+	require.NoError(db.Delete(key1))
+
+	has, err := snapshot.Has(key1)
+	require.NoError(err)
+	require.True(has)
+
+	v, err := snapshot.Get(key1)
+	require.NoError(err)
+	require.Equal(value1, v)
+
+	has, err = snapshot.Has(key2)
+	require.NoError(err)
+	require.False(has)
+
+	_, err = snapshot.Get(key2)
+	require.Equal(ErrNotFound, err)
+
+	// The database should still reflect the latest writes.
+	has, err = db.Has(key1)
+	require.NoError(err)
+	require.False(has)
+
+	v, err = db.Get(key2)
+	require.NoError(err)
+	require.Equal(value2, v)
+}
+
+// TestSnapshotIterator tests to make sure that iterators created from a
+// snapshot only contain the key/value pairs that existed when the snapshot was
+// taken.
+func TestSnapshotIterator(t *testing.T, db Database) {
+	require := require.New(t)
+
+	key1 := []byte("hello1")
+	value1 := []byte("world1")
+
+	key2 := []byte("hello2")
+	value2 := []byte("world2")
+
+	key3 := []byte("hello3")
+	value3 := []byte("world3")
+
+	require.NoError(db.Put(key1, value1))
+	require.NoError(db.Put(key2, value2))
+
+	snapshot := newSnapshotOrSkip(t, db)
+	defer snapshot.Release()
+
+	require.NoError(db.Put(key3, value3))
+	require.NoError(db.Put(key1, value2))
+	require.NoError(db.Delete(key2))
+
+	iterator := snapshot.NewIteratorWithStartAndPrefix(key1, []byte("hello"))
+	defer iterator.Release()
+
+	require.True(iterator.Next())
+	require.Equal(key1, iterator.Key())
+	require.Equal(value1, iterator.Value())
+
+	require.True(iterator.Next())
+	require.Equal(key2, iterator.Key())
+	require.Equal(value2, iterator.Value())
+
+	require.False(iterator.Next())
+	require.Nil(iterator.Key())
+	require.Nil(iterator.Value())
+	require.NoError(iterator.Error())
+}
+
+// TestSnapshotClosed tests to make sure that a snapshot can't be created from
+// a closed database.
+func TestSnapshotClosed(t *testing.T, db Database) {
+	require := require.New(t)
+
+	snapshot := newSnapshotOrSkip(t, db)
+	snapshot.Release()
+	snapshot.Release()
+
+	require.NoError(db.Close())
+
+	_, err := NewSnapshot(db)
+	require.Equal(ErrClosed, err)
+}
+
 // TestIterator tests to make sure the database iterates over the database
 // contents lexicographically.
 func TestIterator(t *testing.T, db Database) {
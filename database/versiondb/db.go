@@ -128,10 +128,28 @@ func (db *Database) NewIteratorWithStartAndPrefix(start, prefix []byte) database
 		}
 	}
 
+	return newIterator(
+		db,
+		db.mem,
+		db.db.NewIteratorWithStartAndPrefix(start, prefix),
+		start,
+		prefix,
+	)
+}
+
+// newIterator returns an iterator that merges the pending changes in [mem]
+// with [it].
+func newIterator(
+	db *Database,
+	mem map[string]valueDelete,
+	it database.Iterator,
+	start []byte,
+	prefix []byte,
+) *iterator {
 	startString := string(start)
 	prefixString := string(prefix)
-	keys := make([]string, 0, len(db.mem))
-	for key := range db.mem {
+	keys := make([]string, 0, len(mem))
+	for key := range mem {
 		if strings.HasPrefix(key, prefixString) && key >= startString {
 			keys = append(keys, key)
 		}
@@ -139,12 +157,12 @@ func (db *Database) NewIteratorWithStartAndPrefix(start, prefix []byte) database
 	slices.Sort(keys) // Keys need to be in sorted order
 	values := make([]valueDelete, len(keys))
 	for i, key := range keys {
-		values[i] = db.mem[key]
+		values[i] = mem[key]
 	}
 
 	return &iterator{
 		db:       db,
-		Iterator: db.db.NewIteratorWithStartAndPrefix(start, prefix),
+		Iterator: it,
 		keys:     keys,
 		values:   values,
 	}
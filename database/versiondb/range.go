@@ -0,0 +1,85 @@
+// Copyright (C) 2019-2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package versiondb
+
+import (
+	"bytes"
+	"slices"
+
+	"github.com/shubhamdubey02/cryftgo/database"
+)
+
+var (
+	_ database.RangeDeleter = (*Database)(nil)
+	_ database.Ingester     = (*Database)(nil)
+)
+
+// DeleteRange marks every key in [start, limit) as deleted. Like all other
+// writes, the deletions are only applied to the underlying database when the
+// database is committed.
+func (db *Database) DeleteRange(start, limit []byte) error {
+	db.lock.Lock()
+	defer db.lock.Unlock()
+
+	if db.mem == nil {
+		return database.ErrClosed
+	}
+
+	startString := string(start)
+	limitString := string(limit)
+	for key := range db.mem {
+		if key >= startString && (limit == nil || key < limitString) {
+			db.mem[key] = valueDelete{delete: true}
+		}
+	}
+
+	it := db.db.NewIteratorWithStart(start)
+	defer it.Release()
+
+	for it.Next() {
+		key := it.Key()
+		if limit != nil && bytes.Compare(key, limit) >= 0 {
+			break
+		}
+		db.mem[string(key)] = valueDelete{delete: true}
+	}
+	return it.Error()
+}
+
+// Ingest adds the pairs provided by [it] to the pending changes. Like all
+// other writes, the pairs are only applied to the underlying database when the
+// database is committed.
+func (db *Database) Ingest(it database.Iterator) error {
+	// The pairs are read before grabbing the lock in case [it] iterates over
+	// this database.
+	var (
+		ops     []database.BatchOp
+		lastKey []byte
+	)
+	for it.Next() {
+		key := slices.Clone(it.Key())
+		if err := database.VerifySorted(lastKey, key); err != nil {
+			return err
+		}
+		ops = append(ops, database.BatchOp{
+			Key:   key,
+			Value: slices.Clone(it.Value()),
+		})
+		lastKey = key
+	}
+	if err := it.Error(); err != nil {
+		return err
+	}
+
+	db.lock.Lock()
+	defer db.lock.Unlock()
+
+	if db.mem == nil {
+		return database.ErrClosed
+	}
+	for _, op := range ops {
+		db.mem[string(op.Key)] = valueDelete{value: op.Value}
+	}
+	return nil
+}
@@ -0,0 +1,96 @@
+// Copyright (C) 2019-2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package versiondb
+
+import (
+	"maps"
+	"slices"
+
+	"github.com/shubhamdubey02/cryftgo/database"
+)
+
+var (
+	_ database.Snapshotter = (*Database)(nil)
+	_ database.Snapshot    = (*snapshot)(nil)
+)
+
+// snapshot combines a copy of the uncommitted changes with a snapshot of the
+// underlying database.
+type snapshot struct {
+	database.Snapshot
+	db  *Database
+	mem map[string]valueDelete
+}
+
+// NewSnapshot returns a snapshot that includes all uncommitted changes.
+// Returns [database.ErrSnapshotNotSupported] if the underlying database
+// doesn't support snapshots.
+func (db *Database) NewSnapshot() (database.Snapshot, error) {
+	db.lock.RLock()
+	defer db.lock.RUnlock()
+
+	if db.mem == nil {
+		return nil, database.ErrClosed
+	}
+
+	s, err := database.NewSnapshot(db.db)
+	if err != nil {
+		return nil, err
+	}
+	return &snapshot{
+		Snapshot: s,
+		db:       db,
+		mem:      maps.Clone(db.mem),
+	}, nil
+}
+
+func (s *snapshot) Has(key []byte) (bool, error) {
+	if s.db.isClosed() {
+		return false, database.ErrClosed
+	}
+	if val, has := s.mem[string(key)]; has {
+		return !val.delete, nil
+	}
+	return s.Snapshot.Has(key)
+}
+
+func (s *snapshot) Get(key []byte) ([]byte, error) {
+	if s.db.isClosed() {
+		return nil, database.ErrClosed
+	}
+	if val, has := s.mem[string(key)]; has {
+		if val.delete {
+			return nil, database.ErrNotFound
+		}
+		return slices.Clone(val.value), nil
+	}
+	return s.Snapshot.Get(key)
+}
+
+func (s *snapshot) NewIterator() database.Iterator {
+	return s.NewIteratorWithStartAndPrefix(nil, nil)
+}
+
+func (s *snapshot) NewIteratorWithStart(start []byte) database.Iterator {
+	return s.NewIteratorWithStartAndPrefix(start, nil)
+}
+
+func (s *snapshot) NewIteratorWithPrefix(prefix []byte) database.Iterator {
+	return s.NewIteratorWithStartAndPrefix(nil, prefix)
+}
+
+func (s *snapshot) NewIteratorWithStartAndPrefix(start, prefix []byte) database.Iterator {
+	if s.db.isClosed() {
+		return &database.IteratorError{
+			Err: database.ErrClosed,
+		}
+	}
+	return newIterator(
+		s.db,
+		s.mem,
+		s.Snapshot.NewIteratorWithStartAndPrefix(start, prefix),
+		start,
+		prefix,
+	)
+}
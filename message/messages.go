@@ -111,6 +111,9 @@ type OutboundMessage interface {
 	// BytesSavedCompression returns the number of bytes that this message saved
 	// due to being compressed
 	BytesSavedCompression() int
+	// ChainID returns the chain this message is sent to. Returns false if this
+	// message isn't sent to a chain.
+	ChainID() (ids.ID, bool)
 }
 
 type outboundMessage struct {
@@ -118,6 +121,8 @@ type outboundMessage struct {
 	op                    Op
 	bytes                 []byte
 	bytesSavedCompression int
+	chainID               ids.ID
+	hasChainID            bool
 }
 
 func (m *outboundMessage) BypassThrottling() bool {
@@ -136,6 +141,10 @@ func (m *outboundMessage) BytesSavedCompression() int {
 	return m.bytesSavedCompression
 }
 
+func (m *outboundMessage) ChainID() (ids.ID, bool) {
+	return m.chainID, m.hasChainID
+}
+
 // TODO: add other compression algorithms with extended interface
 type msgBuilder struct {
 	log logging.Logger
@@ -304,12 +313,17 @@ func (mb *msgBuilder) createOutbound(m *p2p.Message, compressionType compression
 		return nil, err
 	}
 
-	return &outboundMessage{
+	msg := &outboundMessage{
 		bypassThrottling:      bypassThrottling,
 		op:                    op,
 		bytes:                 b,
 		bytesSavedCompression: saved,
-	}, nil
+	}
+	if inner, err := Unwrap(m); err == nil {
+		msg.chainID, err = GetChainID(inner)
+		msg.hasChainID = err == nil
+	}
+	return msg, nil
 }
 
 func (mb *msgBuilder) parseInbound(
@@ -44,3 +44,30 @@ func Test_newOutboundBuilder(t *testing.T) {
 		})
 	}
 }
+
+func TestOutboundMessageChainID(t *testing.T) {
+	require := require.New(t)
+
+	mb, err := newMsgBuilder(
+		logging.NoLog{},
+		"test",
+		prometheus.NewRegistry(),
+		10*time.Second,
+	)
+	require.NoError(err)
+	builder := newOutboundBuilder(compression.TypeNone, mb)
+
+	chainID := ids.GenerateTestID()
+	msg, err := builder.AppGossip(chainID, []byte{1})
+	require.NoError(err)
+
+	gotChainID, ok := msg.ChainID()
+	require.True(ok)
+	require.Equal(chainID, gotChainID)
+
+	msg, err = builder.Ping(0, nil)
+	require.NoError(err)
+
+	_, ok = msg.ChainID()
+	require.False(ok)
+}
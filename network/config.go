@@ -10,7 +10,9 @@ import (
 
 	"github.com/shubhamdubey02/cryftgo/ids"
 	"github.com/shubhamdubey02/cryftgo/network/dialer"
+	"github.com/shubhamdubey02/cryftgo/network/peer"
 	"github.com/shubhamdubey02/cryftgo/network/throttling"
+	"github.com/shubhamdubey02/cryftgo/snow/networking/reputation"
 	"github.com/shubhamdubey02/cryftgo/snow/networking/tracker"
 	"github.com/shubhamdubey02/cryftgo/snow/uptime"
 	"github.com/shubhamdubey02/cryftgo/snow/validators"
@@ -110,6 +112,11 @@ type Config struct {
 
 	TLSKeyLogFile string `json:"tlsKeyLogFile"`
 
+	// If true, the messages sent to and received from peers are recorded as
+	// described by RecorderConfig.
+	RecorderEnabled bool                `json:"recorderEnabled"`
+	RecorderConfig  peer.RecorderConfig `json:"recorderConfig"`
+
 	Namespace          string            `json:"namespace"`
 	MyNodeID           ids.NodeID        `json:"myNodeID"`
 	MyIPPort           ips.DynamicIPPort `json:"myIP"`
@@ -147,6 +154,10 @@ type Config struct {
 	// responsive for us to vote that they should receive a staking reward.
 	UptimeRequirement float64 `json:"-"`
 
+	// ConnectionPolicyConfig restricts which peers this node will connect to.
+	// It can be updated while the node is running.
+	ConnectionPolicyConfig ConnectionPolicyConfig `json:"connectionPolicyConfig"`
+
 	// RequireValidatorToConnect require that all connections must have at least
 	// one validator between the 2 peers. This can be useful to enable if the
 	// node wants to connect to the minimum number of nodes without impacting
@@ -169,6 +180,16 @@ type Config struct {
 	// Tracks the CPU/disk usage caused by processing messages of each peer.
 	ResourceTracker tracker.ResourceTracker `json:"-"`
 
+	// Tracks the misbehavior of each peer. Banned peers are not connected to.
+	ReputationTracker reputation.Tracker `json:"-"`
+
+	// Records the messages sent to and received from each peer.
+	Recorder peer.Recorder `json:"-"`
+
+	// Tracks the subnet of each chain running on this node. Used to account
+	// message bytes to subnets and to enforce per-subnet quotas.
+	ChainSubnets *throttling.ChainSubnets `json:"-"`
+
 	// Specifies how much CPU usage each peer can cause before
 	// we rate-limit them.
 	CPUTargeter tracker.Targeter `json:"-"`
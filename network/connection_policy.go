@@ -0,0 +1,207 @@
+// Copyright (C) 2019-2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package network
+
+import (
+	"fmt"
+	"net"
+	"sync"
+	"time"
+
+	"github.com/shubhamdubey02/cryftgo/ids"
+	"github.com/shubhamdubey02/cryftgo/snow/validators"
+	"github.com/shubhamdubey02/cryftgo/utils/constants"
+	"github.com/shubhamdubey02/cryftgo/utils/linked"
+	"github.com/shubhamdubey02/cryftgo/utils/set"
+)
+
+const (
+	inboundDirection  = "inbound"
+	outboundDirection = "outbound"
+
+	deniedNodeReason   = "denied node"
+	deniedIPReason     = "denied ip"
+	notAllowedReason   = "not allowed"
+	notValidatorReason = "not a validator"
+	bannedReason       = "banned"
+
+	// maxRejectedPeers is the number of recently rejected peers that are
+	// reported by RejectedPeers.
+	maxRejectedPeers = 256
+)
+
+// ConnectionPolicyConfig describes which peers this node is willing to be
+// connected to. A peer is rejected if:
+//   - its NodeID is in DeniedNodeIDs
+//   - its IP is in one of DeniedCIDRs
+//   - AllowedNodeIDs or AllowedCIDRs is non-empty, and its NodeID isn't in
+//     AllowedNodeIDs and its IP isn't in any of AllowedCIDRs
+//   - ValidatorsOnlyInbound is true, the connection is inbound, and the peer
+//     isn't a beacon, an allowed NodeID, or a validator of the primary network
+//     or of a tracked subnet
+type ConnectionPolicyConfig struct {
+	AllowedNodeIDs        []ids.NodeID `json:"allowedNodeIDs"`
+	DeniedNodeIDs         []ids.NodeID `json:"deniedNodeIDs"`
+	AllowedCIDRs          []string     `json:"allowedCIDRs"`
+	DeniedCIDRs           []string     `json:"deniedCIDRs"`
+	ValidatorsOnlyInbound bool         `json:"validatorsOnlyInbound"`
+}
+
+// RejectedPeer is a peer whose most recent connection was rejected by the
+// connection policy or because the peer was banned.
+type RejectedPeer struct {
+	NodeID    ids.NodeID `json:"nodeID"`
+	IP        string     `json:"ip"`
+	Direction string     `json:"direction"`
+	Reason    string     `json:"reason"`
+	Time      time.Time  `json:"time"`
+}
+
+type connectionPolicy struct {
+	validators     validators.Manager
+	beacons        validators.Manager
+	trackedSubnets set.Set[ids.ID]
+
+	lock           sync.RWMutex
+	config         ConnectionPolicyConfig
+	allowedNodeIDs set.Set[ids.NodeID]
+	deniedNodeIDs  set.Set[ids.NodeID]
+	allowedCIDRs   []*net.IPNet
+	deniedCIDRs    []*net.IPNet
+
+	rejectedLock sync.Mutex
+	rejected     *linked.Hashmap[ids.NodeID, RejectedPeer]
+}
+
+func newConnectionPolicy(
+	config ConnectionPolicyConfig,
+	validators validators.Manager,
+	beacons validators.Manager,
+	trackedSubnets set.Set[ids.ID],
+) (*connectionPolicy, error) {
+	c := &connectionPolicy{
+		validators:     validators,
+		beacons:        beacons,
+		trackedSubnets: trackedSubnets,
+		rejected:       linked.NewHashmap[ids.NodeID, RejectedPeer](),
+	}
+	return c, c.set(config)
+}
+
+// set replaces the current policy with [config]. If [config] is invalid, the
+// current policy is left unchanged.
+func (c *connectionPolicy) set(config ConnectionPolicyConfig) error {
+	allowedCIDRs, err := parseCIDRs(config.AllowedCIDRs)
+	if err != nil {
+		return err
+	}
+	deniedCIDRs, err := parseCIDRs(config.DeniedCIDRs)
+	if err != nil {
+		return err
+	}
+
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	c.config = config
+	c.allowedNodeIDs = set.Of(config.AllowedNodeIDs...)
+	c.deniedNodeIDs = set.Of(config.DeniedNodeIDs...)
+	c.allowedCIDRs = allowedCIDRs
+	c.deniedCIDRs = deniedCIDRs
+	return nil
+}
+
+func (c *connectionPolicy) get() ConnectionPolicyConfig {
+	c.lock.RLock()
+	defer c.lock.RUnlock()
+
+	return c.config
+}
+
+// allow returns the reason a connection with [nodeID] at [ip] should be
+// rejected, and false if it should be rejected.
+func (c *connectionPolicy) allow(nodeID ids.NodeID, ip net.IP, inbound bool) (string, bool) {
+	c.lock.RLock()
+	defer c.lock.RUnlock()
+
+	if c.deniedNodeIDs.Contains(nodeID) {
+		return deniedNodeReason, false
+	}
+	if containsIP(c.deniedCIDRs, ip) {
+		return deniedIPReason, false
+	}
+
+	explicitlyAllowed := c.allowedNodeIDs.Contains(nodeID)
+	hasAllowlist := c.allowedNodeIDs.Len() > 0 || len(c.allowedCIDRs) > 0
+	if hasAllowlist && !explicitlyAllowed && !containsIP(c.allowedCIDRs, ip) {
+		return notAllowedReason, false
+	}
+
+	if inbound && c.config.ValidatorsOnlyInbound && !explicitlyAllowed && !c.isValidator(nodeID) {
+		return notValidatorReason, false
+	}
+	return "", true
+}
+
+// isValidator returns true if [nodeID] is a beacon or a validator of the
+// primary network or of a tracked subnet.
+func (c *connectionPolicy) isValidator(nodeID ids.NodeID) bool {
+	if _, ok := c.beacons.GetValidator(constants.PrimaryNetworkID, nodeID); ok {
+		return true
+	}
+	if _, ok := c.validators.GetValidator(constants.PrimaryNetworkID, nodeID); ok {
+		return true
+	}
+	for subnetID := range c.trackedSubnets {
+		if _, ok := c.validators.GetValidator(subnetID, nodeID); ok {
+			return true
+		}
+	}
+	return false
+}
+
+func (c *connectionPolicy) markRejected(rejected RejectedPeer) {
+	c.rejectedLock.Lock()
+	defer c.rejectedLock.Unlock()
+
+	c.rejected.Put(rejected.NodeID, rejected)
+	if c.rejected.Len() > maxRejectedPeers {
+		oldest, _, _ := c.rejected.Oldest()
+		c.rejected.Delete(oldest)
+	}
+}
+
+// rejectedPeers returns the most recently rejected peers, newest first.
+func (c *connectionPolicy) rejectedPeers() []RejectedPeer {
+	c.rejectedLock.Lock()
+	defer c.rejectedLock.Unlock()
+
+	rejected := make([]RejectedPeer, c.rejected.Len())
+	i := len(rejected) - 1
+	for it := c.rejected.NewIterator(); it.Next(); i-- {
+		rejected[i] = it.Value()
+	}
+	return rejected
+}
+
+func parseCIDRs(cidrs []string) ([]*net.IPNet, error) {
+	ipNets := make([]*net.IPNet, len(cidrs))
+	for i, cidr := range cidrs {
+		_, ipNet, err := net.ParseCIDR(cidr)
+		if err != nil {
+			return nil, fmt.Errorf("invalid CIDR %q: %w", cidr, err)
+		}
+		ipNets[i] = ipNet
+	}
+	return ipNets, nil
+}
+
+func containsIP(ipNets []*net.IPNet, ip net.IP) bool {
+	for _, ipNet := range ipNets {
+		if ipNet.Contains(ip) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,237 @@
+// Copyright (C) 2019-2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package network
+
+import (
+	"net"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/shubhamdubey02/cryftgo/ids"
+	"github.com/shubhamdubey02/cryftgo/snow/validators"
+	"github.com/shubhamdubey02/cryftgo/utils/constants"
+	"github.com/shubhamdubey02/cryftgo/utils/set"
+)
+
+func TestConnectionPolicyAllow(t *testing.T) {
+	var (
+		validatorID    = ids.GenerateTestNodeID()
+		subnetVdrID    = ids.GenerateTestNodeID()
+		beaconID       = ids.GenerateTestNodeID()
+		allowedID      = ids.GenerateTestNodeID()
+		deniedID       = ids.GenerateTestNodeID()
+		otherID        = ids.GenerateTestNodeID()
+		trackedSubnet  = ids.GenerateTestID()
+		publicIP       = net.ParseIP("1.2.3.4")
+		privateIP      = net.ParseIP("10.0.0.1")
+		deniedIP       = net.ParseIP("192.168.1.1")
+		privateIPRange = "10.0.0.0/8"
+		deniedIPRange  = "192.168.0.0/16"
+	)
+
+	vdrs := validators.NewManager()
+	require.NoError(t, vdrs.AddStaker(constants.PrimaryNetworkID, validatorID, nil, ids.Empty, 1))
+	require.NoError(t, vdrs.AddStaker(trackedSubnet, subnetVdrID, nil, ids.Empty, 1))
+	beacons := validators.NewManager()
+	require.NoError(t, beacons.AddStaker(constants.PrimaryNetworkID, beaconID, nil, ids.Empty, 1))
+
+	tests := []struct {
+		name           string
+		config         ConnectionPolicyConfig
+		nodeID         ids.NodeID
+		ip             net.IP
+		inbound        bool
+		expectedReason string
+		expectedAllow  bool
+	}{
+		{
+			name:          "empty policy",
+			nodeID:        otherID,
+			ip:            publicIP,
+			inbound:       true,
+			expectedAllow: true,
+		},
+		{
+			name: "denied node",
+			config: ConnectionPolicyConfig{
+				AllowedNodeIDs: []ids.NodeID{deniedID},
+				DeniedNodeIDs:  []ids.NodeID{deniedID},
+			},
+			nodeID:         deniedID,
+			ip:             publicIP,
+			expectedReason: deniedNodeReason,
+		},
+		{
+			name: "denied ip",
+			config: ConnectionPolicyConfig{
+				DeniedCIDRs: []string{deniedIPRange},
+			},
+			nodeID:         otherID,
+			ip:             deniedIP,
+			expectedReason: deniedIPReason,
+		},
+		{
+			name: "allowed node",
+			config: ConnectionPolicyConfig{
+				AllowedNodeIDs: []ids.NodeID{allowedID},
+			},
+			nodeID:        allowedID,
+			ip:            publicIP,
+			expectedAllow: true,
+		},
+		{
+			name: "allowed ip",
+			config: ConnectionPolicyConfig{
+				AllowedNodeIDs: []ids.NodeID{allowedID},
+				AllowedCIDRs:   []string{privateIPRange},
+			},
+			nodeID:        otherID,
+			ip:            privateIP,
+			expectedAllow: true,
+		},
+		{
+			name: "not allowed",
+			config: ConnectionPolicyConfig{
+				AllowedCIDRs: []string{privateIPRange},
+			},
+			nodeID:         otherID,
+			ip:             publicIP,
+			expectedReason: notAllowedReason,
+		},
+		{
+			name: "validators only inbound non-validator",
+			config: ConnectionPolicyConfig{
+				ValidatorsOnlyInbound: true,
+			},
+			nodeID:         otherID,
+			ip:             publicIP,
+			inbound:        true,
+			expectedReason: notValidatorReason,
+		},
+		{
+			name: "validators only inbound outbound non-validator",
+			config: ConnectionPolicyConfig{
+				ValidatorsOnlyInbound: true,
+			},
+			nodeID:        otherID,
+			ip:            publicIP,
+			expectedAllow: true,
+		},
+		{
+			name: "validators only inbound primary network validator",
+			config: ConnectionPolicyConfig{
+				ValidatorsOnlyInbound: true,
+			},
+			nodeID:        validatorID,
+			ip:            publicIP,
+			inbound:       true,
+			expectedAllow: true,
+		},
+		{
+			name: "validators only inbound subnet validator",
+			config: ConnectionPolicyConfig{
+				ValidatorsOnlyInbound: true,
+			},
+			nodeID:        subnetVdrID,
+			ip:            publicIP,
+			inbound:       true,
+			expectedAllow: true,
+		},
+		{
+			name: "validators only inbound beacon",
+			config: ConnectionPolicyConfig{
+				ValidatorsOnlyInbound: true,
+			},
+			nodeID:        beaconID,
+			ip:            publicIP,
+			inbound:       true,
+			expectedAllow: true,
+		},
+		{
+			name: "validators only inbound allowed node",
+			config: ConnectionPolicyConfig{
+				AllowedNodeIDs:        []ids.NodeID{allowedID},
+				ValidatorsOnlyInbound: true,
+			},
+			nodeID:        allowedID,
+			ip:            publicIP,
+			inbound:       true,
+			expectedAllow: true,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			require := require.New(t)
+
+			policy, err := newConnectionPolicy(
+				test.config,
+				vdrs,
+				beacons,
+				set.Of(trackedSubnet),
+			)
+			require.NoError(err)
+
+			reason, allow := policy.allow(test.nodeID, test.ip, test.inbound)
+			require.Equal(test.expectedAllow, allow)
+			require.Equal(test.expectedReason, reason)
+		})
+	}
+}
+
+func TestConnectionPolicySetInvalid(t *testing.T) {
+	require := require.New(t)
+
+	config := ConnectionPolicyConfig{
+		DeniedCIDRs: []string{"192.168.0.0/16"},
+	}
+	policy, err := newConnectionPolicy(
+		config,
+		validators.NewManager(),
+		validators.NewManager(),
+		nil,
+	)
+	require.NoError(err)
+
+	err = policy.set(ConnectionPolicyConfig{
+		AllowedCIDRs: []string{"not a cidr"},
+	})
+	var parseErr *net.ParseError
+	require.ErrorAs(err, &parseErr)
+	require.Equal(config, policy.get())
+}
+
+func TestConnectionPolicyRejectedPeers(t *testing.T) {
+	require := require.New(t)
+
+	policy, err := newConnectionPolicy(
+		ConnectionPolicyConfig{},
+		validators.NewManager(),
+		validators.NewManager(),
+		nil,
+	)
+	require.NoError(err)
+
+	nodeIDs := make([]ids.NodeID, maxRejectedPeers+1)
+	for i := range nodeIDs {
+		nodeIDs[i] = ids.GenerateTestNodeID()
+		policy.markRejected(RejectedPeer{
+			NodeID: nodeIDs[i],
+			Time:   time.Unix(int64(i), 0),
+		})
+	}
+
+	// Rejecting a peer again should make it the most recently rejected peer.
+	policy.markRejected(RejectedPeer{
+		NodeID: nodeIDs[1],
+		Time:   time.Unix(int64(len(nodeIDs)), 0),
+	})
+
+	rejected := policy.rejectedPeers()
+	require.Len(rejected, maxRejectedPeers)
+	require.Equal(nodeIDs[1], rejected[0].NodeID)
+	require.Equal(nodeIDs[len(nodeIDs)-1], rejected[1].NodeID)
+	require.Equal(nodeIDs[2], rejected[len(rejected)-1].NodeID)
+}
@@ -31,6 +31,7 @@ type metrics struct {
 	inboundConnRateLimited          prometheus.Counter
 	inboundConnAllowed              prometheus.Counter
 	tlsConnRejected                 prometheus.Counter
+	connPolicyRejected              *prometheus.CounterVec
 	numUselessPeerListBytes         prometheus.Counter
 	nodeUptimeWeightedAverage       prometheus.Gauge
 	nodeUptimeRewardingStake        prometheus.Gauge
@@ -108,6 +109,14 @@ func newMetrics(
 			Name:      "tls_conn_rejected",
 			Help:      "Times this node rejected a connection due to an unsupported TLS certificate",
 		}),
+		connPolicyRejected: prometheus.NewCounterVec(
+			prometheus.CounterOpts{
+				Namespace: namespace,
+				Name:      "conn_policy_rejected",
+				Help:      "Times this node rejected a connection due to the connection policy",
+			},
+			[]string{"direction", "reason"},
+		),
 		numUselessPeerListBytes: prometheus.NewCounter(prometheus.CounterOpts{
 			Namespace: namespace,
 			Name:      "num_useless_peerlist_bytes",
@@ -166,6 +175,7 @@ func newMetrics(
 		registerer.Register(m.acceptFailed),
 		registerer.Register(m.inboundConnAllowed),
 		registerer.Register(m.tlsConnRejected),
+		registerer.Register(m.connPolicyRejected),
 		registerer.Register(m.numUselessPeerListBytes),
 		registerer.Register(m.inboundConnRateLimited),
 		registerer.Register(m.nodeUptimeWeightedAverage),
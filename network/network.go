@@ -26,6 +26,7 @@ import (
 	"github.com/shubhamdubey02/cryftgo/network/peer"
 	"github.com/shubhamdubey02/cryftgo/network/throttling"
 	"github.com/shubhamdubey02/cryftgo/snow/engine/common"
+	"github.com/shubhamdubey02/cryftgo/snow/networking/reputation"
 	"github.com/shubhamdubey02/cryftgo/snow/networking/router"
 	"github.com/shubhamdubey02/cryftgo/snow/networking/sender"
 	"github.com/shubhamdubey02/cryftgo/subnets"
@@ -48,7 +49,8 @@ const (
 )
 
 var (
-	_ Network = (*network)(nil)
+	_ Network             = (*network)(nil)
+	_ reputation.Listener = (*network)(nil)
 
 	errNotValidator        = errors.New("node is not a validator")
 	errNotTracked          = errors.New("subnet is not tracked")
@@ -87,6 +89,17 @@ type Network interface {
 	// NodeUptime returns given node's [subnetID] UptimeResults in the view of
 	// this node's peer validators.
 	NodeUptime(subnetID ids.ID) (UptimeResult, error)
+
+	// ConnectionPolicy returns the current connection policy.
+	ConnectionPolicy() ConnectionPolicyConfig
+
+	// SetConnectionPolicy replaces the current connection policy. Connected
+	// peers that are no longer allowed by the policy are disconnected.
+	SetConnectionPolicy(config ConnectionPolicyConfig) error
+
+	// RejectedPeers returns the peers whose connections were most recently
+	// rejected by the connection policy, newest first.
+	RejectedPeers() []RejectedPeer
 }
 
 type UptimeResult struct {
@@ -120,6 +133,9 @@ type network struct {
 
 	outboundMsgThrottler throttling.OutboundMsgThrottler
 
+	// Restricts which peers connections are allowed with
+	connectionPolicy *connectionPolicy
+
 	// Limits the number of connection attempts based on IP.
 	inboundConnUpgradeThrottler throttling.InboundConnUpgradeThrottler
 	// Listens for and accepts new inbound connections
@@ -205,6 +221,7 @@ func NewNetwork(
 		config.Namespace,
 		metricsRegisterer,
 		config.Validators,
+		config.ChainSubnets,
 		config.ThrottlerConfig.InboundMsgThrottlerConfig,
 		config.ResourceTracker,
 		config.CPUTargeter,
@@ -219,12 +236,23 @@ func NewNetwork(
 		config.Namespace,
 		metricsRegisterer,
 		config.Validators,
+		config.ChainSubnets,
 		config.ThrottlerConfig.OutboundMsgThrottlerConfig,
 	)
 	if err != nil {
 		return nil, fmt.Errorf("initializing outbound message throttler failed with: %w", err)
 	}
 
+	connectionPolicy, err := newConnectionPolicy(
+		config.ConnectionPolicyConfig,
+		config.Validators,
+		config.Beacons,
+		config.TrackedSubnets,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("initializing connection policy failed with: %w", err)
+	}
+
 	peerMetrics, err := peer.NewMetrics(config.Namespace, metricsRegisterer)
 	if err != nil {
 		return nil, fmt.Errorf("initializing peer metrics failed with: %w", err)
@@ -274,6 +302,9 @@ func NewNetwork(
 		ObjectedACPs:         config.ObjectedACPs.List(),
 		ResourceTracker:      config.ResourceTracker,
 		UptimeCalculator:     config.UptimeCalculator,
+		Reputation:           config.ReputationTracker,
+		Recorder:             config.Recorder,
+		ChainSubnets:         config.ChainSubnets,
 		IPSigner:             peer.NewIPSigner(config.MyIPPort, config.TLSKey, config.BLSKey),
 	}
 
@@ -283,6 +314,7 @@ func NewNetwork(
 		peerConfig:           peerConfig,
 		metrics:              metrics,
 		outboundMsgThrottler: outboundMsgThrottler,
+		connectionPolicy:     connectionPolicy,
 
 		inboundConnUpgradeThrottler: throttling.NewInboundConnUpgradeThrottler(log, config.ThrottlerConfig.InboundConnUpgradeThrottlerConfig),
 		listener:                    listener,
@@ -306,6 +338,7 @@ func NewNetwork(
 		router:          router,
 	}
 	n.peerConfig.Network = n
+	config.ReputationTracker.RegisterListener(n)
 	return n, nil
 }
 
@@ -572,7 +605,7 @@ func (n *network) Dispatch() error {
 				zap.Stringer("peerIP", ip),
 			)
 
-			if err := n.upgrade(conn, n.serverUpgrader); err != nil {
+			if err := n.upgrade(conn, n.serverUpgrader, true); err != nil {
 				n.peerConfig.Log.Verbo("failed to upgrade connection",
 					zap.String("direction", "inbound"),
 					zap.Error(err),
@@ -908,6 +941,18 @@ func (n *network) dial(nodeID ids.NodeID, ip *trackedIP) {
 				continue
 			}
 
+			// Skipped dials aren't recorded as rejected connections because
+			// they are retried until [nodeID] is no longer tracked.
+			if _, ok := n.connectionAllowed(nodeID, ip.ip, false); !ok {
+				n.peerConfig.Log.Verbo("skipping connection dial",
+					zap.String("reason", "prohibited by the connection policy"),
+					zap.Stringer("nodeID", nodeID),
+					zap.Stringer("peerIP", ip.ip),
+					zap.Duration("delay", ip.delay),
+				)
+				continue
+			}
+
 			conn, err := n.dialer.Dial(n.onCloseCtx, ip.ip)
 			if err != nil {
 				n.peerConfig.Log.Verbo(
@@ -925,7 +970,7 @@ func (n *network) dial(nodeID ids.NodeID, ip *trackedIP) {
 				zap.Stringer("peerIP", ip.ip),
 			)
 
-			err = n.upgrade(conn, n.clientUpgrader)
+			err = n.upgrade(conn, n.clientUpgrader, false)
 			if err != nil {
 				n.peerConfig.Log.Verbo(
 					"failed to upgrade, attempting again",
@@ -941,14 +986,15 @@ func (n *network) dial(nodeID ids.NodeID, ip *trackedIP) {
 }
 
 // upgrade the provided connection, which may be an inbound connection or an
-// outbound connection, with the provided [upgrader].
+// outbound connection, with the provided [upgrader]. [inbound] should be true
+// if the connection was initiated by the peer.
 //
 // If the connection is successfully upgraded, [nil] will be returned.
 //
 // If the connection is desired by the node, then the resulting upgraded
 // connection will be used to create a new peer. Otherwise the connection will
 // be immediately closed.
-func (n *network) upgrade(conn net.Conn, upgrader peer.Upgrader) error {
+func (n *network) upgrade(conn net.Conn, upgrader peer.Upgrader, inbound bool) error {
 	upgradeTimeout := n.peerConfig.Clock.Time().Add(n.config.ReadHandshakeTimeout)
 	if err := conn.SetReadDeadline(upgradeTimeout); err != nil {
 		_ = conn.Close()
@@ -984,6 +1030,27 @@ func (n *network) upgrade(conn net.Conn, upgrader peer.Upgrader) error {
 		return nil
 	}
 
+	remoteIP, err := ips.ToIPPort(tlsConn.RemoteAddr().String())
+	if err != nil {
+		_ = tlsConn.Close()
+		n.peerConfig.Log.Verbo("failed to parse remote address",
+			zap.Stringer("nodeID", nodeID),
+			zap.Error(err),
+		)
+		return nil
+	}
+
+	if !n.allowConnection(nodeID, remoteIP, inbound) {
+		_ = tlsConn.Close()
+		n.peerConfig.Log.Verbo(
+			"dropping connection",
+			zap.String("reason", "prohibited by the connection policy"),
+			zap.Stringer("nodeID", nodeID),
+			zap.Stringer("peerIP", remoteIP),
+		)
+		return nil
+	}
+
 	if !n.AllowConnection(nodeID) {
 		_ = tlsConn.Close()
 		n.peerConfig.Log.Verbo(
@@ -1042,6 +1109,7 @@ func (n *network) upgrade(conn net.Conn, upgrader peer.Upgrader) error {
 		tlsConn,
 		cert,
 		nodeID,
+		inbound,
 		peer.NewThrottledMessageQueue(
 			n.peerConfig.Metrics,
 			nodeID,
@@ -1064,6 +1132,96 @@ func (n *network) PeerInfo(nodeIDs []ids.NodeID) []peer.Info {
 	return n.connectedPeers.Info(nodeIDs)
 }
 
+func (n *network) ConnectionPolicy() ConnectionPolicyConfig {
+	return n.connectionPolicy.get()
+}
+
+func (n *network) SetConnectionPolicy(config ConnectionPolicyConfig) error {
+	if err := n.connectionPolicy.set(config); err != nil {
+		return err
+	}
+
+	n.peersLock.RLock()
+	connected := n.connectedPeers.Sample(n.connectedPeers.Len(), peer.NoPrecondition)
+	n.peersLock.RUnlock()
+
+	for _, p := range connected {
+		ip, err := ips.ToIPPort(p.Info().IP)
+		if err != nil {
+			continue
+		}
+		if n.allowConnection(p.ID(), ip, p.Inbound()) {
+			continue
+		}
+
+		n.peerConfig.Log.Info("disconnecting from peer",
+			zap.String("reason", "prohibited by the connection policy"),
+			zap.Stringer("nodeID", p.ID()),
+			zap.Stringer("peerIP", ip),
+		)
+		p.StartClose()
+	}
+	return nil
+}
+
+func (n *network) RejectedPeers() []RejectedPeer {
+	return n.connectionPolicy.rejectedPeers()
+}
+
+// Misbehaving disconnects from [nodeID] because of its reputation.
+func (n *network) Misbehaving(nodeID ids.NodeID) {
+	n.peersLock.RLock()
+	peer, connected := n.connectedPeers.GetByID(nodeID)
+	if !connected {
+		peer, connected = n.connectingPeers.GetByID(nodeID)
+	}
+	n.peersLock.RUnlock()
+
+	if !connected {
+		return
+	}
+
+	n.peerConfig.Log.Info("disconnecting from peer",
+		zap.String("reason", "misbehaving"),
+		zap.Stringer("nodeID", nodeID),
+	)
+	peer.StartClose()
+}
+
+// allowConnection returns true if [nodeID] isn't banned and the connection
+// policy allows a connection with [nodeID] at [ip]. Rejected connections are
+// recorded in the metrics and in the recently rejected peers.
+func (n *network) allowConnection(nodeID ids.NodeID, ip ips.IPPort, inbound bool) bool {
+	reason, ok := n.connectionAllowed(nodeID, ip, inbound)
+	if ok {
+		return true
+	}
+
+	direction := outboundDirection
+	if inbound {
+		direction = inboundDirection
+	}
+	n.metrics.connPolicyRejected.WithLabelValues(direction, reason).Inc()
+	n.connectionPolicy.markRejected(RejectedPeer{
+		NodeID:    nodeID,
+		IP:        ip.String(),
+		Direction: direction,
+		Reason:    reason,
+		Time:      n.peerConfig.Clock.Time(),
+	})
+	return false
+}
+
+// connectionAllowed returns true if [nodeID] isn't banned and the connection
+// policy allows a connection with [nodeID] at [ip]. Otherwise, the reason the
+// connection isn't allowed is returned.
+func (n *network) connectionAllowed(nodeID ids.NodeID, ip ips.IPPort, inbound bool) (string, bool) {
+	if _, banned := n.config.ReputationTracker.BannedUntil(nodeID); banned {
+		return bannedReason, false
+	}
+	return n.connectionPolicy.allow(nodeID, ip.IP, inbound)
+}
+
 func (n *network) StartClose() {
 	n.closeOnce.Do(func() {
 		n.peerConfig.Log.Info("shutting down the p2p networking")
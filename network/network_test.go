@@ -12,6 +12,7 @@ import (
 	"time"
 
 	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
 	"github.com/stretchr/testify/require"
 
 	"github.com/shubhamdubey02/cryftgo/ids"
@@ -20,6 +21,7 @@ import (
 	"github.com/shubhamdubey02/cryftgo/network/peer"
 	"github.com/shubhamdubey02/cryftgo/network/throttling"
 	"github.com/shubhamdubey02/cryftgo/snow/engine/common"
+	"github.com/shubhamdubey02/cryftgo/snow/networking/reputation"
 	"github.com/shubhamdubey02/cryftgo/snow/networking/router"
 	"github.com/shubhamdubey02/cryftgo/snow/networking/tracker"
 	"github.com/shubhamdubey02/cryftgo/snow/uptime"
@@ -120,6 +122,9 @@ var (
 
 		MaximumInboundMessageTimeout: 30 * time.Second,
 		ResourceTracker:              newDefaultResourceTracker(),
+		ReputationTracker:            reputation.NewNoTracker(),
+		Recorder:                     peer.NewNoRecorder(),
+		ChainSubnets:                 throttling.NewChainSubnets(),
 		CPUTargeter:                  nil, // Set in init
 		DiskTargeter:                 nil, // Set in init
 	}
@@ -749,3 +754,120 @@ func TestAllowConnectionAsAValidator(t *testing.T) {
 	}
 	wg.Wait()
 }
+
+func TestSetConnectionPolicyDisconnectsInboundPeers(t *testing.T) {
+	require := require.New(t)
+
+	nodeIDs, networks, wg := newFullyConnectedTestNetwork(t, []router.InboundHandler{nil, nil})
+
+	// [nodeIDs[1]] dialed [nodeIDs[0]], so it is an inbound peer of
+	// [networks[0]].
+	net0 := networks[0]
+	require.NoError(net0.config.Validators.RemoveWeight(constants.PrimaryNetworkID, nodeIDs[1], 1))
+	require.NoError(net0.SetConnectionPolicy(ConnectionPolicyConfig{
+		ValidatorsOnlyInbound: true,
+	}))
+
+	require.Eventually(
+		func() bool {
+			net0.peersLock.RLock()
+			defer net0.peersLock.RUnlock()
+
+			_, connected := net0.connectedPeers.GetByID(nodeIDs[1])
+			return !connected
+		},
+		10*time.Second,
+		50*time.Millisecond,
+	)
+	rejected := net0.metrics.connPolicyRejected.WithLabelValues(inboundDirection, notValidatorReason)
+	require.Positive(testutil.ToFloat64(rejected))
+
+	for _, net := range networks {
+		net.StartClose()
+	}
+	wg.Wait()
+}
+
+func TestDialDoesNotRecordSkippedDialsAsRejected(t *testing.T) {
+	require := require.New(t)
+
+	dialer, listeners, nodeIDs, configs := newTestNetwork(t, 2)
+	configs[1].ConnectionPolicyConfig = ConnectionPolicyConfig{
+		DeniedNodeIDs: []ids.NodeID{nodeIDs[0]},
+	}
+
+	networks := make([]Network, len(configs))
+	for i, config := range configs {
+		msgCreator := newMessageCreator(t)
+		registry := prometheus.NewRegistry()
+
+		beacons := validators.NewManager()
+		require.NoError(beacons.AddStaker(constants.PrimaryNetworkID, nodeIDs[0], nil, ids.GenerateTestID(), 1))
+
+		vdrs := validators.NewManager()
+		for _, nodeID := range nodeIDs {
+			require.NoError(vdrs.AddStaker(constants.PrimaryNetworkID, nodeID, nil, ids.GenerateTestID(), 1))
+		}
+
+		config := config
+
+		config.Beacons = beacons
+		config.Validators = vdrs
+
+		net, err := NewNetwork(
+			config,
+			msgCreator,
+			registry,
+			logging.NoLog{},
+			listeners[i],
+			dialer,
+			&testHandler{
+				InboundHandler: nil,
+				ConnectedF: func(ids.NodeID, *version.Application, ids.ID) {
+					require.FailNow("unexpectedly connected to a peer")
+				},
+				DisconnectedF: nil,
+			},
+		)
+		require.NoError(err)
+		networks[i] = net
+	}
+
+	wg := sync.WaitGroup{}
+	wg.Add(len(networks))
+	for i, net := range networks {
+		if i != 0 {
+			config := configs[0]
+			net.ManuallyTrack(config.MyNodeID, config.MyIPPort.IPPort())
+		}
+
+		go func(net Network) {
+			defer wg.Done()
+
+			require.NoError(net.Dispatch())
+		}(net)
+	}
+
+	network := networks[1].(*network)
+	require.Eventually(
+		func() bool {
+			network.peersLock.RLock()
+			defer network.peersLock.RUnlock()
+
+			nodeID := nodeIDs[0]
+			require.Contains(network.trackedIPs, nodeID)
+			ip := network.trackedIPs[nodeID]
+			return ip.getDelay() != 0
+		},
+		10*time.Second,
+		50*time.Millisecond,
+	)
+	rejected := network.metrics.connPolicyRejected.WithLabelValues(outboundDirection, deniedNodeReason)
+	require.Zero(testutil.ToFloat64(rejected))
+	require.Empty(network.RejectedPeers())
+
+	for _, net := range networks {
+		net.StartClose()
+	}
+	wg.Wait()
+}
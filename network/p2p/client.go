@@ -48,16 +48,25 @@ type Client struct {
 	router        *router
 	sender        common.AppSender
 	options       *clientOptions
+	appRequest    AppRequestFunc
 }
 
 // AppRequestAny issues an AppRequest to an arbitrary node decided by Client.
 // If a specific node needs to be requested, use AppRequest instead.
 // See AppRequest for more docs.
+//
+// If the Client was configured WithRetryPolicy, the request may be sent to
+// multiple nodes and [onResponse] is invoked once with the first response, or
+// with the last error if every attempt failed.
 func (c *Client) AppRequestAny(
 	ctx context.Context,
 	appRequestBytes []byte,
 	onResponse AppResponseCallback,
 ) error {
+	if c.options.retryPolicy != nil {
+		return c.appRequestWithRetries(ctx, appRequestBytes, onResponse)
+	}
+
 	sampled := c.options.nodeSampler.Sample(ctx, 1)
 	if len(sampled) != 1 {
 		return ErrNoPeers
@@ -74,6 +83,22 @@ func (c *Client) AppRequest(
 	nodeIDs set.Set[ids.NodeID],
 	appRequestBytes []byte,
 	onResponse AppResponseCallback,
+) error {
+	for nodeID := range nodeIDs {
+		if err := c.appRequest(ctx, nodeID, appRequestBytes, onResponse); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// sendAppRequest issues a request to [nodeID] without applying any
+// ClientMiddleware.
+func (c *Client) sendAppRequest(
+	ctx context.Context,
+	nodeID ids.NodeID,
+	appRequestBytes []byte,
+	onResponse AppResponseCallback,
 ) error {
 	// Cancellation is removed from this context to avoid erroring unexpectedly.
 	// SendAppRequest should be non-blocking and any error other than context
@@ -86,39 +111,39 @@ func (c *Client) AppRequest(
 	c.router.lock.Lock()
 	defer c.router.lock.Unlock()
 
-	appRequestBytes = PrefixMessage(c.handlerPrefix, appRequestBytes)
-	for nodeID := range nodeIDs {
-		requestID := c.router.requestID
-		if _, ok := c.router.pendingAppRequests[requestID]; ok {
-			return fmt.Errorf(
-				"failed to issue request with request id %d: %w",
-				requestID,
-				ErrRequestPending,
-			)
-		}
-
-		if err := c.sender.SendAppRequest(
-			ctxWithoutCancel,
-			set.Of(nodeID),
+	requestID := c.router.requestID
+	if _, ok := c.router.pendingAppRequests[requestID]; ok || c.router.cancelledAppRequests.Contains(requestID) {
+		return fmt.Errorf(
+			"failed to issue request with request id %d: %w",
 			requestID,
-			appRequestBytes,
-		); err != nil {
-			c.router.log.Error("unexpected error when sending message",
-				zap.Stringer("op", message.AppRequestOp),
-				zap.Stringer("nodeID", nodeID),
-				zap.Uint32("requestID", requestID),
-				zap.Error(err),
-			)
-			return err
-		}
+			ErrRequestPending,
+		)
+	}
 
-		c.router.pendingAppRequests[requestID] = pendingAppRequest{
-			handlerID: c.handlerIDStr,
-			callback:  onResponse,
-		}
-		c.router.requestID += 2
+	if err := c.sender.SendAppRequest(
+		ctxWithoutCancel,
+		set.Of(nodeID),
+		requestID,
+		PrefixMessage(c.handlerPrefix, appRequestBytes),
+	); err != nil {
+		c.router.log.Error("unexpected error when sending message",
+			zap.Stringer("op", message.AppRequestOp),
+			zap.Stringer("nodeID", nodeID),
+			zap.Uint32("requestID", requestID),
+			zap.Error(err),
+		)
+		return err
+	}
+
+	c.router.pendingAppRequests[requestID] = pendingAppRequest{
+		handlerID: c.handlerIDStr,
+		callback:  onResponse,
 	}
+	c.router.requestID += 2
 
+	if onSent, ok := ctx.Value(appRequestSentKey{}).(appRequestSentFunc); ok {
+		onSent(nodeID, requestID)
+	}
 	return nil
 }
 
@@ -232,9 +232,23 @@ func (p *PullGossiper[_]) handleResponse(
 	nodeID ids.NodeID,
 	responseBytes []byte,
 	err error,
+) {
+	handlePullResponse(p.log, p.marshaller, p.set, p.metrics, nodeID, responseBytes, err)
+}
+
+// handlePullResponse adds the gossip in a response to a pull gossip request
+// sent to [nodeID] to [set].
+func handlePullResponse[T Gossipable](
+	log logging.Logger,
+	marshaller Marshaller[T],
+	set Set[T],
+	metrics Metrics,
+	nodeID ids.NodeID,
+	responseBytes []byte,
+	err error,
 ) {
 	if err != nil {
-		p.log.Debug(
+		log.Debug(
 			"failed gossip request",
 			zap.Stringer("nodeID", nodeID),
 			zap.Error(err),
@@ -244,7 +258,7 @@ func (p *PullGossiper[_]) handleResponse(
 
 	gossip, err := ParseAppResponse(responseBytes)
 	if err != nil {
-		p.log.Debug("failed to unmarshal gossip response", zap.Error(err))
+		log.Debug("failed to unmarshal gossip response", zap.Error(err))
 		return
 	}
 
@@ -252,9 +266,9 @@ func (p *PullGossiper[_]) handleResponse(
 	for _, bytes := range gossip {
 		receivedBytes += len(bytes)
 
-		gossipable, err := p.marshaller.UnmarshalGossip(bytes)
+		gossipable, err := marshaller.UnmarshalGossip(bytes)
 		if err != nil {
-			p.log.Debug(
+			log.Debug(
 				"failed to unmarshal gossip",
 				zap.Stringer("nodeID", nodeID),
 				zap.Error(err),
@@ -263,13 +277,13 @@ func (p *PullGossiper[_]) handleResponse(
 		}
 
 		gossipID := gossipable.GossipID()
-		p.log.Debug(
+		log.Debug(
 			"received gossip",
 			zap.Stringer("nodeID", nodeID),
 			zap.Stringer("id", gossipID),
 		)
-		if err := p.set.Add(gossipable); err != nil {
-			p.log.Debug(
+		if err := set.Add(gossipable); err != nil {
+			log.Debug(
 				"failed to add gossip to the known set",
 				zap.Stringer("nodeID", nodeID),
 				zap.Stringer("id", gossipID),
@@ -279,8 +293,8 @@ func (p *PullGossiper[_]) handleResponse(
 		}
 	}
 
-	if err := p.metrics.observeMessage(receivedPullLabels, len(gossip), receivedBytes); err != nil {
-		p.log.Error("failed to update metrics",
+	if err := metrics.observeMessage(receivedPullLabels, len(gossip), receivedBytes); err != nil {
+		log.Error("failed to update metrics",
 			zap.Error(err),
 		)
 	}
@@ -603,6 +603,305 @@ func TestPushGossiper(t *testing.T) {
 	}
 }
 
+func TestReconcileGossiperGossip(t *testing.T) {
+	tests := []struct {
+		name        string
+		requester   []*testTx // what we have
+		responder   []*testTx // what the peer we're requesting gossip from has
+		expectedLen int
+	}{
+		{
+			name: "no gossip - no one knows anything",
+		},
+		{
+			name:        "no gossip - requester knows more than responder",
+			requester:   []*testTx{{id: ids.ID{0}}, {id: ids.ID{1}}},
+			responder:   []*testTx{{id: ids.ID{0}}},
+			expectedLen: 2,
+		},
+		{
+			name:        "gossip - requester knows nothing",
+			responder:   []*testTx{{id: ids.ID{0}}, {id: ids.ID{1}}},
+			expectedLen: 2,
+		},
+		{
+			name:        "gossip - requester and responder know different gossip",
+			requester:   []*testTx{{id: ids.ID{0}}, {id: ids.ID{1}}},
+			responder:   []*testTx{{id: ids.ID{1}}, {id: ids.ID{2}}},
+			expectedLen: 3,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			require := require.New(t)
+			ctx := context.Background()
+
+			responseSender := &common.FakeSender{
+				SentAppResponse: make(chan []byte, 1),
+			}
+			responseNetwork, err := p2p.NewNetwork(logging.NoLog{}, responseSender, prometheus.NewRegistry(), "")
+			require.NoError(err)
+
+			responseBloom, err := NewBloomFilter(prometheus.NewRegistry(), "", 1000, 0.01, 0.05)
+			require.NoError(err)
+			responseSet := &testSet{
+				txs:   make(map[ids.ID]*testTx),
+				bloom: responseBloom,
+			}
+			for _, item := range tt.responder {
+				require.NoError(responseSet.Add(item))
+			}
+
+			metrics, err := NewMetrics(prometheus.NewRegistry(), "")
+			require.NoError(err)
+			marshaller := testMarshaller{}
+			handler := NewReconcileHandler[*testTx](
+				logging.NoLog{},
+				marshaller,
+				responseSet,
+				metrics,
+				units.MiB,
+			)
+			require.NoError(responseNetwork.AddHandler(0x0, handler))
+
+			requestSender := &common.FakeSender{
+				SentAppRequest: make(chan []byte, 1),
+			}
+			requestNetwork, err := p2p.NewNetwork(logging.NoLog{}, requestSender, prometheus.NewRegistry(), "")
+			require.NoError(err)
+			require.NoError(requestNetwork.Connected(context.Background(), ids.EmptyNodeID, nil))
+
+			bloom, err := NewBloomFilter(prometheus.NewRegistry(), "", 1000, 0.01, 0.05)
+			require.NoError(err)
+			requestSet := &testSet{
+				txs:   make(map[ids.ID]*testTx),
+				bloom: bloom,
+			}
+			for _, item := range tt.requester {
+				require.NoError(requestSet.Add(item))
+			}
+
+			gossiper := NewReconcilePullGossiper[*testTx](
+				logging.NoLog{},
+				marshaller,
+				requestSet,
+				requestNetwork.NewClient(0x0),
+				metrics,
+				1,
+				30,
+			)
+			received := set.Set[*testTx]{}
+			requestSet.onAdd = func(tx *testTx) {
+				received.Add(tx)
+			}
+
+			require.NoError(gossiper.Gossip(ctx))
+			require.NoError(responseNetwork.AppRequest(ctx, ids.EmptyNodeID, 1, time.Time{}, <-requestSender.SentAppRequest))
+			require.NoError(requestNetwork.AppResponse(ctx, ids.EmptyNodeID, 1, <-responseSender.SentAppResponse))
+
+			require.Len(requestSet.txs, tt.expectedLen)
+			for _, tx := range tt.responder {
+				require.Contains(requestSet.txs, tx.id)
+			}
+
+			// we should not receive anything that we already had before we
+			// requested the gossip
+			for _, tx := range tt.requester {
+				require.NotContains(received, tx)
+			}
+		})
+	}
+}
+
+func TestKnownSetPushGossiper(t *testing.T) {
+	require := require.New(t)
+	ctx := context.Background()
+
+	sender := &common.FakeSender{
+		SentAppGossip: make(chan []byte, 1),
+	}
+	network, err := p2p.NewNetwork(logging.NoLog{}, sender, prometheus.NewRegistry(), "")
+	require.NoError(err)
+	client := network.NewClient(0)
+
+	bloom, err := NewBloomFilter(prometheus.NewRegistry(), "", 1000, 0.01, 0.05)
+	require.NoError(err)
+	gossipSet := &testSet{
+		txs:   make(map[ids.ID]*testTx),
+		bloom: bloom,
+	}
+	require.NoError(gossipSet.Add(&testTx{id: ids.ID{0}}))
+
+	metrics, err := NewMetrics(prometheus.NewRegistry(), "")
+	require.NoError(err)
+	marshaller := testMarshaller{}
+	knownSets := NewKnownSets(10, 10)
+
+	nodeID := ids.GenerateTestNodeID()
+	gossiper, err := NewKnownSetPushGossiper[*testTx](
+		marshaller,
+		gossipSet,
+		testNodeSampler{nodeIDs: []ids.NodeID{nodeID}},
+		client,
+		metrics,
+		knownSets,
+		1,
+		units.MiB,
+	)
+	require.NoError(err)
+
+	requireGossip := func(expected ...*testTx) {
+		if len(expected) == 0 {
+			select {
+			case <-sender.SentAppGossip:
+				require.FailNow("unexpectedly sent gossip message")
+			default:
+			}
+			return
+		}
+
+		want := make([][]byte, 0, len(expected))
+		for _, gossipable := range expected {
+			bytes, err := marshaller.MarshalGossip(gossipable)
+			require.NoError(err)
+			want = append(want, bytes)
+		}
+
+		// remove the handler prefix
+		sentMsg := <-sender.SentAppGossip
+		got := &sdk.PushGossip{}
+		require.NoError(proto.Unmarshal(sentMsg[1:], got))
+		require.Equal(want, got.Gossip)
+	}
+
+	// Unknown gossip is sent to the peer
+	require.NoError(gossiper.Gossip(ctx))
+	requireGossip(&testTx{id: ids.ID{0}})
+
+	// Gossip that was already sent to the peer isn't resent
+	require.NoError(gossiper.Gossip(ctx))
+	requireGossip()
+
+	// Gossip that was received from the peer isn't sent back
+	handler := NewKnownSetHandler[*testTx](
+		logging.NoLog{},
+		marshaller,
+		gossipSet,
+		metrics,
+		knownSets,
+		units.MiB,
+	)
+	gossipBytes, err := MarshalAppGossip([][]byte{{1: 1, 31: 0}})
+	require.NoError(err)
+	handler.AppGossip(ctx, nodeID, gossipBytes)
+	require.True(gossipSet.Has(ids.ID{1: 1}))
+	require.True(knownSets.Has(nodeID, ids.ID{1: 1}))
+
+	require.NoError(gossiper.Gossip(ctx))
+	requireGossip()
+
+	// Gossip that the peer doesn't know about is sent
+	require.NoError(gossipSet.Add(&testTx{id: ids.ID{2}}))
+	require.NoError(gossiper.Gossip(ctx))
+	requireGossip(&testTx{id: ids.ID{2}})
+}
+
+func TestWeightedPushGossiper(t *testing.T) {
+	require := require.New(t)
+	ctx := context.Background()
+
+	sender := &common.FakeSender{
+		SentAppGossip: make(chan []byte, 1),
+	}
+	network, err := p2p.NewNetwork(logging.NoLog{}, sender, prometheus.NewRegistry(), "")
+	require.NoError(err)
+	client := network.NewClient(0)
+	validators := p2p.NewValidators(
+		&p2p.Peers{},
+		logging.NoLog{},
+		constants.PrimaryNetworkID,
+		&validators.TestState{
+			GetCurrentHeightF: func(context.Context) (uint64, error) {
+				return 1, nil
+			},
+			GetValidatorSetF: func(context.Context, uint64, ids.ID) (map[ids.NodeID]*validators.GetValidatorOutput, error) {
+				return nil, nil
+			},
+		},
+		time.Hour,
+	)
+
+	bloom, err := NewBloomFilter(prometheus.NewRegistry(), "", 1000, 0.01, 0.05)
+	require.NoError(err)
+	gossipSet := &testSet{
+		txs:   make(map[ids.ID]*testTx),
+		bloom: bloom,
+	}
+	require.NoError(gossipSet.Add(&testTx{id: ids.ID{0}}))
+	require.NoError(gossipSet.Add(&testTx{id: ids.ID{2}}))
+	require.NoError(gossipSet.Add(&testTx{id: ids.ID{3}}))
+
+	metrics, err := NewMetrics(prometheus.NewRegistry(), "")
+	require.NoError(err)
+	marshaller := testMarshaller{}
+
+	gossiper, err := NewWeightedPushGossiper[*testTx](
+		marshaller,
+		gossipSet,
+		validators,
+		client,
+		metrics,
+		1,
+		2*ids.IDLen,
+	)
+	require.NoError(err)
+
+	// Gossip that was dropped from the set isn't sent, and gossip exceeding the
+	// target gossip size is sent in the next cycle.
+	gossiper.Add(
+		&testTx{id: ids.ID{0}},
+		&testTx{id: ids.ID{1}},
+		&testTx{id: ids.ID{2}},
+		&testTx{id: ids.ID{3}},
+	)
+	for _, expected := range [][]ids.ID{
+		{{0}, {2}},
+		{{3}},
+	} {
+		require.NoError(gossiper.Gossip(ctx))
+
+		// remove the handler prefix
+		sentMsg := <-sender.SentAppGossip
+		got := &sdk.PushGossip{}
+		require.NoError(proto.Unmarshal(sentMsg[1:], got))
+
+		want := make([][]byte, 0, len(expected))
+		for _, gossipID := range expected {
+			bytes, err := marshaller.MarshalGossip(&testTx{id: gossipID})
+			require.NoError(err)
+			want = append(want, bytes)
+		}
+		require.Equal(want, got.Gossip)
+	}
+
+	// There is nothing left to gossip
+	require.NoError(gossiper.Gossip(ctx))
+	select {
+	case <-sender.SentAppGossip:
+		require.FailNow("unexpectedly sent gossip message")
+	default:
+	}
+}
+
+type testNodeSampler struct {
+	nodeIDs []ids.NodeID
+}
+
+func (t testNodeSampler) Sample(_ context.Context, limit int) []ids.NodeID {
+	return t.nodeIDs[:min(limit, len(t.nodeIDs))]
+}
+
 type testValidatorSet struct {
 	validators set.Set[ids.NodeID]
 }
@@ -34,12 +34,28 @@ func NewHandler[T Gossipable](
 	}
 }
 
+// NewKnownSetHandler returns a Handler that marks gossip pushed by a peer as
+// known by that peer in [knownSets].
+func NewKnownSetHandler[T Gossipable](
+	log logging.Logger,
+	marshaller Marshaller[T],
+	set Set[T],
+	metrics Metrics,
+	knownSets *KnownSets,
+	targetResponseSize int,
+) *Handler[T] {
+	h := NewHandler[T](log, marshaller, set, metrics, targetResponseSize)
+	h.knownSets = knownSets
+	return h
+}
+
 type Handler[T Gossipable] struct {
 	p2p.Handler
 	marshaller         Marshaller[T]
 	log                logging.Logger
 	set                Set[T]
 	metrics            Metrics
+	knownSets          *KnownSets // nil if known sets aren't tracked
 	targetResponseSize int
 }
 
@@ -102,11 +118,15 @@ func (h Handler[_]) AppGossip(_ context.Context, nodeID ids.NodeID, gossipBytes
 			continue
 		}
 
+		gossipID := gossipable.GossipID()
+		if h.knownSets != nil {
+			h.knownSets.Add(nodeID, gossipID)
+		}
 		if err := h.set.Add(gossipable); err != nil {
 			h.log.Debug(
 				"failed to add gossip to the known set",
 				zap.Stringer("nodeID", nodeID),
-				zap.Stringer("id", gossipable.GossipID()),
+				zap.Stringer("id", gossipID),
 				zap.Error(err),
 			)
 		}
@@ -0,0 +1,181 @@
+// Copyright (C) 2019-2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package gossip
+
+import (
+	"context"
+	"fmt"
+	"sync"
+
+	"github.com/shubhamdubey02/cryftgo/cache"
+	"github.com/shubhamdubey02/cryftgo/ids"
+	"github.com/shubhamdubey02/cryftgo/network/p2p"
+	"github.com/shubhamdubey02/cryftgo/snow/engine/common"
+	"github.com/shubhamdubey02/cryftgo/utils/set"
+)
+
+var _ Gossiper = (*KnownSetPushGossiper[*testTx])(nil)
+
+// NewKnownSets returns a KnownSets that tracks at most [maxPerPeer] gossip IDs
+// for each of the [maxPeers] most recently used peers.
+func NewKnownSets(maxPeers int, maxPerPeer int) *KnownSets {
+	return &KnownSets{
+		maxPerPeer: maxPerPeer,
+		peers:      &cache.LRU[ids.NodeID, *cache.LRU[ids.ID, struct{}]]{Size: maxPeers},
+	}
+}
+
+// KnownSets tracks the gossip each peer is known to have, either because it was
+// sent to the peer or because it was received from the peer.
+type KnownSets struct {
+	maxPerPeer int
+
+	// Protects the creation of per-peer known sets.
+	lock  sync.Mutex
+	peers *cache.LRU[ids.NodeID, *cache.LRU[ids.ID, struct{}]]
+}
+
+// Add marks [gossipIDs] as known by [nodeID].
+func (k *KnownSets) Add(nodeID ids.NodeID, gossipIDs ...ids.ID) {
+	k.lock.Lock()
+	known, ok := k.peers.Get(nodeID)
+	if !ok {
+		known = &cache.LRU[ids.ID, struct{}]{Size: k.maxPerPeer}
+		k.peers.Put(nodeID, known)
+	}
+	k.lock.Unlock()
+
+	for _, gossipID := range gossipIDs {
+		known.Put(gossipID, struct{}{})
+	}
+}
+
+// Has returns true if [gossipID] is known by [nodeID].
+func (k *KnownSets) Has(nodeID ids.NodeID, gossipID ids.ID) bool {
+	k.lock.Lock()
+	known, ok := k.peers.Get(nodeID)
+	k.lock.Unlock()
+	if !ok {
+		return false
+	}
+
+	_, ok = known.Get(gossipID)
+	return ok
+}
+
+// NewKnownSetPushGossiper returns an instance of KnownSetPushGossiper.
+//
+// Gossip received by a Handler created with [NewKnownSetHandler] and the same
+// [knownSets] isn't sent back to the peer it was received from.
+func NewKnownSetPushGossiper[T Gossipable](
+	marshaller Marshaller[T],
+	set Set[T],
+	peers p2p.NodeSampler,
+	client *p2p.Client,
+	metrics Metrics,
+	knownSets *KnownSets,
+	numPeers int,
+	targetGossipSize int,
+) (*KnownSetPushGossiper[T], error) {
+	switch {
+	case numPeers < 0:
+		return nil, ErrInvalidNumPeers
+	case numPeers == 0:
+		return nil, ErrInvalidNumToGossip
+	case targetGossipSize < 0:
+		return nil, ErrInvalidTargetGossipSize
+	}
+
+	return &KnownSetPushGossiper[T]{
+		marshaller:       marshaller,
+		set:              set,
+		peers:            peers,
+		client:           client,
+		metrics:          metrics,
+		knownSets:        knownSets,
+		numPeers:         numPeers,
+		targetGossipSize: targetGossipSize,
+	}, nil
+}
+
+// KnownSetPushGossiper pushes the gossip in a set to sampled peers. Each peer is
+// only sent the gossip it isn't known to have, so gossip is never resent to a
+// peer unless it's evicted from the peer's known set.
+type KnownSetPushGossiper[T Gossipable] struct {
+	marshaller       Marshaller[T]
+	set              Set[T]
+	peers            p2p.NodeSampler
+	client           *p2p.Client
+	metrics          Metrics
+	knownSets        *KnownSets
+	numPeers         int
+	targetGossipSize int
+}
+
+// Gossip sends up to [targetGossipSize] bytes of gossip to each of [numPeers]
+// sampled peers.
+func (p *KnownSetPushGossiper[T]) Gossip(ctx context.Context) error {
+	for _, nodeID := range p.peers.Sample(ctx, p.numPeers) {
+		if err := p.gossip(ctx, nodeID); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (p *KnownSetPushGossiper[T]) gossip(ctx context.Context, nodeID ids.NodeID) error {
+	var (
+		err       error
+		sentBytes = 0
+		gossip    = make([][]byte, 0, defaultGossipableCount)
+		gossipIDs = make([]ids.ID, 0, defaultGossipableCount)
+	)
+	p.set.Iterate(func(gossipable T) bool {
+		gossipID := gossipable.GossipID()
+		if p.knownSets.Has(nodeID, gossipID) {
+			return true
+		}
+
+		var bytes []byte
+		bytes, err = p.marshaller.MarshalGossip(gossipable)
+		if err != nil {
+			return false
+		}
+
+		gossip = append(gossip, bytes)
+		gossipIDs = append(gossipIDs, gossipID)
+		sentBytes += len(bytes)
+		return sentBytes < p.targetGossipSize
+	})
+	if err != nil {
+		return err
+	}
+
+	// If there is nothing to gossip, we can exit early.
+	if len(gossip) == 0 {
+		return nil
+	}
+
+	msgBytes, err := MarshalAppGossip(gossip)
+	if err != nil {
+		return err
+	}
+
+	if err := p.metrics.observeMessage(sentPushLabels, len(gossip), sentBytes); err != nil {
+		return err
+	}
+
+	if err := p.client.AppGossip(
+		ctx,
+		common.SendConfig{
+			NodeIDs: set.Of(nodeID),
+		},
+		msgBytes,
+	); err != nil {
+		return fmt.Errorf("failed to gossip to %s: %w", nodeID, err)
+	}
+
+	p.knownSets.Add(nodeID, gossipIDs...)
+	return nil
+}
@@ -34,6 +34,24 @@ func ParseAppRequest(bytes []byte) (*bloom.ReadFilter, ids.ID, error) {
 	return filter, salt, err
 }
 
+func MarshalReconcileAppRequest(salt ids.ID, sketch []byte) ([]byte, error) {
+	request := &sdk.ReconcileGossipRequest{
+		Salt:   salt[:],
+		Sketch: sketch,
+	}
+	return proto.Marshal(request)
+}
+
+func ParseReconcileAppRequest(bytes []byte) (ids.ID, []byte, error) {
+	request := &sdk.ReconcileGossipRequest{}
+	if err := proto.Unmarshal(bytes, request); err != nil {
+		return ids.Empty, nil, err
+	}
+
+	salt, err := ids.ToID(request.Salt)
+	return salt, request.Sketch, err
+}
+
 func MarshalAppResponse(gossip [][]byte) ([]byte, error) {
 	return proto.Marshal(&sdk.PullGossipResponse{
 		Gossip: gossip,
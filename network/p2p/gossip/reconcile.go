@@ -0,0 +1,179 @@
+// Copyright (C) 2019-2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package gossip
+
+import (
+	"context"
+	"crypto/rand"
+	"errors"
+	"time"
+
+	"go.uber.org/zap"
+
+	"github.com/shubhamdubey02/cryftgo/ids"
+	"github.com/shubhamdubey02/cryftgo/network/p2p"
+	"github.com/shubhamdubey02/cryftgo/utils/logging"
+	"github.com/shubhamdubey02/cryftgo/utils/set"
+)
+
+var (
+	_ Gossiper    = (*ReconcilePullGossiper[*testTx])(nil)
+	_ p2p.Handler = (*ReconcileHandler[*testTx])(nil)
+)
+
+// NewReconcilePullGossiper returns a PullGossiper that requests gossip using
+// set reconciliation rather than a bloom filter. Each request contains a sketch
+// of the gossip IDs in [set] with [sketchSize] cells. Peers can find all the
+// gossip they have that isn't in [set] as long as the number of gossip IDs
+// only one of them has is less than about [sketchSize] / 1.5.
+//
+// Peers must handle requests with a ReconcileHandler.
+func NewReconcilePullGossiper[T Gossipable](
+	log logging.Logger,
+	marshaller Marshaller[T],
+	set Set[T],
+	client *p2p.Client,
+	metrics Metrics,
+	pollSize int,
+	sketchSize int,
+) *ReconcilePullGossiper[T] {
+	return &ReconcilePullGossiper[T]{
+		log:        log,
+		marshaller: marshaller,
+		set:        set,
+		client:     client,
+		metrics:    metrics,
+		pollSize:   pollSize,
+		sketchSize: sketchSize,
+	}
+}
+
+type ReconcilePullGossiper[T Gossipable] struct {
+	log        logging.Logger
+	marshaller Marshaller[T]
+	set        Set[T]
+	client     *p2p.Client
+	metrics    Metrics
+	pollSize   int
+	sketchSize int
+}
+
+func (p *ReconcilePullGossiper[T]) Gossip(ctx context.Context) error {
+	// A new salt is used for every request so that gossip IDs that can't be
+	// decoded from one sketch are unlikely to collide in the next one.
+	var salt ids.ID
+	if _, err := rand.Read(salt[:]); err != nil {
+		return err
+	}
+
+	sketch := newSketch(salt, p.sketchSize)
+	p.set.Iterate(func(gossipable T) bool {
+		sketch.Add(gossipable.GossipID())
+		return true
+	})
+
+	msgBytes, err := MarshalReconcileAppRequest(salt, sketch.Marshal())
+	if err != nil {
+		return err
+	}
+
+	for i := 0; i < p.pollSize; i++ {
+		err := p.client.AppRequestAny(ctx, msgBytes, p.handleResponse)
+		if err != nil && !errors.Is(err, p2p.ErrNoPeers) {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (p *ReconcilePullGossiper[_]) handleResponse(
+	_ context.Context,
+	nodeID ids.NodeID,
+	responseBytes []byte,
+	err error,
+) {
+	handlePullResponse(p.log, p.marshaller, p.set, p.metrics, nodeID, responseBytes, err)
+}
+
+// NewReconcileHandler returns a Handler that responds to requests sent by a
+// ReconcilePullGossiper. Push gossip is handled the same as by [Handler].
+func NewReconcileHandler[T Gossipable](
+	log logging.Logger,
+	marshaller Marshaller[T],
+	set Set[T],
+	metrics Metrics,
+	targetResponseSize int,
+) *ReconcileHandler[T] {
+	return &ReconcileHandler[T]{
+		Handler: NewHandler[T](log, marshaller, set, metrics, targetResponseSize),
+	}
+}
+
+type ReconcileHandler[T Gossipable] struct {
+	*Handler[T]
+}
+
+func (h ReconcileHandler[T]) AppRequest(_ context.Context, nodeID ids.NodeID, _ time.Time, requestBytes []byte) ([]byte, error) {
+	salt, sketchBytes, err := ParseReconcileAppRequest(requestBytes)
+	if err != nil {
+		return nil, err
+	}
+	peerSketch, err := parseSketch(salt, sketchBytes)
+	if err != nil {
+		return nil, err
+	}
+
+	sketch := newSketch(salt, len(peerSketch.cells))
+	h.set.Iterate(func(gossipable T) bool {
+		sketch.Add(gossipable.GossipID())
+		return true
+	})
+	if err := sketch.Subtract(peerSketch); err != nil {
+		return nil, err
+	}
+
+	// If the difference was too large to fully decode, the gossip that was
+	// decoded is still sent.
+	missing, _, complete := sketch.Decode()
+	if !complete {
+		h.log.Debug("failed to fully decode gossip sketch",
+			zap.Stringer("nodeID", nodeID),
+			zap.Int("numDecoded", len(missing)),
+		)
+	}
+
+	var (
+		missingIDs   = set.Of(missing...)
+		responseSize = 0
+		gossipBytes  = make([][]byte, 0, len(missing))
+	)
+	if len(missing) > 0 {
+		h.set.Iterate(func(gossipable T) bool {
+			if !missingIDs.Contains(gossipable.GossipID()) {
+				return true
+			}
+
+			var bytes []byte
+			bytes, err = h.marshaller.MarshalGossip(gossipable)
+			if err != nil {
+				return false
+			}
+
+			gossipBytes = append(gossipBytes, bytes)
+			responseSize += len(bytes)
+
+			return responseSize <= h.targetResponseSize
+		})
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	if err := h.metrics.observeMessage(sentPullLabels, len(gossipBytes), responseSize); err != nil {
+		return nil, err
+	}
+
+	return MarshalAppResponse(gossipBytes)
+}
@@ -0,0 +1,209 @@
+// Copyright (C) 2019-2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package gossip
+
+import (
+	"encoding/binary"
+	"errors"
+
+	"github.com/shubhamdubey02/cryftgo/ids"
+	"github.com/shubhamdubey02/cryftgo/utils/hashing"
+)
+
+const (
+	// Each gossip ID is added to one cell in each of [sketchNumHashes]
+	// equally sized partitions of the sketch.
+	sketchNumHashes = 3
+	// count + idSum + hashSum
+	sketchCellLen = 4 + ids.IDLen + 8
+)
+
+var (
+	errInvalidSketchLen = errors.New("invalid sketch length")
+	errSketchMismatch   = errors.New("sketches have different salts or lengths")
+)
+
+// sketch is an invertible bloom lookup table of gossip IDs.
+//
+// Subtracting the sketch of one set from the sketch of another produces a
+// sketch of their symmetric difference, which can be decoded as long as the
+// difference is small relative to the number of cells. This allows two peers
+// to find the gossip IDs only one of them has with a message whose size
+// depends on the size of the difference rather than the size of the sets.
+type sketch struct {
+	salt  ids.ID
+	cells []sketchCell
+}
+
+type sketchCell struct {
+	// Number of gossip IDs added to the cell. Can be negative after
+	// subtracting another sketch.
+	count int32
+	// XOR of the gossip IDs added to the cell.
+	idSum ids.ID
+	// XOR of the checksums of the gossip IDs added to the cell.
+	hashSum uint64
+}
+
+// newSketch returns an empty sketch with at least [numCells] cells.
+func newSketch(salt ids.ID, numCells int) *sketch {
+	numCells = max(numCells, sketchNumHashes)
+	if remainder := numCells % sketchNumHashes; remainder != 0 {
+		numCells += sketchNumHashes - remainder
+	}
+	return &sketch{
+		salt:  salt,
+		cells: make([]sketchCell, numCells),
+	}
+}
+
+// parseSketch parses a sketch marshalled by [sketch.Marshal].
+func parseSketch(salt ids.ID, bytes []byte) (*sketch, error) {
+	if len(bytes) == 0 || len(bytes)%(sketchNumHashes*sketchCellLen) != 0 {
+		return nil, errInvalidSketchLen
+	}
+
+	s := &sketch{
+		salt:  salt,
+		cells: make([]sketchCell, len(bytes)/sketchCellLen),
+	}
+	for i := range s.cells {
+		cellBytes := bytes[i*sketchCellLen : (i+1)*sketchCellLen]
+		cell := &s.cells[i]
+		cell.count = int32(binary.BigEndian.Uint32(cellBytes))
+		copy(cell.idSum[:], cellBytes[4:])
+		cell.hashSum = binary.BigEndian.Uint64(cellBytes[4+ids.IDLen:])
+	}
+	return s, nil
+}
+
+func (s *sketch) Add(gossipID ids.ID) {
+	s.update(gossipID, 1)
+}
+
+// Subtract removes the gossip IDs in [other] from the sketch. [other] must
+// have the same salt and length as the sketch.
+func (s *sketch) Subtract(other *sketch) error {
+	if s.salt != other.salt || len(s.cells) != len(other.cells) {
+		return errSketchMismatch
+	}
+
+	for i := range s.cells {
+		cell := &s.cells[i]
+		otherCell := &other.cells[i]
+		cell.count -= otherCell.count
+		xor(&cell.idSum, otherCell.idSum)
+		cell.hashSum ^= otherCell.hashSum
+	}
+	return nil
+}
+
+// Decode returns the gossip IDs that were added to the sketch more times than
+// they were subtracted, and the gossip IDs that were subtracted more times
+// than they were added. Returns false if the sketch could only be partially
+// decoded.
+//
+// Decoding modifies the sketch.
+func (s *sketch) Decode() ([]ids.ID, []ids.ID, bool) {
+	var (
+		added   []ids.ID
+		removed []ids.ID
+		pure    = make([]int, 0, len(s.cells))
+	)
+	for i := range s.cells {
+		if s.isPure(i) {
+			pure = append(pure, i)
+		}
+	}
+
+	// Peel gossip IDs from cells that only contain a single gossip ID, which
+	// may leave other cells with a single gossip ID.
+	for len(pure) > 0 {
+		i := pure[len(pure)-1]
+		pure = pure[:len(pure)-1]
+		if !s.isPure(i) {
+			continue
+		}
+
+		var (
+			cell     = s.cells[i]
+			gossipID = cell.idSum
+		)
+		if cell.count == 1 {
+			added = append(added, gossipID)
+		} else {
+			removed = append(removed, gossipID)
+		}
+		for _, index := range s.update(gossipID, -cell.count) {
+			if s.isPure(index) {
+				pure = append(pure, index)
+			}
+		}
+	}
+
+	for _, cell := range s.cells {
+		if cell.count != 0 || cell.idSum != ids.Empty || cell.hashSum != 0 {
+			return added, removed, false
+		}
+	}
+	return added, removed, true
+}
+
+func (s *sketch) Marshal() []byte {
+	bytes := make([]byte, len(s.cells)*sketchCellLen)
+	for i, cell := range s.cells {
+		cellBytes := bytes[i*sketchCellLen : (i+1)*sketchCellLen]
+		binary.BigEndian.PutUint32(cellBytes, uint32(cell.count))
+		copy(cellBytes[4:], cell.idSum[:])
+		binary.BigEndian.PutUint64(cellBytes[4+ids.IDLen:], cell.hashSum)
+	}
+	return bytes
+}
+
+// update adds [count] to the cells [gossipID] maps to and returns their
+// indices.
+func (s *sketch) update(gossipID ids.ID, count int32) [sketchNumHashes]int {
+	var (
+		hash, checksum = s.hash(gossipID)
+		cellsPerHash   = len(s.cells) / sketchNumHashes
+		indices        [sketchNumHashes]int
+	)
+	for i := range indices {
+		offset := binary.BigEndian.Uint64(hash[8*i:]) % uint64(cellsPerHash)
+		indices[i] = i*cellsPerHash + int(offset)
+
+		cell := &s.cells[indices[i]]
+		cell.count += count
+		xor(&cell.idSum, gossipID)
+		cell.hashSum ^= checksum
+	}
+	return indices
+}
+
+// isPure returns true if the cell at [index] contains a single gossip ID,
+// either added or subtracted.
+func (s *sketch) isPure(index int) bool {
+	cell := s.cells[index]
+	if cell.count != 1 && cell.count != -1 {
+		return false
+	}
+	_, checksum := s.hash(cell.idSum)
+	return cell.hashSum == checksum
+}
+
+// hash returns the salted hash of [gossipID] used to pick its cells, and the
+// checksum used to detect cells that contain only [gossipID].
+func (s *sketch) hash(gossipID ids.ID) (hashing.Hash256, uint64) {
+	var preimage [2 * ids.IDLen]byte
+	copy(preimage[:], s.salt[:])
+	copy(preimage[ids.IDLen:], gossipID[:])
+	hash := hashing.ComputeHash256Array(preimage[:])
+	return hash, binary.BigEndian.Uint64(hash[8*sketchNumHashes:])
+}
+
+func xor(dst *ids.ID, src ids.ID) {
+	for i := range dst {
+		dst[i] ^= src[i]
+	}
+}
@@ -0,0 +1,141 @@
+// Copyright (C) 2019-2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package gossip
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/shubhamdubey02/cryftgo/ids"
+)
+
+func TestSketchDecode(t *testing.T) {
+	shared := make([]ids.ID, 1000)
+	for i := range shared {
+		shared[i] = ids.GenerateTestID()
+	}
+
+	tests := []struct {
+		name           string
+		numCells       int
+		numOnlyLocal   int
+		numOnlyRemote  int
+		expectComplete bool
+	}{
+		{
+			name:           "identical sets",
+			numCells:       200,
+			expectComplete: true,
+		},
+		{
+			name:           "only local",
+			numCells:       200,
+			numOnlyLocal:   10,
+			expectComplete: true,
+		},
+		{
+			name:           "only remote",
+			numCells:       200,
+			numOnlyRemote:  10,
+			expectComplete: true,
+		},
+		{
+			name:           "both",
+			numCells:       200,
+			numOnlyLocal:   10,
+			numOnlyRemote:  10,
+			expectComplete: true,
+		},
+		{
+			name:           "difference too large",
+			numCells:       3,
+			numOnlyLocal:   100,
+			numOnlyRemote:  100,
+			expectComplete: false,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			require := require.New(t)
+
+			salt := ids.GenerateTestID()
+			local := newSketch(salt, test.numCells)
+			remote := newSketch(salt, test.numCells)
+			for _, gossipID := range shared {
+				local.Add(gossipID)
+				remote.Add(gossipID)
+			}
+
+			onlyLocal := make([]ids.ID, test.numOnlyLocal)
+			for i := range onlyLocal {
+				onlyLocal[i] = ids.GenerateTestID()
+				local.Add(onlyLocal[i])
+			}
+			onlyRemote := make([]ids.ID, test.numOnlyRemote)
+			for i := range onlyRemote {
+				onlyRemote[i] = ids.GenerateTestID()
+				remote.Add(onlyRemote[i])
+			}
+
+			parsedRemote, err := parseSketch(salt, remote.Marshal())
+			require.NoError(err)
+			require.Equal(remote, parsedRemote)
+
+			require.NoError(local.Subtract(parsedRemote))
+			added, removed, complete := local.Decode()
+			require.Equal(test.expectComplete, complete)
+			require.Subset(onlyLocal, added)
+			require.Subset(onlyRemote, removed)
+			if test.expectComplete {
+				require.ElementsMatch(onlyLocal, added)
+				require.ElementsMatch(onlyRemote, removed)
+			}
+		})
+	}
+}
+
+func TestNewSketchRoundsUpCells(t *testing.T) {
+	require := require.New(t)
+
+	require.Len(newSketch(ids.Empty, 0).cells, sketchNumHashes)
+	require.Len(newSketch(ids.Empty, sketchNumHashes+1).cells, 2*sketchNumHashes)
+}
+
+func TestParseSketchInvalidLen(t *testing.T) {
+	tests := []struct {
+		name  string
+		bytes []byte
+	}{
+		{
+			name:  "empty",
+			bytes: nil,
+		},
+		{
+			name:  "partial cell",
+			bytes: make([]byte, sketchNumHashes*sketchCellLen+1),
+		},
+		{
+			name:  "partial partition",
+			bytes: make([]byte, sketchCellLen),
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			_, err := parseSketch(ids.Empty, test.bytes)
+			require.ErrorIs(t, err, errInvalidSketchLen)
+		})
+	}
+}
+
+func TestSketchSubtractMismatch(t *testing.T) {
+	require := require.New(t)
+
+	s := newSketch(ids.Empty, 3)
+	err := s.Subtract(newSketch(ids.GenerateTestID(), 3))
+	require.ErrorIs(err, errSketchMismatch)
+
+	err = s.Subtract(newSketch(ids.Empty, 6))
+	require.ErrorIs(err, errSketchMismatch)
+}
@@ -0,0 +1,128 @@
+// Copyright (C) 2019-2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package gossip
+
+import (
+	"context"
+	"sync"
+
+	"github.com/shubhamdubey02/cryftgo/network/p2p"
+	"github.com/shubhamdubey02/cryftgo/snow/engine/common"
+	"github.com/shubhamdubey02/cryftgo/utils/buffer"
+	"github.com/shubhamdubey02/cryftgo/utils/set"
+)
+
+var _ Gossiper = (*WeightedPushGossiper[*testTx])(nil)
+
+// NewWeightedPushGossiper returns an instance of WeightedPushGossiper
+func NewWeightedPushGossiper[T Gossipable](
+	marshaller Marshaller[T],
+	set Set[T],
+	validators p2p.WeightedValidatorSampler,
+	client *p2p.Client,
+	metrics Metrics,
+	numValidators int,
+	targetGossipSize int,
+) (*WeightedPushGossiper[T], error) {
+	switch {
+	case numValidators < 0:
+		return nil, ErrInvalidNumValidators
+	case numValidators == 0:
+		return nil, ErrInvalidNumToGossip
+	case targetGossipSize < 0:
+		return nil, ErrInvalidTargetGossipSize
+	}
+
+	return &WeightedPushGossiper[T]{
+		marshaller:       marshaller,
+		set:              set,
+		validators:       validators,
+		client:           client,
+		metrics:          metrics,
+		numValidators:    numValidators,
+		targetGossipSize: targetGossipSize,
+		toGossip:         buffer.NewUnboundedDeque[T](0),
+	}, nil
+}
+
+// WeightedPushGossiper pushes gossip to connected validators sampled by stake,
+// so that validators with more stake are more likely to receive gossip
+// directly.
+type WeightedPushGossiper[T Gossipable] struct {
+	marshaller       Marshaller[T]
+	set              Set[T]
+	validators       p2p.WeightedValidatorSampler
+	client           *p2p.Client
+	metrics          Metrics
+	numValidators    int
+	targetGossipSize int
+
+	lock     sync.Mutex
+	toGossip buffer.Deque[T]
+}
+
+// Gossip sends up to [targetGossipSize] bytes of queued gossip to
+// [numValidators] validators sampled by stake. Any remaining gossip is sent in
+// later calls.
+func (p *WeightedPushGossiper[T]) Gossip(ctx context.Context) error {
+	p.lock.Lock()
+	defer p.lock.Unlock()
+
+	var (
+		sentBytes = 0
+		gossip    = make([][]byte, 0, defaultGossipableCount)
+	)
+	for sentBytes < p.targetGossipSize {
+		gossipable, ok := p.toGossip.PopLeft()
+		if !ok {
+			break
+		}
+
+		// Ensure item is still in the set before we gossip.
+		if !p.set.Has(gossipable.GossipID()) {
+			continue
+		}
+
+		bytes, err := p.marshaller.MarshalGossip(gossipable)
+		if err != nil {
+			return err
+		}
+
+		gossip = append(gossip, bytes)
+		sentBytes += len(bytes)
+	}
+
+	// If there is nothing to gossip, we can exit early.
+	if len(gossip) == 0 {
+		return nil
+	}
+
+	msgBytes, err := MarshalAppGossip(gossip)
+	if err != nil {
+		return err
+	}
+
+	if err := p.metrics.observeMessage(sentPushLabels, len(gossip), sentBytes); err != nil {
+		return err
+	}
+
+	validators := p.validators.SampleByWeight(ctx, p.numValidators)
+	return p.client.AppGossip(
+		ctx,
+		common.SendConfig{
+			NodeIDs: set.Of(validators...),
+		},
+		msgBytes,
+	)
+}
+
+// Add enqueues new gossipables to be pushed.
+func (p *WeightedPushGossiper[T]) Add(gossipables ...T) {
+	p.lock.Lock()
+	defer p.lock.Unlock()
+
+	for _, gossipable := range gossipables {
+		p.toGossip.PushRight(gossipable)
+	}
+}
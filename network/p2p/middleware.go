@@ -0,0 +1,333 @@
+// Copyright (C) 2019-2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package p2p
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"go.uber.org/zap"
+
+	"github.com/shubhamdubey02/cryftgo/ids"
+	"github.com/shubhamdubey02/cryftgo/message"
+	"github.com/shubhamdubey02/cryftgo/snow/networking/reputation"
+	"github.com/shubhamdubey02/cryftgo/utils/logging"
+)
+
+var (
+	ErrDeadlineExceeded = errors.New("deadline exceeded")
+
+	_ Handler = (*loggingHandler)(nil)
+	_ Handler = (*latencyHandler)(nil)
+	_ Handler = (*deadlineHandler)(nil)
+	_ Handler = (*reputationHandler)(nil)
+)
+
+// HandlerMiddleware wraps the Handler registered for [handlerID].
+type HandlerMiddleware func(handlerID uint64, handler Handler) Handler
+
+// ClientMiddleware wraps the AppRequests sent by the Client for [handlerID].
+type ClientMiddleware func(handlerID uint64, next AppRequestFunc) AppRequestFunc
+
+// AppRequestFunc sends [appRequestBytes] to [nodeID].
+// [onResponse] is invoked upon an error or a response.
+type AppRequestFunc func(
+	ctx context.Context,
+	nodeID ids.NodeID,
+	appRequestBytes []byte,
+	onResponse AppResponseCallback,
+) error
+
+// chainHandler applies [middlewares] to [handler]. The first middleware is the
+// first to handle a message.
+func chainHandler(handlerID uint64, handler Handler, middlewares []HandlerMiddleware) Handler {
+	for i := len(middlewares) - 1; i >= 0; i-- {
+		handler = middlewares[i](handlerID, handler)
+	}
+	return handler
+}
+
+// chainClient applies [middlewares] to [appRequest]. The first middleware is
+// the first to see a request.
+func chainClient(handlerID uint64, appRequest AppRequestFunc, middlewares []ClientMiddleware) AppRequestFunc {
+	for i := len(middlewares) - 1; i >= 0; i-- {
+		appRequest = middlewares[i](handlerID, appRequest)
+	}
+	return appRequest
+}
+
+// NewValidatorMiddleware drops messages from non-validators.
+func NewValidatorMiddleware(validatorSet ValidatorSet, log logging.Logger) HandlerMiddleware {
+	return func(_ uint64, handler Handler) Handler {
+		return NewValidatorHandler(handler, validatorSet, log)
+	}
+}
+
+// NewThrottlerMiddleware drops messages from nodes throttled by [throttler].
+func NewThrottlerMiddleware(throttler Throttler, log logging.Logger) HandlerMiddleware {
+	return func(_ uint64, handler Handler) Handler {
+		return NewThrottlerHandler(handler, throttler, log)
+	}
+}
+
+// NewLoggingMiddleware logs every message handled and how long it took to
+// handle.
+func NewLoggingMiddleware(log logging.Logger) HandlerMiddleware {
+	return func(handlerID uint64, handler Handler) Handler {
+		return &loggingHandler{
+			handler:   handler,
+			handlerID: handlerID,
+			log:       log,
+		}
+	}
+}
+
+type loggingHandler struct {
+	handler   Handler
+	handlerID uint64
+	log       logging.Logger
+}
+
+func (l *loggingHandler) AppGossip(ctx context.Context, nodeID ids.NodeID, gossipBytes []byte) {
+	start := time.Now()
+	l.handler.AppGossip(ctx, nodeID, gossipBytes)
+	l.log.Debug("handled message",
+		zap.Stringer("messageOp", message.AppGossipOp),
+		zap.Stringer("nodeID", nodeID),
+		zap.Uint64("handlerID", l.handlerID),
+		zap.Int("size", len(gossipBytes)),
+		zap.Duration("duration", time.Since(start)),
+	)
+}
+
+func (l *loggingHandler) AppRequest(ctx context.Context, nodeID ids.NodeID, deadline time.Time, requestBytes []byte) ([]byte, error) {
+	start := time.Now()
+	response, err := l.handler.AppRequest(ctx, nodeID, deadline, requestBytes)
+	l.log.Debug("handled message",
+		zap.Stringer("messageOp", message.AppRequestOp),
+		zap.Stringer("nodeID", nodeID),
+		zap.Uint64("handlerID", l.handlerID),
+		zap.Time("deadline", deadline),
+		zap.Int("size", len(requestBytes)),
+		zap.Int("responseSize", len(response)),
+		zap.Duration("duration", time.Since(start)),
+		zap.Error(err),
+	)
+	return response, err
+}
+
+func (l *loggingHandler) CrossChainAppRequest(ctx context.Context, chainID ids.ID, deadline time.Time, requestBytes []byte) ([]byte, error) {
+	start := time.Now()
+	response, err := l.handler.CrossChainAppRequest(ctx, chainID, deadline, requestBytes)
+	l.log.Debug("handled message",
+		zap.Stringer("messageOp", message.CrossChainAppRequestOp),
+		zap.Stringer("chainID", chainID),
+		zap.Uint64("handlerID", l.handlerID),
+		zap.Time("deadline", deadline),
+		zap.Int("size", len(requestBytes)),
+		zap.Int("responseSize", len(response)),
+		zap.Duration("duration", time.Since(start)),
+		zap.Error(err),
+	)
+	return response, err
+}
+
+// NewLatencyMiddleware returns a middleware that reports a histogram of how
+// long each handler takes to handle messages.
+func NewLatencyMiddleware(registerer prometheus.Registerer, namespace string) (HandlerMiddleware, error) {
+	latency := prometheus.NewHistogramVec(
+		prometheus.HistogramOpts{
+			Namespace: namespace,
+			Name:      "handler_latency",
+			Help:      "time spent handling messages (s)",
+		},
+		labelNames,
+	)
+	if err := registerer.Register(latency); err != nil {
+		return nil, err
+	}
+
+	return func(handlerID uint64, handler Handler) Handler {
+		handlerIDStr := strconv.FormatUint(handlerID, 10)
+		return &latencyHandler{
+			handler:                  handler,
+			appGossipLatency:         latency.WithLabelValues(message.AppGossipOp.String(), handlerIDStr),
+			appRequestLatency:        latency.WithLabelValues(message.AppRequestOp.String(), handlerIDStr),
+			crossChainRequestLatency: latency.WithLabelValues(message.CrossChainAppRequestOp.String(), handlerIDStr),
+		}
+	}, nil
+}
+
+type latencyHandler struct {
+	handler                  Handler
+	appGossipLatency         prometheus.Observer
+	appRequestLatency        prometheus.Observer
+	crossChainRequestLatency prometheus.Observer
+}
+
+func (l *latencyHandler) AppGossip(ctx context.Context, nodeID ids.NodeID, gossipBytes []byte) {
+	start := time.Now()
+	l.handler.AppGossip(ctx, nodeID, gossipBytes)
+	l.appGossipLatency.Observe(time.Since(start).Seconds())
+}
+
+func (l *latencyHandler) AppRequest(ctx context.Context, nodeID ids.NodeID, deadline time.Time, requestBytes []byte) ([]byte, error) {
+	start := time.Now()
+	response, err := l.handler.AppRequest(ctx, nodeID, deadline, requestBytes)
+	l.appRequestLatency.Observe(time.Since(start).Seconds())
+	return response, err
+}
+
+func (l *latencyHandler) CrossChainAppRequest(ctx context.Context, chainID ids.ID, deadline time.Time, requestBytes []byte) ([]byte, error) {
+	start := time.Now()
+	response, err := l.handler.CrossChainAppRequest(ctx, chainID, deadline, requestBytes)
+	l.crossChainRequestLatency.Observe(time.Since(start).Seconds())
+	return response, err
+}
+
+// NewDeadlineMiddleware drops requests whose deadline has already passed and
+// cancels the context passed to the handler once the deadline passes.
+func NewDeadlineMiddleware() HandlerMiddleware {
+	return func(_ uint64, handler Handler) Handler {
+		return &deadlineHandler{
+			handler: handler,
+		}
+	}
+}
+
+type deadlineHandler struct {
+	handler Handler
+}
+
+func (d *deadlineHandler) AppGossip(ctx context.Context, nodeID ids.NodeID, gossipBytes []byte) {
+	d.handler.AppGossip(ctx, nodeID, gossipBytes)
+}
+
+func (d *deadlineHandler) AppRequest(ctx context.Context, nodeID ids.NodeID, deadline time.Time, requestBytes []byte) ([]byte, error) {
+	if deadline.IsZero() {
+		return d.handler.AppRequest(ctx, nodeID, deadline, requestBytes)
+	}
+	if !time.Now().Before(deadline) {
+		return nil, fmt.Errorf("dropping message from %s: %w", nodeID, ErrDeadlineExceeded)
+	}
+
+	ctx, cancel := context.WithDeadline(ctx, deadline)
+	defer cancel()
+
+	return d.handler.AppRequest(ctx, nodeID, deadline, requestBytes)
+}
+
+func (d *deadlineHandler) CrossChainAppRequest(ctx context.Context, chainID ids.ID, deadline time.Time, requestBytes []byte) ([]byte, error) {
+	if deadline.IsZero() {
+		return d.handler.CrossChainAppRequest(ctx, chainID, deadline, requestBytes)
+	}
+	if !time.Now().Before(deadline) {
+		return nil, fmt.Errorf("dropping message from %s: %w", chainID, ErrDeadlineExceeded)
+	}
+
+	ctx, cancel := context.WithDeadline(ctx, deadline)
+	defer cancel()
+
+	return d.handler.CrossChainAppRequest(ctx, chainID, deadline, requestBytes)
+}
+
+// NewReputationMiddleware reports peers whose requests fail to be handled to
+// [reporter]. Requests dropped by middlewares that run after this one are
+// reported too, so this should run after the validator and throttler
+// middlewares if their errors should not count against a peer.
+func NewReputationMiddleware(reporter reputation.Reporter) HandlerMiddleware {
+	return func(_ uint64, handler Handler) Handler {
+		return &reputationHandler{
+			handler:  handler,
+			reporter: reporter,
+		}
+	}
+}
+
+type reputationHandler struct {
+	handler  Handler
+	reporter reputation.Reporter
+}
+
+func (r *reputationHandler) AppGossip(ctx context.Context, nodeID ids.NodeID, gossipBytes []byte) {
+	r.handler.AppGossip(ctx, nodeID, gossipBytes)
+}
+
+func (r *reputationHandler) AppRequest(ctx context.Context, nodeID ids.NodeID, deadline time.Time, requestBytes []byte) ([]byte, error) {
+	response, err := r.handler.AppRequest(ctx, nodeID, deadline, requestBytes)
+	if err != nil {
+		r.reporter.Report(nodeID, reputation.InvalidAppRequest)
+	}
+	return response, err
+}
+
+func (r *reputationHandler) CrossChainAppRequest(ctx context.Context, chainID ids.ID, deadline time.Time, requestBytes []byte) ([]byte, error) {
+	return r.handler.CrossChainAppRequest(ctx, chainID, deadline, requestBytes)
+}
+
+// NewClientLoggingMiddleware logs every request sent by a Client and the
+// outcome of the request.
+func NewClientLoggingMiddleware(log logging.Logger) ClientMiddleware {
+	return func(handlerID uint64, next AppRequestFunc) AppRequestFunc {
+		return func(ctx context.Context, nodeID ids.NodeID, appRequestBytes []byte, onResponse AppResponseCallback) error {
+			start := time.Now()
+			err := next(ctx, nodeID, appRequestBytes, func(ctx context.Context, nodeID ids.NodeID, responseBytes []byte, err error) {
+				log.Debug("received response",
+					zap.Stringer("nodeID", nodeID),
+					zap.Uint64("handlerID", handlerID),
+					zap.Int("responseSize", len(responseBytes)),
+					zap.Duration("duration", time.Since(start)),
+					zap.Error(err),
+				)
+				onResponse(ctx, nodeID, responseBytes, err)
+			})
+			log.Debug("sent request",
+				zap.Stringer("nodeID", nodeID),
+				zap.Uint64("handlerID", handlerID),
+				zap.Int("size", len(appRequestBytes)),
+				zap.Error(err),
+			)
+			return err
+		}
+	}
+}
+
+// NewClientLatencyMiddleware returns a middleware that reports a histogram of
+// how long requests sent by each Client take to complete.
+func NewClientLatencyMiddleware(registerer prometheus.Registerer, namespace string) (ClientMiddleware, error) {
+	latency := prometheus.NewHistogramVec(
+		prometheus.HistogramOpts{
+			Namespace: namespace,
+			Name:      "request_latency",
+			Help:      "time until a request is responded to or fails (s)",
+		},
+		labelNames,
+	)
+	if err := registerer.Register(latency); err != nil {
+		return nil, err
+	}
+
+	return func(handlerID uint64, next AppRequestFunc) AppRequestFunc {
+		handlerIDStr := strconv.FormatUint(handlerID, 10)
+		var (
+			responseLatency = latency.WithLabelValues(message.AppResponseOp.String(), handlerIDStr)
+			errorLatency    = latency.WithLabelValues(message.AppErrorOp.String(), handlerIDStr)
+		)
+		return func(ctx context.Context, nodeID ids.NodeID, appRequestBytes []byte, onResponse AppResponseCallback) error {
+			start := time.Now()
+			return next(ctx, nodeID, appRequestBytes, func(ctx context.Context, nodeID ids.NodeID, responseBytes []byte, err error) {
+				if err != nil {
+					errorLatency.Observe(time.Since(start).Seconds())
+				} else {
+					responseLatency.Observe(time.Since(start).Seconds())
+				}
+				onResponse(ctx, nodeID, responseBytes, err)
+			})
+		}
+	}, nil
+}
@@ -0,0 +1,205 @@
+// Copyright (C) 2019-2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package p2p
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/stretchr/testify/require"
+
+	"github.com/shubhamdubey02/cryftgo/ids"
+	"github.com/shubhamdubey02/cryftgo/snow/engine/common"
+	"github.com/shubhamdubey02/cryftgo/snow/networking/reputation"
+	"github.com/shubhamdubey02/cryftgo/utils/logging"
+	"github.com/shubhamdubey02/cryftgo/utils/set"
+)
+
+func TestHandlerMiddlewareOrder(t *testing.T) {
+	require := require.New(t)
+	ctx := context.Background()
+
+	var calls []string
+	middleware := func(name string) HandlerMiddleware {
+		return func(gotHandlerID uint64, handler Handler) Handler {
+			require.Equal(uint64(handlerID), gotHandlerID)
+			return TestHandler{
+				AppGossipF: func(ctx context.Context, nodeID ids.NodeID, gossipBytes []byte) {
+					calls = append(calls, name)
+					handler.AppGossip(ctx, nodeID, gossipBytes)
+				},
+			}
+		}
+	}
+
+	network, err := NewNetwork(logging.NoLog{}, &common.FakeSender{}, prometheus.NewRegistry(), "")
+	require.NoError(err)
+	require.NoError(network.AddHandler(
+		handlerID,
+		TestHandler{
+			AppGossipF: func(context.Context, ids.NodeID, []byte) {
+				calls = append(calls, "handler")
+			},
+		},
+		middleware("first"),
+		middleware("second"),
+	))
+
+	require.NoError(network.AppGossip(ctx, ids.GenerateTestNodeID(), PrefixMessage(ProtocolPrefix(handlerID), nil)))
+	require.Equal([]string{"first", "second", "handler"}, calls)
+}
+
+func TestDeadlineMiddlewareAppRequest(t *testing.T) {
+	tests := []struct {
+		name        string
+		deadline    time.Time
+		expectedErr error
+	}{
+		{
+			name: "no deadline",
+		},
+		{
+			name:     "deadline in the future",
+			deadline: time.Now().Add(time.Hour),
+		},
+		{
+			name:        "deadline exceeded",
+			deadline:    time.Now().Add(-time.Second),
+			expectedErr: ErrDeadlineExceeded,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			require := require.New(t)
+
+			handler := NewDeadlineMiddleware()(handlerID, TestHandler{
+				AppRequestF: func(ctx context.Context, _ ids.NodeID, deadline time.Time, _ []byte) ([]byte, error) {
+					ctxDeadline, ok := ctx.Deadline()
+					require.Equal(!deadline.IsZero(), ok)
+					require.Equal(deadline, ctxDeadline)
+					return nil, nil
+				},
+			})
+
+			_, err := handler.AppRequest(context.Background(), ids.GenerateTestNodeID(), tt.deadline, []byte("foobar"))
+			require.ErrorIs(err, tt.expectedErr)
+		})
+	}
+}
+
+func TestLatencyMiddleware(t *testing.T) {
+	require := require.New(t)
+
+	registry := prometheus.NewRegistry()
+	middleware, err := NewLatencyMiddleware(registry, "")
+	require.NoError(err)
+
+	handler := middleware(handlerID, NoOpHandler{})
+	handler.AppGossip(context.Background(), ids.GenerateTestNodeID(), nil)
+	_, err = handler.AppRequest(context.Background(), ids.GenerateTestNodeID(), time.Time{}, nil)
+	require.NoError(err)
+
+	metrics, err := registry.Gather()
+	require.NoError(err)
+	require.Len(metrics, 1)
+
+	var count uint64
+	for _, metric := range metrics[0].Metric {
+		count += metric.Histogram.GetSampleCount()
+	}
+	require.Equal(uint64(2), count)
+}
+
+type testReporter struct {
+	reports map[ids.NodeID][]reputation.Offense
+}
+
+func (r *testReporter) Report(nodeID ids.NodeID, offense reputation.Offense) {
+	r.reports[nodeID] = append(r.reports[nodeID], offense)
+}
+
+func TestReputationMiddleware(t *testing.T) {
+	require := require.New(t)
+	ctx := context.Background()
+	errFoobar := errors.New("foobar")
+
+	reporter := &testReporter{
+		reports: make(map[ids.NodeID][]reputation.Offense),
+	}
+	handler := NewReputationMiddleware(reporter)(handlerID, TestHandler{
+		AppRequestF: func(_ context.Context, _ ids.NodeID, _ time.Time, requestBytes []byte) ([]byte, error) {
+			if len(requestBytes) == 0 {
+				return nil, errFoobar
+			}
+			return requestBytes, nil
+		},
+	})
+
+	honest := ids.GenerateTestNodeID()
+	_, err := handler.AppRequest(ctx, honest, time.Time{}, []byte("foobar"))
+	require.NoError(err)
+
+	malicious := ids.GenerateTestNodeID()
+	_, err = handler.AppRequest(ctx, malicious, time.Time{}, nil)
+	require.ErrorIs(err, errFoobar)
+
+	handler.AppGossip(ctx, malicious, nil)
+
+	require.Equal(
+		map[ids.NodeID][]reputation.Offense{
+			malicious: {reputation.InvalidAppRequest},
+		},
+		reporter.reports,
+	)
+}
+
+func TestClientMiddleware(t *testing.T) {
+	require := require.New(t)
+	ctx := context.Background()
+
+	sender := common.FakeSender{
+		SentAppRequest: make(chan []byte, 1),
+	}
+	network, err := NewNetwork(logging.NoLog{}, sender, prometheus.NewRegistry(), "")
+	require.NoError(err)
+
+	latencyMiddleware, err := NewClientLatencyMiddleware(prometheus.NewRegistry(), "")
+	require.NoError(err)
+
+	var (
+		sentNodeID  ids.NodeID
+		gotResponse []byte
+	)
+	client := network.NewClient(
+		handlerID,
+		WithClientMiddleware(
+			NewClientLoggingMiddleware(logging.NoLog{}),
+			latencyMiddleware,
+			func(_ uint64, next AppRequestFunc) AppRequestFunc {
+				return func(ctx context.Context, nodeID ids.NodeID, appRequestBytes []byte, onResponse AppResponseCallback) error {
+					sentNodeID = nodeID
+					return next(ctx, nodeID, appRequestBytes, func(ctx context.Context, nodeID ids.NodeID, responseBytes []byte, err error) {
+						gotResponse = responseBytes
+						onResponse(ctx, nodeID, responseBytes, err)
+					})
+				}
+			},
+		),
+	)
+
+	nodeID := ids.GenerateTestNodeID()
+	done := make(chan struct{})
+	require.NoError(client.AppRequest(ctx, set.Of(nodeID), []byte("request"), func(context.Context, ids.NodeID, []byte, error) {
+		close(done)
+	}))
+	require.Equal(nodeID, sentNodeID)
+	require.Equal([]byte("request"), (<-sender.SentAppRequest)[1:])
+
+	require.NoError(network.AppResponse(ctx, nodeID, 1, []byte("response")))
+	<-done
+	require.Equal([]byte("response"), gotResponse)
+}
@@ -49,10 +49,22 @@ func WithValidatorSampling(validators *Validators) ClientOption {
 	})
 }
 
+// WithClientMiddleware configures the Client to send its AppRequests through
+// [middlewares]. The first middleware is the first to see a request.
+func WithClientMiddleware(middlewares ...ClientMiddleware) ClientOption {
+	return clientOptionFunc(func(options *clientOptions) {
+		options.middlewares = append(options.middlewares, middlewares...)
+	})
+}
+
 // clientOptions holds client-configurable values
 type clientOptions struct {
 	// nodeSampler is used to select nodes to route Client.AppRequestAny to
 	nodeSampler NodeSampler
+	// middlewares wrap every AppRequest sent by the Client
+	middlewares []ClientMiddleware
+	// retryPolicy, if non-nil, is followed by Client.AppRequestAny
+	retryPolicy *RetryPolicy
 }
 
 // NewNetwork returns an instance of Network
@@ -165,13 +177,16 @@ func (n *Network) NewClient(handlerID uint64, options ...ClientOption) *Client {
 	for _, option := range options {
 		option.apply(client.options)
 	}
+	client.appRequest = chainClient(handlerID, client.sendAppRequest, client.options.middlewares)
 
 	return client
 }
 
-// AddHandler reserves an identifier for an application protocol
-func (n *Network) AddHandler(handlerID uint64, handler Handler) error {
-	return n.router.addHandler(handlerID, handler)
+// AddHandler reserves an identifier for an application protocol. Messages are
+// passed through [middlewares] before reaching [handler]. The first middleware
+// is the first to handle a message.
+func (n *Network) AddHandler(handlerID uint64, handler Handler, middlewares ...HandlerMiddleware) error {
+	return n.router.addHandler(handlerID, chainHandler(handlerID, handler, middlewares))
 }
 
 // Peers contains metadata about the current set of connected peers
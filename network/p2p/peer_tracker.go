@@ -238,6 +238,19 @@ func (p *PeerTracker) RegisterFailure(nodeID ids.NodeID) {
 	p.updateBandwidth(nodeID, 0, false)
 }
 
+// Record that a request to [nodeID] was cancelled before it completed.
+//
+// Adds the peer's bandwidth averager, if any, back to the bandwidth heap
+// without observing a new bandwidth.
+func (p *PeerTracker) RegisterCancellation(nodeID ids.NodeID) {
+	p.lock.Lock()
+	defer p.lock.Unlock()
+
+	if peerBandwidth, ok := p.peerBandwidth[nodeID]; ok && p.trackedPeers.Contains(nodeID) {
+		p.bandwidthHeap.Push(nodeID, peerBandwidth)
+	}
+}
+
 func (p *PeerTracker) updateBandwidth(nodeID ids.NodeID, bandwidth float64, responsive bool) {
 	p.lock.Lock()
 	defer p.lock.Unlock()
@@ -0,0 +1,304 @@
+// Copyright (C) 2019-2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package p2p
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"go.uber.org/zap"
+
+	"github.com/shubhamdubey02/cryftgo/ids"
+	"github.com/shubhamdubey02/cryftgo/utils/set"
+)
+
+const epsilon = 1e-6 // small amount to add to time to avoid division by 0
+
+// RetryPolicy configures Client.AppRequestAny to retry failed requests and to
+// hedge slow requests.
+type RetryPolicy struct {
+	// MaxAttempts is the maximum number of peers a request is sent to,
+	// including both retries and hedged requests. Values less than 1 are
+	// treated as 1.
+	MaxAttempts int
+	// HedgeDelay is how long to wait for a response before sending another
+	// copy of the request to a different peer. Requests are not hedged if
+	// HedgeDelay is 0.
+	HedgeDelay time.Duration
+	// PeerTracker, if non-nil, is used to select peers and is informed of the
+	// outcome of every request. If it can't provide a peer that hasn't been
+	// tried yet, peers are sampled by the Client's NodeSampler.
+	PeerTracker *PeerTracker
+}
+
+// WithRetryPolicy configures Client.AppRequestAny to follow [policy].
+func WithRetryPolicy(policy RetryPolicy) ClientOption {
+	return clientOptionFunc(func(options *clientOptions) {
+		policy.MaxAttempts = max(policy.MaxAttempts, 1)
+		options.retryPolicy = &policy
+	})
+}
+
+// appRequestSentKey is the context key of an appRequestSentFunc, which
+// sendAppRequest invokes with the request ID of every request it sends. This
+// allows retryingRequest to learn the request IDs of its copies through any
+// ClientMiddleware.
+type appRequestSentKey struct{}
+
+type appRequestSentFunc func(nodeID ids.NodeID, requestID uint32)
+
+// retryingRequest is an AppRequest that may be sent to multiple peers. The
+// first response is passed to the caller's callback. Once a response has been
+// passed to the callback, the remaining copies of the request are cancelled:
+// they are removed from the router, so their responses are dropped, and they
+// aren't reported to the PeerTracker as either a response or a failure.
+//
+// The AppSender has no way to cancel a request, so a cancelled copy still
+// holds its outbound request slot until the engine delivers its response or
+// times it out.
+type retryingRequest struct {
+	client          *Client
+	policy          *RetryPolicy
+	ctx             context.Context
+	appRequestBytes []byte
+	onResponse      AppResponseCallback
+
+	lock        sync.Mutex
+	done        bool
+	tried       set.Set[ids.NodeID]
+	outstanding int
+	hedgeTimer  *time.Timer
+	// pending maps the request IDs of the copies that may still be pending in
+	// the router to the peer they were sent to.
+	pending map[uint32]ids.NodeID
+}
+
+// appRequestWithRetries issues an AppRequest following the Client's
+// RetryPolicy.
+func (c *Client) appRequestWithRetries(
+	ctx context.Context,
+	appRequestBytes []byte,
+	onResponse AppResponseCallback,
+) error {
+	r := &retryingRequest{
+		client:          c,
+		policy:          c.options.retryPolicy,
+		ctx:             ctx,
+		appRequestBytes: appRequestBytes,
+		onResponse:      onResponse,
+		pending:         make(map[uint32]ids.NodeID),
+	}
+	r.ctx = context.WithValue(ctx, appRequestSentKey{}, appRequestSentFunc(r.sent))
+	return r.send()
+}
+
+// send sends a copy of the request to a peer that hasn't been tried yet, unless
+// the request is already done.
+func (r *retryingRequest) send() error {
+	r.lock.Lock()
+	if r.done {
+		r.lock.Unlock()
+		return nil
+	}
+	nodeID, ok := r.selectPeer()
+	if !ok {
+		r.lock.Unlock()
+		return ErrNoPeers
+	}
+	r.tried.Add(nodeID)
+	r.outstanding++
+	r.lock.Unlock()
+
+	if r.policy.PeerTracker != nil {
+		r.policy.PeerTracker.RegisterRequest(nodeID)
+	}
+
+	start := time.Now()
+	err := r.client.appRequest(
+		r.ctx,
+		nodeID,
+		r.appRequestBytes,
+		func(ctx context.Context, nodeID ids.NodeID, responseBytes []byte, err error) {
+			r.handleResponse(ctx, nodeID, start, responseBytes, err)
+		},
+	)
+
+	r.lock.Lock()
+	if err != nil {
+		r.outstanding--
+		r.lock.Unlock()
+		if r.policy.PeerTracker != nil {
+			r.policy.PeerTracker.RegisterFailure(nodeID)
+		}
+		return err
+	}
+
+	if r.done {
+		// A different copy of the request finished while this copy was being
+		// sent.
+		r.cancel()
+		return nil
+	}
+	defer r.lock.Unlock()
+
+	if r.policy.HedgeDelay > 0 && r.tried.Len() < r.policy.MaxAttempts {
+		if r.hedgeTimer != nil {
+			r.hedgeTimer.Stop()
+		}
+		r.hedgeTimer = time.AfterFunc(r.policy.HedgeDelay, r.hedge)
+	}
+	return nil
+}
+
+// sent records that a copy of the request was sent to [nodeID] with
+// [requestID].
+//
+// Invoked while the router's lock is held.
+func (r *retryingRequest) sent(nodeID ids.NodeID, requestID uint32) {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+
+	r.pending[requestID] = nodeID
+}
+
+// hedge sends another copy of the request if a response hasn't been received
+// yet.
+func (r *retryingRequest) hedge() {
+	if err := r.send(); err != nil {
+		r.client.router.log.Debug("failed to hedge request",
+			zap.Uint64("handlerID", r.client.handlerID),
+			zap.Error(err),
+		)
+	}
+}
+
+func (r *retryingRequest) handleResponse(
+	ctx context.Context,
+	nodeID ids.NodeID,
+	start time.Time,
+	responseBytes []byte,
+	err error,
+) {
+	r.lock.Lock()
+	r.outstanding--
+	if r.done {
+		// A different copy of the request already finished, so this copy
+		// raced with its cancellation.
+		r.lock.Unlock()
+		if r.policy.PeerTracker != nil {
+			r.policy.PeerTracker.RegisterCancellation(nodeID)
+		}
+		return
+	}
+
+	if err == nil {
+		r.finish()
+		r.cancel()
+		r.registerResponse(nodeID, start, responseBytes)
+		r.onResponse(ctx, nodeID, responseBytes, nil)
+		return
+	}
+
+	canRetry := r.tried.Len() < r.policy.MaxAttempts
+	r.lock.Unlock()
+
+	if r.policy.PeerTracker != nil {
+		r.policy.PeerTracker.RegisterFailure(nodeID)
+	}
+
+	if canRetry {
+		retryErr := r.send()
+		if retryErr == nil {
+			return
+		}
+		r.client.router.log.Debug("failed to retry request",
+			zap.Uint64("handlerID", r.client.handlerID),
+			zap.Stringer("failedNodeID", nodeID),
+			zap.Error(retryErr),
+		)
+	}
+
+	// Only report the failure once no other copies of the request could
+	// still succeed.
+	r.lock.Lock()
+	if r.done || r.outstanding > 0 {
+		r.lock.Unlock()
+		return
+	}
+	r.finish()
+	r.lock.Unlock()
+
+	r.onResponse(ctx, nodeID, nil, err)
+}
+
+// finish marks the request as done.
+//
+// Assumes [r.lock] is held.
+func (r *retryingRequest) finish() {
+	r.done = true
+	if r.hedgeTimer != nil {
+		r.hedgeTimer.Stop()
+	}
+}
+
+// cancel removes the copies of the request that are still pending from the
+// router and releases [r.lock].
+//
+// Assumes [r.lock] is held and that the router's lock isn't held.
+func (r *retryingRequest) cancel() {
+	requestIDs := make([]uint32, 0, len(r.pending))
+	nodeIDs := make(map[uint32]ids.NodeID, len(r.pending))
+	for requestID, nodeID := range r.pending {
+		requestIDs = append(requestIDs, requestID)
+		nodeIDs[requestID] = nodeID
+	}
+	clear(r.pending)
+	r.lock.Unlock()
+
+	for _, requestID := range r.client.router.cancelAppRequests(requestIDs) {
+		if r.policy.PeerTracker != nil {
+			r.policy.PeerTracker.RegisterCancellation(nodeIDs[requestID])
+		}
+	}
+}
+
+func (r *retryingRequest) registerResponse(nodeID ids.NodeID, start time.Time, responseBytes []byte) {
+	if r.policy.PeerTracker == nil {
+		return
+	}
+	elapsedSeconds := time.Since(start).Seconds()
+	bandwidth := float64(len(responseBytes)) / (elapsedSeconds + epsilon)
+	r.policy.PeerTracker.RegisterResponse(nodeID, bandwidth)
+}
+
+// selectPeer returns a peer that hasn't been sent the request yet.
+//
+// Assumes [r.lock] is held.
+func (r *retryingRequest) selectPeer() (ids.NodeID, bool) {
+	if r.tried.Len() >= r.policy.MaxAttempts {
+		return ids.EmptyNodeID, false
+	}
+
+	if r.policy.PeerTracker != nil {
+		if nodeID, ok := r.policy.PeerTracker.SelectPeer(); ok && !r.tried.Contains(nodeID) {
+			return nodeID, true
+		}
+	}
+
+	// The sampler may return peers that were already tried, so the number of
+	// sampled peers is increased until an untried peer is sampled or the
+	// sampler runs out of peers.
+	for limit := r.tried.Len() + 1; ; limit *= 2 {
+		sampled := r.client.options.nodeSampler.Sample(r.ctx, limit)
+		for _, nodeID := range sampled {
+			if !r.tried.Contains(nodeID) {
+				return nodeID, true
+			}
+		}
+		if len(sampled) < limit {
+			return ids.EmptyNodeID, false
+		}
+	}
+}
@@ -0,0 +1,250 @@
+// Copyright (C) 2019-2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package p2p
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/stretchr/testify/require"
+
+	"github.com/shubhamdubey02/cryftgo/ids"
+	"github.com/shubhamdubey02/cryftgo/snow/engine/common"
+	"github.com/shubhamdubey02/cryftgo/utils/logging"
+	"github.com/shubhamdubey02/cryftgo/utils/set"
+	"github.com/shubhamdubey02/cryftgo/version"
+)
+
+type sentAppRequest struct {
+	nodeID    ids.NodeID
+	requestID uint32
+}
+
+type appResponse struct {
+	nodeID        ids.NodeID
+	responseBytes []byte
+	err           error
+}
+
+func newRetryTestClient(t *testing.T, numPeers int, policy RetryPolicy, options ...ClientOption) (*Network, *Client, chan sentAppRequest) {
+	require := require.New(t)
+
+	sent := make(chan sentAppRequest, numPeers)
+	sender := &common.SenderTest{
+		SendAppRequestF: func(_ context.Context, nodeIDs set.Set[ids.NodeID], requestID uint32, _ []byte) error {
+			for nodeID := range nodeIDs {
+				sent <- sentAppRequest{
+					nodeID:    nodeID,
+					requestID: requestID,
+				}
+			}
+			return nil
+		},
+	}
+
+	network, err := NewNetwork(logging.NoLog{}, sender, prometheus.NewRegistry(), "")
+	require.NoError(err)
+	for i := 0; i < numPeers; i++ {
+		require.NoError(network.Connected(context.Background(), ids.GenerateTestNodeID(), &version.Application{}))
+	}
+	options = append([]ClientOption{WithRetryPolicy(policy)}, options...)
+	return network, network.NewClient(handlerID, options...), sent
+}
+
+func TestAppRequestAnyRetriesFailures(t *testing.T) {
+	require := require.New(t)
+	ctx := context.Background()
+
+	network, client, sent := newRetryTestClient(t, 2, RetryPolicy{
+		MaxAttempts: 2,
+	})
+
+	responses := make(chan appResponse, 1)
+	require.NoError(client.AppRequestAny(ctx, []byte("request"), func(_ context.Context, nodeID ids.NodeID, responseBytes []byte, err error) {
+		responses <- appResponse{
+			nodeID:        nodeID,
+			responseBytes: responseBytes,
+			err:           err,
+		}
+	}))
+
+	// The first failure is retried with a different peer
+	first := <-sent
+	require.NoError(network.AppRequestFailed(ctx, first.nodeID, first.requestID, errFoo))
+	second := <-sent
+	require.NotEqual(first.nodeID, second.nodeID)
+	require.Empty(responses)
+
+	// The second failure is reported because there are no attempts left
+	require.NoError(network.AppRequestFailed(ctx, second.nodeID, second.requestID, errFoo))
+	response := <-responses
+	require.Equal(second.nodeID, response.nodeID)
+	require.ErrorIs(response.err, errFoo)
+	require.Empty(sent)
+}
+
+func TestAppRequestAnyRetryNoUntriedPeers(t *testing.T) {
+	require := require.New(t)
+	ctx := context.Background()
+
+	network, client, sent := newRetryTestClient(t, 1, RetryPolicy{
+		MaxAttempts: 3,
+	})
+
+	responses := make(chan appResponse, 1)
+	require.NoError(client.AppRequestAny(ctx, []byte("request"), func(_ context.Context, nodeID ids.NodeID, responseBytes []byte, err error) {
+		responses <- appResponse{
+			nodeID:        nodeID,
+			responseBytes: responseBytes,
+			err:           err,
+		}
+	}))
+
+	// There isn't another peer to retry with, so the failure is reported
+	first := <-sent
+	require.NoError(network.AppRequestFailed(ctx, first.nodeID, first.requestID, errFoo))
+	response := <-responses
+	require.ErrorIs(response.err, errFoo)
+	require.Empty(sent)
+}
+
+func TestAppRequestAnyHedgesSlowRequests(t *testing.T) {
+	require := require.New(t)
+	ctx := context.Background()
+
+	network, client, sent := newRetryTestClient(t, 2, RetryPolicy{
+		MaxAttempts: 2,
+		HedgeDelay:  time.Millisecond,
+	})
+
+	responses := make(chan appResponse, 2)
+	require.NoError(client.AppRequestAny(ctx, []byte("request"), func(_ context.Context, nodeID ids.NodeID, responseBytes []byte, err error) {
+		responses <- appResponse{
+			nodeID:        nodeID,
+			responseBytes: responseBytes,
+			err:           err,
+		}
+	}))
+
+	// A second copy is sent to a different peer after the hedge delay
+	first := <-sent
+	second := <-sent
+	require.NotEqual(first.nodeID, second.nodeID)
+
+	// The first response wins
+	require.NoError(network.AppResponse(ctx, second.nodeID, second.requestID, []byte("response")))
+	response := <-responses
+	require.Equal(second.nodeID, response.nodeID)
+	require.Equal([]byte("response"), response.responseBytes)
+	require.NoError(response.err)
+
+	// The losing request is cancelled and its response is dropped
+	require.NotContains(network.router.pendingAppRequests, first.requestID)
+	require.NoError(network.AppResponse(ctx, first.nodeID, first.requestID, []byte("response")))
+	require.Empty(responses)
+	require.Empty(network.router.cancelledAppRequests)
+}
+
+func TestAppRequestAnyCancelledRequestNotTracked(t *testing.T) {
+	require := require.New(t)
+	ctx := context.Background()
+
+	peerTracker, err := NewPeerTracker(
+		logging.NoLog{},
+		"",
+		prometheus.NewRegistry(),
+		nil,
+		nil,
+	)
+	require.NoError(err)
+
+	network, client, sent := newRetryTestClient(t, 2, RetryPolicy{
+		MaxAttempts: 2,
+		HedgeDelay:  time.Millisecond,
+		PeerTracker: peerTracker,
+	})
+	for _, nodeID := range network.Peers.Sample(2) {
+		peerTracker.Connected(nodeID, &version.Application{})
+	}
+
+	responses := make(chan appResponse, 2)
+	require.NoError(client.AppRequestAny(ctx, []byte("request"), func(_ context.Context, nodeID ids.NodeID, responseBytes []byte, err error) {
+		responses <- appResponse{
+			nodeID:        nodeID,
+			responseBytes: responseBytes,
+			err:           err,
+		}
+	}))
+
+	first := <-sent
+	second := <-sent
+	require.NoError(network.AppResponse(ctx, second.nodeID, second.requestID, []byte("response")))
+	<-responses
+
+	// The losing request is neither a response nor a failure
+	require.NoError(network.AppRequestFailed(ctx, first.nodeID, first.requestID, errFoo))
+	require.Empty(responses)
+	require.Contains(peerTracker.peerBandwidth, second.nodeID)
+	require.NotContains(peerTracker.peerBandwidth, first.nodeID)
+}
+
+// repeatingSampler returns each of its nodes twice, in the same order, like a
+// sampler that samples with replacement could.
+type repeatingSampler struct {
+	nodeIDs []ids.NodeID
+}
+
+func (s *repeatingSampler) Sample(_ context.Context, limit int) []ids.NodeID {
+	sampled := make([]ids.NodeID, 0, limit)
+	for i := 0; i < limit && i < 2*len(s.nodeIDs); i++ {
+		sampled = append(sampled, s.nodeIDs[i/2])
+	}
+	return sampled
+}
+
+func TestAppRequestAnyRetrySamplesUntriedPeer(t *testing.T) {
+	require := require.New(t)
+	ctx := context.Background()
+
+	sampler := &repeatingSampler{
+		nodeIDs: []ids.NodeID{
+			ids.GenerateTestNodeID(),
+			ids.GenerateTestNodeID(),
+			ids.GenerateTestNodeID(),
+		},
+	}
+	network, client, sent := newRetryTestClient(
+		t,
+		len(sampler.nodeIDs),
+		RetryPolicy{
+			MaxAttempts: len(sampler.nodeIDs),
+		},
+		clientOptionFunc(func(options *clientOptions) {
+			options.nodeSampler = sampler
+		}),
+	)
+
+	require.NoError(client.AppRequestAny(ctx, []byte("request"), func(context.Context, ids.NodeID, []byte, error) {}))
+
+	// Every retry is sent to an untried peer, even though the sampler returns
+	// more tried peers than the number of peers that were tried
+	for _, nodeID := range sampler.nodeIDs {
+		request := <-sent
+		require.Equal(nodeID, request.nodeID)
+		if nodeID != sampler.nodeIDs[len(sampler.nodeIDs)-1] {
+			require.NoError(network.AppRequestFailed(ctx, request.nodeID, request.requestID, errFoo))
+		}
+	}
+}
+
+func TestAppRequestAnyRetryPolicyNoPeers(t *testing.T) {
+	_, client, _ := newRetryTestClient(t, 0, RetryPolicy{
+		MaxAttempts: 2,
+	})
+
+	err := client.AppRequestAny(context.Background(), []byte("request"), nil)
+	require.ErrorIs(t, err, ErrNoPeers)
+}
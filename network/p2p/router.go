@@ -19,6 +19,7 @@ import (
 	"github.com/shubhamdubey02/cryftgo/message"
 	"github.com/shubhamdubey02/cryftgo/snow/engine/common"
 	"github.com/shubhamdubey02/cryftgo/utils/logging"
+	"github.com/shubhamdubey02/cryftgo/utils/set"
 )
 
 var (
@@ -77,7 +78,10 @@ type router struct {
 	handlers                     map[uint64]*meteredHandler
 	pendingAppRequests           map[uint32]pendingAppRequest
 	pendingCrossChainAppRequests map[uint32]pendingCrossChainAppRequest
-	requestID                    uint32
+	// Requests that were cancelled but that haven't received a response or
+	// failure yet.
+	cancelledAppRequests set.Set[uint32]
+	requestID            uint32
 }
 
 // newRouter returns a new instance of Router
@@ -159,7 +163,10 @@ func (r *router) AppRequest(ctx context.Context, nodeID ids.NodeID, requestID ui
 // considered fatal
 func (r *router) AppRequestFailed(ctx context.Context, nodeID ids.NodeID, requestID uint32, appErr *common.AppError) error {
 	start := time.Now()
-	pending, ok := r.clearAppRequest(requestID)
+	pending, ok, cancelled := r.clearAppRequest(requestID)
+	if cancelled {
+		return nil
+	}
 	if !ok {
 		// we should never receive a timeout without a corresponding requestID
 		return ErrUnrequestedResponse
@@ -183,7 +190,10 @@ func (r *router) AppRequestFailed(ctx context.Context, nodeID ids.NodeID, reques
 // considered fatal
 func (r *router) AppResponse(ctx context.Context, nodeID ids.NodeID, requestID uint32, response []byte) error {
 	start := time.Now()
-	pending, ok := r.clearAppRequest(requestID)
+	pending, ok, cancelled := r.clearAppRequest(requestID)
+	if cancelled {
+		return nil
+	}
 	if !ok {
 		// we should never receive a timeout without a corresponding requestID
 		return ErrUnrequestedResponse
@@ -340,14 +350,45 @@ func (r *router) parse(prefixedMsg []byte) ([]byte, *meteredHandler, string, boo
 	return msg, handler, handlerStr, ok
 }
 
+// clearAppRequest removes the request [requestID]. Returns true as the last
+// value if the request was cancelled, in which case its callback must not be
+// invoked.
+//
 // Invariant: Assumes [r.lock] isn't held.
-func (r *router) clearAppRequest(requestID uint32) (pendingAppRequest, bool) {
+func (r *router) clearAppRequest(requestID uint32) (pendingAppRequest, bool, bool) {
 	r.lock.Lock()
 	defer r.lock.Unlock()
 
+	if r.cancelledAppRequests.Contains(requestID) {
+		r.cancelledAppRequests.Remove(requestID)
+		return pendingAppRequest{}, false, true
+	}
+
 	callback, ok := r.pendingAppRequests[requestID]
 	delete(r.pendingAppRequests, requestID)
-	return callback, ok
+	return callback, ok, false
+}
+
+// cancelAppRequests removes the pending requests in [requestIDs], so that their
+// callbacks are never invoked. Returns the requests that were still pending.
+//
+// Invariant: Assumes [r.lock] isn't held.
+func (r *router) cancelAppRequests(requestIDs []uint32) []uint32 {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+
+	cancelled := make([]uint32, 0, len(requestIDs))
+	for _, requestID := range requestIDs {
+		if _, ok := r.pendingAppRequests[requestID]; !ok {
+			continue
+		}
+		delete(r.pendingAppRequests, requestID)
+		// The engine still delivers a response or failure for the request,
+		// which must be dropped.
+		r.cancelledAppRequests.Add(requestID)
+		cancelled = append(cancelled, requestID)
+	}
+	return cancelled
 }
 
 // Invariant: Assumes [r.lock] isn't held.
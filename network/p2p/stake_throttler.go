@@ -0,0 +1,54 @@
+// Copyright (C) 2019-2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package p2p
+
+import (
+	"context"
+	"time"
+
+	"github.com/shubhamdubey02/cryftgo/ids"
+)
+
+var _ Throttler = (*StakeThrottler)(nil)
+
+// NewStakeThrottler returns a new instance of StakeThrottler.
+//
+// Every node may send [nonValidatorLimit] messages during an interval of time
+// over [period]. Validators may additionally send their share of
+// [validatorLimit] messages, proportional to their weight in the validator
+// set.
+func NewStakeThrottler(
+	weights ValidatorWeights,
+	period time.Duration,
+	nonValidatorLimit int,
+	validatorLimit int,
+) *StakeThrottler {
+	return &StakeThrottler{
+		weights:           weights,
+		nonValidatorLimit: float64(nonValidatorLimit),
+		validatorLimit:    float64(validatorLimit),
+		throttler:         NewSlidingWindowThrottler(period, nonValidatorLimit),
+	}
+}
+
+// StakeThrottler is a sliding window throttler that allows validators to send
+// more messages the more stake they have.
+type StakeThrottler struct {
+	weights           ValidatorWeights
+	nonValidatorLimit float64
+	validatorLimit    float64
+	throttler         *SlidingWindowThrottler
+}
+
+// Handle returns true if the amount of calls received from [nodeID] in the
+// last period is less than its stake-weighted limit.
+func (s *StakeThrottler) Handle(nodeID ids.NodeID) bool {
+	limit := s.nonValidatorLimit
+
+	weight, totalWeight := s.weights.Weight(context.Background(), nodeID)
+	if totalWeight > 0 {
+		limit += s.validatorLimit * float64(weight) / float64(totalWeight)
+	}
+	return s.throttler.handle(nodeID, limit)
+}
@@ -0,0 +1,75 @@
+// Copyright (C) 2019-2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package p2p
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/shubhamdubey02/cryftgo/ids"
+)
+
+type testValidatorWeights map[ids.NodeID]uint64
+
+func (t testValidatorWeights) Weight(_ context.Context, nodeID ids.NodeID) (uint64, uint64) {
+	var totalWeight uint64
+	for _, weight := range t {
+		totalWeight += weight
+	}
+	return t[nodeID], totalWeight
+}
+
+func TestStakeThrottlerHandle(t *testing.T) {
+	var (
+		nonValidator = ids.GenerateTestNodeID()
+		smallStaker  = ids.GenerateTestNodeID()
+		largeStaker  = ids.GenerateTestNodeID()
+	)
+
+	tests := []struct {
+		name     string
+		nodeID   ids.NodeID
+		expected int
+	}{
+		{
+			name:     "non-validator",
+			nodeID:   nonValidator,
+			expected: 1,
+		},
+		{
+			name:     "small staker",
+			nodeID:   smallStaker,
+			expected: 3,
+		},
+		{
+			name:     "large staker",
+			nodeID:   largeStaker,
+			expected: 7,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			require := require.New(t)
+
+			throttler := NewStakeThrottler(
+				testValidatorWeights{
+					smallStaker: 1,
+					largeStaker: 3,
+				},
+				time.Hour,
+				1,
+				8,
+			)
+
+			handled := 0
+			for throttler.Handle(tt.nodeID) {
+				handled++
+			}
+			require.Equal(tt.expected, handled)
+		})
+	}
+}
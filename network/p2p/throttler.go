@@ -65,6 +65,12 @@ type SlidingWindowThrottler struct {
 // This is calculated by adding the current period's count to a weighted count
 // of the previous period.
 func (s *SlidingWindowThrottler) Handle(nodeID ids.NodeID) bool {
+	return s.handle(nodeID, s.limit)
+}
+
+// handle returns true if the amount of calls received from [nodeID] in the
+// last [s.period] time is less than [limit]
+func (s *SlidingWindowThrottler) handle(nodeID ids.NodeID, limit float64) bool {
 	s.lock.Lock()
 	defer s.lock.Unlock()
 
@@ -85,7 +91,7 @@ func (s *SlidingWindowThrottler) Handle(nodeID ids.NodeID) bool {
 	previousFraction := float64(s.period-sinceUpdate) / float64(s.period)
 	previous := s.windows[1-s.current].hits[nodeID]
 	estimatedHits := current + previousFraction*previous
-	if estimatedHits >= s.limit {
+	if estimatedHits >= limit {
 		// The peer has sent too many requests, drop this request.
 		return false
 	}
@@ -17,13 +17,14 @@ import (
 	"github.com/shubhamdubey02/cryftgo/utils"
 	"github.com/shubhamdubey02/cryftgo/utils/logging"
 	"github.com/shubhamdubey02/cryftgo/utils/sampler"
-	"github.com/shubhamdubey02/cryftgo/utils/set"
 )
 
 var (
-	_ ValidatorSet    = (*Validators)(nil)
-	_ ValidatorSubset = (*Validators)(nil)
-	_ NodeSampler     = (*Validators)(nil)
+	_ ValidatorSet             = (*Validators)(nil)
+	_ ValidatorSubset          = (*Validators)(nil)
+	_ WeightedValidatorSampler = (*Validators)(nil)
+	_ ValidatorWeights         = (*Validators)(nil)
+	_ NodeSampler              = (*Validators)(nil)
 )
 
 type ValidatorSet interface {
@@ -34,6 +35,18 @@ type ValidatorSubset interface {
 	Top(ctx context.Context, percentage float64) []ids.NodeID // TODO return error
 }
 
+type WeightedValidatorSampler interface {
+	// SampleByWeight returns at most [limit] connected validators, sampled
+	// with a probability proportional to their weight.
+	SampleByWeight(ctx context.Context, limit int) []ids.NodeID // TODO return error
+}
+
+type ValidatorWeights interface {
+	// Weight returns the weight of [nodeID] and the total weight of the
+	// validator set. The weight of a non-validator is 0.
+	Weight(ctx context.Context, nodeID ids.NodeID) (uint64, uint64)
+}
+
 func NewValidators(
 	peers *Peers,
 	log logging.Logger,
@@ -47,6 +60,7 @@ func NewValidators(
 		subnetID:                 subnetID,
 		validators:               validators,
 		maxValidatorSetStaleness: maxValidatorSetStaleness,
+		validatorWeights:         make(map[ids.NodeID]uint64),
 	}
 }
 
@@ -58,11 +72,11 @@ type Validators struct {
 	validators               validators.State
 	maxValidatorSetStaleness time.Duration
 
-	lock          sync.Mutex
-	validatorList []validator
-	validatorSet  set.Set[ids.NodeID]
-	totalWeight   uint64
-	lastUpdated   time.Time
+	lock             sync.Mutex
+	validatorList    []validator
+	validatorWeights map[ids.NodeID]uint64
+	totalWeight      uint64
+	lastUpdated      time.Time
 }
 
 type validator struct {
@@ -84,7 +98,7 @@ func (v *Validators) refresh(ctx context.Context) {
 
 	// Even though validatorList may be nil, truncating will not panic.
 	v.validatorList = v.validatorList[:0]
-	v.validatorSet.Clear()
+	clear(v.validatorWeights)
 	v.totalWeight = 0
 
 	height, err := v.validators.GetCurrentHeight(ctx)
@@ -103,7 +117,7 @@ func (v *Validators) refresh(ctx context.Context) {
 			nodeID: nodeID,
 			weight: vdr.Weight,
 		})
-		v.validatorSet.Add(nodeID)
+		v.validatorWeights[nodeID] = vdr.Weight
 		v.totalWeight += vdr.Weight
 	}
 	utils.Sort(v.validatorList)
@@ -141,6 +155,53 @@ func (v *Validators) Sample(ctx context.Context, limit int) []ids.NodeID {
 	return sampled
 }
 
+// SampleByWeight returns a random sample of connected validators. Validators
+// are sampled without replacement, each with a probability proportional to its
+// weight among the validators that haven't been sampled yet.
+func (v *Validators) SampleByWeight(ctx context.Context, limit int) []ids.NodeID {
+	v.lock.Lock()
+	defer v.lock.Unlock()
+
+	v.refresh(ctx)
+
+	var (
+		connected   = make([]validator, 0, len(v.validatorList))
+		totalWeight uint64
+	)
+	for _, vdr := range v.validatorList {
+		if vdr.weight == 0 || !v.peers.has(vdr.nodeID) {
+			continue
+		}
+		connected = append(connected, vdr)
+		totalWeight += vdr.weight
+	}
+
+	var (
+		uniform = sampler.NewUniform()
+		sampled = make([]ids.NodeID, 0, min(limit, len(connected)))
+	)
+	for len(sampled) < limit && totalWeight > 0 {
+		uniform.Initialize(totalWeight)
+		weight, _ := uniform.Next()
+
+		// Find the validator that [weight] falls into and remove it from the
+		// remaining validators.
+		for i, vdr := range connected {
+			if weight >= vdr.weight {
+				weight -= vdr.weight
+				continue
+			}
+
+			sampled = append(sampled, vdr.nodeID)
+			totalWeight -= vdr.weight
+			connected[i] = connected[len(connected)-1]
+			connected = connected[:len(connected)-1]
+			break
+		}
+	}
+	return sampled
+}
+
 // Top returns the top [percentage] of validators, regardless of if they are
 // connected or not.
 func (v *Validators) Top(ctx context.Context, percentage float64) []ids.NodeID {
@@ -176,5 +237,17 @@ func (v *Validators) Has(ctx context.Context, nodeID ids.NodeID) bool {
 
 	v.refresh(ctx)
 
-	return v.peers.has(nodeID) && v.validatorSet.Contains(nodeID)
+	_, ok := v.validatorWeights[nodeID]
+	return ok && v.peers.has(nodeID)
+}
+
+// Weight returns the weight of [nodeID] and the total weight of the validator
+// set, regardless of if [nodeID] is connected or not.
+func (v *Validators) Weight(ctx context.Context, nodeID ids.NodeID) (uint64, uint64) {
+	v.lock.Lock()
+	defer v.lock.Unlock()
+
+	v.refresh(ctx)
+
+	return v.validatorWeights[nodeID], v.totalWeight
 }
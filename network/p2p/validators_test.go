@@ -328,3 +328,111 @@ func TestValidatorsTop(t *testing.T) {
 		})
 	}
 }
+
+func TestValidatorsSampleByWeight(t *testing.T) {
+	nodeID1 := ids.GenerateTestNodeID()
+	nodeID2 := ids.GenerateTestNodeID()
+	nodeID3 := ids.GenerateTestNodeID()
+
+	tests := []struct {
+		name       string
+		validators []validator
+		limit      int
+		expected   []ids.NodeID
+	}{
+		{
+			name: "limit 0 is empty",
+			validators: []validator{
+				{
+					nodeID: nodeID1,
+					weight: 1,
+				},
+			},
+			limit:    0,
+			expected: []ids.NodeID{},
+		},
+		{
+			name: "drop disconnected validators",
+			validators: []validator{
+				{
+					nodeID: nodeID1,
+					weight: 1,
+				},
+				{
+					nodeID: nodeID3,
+					weight: 1,
+				},
+			},
+			limit: 2,
+			expected: []ids.NodeID{
+				nodeID1,
+			},
+		},
+		{
+			name: "sample heavier validator",
+			validators: []validator{
+				{
+					nodeID: nodeID1,
+					weight: 1,
+				},
+				{
+					nodeID: nodeID2,
+					weight: 1 << 50,
+				},
+			},
+			limit: 1,
+			expected: []ids.NodeID{
+				nodeID2,
+			},
+		},
+		{
+			name: "sample without replacement",
+			validators: []validator{
+				{
+					nodeID: nodeID1,
+					weight: 1,
+				},
+				{
+					nodeID: nodeID2,
+					weight: 1 << 50,
+				},
+			},
+			limit: 3,
+			expected: []ids.NodeID{
+				nodeID2,
+				nodeID1,
+			},
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			require := require.New(t)
+			ctrl := gomock.NewController(t)
+
+			validatorSet := make(map[ids.NodeID]*validators.GetValidatorOutput, 0)
+			for _, validator := range test.validators {
+				validatorSet[validator.nodeID] = &validators.GetValidatorOutput{
+					NodeID: validator.nodeID,
+					Weight: validator.weight,
+				}
+			}
+
+			subnetID := ids.GenerateTestID()
+			mockValidators := validators.NewMockState(ctrl)
+
+			mockValidators.EXPECT().GetCurrentHeight(gomock.Any()).Return(uint64(1), nil)
+			mockValidators.EXPECT().GetValidatorSet(gomock.Any(), uint64(1), subnetID).Return(validatorSet, nil)
+
+			network, err := NewNetwork(logging.NoLog{}, &common.FakeSender{}, prometheus.NewRegistry(), "")
+			require.NoError(err)
+
+			ctx := context.Background()
+			require.NoError(network.Connected(ctx, nodeID1, nil))
+			require.NoError(network.Connected(ctx, nodeID2, nil))
+
+			v := NewValidators(network.Peers, network.log, subnetID, mockValidators, time.Second)
+			nodeIDs := v.SampleByWeight(ctx, test.limit)
+			require.Equal(test.expected, nodeIDs)
+		})
+	}
+}
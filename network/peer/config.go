@@ -9,6 +9,7 @@ import (
 	"github.com/shubhamdubey02/cryftgo/ids"
 	"github.com/shubhamdubey02/cryftgo/message"
 	"github.com/shubhamdubey02/cryftgo/network/throttling"
+	"github.com/shubhamdubey02/cryftgo/snow/networking/reputation"
 	"github.com/shubhamdubey02/cryftgo/snow/networking/router"
 	"github.com/shubhamdubey02/cryftgo/snow/networking/tracker"
 	"github.com/shubhamdubey02/cryftgo/snow/uptime"
@@ -55,6 +56,15 @@ type Config struct {
 	// Calculates uptime of peers
 	UptimeCalculator uptime.Calculator
 
+	// Reports misbehavior of peers
+	Reputation reputation.Reporter
+
+	// Records the messages sent to and received from peers
+	Recorder Recorder
+
+	// Tracks the subnet of each chain running on this node
+	ChainSubnets *throttling.ChainSubnets
+
 	// Signs my IP so I can send my signed IP address in the Handshake message
 	IPSigner *IPSigner
 }
@@ -8,6 +8,7 @@ import (
 
 	"github.com/prometheus/client_golang/prometheus"
 
+	"github.com/shubhamdubey02/cryftgo/ids"
 	"github.com/shubhamdubey02/cryftgo/message"
 	"github.com/shubhamdubey02/cryftgo/utils"
 )
@@ -16,6 +17,8 @@ const (
 	ioLabel         = "io"
 	opLabel         = "op"
 	compressedLabel = "compressed"
+	subnetLabel     = "subnet"
+	chainLabel      = "chain"
 
 	sentLabel     = "sent"
 	receivedLabel = "received"
@@ -25,6 +28,7 @@ var (
 	opLabels             = []string{opLabel}
 	ioOpLabels           = []string{ioLabel, opLabel}
 	ioOpCompressedLabels = []string{ioLabel, opLabel, compressedLabel}
+	ioSubnetChainLabels  = []string{ioLabel, subnetLabel, chainLabel}
 )
 
 type Metrics struct {
@@ -37,6 +41,7 @@ type Metrics struct {
 	Messages   *prometheus.CounterVec // io + op + compressed
 	Bytes      *prometheus.CounterVec // io + op
 	BytesSaved *prometheus.GaugeVec   // io + op
+	ChainBytes *prometheus.CounterVec // io + subnet + chain
 }
 
 func NewMetrics(
@@ -91,6 +96,14 @@ func NewMetrics(
 			},
 			ioOpLabels,
 		),
+		ChainBytes: prometheus.NewCounterVec(
+			prometheus.CounterOpts{
+				Namespace: namespace,
+				Name:      "msgs_chain_bytes",
+				Help:      "number of message bytes sent to or received for each chain running on this node",
+			},
+			ioSubnetChainLabels,
+		),
 	}
 	return m, utils.Err(
 		registerer.Register(m.ClockSkewCount),
@@ -100,6 +113,7 @@ func NewMetrics(
 		registerer.Register(m.Messages),
 		registerer.Register(m.Bytes),
 		registerer.Register(m.BytesSaved),
+		registerer.Register(m.ChainBytes),
 	)
 }
 
@@ -157,3 +171,23 @@ func (m *Metrics) Received(msg message.InboundMessage, msgLen uint32) {
 	m.Bytes.With(bytesLabel).Add(float64(msgLen))
 	m.BytesSaved.With(bytesLabel).Add(float64(saved))
 }
+
+// SentChain updates the metrics for having sent [msgLen] bytes to [chainID],
+// which is validated by [subnetID].
+func (m *Metrics) SentChain(subnetID ids.ID, chainID ids.ID, msgLen uint32) {
+	m.ChainBytes.With(prometheus.Labels{
+		ioLabel:     sentLabel,
+		subnetLabel: subnetID.String(),
+		chainLabel:  chainID.String(),
+	}).Add(float64(msgLen))
+}
+
+// ReceivedChain updates the metrics for having received [msgLen] bytes for
+// [chainID], which is validated by [subnetID].
+func (m *Metrics) ReceivedChain(subnetID ids.ID, chainID ids.ID, msgLen uint32) {
+	m.ChainBytes.With(prometheus.Labels{
+		ioLabel:     receivedLabel,
+		subnetLabel: subnetID.String(),
+		chainLabel:  chainID.String(),
+	}).Add(float64(msgLen))
+}
@@ -18,7 +18,9 @@ import (
 
 	"github.com/shubhamdubey02/cryftgo/ids"
 	"github.com/shubhamdubey02/cryftgo/message"
+	"github.com/shubhamdubey02/cryftgo/network/throttling"
 	"github.com/shubhamdubey02/cryftgo/proto/pb/p2p"
+	"github.com/shubhamdubey02/cryftgo/snow/networking/reputation"
 	"github.com/shubhamdubey02/cryftgo/staking"
 	"github.com/shubhamdubey02/cryftgo/utils"
 	"github.com/shubhamdubey02/cryftgo/utils/bloom"
@@ -62,6 +64,9 @@ type Peer interface {
 	// authenticate their messages.
 	Cert() *staking.Certificate
 
+	// Inbound returns true if the connection was initiated by the remote peer.
+	Inbound() bool
+
 	// LastSent returns the last time a message was sent to the peer.
 	LastSent() time.Time
 
@@ -134,6 +139,9 @@ type peer struct {
 	// node ID of this peer.
 	id ids.NodeID
 
+	// inbound is true if the connection was initiated by the remote peer.
+	inbound bool
+
 	// queue of messages to send to this peer.
 	messageQueue MessageQueue
 
@@ -206,6 +214,7 @@ func Start(
 	conn net.Conn,
 	cert *staking.Certificate,
 	id ids.NodeID,
+	inbound bool,
 	messageQueue MessageQueue,
 ) Peer {
 	onClosingCtx, onClosingCtxCancel := context.WithCancel(context.Background())
@@ -214,6 +223,7 @@ func Start(
 		conn:               conn,
 		cert:               cert,
 		id:                 id,
+		inbound:            inbound,
 		messageQueue:       messageQueue,
 		onFinishHandshake:  make(chan struct{}),
 		numExecuting:       3,
@@ -239,6 +249,10 @@ func (p *peer) Cert() *staking.Certificate {
 	return p.cert
 }
 
+func (p *peer) Inbound() bool {
+	return p.inbound
+}
+
 func (p *peer) LastSent() time.Time {
 	return time.Unix(
 		atomic.LoadInt64(&p.lastSent),
@@ -434,11 +448,19 @@ func (p *peer) readMessages() {
 		// exited before calling [Network.Disconnected] to guarantee that there
 		// can't be multiple instances of this goroutine running over different
 		// peer instances.
-		onFinishedHandling := p.InboundMsgThrottler.Acquire(
+		releaseMsgBytes := p.InboundMsgThrottler.Acquire(
 			p.onClosingCtx,
 			uint64(msgLen),
 			p.id,
 		)
+		// Replaced once the message is known to be sent to a chain whose
+		// subnet has a quota. Only this goroutine modifies it, before the
+		// message is handled.
+		var releaseChainBytes throttling.ReleaseFunc = func() {}
+		onFinishedHandling := func() {
+			releaseChainBytes()
+			releaseMsgBytes()
+		}
 
 		// If the peer is shutting down, there's no need to read the message.
 		if err := p.onClosingCtx.Err(); err != nil {
@@ -480,6 +502,7 @@ func (p *peer) readMessages() {
 			zap.Stringer("nodeID", p.id),
 			zap.Binary("messageBytes", msgBytes),
 		)
+		p.Recorder.Record(Inbound, p.id, msgBytes)
 
 		// Parse the message
 		msg, err := p.MessageCreator.Parse(msgBytes, p.id, onFinishedHandling)
@@ -491,6 +514,7 @@ func (p *peer) readMessages() {
 			)
 
 			p.Metrics.NumFailedToParse.Inc()
+			p.Reputation.Report(p.id, reputation.InvalidMessage)
 
 			// Couldn't parse the message. Read the next one.
 			onFinishedHandling()
@@ -502,6 +526,26 @@ func (p *peer) readMessages() {
 		p.storeLastReceived(now)
 		p.Metrics.Received(msg, msgLen)
 
+		if chainID, err := message.GetChainID(msg.Message()); err == nil {
+			if subnetID, ok := p.ChainSubnets.SubnetID(chainID); ok {
+				p.Metrics.ReceivedChain(subnetID, chainID, msgLen)
+			}
+
+			release, ok := p.InboundMsgThrottler.AcquireChain(chainID, uint64(msgLen))
+			if !ok {
+				p.Log.Debug("dropping message",
+					zap.Stringer("nodeID", p.id),
+					zap.Stringer("messageOp", msg.Op()),
+					zap.Stringer("chainID", chainID),
+					zap.String("reason", "subnet exhausted its inbound byte quota"),
+				)
+				msg.OnFinishedHandling()
+				p.ResourceTracker.StopProcessing(p.id, p.Clock.Time())
+				continue
+			}
+			releaseChainBytes = release
+		}
+
 		// Handle the message. Note that when we are done handling this message,
 		// we must call [msg.OnFinishedHandling()].
 		p.handle(msg)
@@ -631,6 +675,12 @@ func (p *peer) writeMessage(writer io.Writer, msg message.OutboundMessage) {
 	now := p.Clock.Time()
 	p.storeLastSent(now)
 	p.Metrics.Sent(msg)
+	if chainID, ok := msg.ChainID(); ok {
+		if subnetID, ok := p.ChainSubnets.SubnetID(chainID); ok {
+			p.Metrics.SentChain(subnetID, chainID, msgLen)
+		}
+	}
+	p.Recorder.Record(Outbound, p.id, msgBytes)
 }
 
 func (p *peer) sendNetworkMessages() {
@@ -728,6 +778,7 @@ func (p *peer) shouldDisconnect() bool {
 			zap.String("reason", "invalid BLS signature"),
 			zap.Stringer("nodeID", p.id),
 		)
+		p.Reputation.Report(p.id, reputation.InvalidSignature)
 		return true
 	}
 
@@ -1085,6 +1136,9 @@ func (p *peer) handleHandshake(msg *p2p.Handshake) {
 			zap.Error(err),
 		)
 
+		if errors.Is(err, errInvalidTLSSignature) {
+			p.Reputation.Report(p.id, reputation.InvalidSignature)
+		}
 		p.StartClose()
 		return
 	}
@@ -11,12 +11,15 @@ import (
 	"time"
 
 	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
 	"github.com/stretchr/testify/require"
 
 	"github.com/shubhamdubey02/cryftgo/ids"
 	"github.com/shubhamdubey02/cryftgo/message"
 	"github.com/shubhamdubey02/cryftgo/network/throttling"
 	"github.com/shubhamdubey02/cryftgo/proto/pb/p2p"
+	"github.com/shubhamdubey02/cryftgo/snow"
+	"github.com/shubhamdubey02/cryftgo/snow/networking/reputation"
 	"github.com/shubhamdubey02/cryftgo/snow/networking/router"
 	"github.com/shubhamdubey02/cryftgo/snow/networking/tracker"
 	"github.com/shubhamdubey02/cryftgo/snow/uptime"
@@ -96,6 +99,9 @@ func newConfig(t *testing.T) Config {
 		MaxClockDifference:   time.Minute,
 		ResourceTracker:      resourceTracker,
 		UptimeCalculator:     uptime.NoOpCalculator,
+		Reputation:           reputation.NewNoTracker(),
+		Recorder:             NewNoRecorder(),
+		ChainSubnets:         throttling.NewChainSubnets(),
 		IPSigner:             nil,
 	}
 }
@@ -137,6 +143,7 @@ func startTestPeer(self *rawTestPeer, peer *rawTestPeer, conn net.Conn) *testPee
 			conn,
 			peer.cert,
 			peer.nodeID,
+			false, // inbound
 			NewThrottledMessageQueue(
 				self.config.Metrics,
 				peer.nodeID,
@@ -210,6 +217,48 @@ func TestSend(t *testing.T) {
 	require.NoError(peer1.AwaitClosed(context.Background()))
 }
 
+func TestSendChainBytes(t *testing.T) {
+	require := require.New(t)
+
+	subnetID := ids.GenerateTestID()
+	chainID := ids.GenerateTestID()
+
+	sharedConfig := newConfig(t)
+	sharedConfig.ChainSubnets.RegisterChain("", &snow.ConsensusContext{
+		Context: &snow.Context{
+			SubnetID: subnetID,
+			ChainID:  chainID,
+		},
+	}, nil)
+
+	rawPeer0 := newRawTestPeer(t, sharedConfig)
+	rawPeer1 := newRawTestPeer(t, sharedConfig)
+
+	peer0, peer1 := startTestPeers(rawPeer0, rawPeer1)
+	awaitReady(t, peer0, peer1)
+
+	outboundMsg, err := sharedConfig.MessageCreator.AppGossip(chainID, []byte{1, 2, 3})
+	require.NoError(err)
+
+	require.True(peer0.Send(context.Background(), outboundMsg))
+
+	inboundMsg := <-peer1.inboundMsgChan
+	require.Equal(message.AppGossipOp, inboundMsg.Op())
+
+	peer1.StartClose()
+	require.NoError(peer0.AwaitClosed(context.Background()))
+	require.NoError(peer1.AwaitClosed(context.Background()))
+
+	for _, io := range []string{sentLabel, receivedLabel} {
+		bytes := sharedConfig.Metrics.ChainBytes.With(prometheus.Labels{
+			ioLabel:     io,
+			subnetLabel: subnetID.String(),
+			chainLabel:  chainID.String(),
+		})
+		require.Equal(float64(len(outboundMsg.Bytes())), testutil.ToFloat64(bytes))
+	}
+}
+
 func TestPingUptimes(t *testing.T) {
 	trackedSubnetID := ids.GenerateTestID()
 	untrackedSubnetID := ids.GenerateTestID()
@@ -585,6 +634,7 @@ func TestShouldDisconnect(t *testing.T) {
 			initialPeer: &peer{
 				Config: &Config{
 					Log:                  logging.NoLog{},
+					Reputation:           reputation.NewNoTracker(),
 					VersionCompatibility: version.GetCompatibility(constants.UnitTestID),
 					Validators: func() validators.Manager {
 						vdrs := validators.NewManager()
@@ -605,6 +655,7 @@ func TestShouldDisconnect(t *testing.T) {
 			expectedPeer: &peer{
 				Config: &Config{
 					Log:                  logging.NoLog{},
+					Reputation:           reputation.NewNoTracker(),
 					VersionCompatibility: version.GetCompatibility(constants.UnitTestID),
 					Validators: func() validators.Manager {
 						vdrs := validators.NewManager()
@@ -629,6 +680,7 @@ func TestShouldDisconnect(t *testing.T) {
 			initialPeer: &peer{
 				Config: &Config{
 					Log:                  logging.NoLog{},
+					Reputation:           reputation.NewNoTracker(),
 					VersionCompatibility: version.GetCompatibility(constants.UnitTestID),
 					Validators: func() validators.Manager {
 						vdrs := validators.NewManager()
@@ -651,6 +703,7 @@ func TestShouldDisconnect(t *testing.T) {
 			expectedPeer: &peer{
 				Config: &Config{
 					Log:                  logging.NoLog{},
+					Reputation:           reputation.NewNoTracker(),
 					VersionCompatibility: version.GetCompatibility(constants.UnitTestID),
 					Validators: func() validators.Manager {
 						vdrs := validators.NewManager()
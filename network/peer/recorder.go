@@ -0,0 +1,277 @@
+// Copyright (C) 2019-2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package peer
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"sync"
+	"time"
+
+	"go.uber.org/zap"
+	"gopkg.in/natefinch/lumberjack.v2"
+
+	"github.com/shubhamdubey02/cryftgo/ids"
+	"github.com/shubhamdubey02/cryftgo/utils/constants"
+	"github.com/shubhamdubey02/cryftgo/utils/logging"
+	"github.com/shubhamdubey02/cryftgo/utils/perms"
+	"github.com/shubhamdubey02/cryftgo/utils/timer/mockable"
+	"github.com/shubhamdubey02/cryftgo/utils/wrappers"
+)
+
+const (
+	// recordingFileName is the name of the file that messages are currently
+	// being recorded to. Rotated files are named
+	// recordingFileBase-<timestamp>recordingFileExt.
+	recordingFileBase = "messages"
+	recordingFileExt  = ".rec"
+	recordingFileName = recordingFileBase + recordingFileExt
+
+	// recordHeaderLen is the length of the header of a record: the time it was
+	// recorded, its direction, the NodeID of the peer and the length of the
+	// message.
+	recordHeaderLen = wrappers.LongLen + wrappers.ByteLen + ids.NodeIDLen + wrappers.IntLen
+)
+
+var (
+	_ Recorder = (*fileRecorder)(nil)
+	_ Recorder = noRecorder{}
+
+	errUnknownDirection   = errors.New("unknown direction")
+	errRecordTooLarge     = errors.New("record too large")
+	errNonPositiveMaxSize = errors.New("max size must be > 0")
+	errNegativeMaxFiles   = errors.New("max files must be >= 0")
+)
+
+// Direction is whether a message was sent to or received from a peer.
+type Direction byte
+
+const (
+	Inbound Direction = iota
+	Outbound
+)
+
+func (d Direction) String() string {
+	switch d {
+	case Inbound:
+		return "inbound"
+	case Outbound:
+		return "outbound"
+	default:
+		return "unknown"
+	}
+}
+
+// Record is a message that was sent to or received from a peer.
+type Record struct {
+	Time      time.Time
+	Direction Direction
+	NodeID    ids.NodeID
+	// Bytes of the message as they were sent over the wire. They can be parsed
+	// with message.InboundMsgBuilder.Parse regardless of [Direction].
+	Bytes []byte
+}
+
+// Recorder records the messages sent to and received from peers.
+type Recorder interface {
+	// Record that [msgBytes] was sent to or received from [nodeID].
+	Record(direction Direction, nodeID ids.NodeID, msgBytes []byte)
+
+	// Close flushes and closes the recording.
+	Close() error
+}
+
+// RecorderConfig describes where messages are recorded to.
+type RecorderConfig struct {
+	// Directory the recording files are written to
+	Directory string `json:"directory"`
+	// Size, in megabytes, of a recording file before it is rotated
+	MaxSize int `json:"maxSize"`
+	// Number of rotated recording files to keep. If 0, all rotated files are
+	// kept.
+	MaxFiles int `json:"maxFiles"`
+}
+
+// Verify returns an error if the config is invalid.
+func (c *RecorderConfig) Verify() error {
+	switch {
+	case c.MaxSize <= 0:
+		return errNonPositiveMaxSize
+	case c.MaxFiles < 0:
+		return errNegativeMaxFiles
+	default:
+		return nil
+	}
+}
+
+type fileRecorder struct {
+	log   logging.Logger
+	clock mockable.Clock
+
+	lock   sync.Mutex
+	writer *lumberjack.Logger
+}
+
+// NewFileRecorder returns a Recorder that writes every message to a file in
+// [config.Directory]. The file is rotated once it reaches [config.MaxSize].
+//
+// Every record is written as the unix time in nanoseconds it was recorded at,
+// its direction, the NodeID of the peer and the length-prefixed message.
+func NewFileRecorder(config RecorderConfig, log logging.Logger) (Recorder, error) {
+	if err := config.Verify(); err != nil {
+		return nil, err
+	}
+	if err := os.MkdirAll(config.Directory, perms.ReadWriteExecute); err != nil {
+		return nil, fmt.Errorf("failed to create recording directory: %w", err)
+	}
+	return &fileRecorder{
+		log: log,
+		writer: &lumberjack.Logger{
+			Filename:   filepath.Join(config.Directory, recordingFileName),
+			MaxSize:    config.MaxSize,  // megabytes
+			MaxBackups: config.MaxFiles, // files
+		},
+	}, nil
+}
+
+func (r *fileRecorder) Record(direction Direction, nodeID ids.NodeID, msgBytes []byte) {
+	p := wrappers.Packer{
+		Bytes:   make([]byte, 0, recordHeaderLen+len(msgBytes)),
+		MaxSize: recordHeaderLen + constants.DefaultMaxMessageSize,
+	}
+	p.PackLong(uint64(r.clock.Time().UnixNano()))
+	p.PackByte(byte(direction))
+	p.PackFixedBytes(nodeID.Bytes())
+	p.PackBytes(msgBytes)
+	if p.Err != nil {
+		r.log.Debug("failed to record message",
+			zap.Stringer("nodeID", nodeID),
+			zap.Stringer("direction", direction),
+			zap.Error(p.Err),
+		)
+		return
+	}
+
+	r.lock.Lock()
+	defer r.lock.Unlock()
+
+	// Each record is written with a single call so that a record is never
+	// split across rotated files.
+	if _, err := r.writer.Write(p.Bytes); err != nil {
+		r.log.Debug("failed to record message",
+			zap.Stringer("nodeID", nodeID),
+			zap.Stringer("direction", direction),
+			zap.Error(err),
+		)
+	}
+}
+
+func (r *fileRecorder) Close() error {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+
+	return r.writer.Close()
+}
+
+type noRecorder struct{}
+
+// NewNoRecorder returns a Recorder that doesn't record anything.
+func NewNoRecorder() Recorder {
+	return noRecorder{}
+}
+
+func (noRecorder) Record(Direction, ids.NodeID, []byte) {}
+
+func (noRecorder) Close() error {
+	return nil
+}
+
+// RecordingFiles returns the recording files in [dir], oldest first.
+func RecordingFiles(dir string) ([]string, error) {
+	rotated, err := filepath.Glob(filepath.Join(dir, recordingFileBase+"-*"+recordingFileExt))
+	if err != nil {
+		return nil, err
+	}
+	// Rotated files are suffixed with the time they were rotated at, so
+	// sorting them by name sorts them by age.
+	sort.Strings(rotated)
+
+	current := filepath.Join(dir, recordingFileName)
+	if _, err := os.Stat(current); err == nil {
+		rotated = append(rotated, current)
+	} else if !errors.Is(err, os.ErrNotExist) {
+		return nil, err
+	}
+	return rotated, nil
+}
+
+// ReadRecordingFiles returns the records in [files], in order.
+func ReadRecordingFiles(files ...string) ([]*Record, error) {
+	var records []*Record
+	for _, file := range files {
+		f, err := os.Open(file)
+		if err != nil {
+			return nil, err
+		}
+		fileRecords, err := ReadRecords(f)
+		_ = f.Close()
+		if err != nil {
+			return nil, fmt.Errorf("failed to read %s: %w", file, err)
+		}
+		records = append(records, fileRecords...)
+	}
+	return records, nil
+}
+
+// ReadRecords reads every record in [r].
+func ReadRecords(r io.Reader) ([]*Record, error) {
+	var (
+		records []*Record
+		header  [recordHeaderLen]byte
+	)
+	for {
+		if _, err := io.ReadFull(r, header[:]); err != nil {
+			if errors.Is(err, io.EOF) {
+				return records, nil
+			}
+			return nil, err
+		}
+
+		p := wrappers.Packer{Bytes: header[:]}
+		var (
+			timestamp   = p.UnpackLong()
+			direction   = Direction(p.UnpackByte())
+			nodeIDBytes = p.UnpackFixedBytes(ids.NodeIDLen)
+			msgLen      = p.UnpackInt()
+		)
+		if p.Err != nil {
+			return nil, p.Err
+		}
+		nodeID, err := ids.ToNodeID(nodeIDBytes)
+		if err != nil {
+			return nil, err
+		}
+		if direction != Inbound && direction != Outbound {
+			return nil, fmt.Errorf("%w: %d", errUnknownDirection, direction)
+		}
+		if msgLen > constants.DefaultMaxMessageSize {
+			return nil, fmt.Errorf("%w: %d > %d", errRecordTooLarge, msgLen, constants.DefaultMaxMessageSize)
+		}
+
+		msgBytes := make([]byte, msgLen)
+		if _, err := io.ReadFull(r, msgBytes); err != nil {
+			return nil, err
+		}
+		records = append(records, &Record{
+			Time:      time.Unix(0, int64(timestamp)),
+			Direction: direction,
+			NodeID:    nodeID,
+			Bytes:     msgBytes,
+		})
+	}
+}
@@ -0,0 +1,196 @@
+// Copyright (C) 2019-2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package peer
+
+import (
+	"bytes"
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/shubhamdubey02/cryftgo/ids"
+	"github.com/shubhamdubey02/cryftgo/message"
+	"github.com/shubhamdubey02/cryftgo/utils/logging"
+	"github.com/shubhamdubey02/cryftgo/utils/units"
+)
+
+func TestFileRecorder(t *testing.T) {
+	require := require.New(t)
+
+	dir := t.TempDir()
+	r, err := NewFileRecorder(RecorderConfig{
+		Directory: dir,
+		MaxSize:   1,
+	}, logging.NoLog{})
+	require.NoError(err)
+
+	fr := r.(*fileRecorder)
+	now := time.Unix(1000, 0)
+	fr.clock.Set(now)
+
+	expected := []*Record{
+		{
+			Time:      now,
+			Direction: Inbound,
+			NodeID:    ids.GenerateTestNodeID(),
+			Bytes:     []byte("foo"),
+		},
+		{
+			Time:      now,
+			Direction: Outbound,
+			NodeID:    ids.GenerateTestNodeID(),
+			Bytes:     []byte{},
+		},
+	}
+	for _, record := range expected {
+		r.Record(record.Direction, record.NodeID, record.Bytes)
+	}
+	require.NoError(r.Close())
+
+	files, err := RecordingFiles(dir)
+	require.NoError(err)
+	require.Len(files, 1)
+
+	records, err := ReadRecordingFiles(files...)
+	require.NoError(err)
+	require.Equal(expected, records)
+}
+
+func TestFileRecorderRotates(t *testing.T) {
+	require := require.New(t)
+
+	dir := t.TempDir()
+	r, err := NewFileRecorder(RecorderConfig{
+		Directory: dir,
+		MaxSize:   1,
+	}, logging.NoLog{})
+	require.NoError(err)
+
+	nodeID := ids.GenerateTestNodeID()
+	msgs := [][]byte{
+		bytes.Repeat([]byte{0}, 400*units.KiB),
+		bytes.Repeat([]byte{1}, 400*units.KiB),
+		bytes.Repeat([]byte{2}, 400*units.KiB),
+	}
+	for _, msg := range msgs {
+		r.Record(Inbound, nodeID, msg)
+	}
+	require.NoError(r.Close())
+
+	// The third record doesn't fit in the first file.
+	files, err := RecordingFiles(dir)
+	require.NoError(err)
+	require.Len(files, 2)
+
+	records, err := ReadRecordingFiles(files...)
+	require.NoError(err)
+	require.Len(records, len(msgs))
+	for i, record := range records {
+		require.Equal(msgs[i], record.Bytes)
+	}
+}
+
+func TestRecorderConfigVerify(t *testing.T) {
+	tests := []struct {
+		name        string
+		config      RecorderConfig
+		expectedErr error
+	}{
+		{
+			name: "valid",
+			config: RecorderConfig{
+				MaxSize:  1,
+				MaxFiles: 1,
+			},
+		},
+		{
+			name: "zero max size",
+			config: RecorderConfig{
+				MaxFiles: 1,
+			},
+			expectedErr: errNonPositiveMaxSize,
+		},
+		{
+			name: "negative max files",
+			config: RecorderConfig{
+				MaxSize:  1,
+				MaxFiles: -1,
+			},
+			expectedErr: errNegativeMaxFiles,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			require.ErrorIs(t, test.config.Verify(), test.expectedErr)
+		})
+	}
+}
+
+func TestReadRecordsUnknownDirection(t *testing.T) {
+	require := require.New(t)
+
+	recordBytes := make([]byte, recordHeaderLen)
+	recordBytes[8] = 2 // direction
+
+	_, err := ReadRecords(bytes.NewReader(recordBytes))
+	require.ErrorIs(err, errUnknownDirection)
+}
+
+func TestPeerRecordsMessages(t *testing.T) {
+	require := require.New(t)
+
+	dir := t.TempDir()
+	recorder, err := NewFileRecorder(RecorderConfig{
+		Directory: dir,
+		MaxSize:   1,
+	}, logging.NoLog{})
+	require.NoError(err)
+
+	sharedConfig := newConfig(t)
+	sharedConfig.Recorder = recorder
+
+	rawPeer0 := newRawTestPeer(t, sharedConfig)
+	rawPeer1 := newRawTestPeer(t, sharedConfig)
+
+	peer0, peer1 := startTestPeers(rawPeer0, rawPeer1)
+	awaitReady(t, peer0, peer1)
+
+	outboundGetMsg, err := sharedConfig.MessageCreator.Get(ids.Empty, 1, time.Second, ids.Empty)
+	require.NoError(err)
+
+	require.True(peer0.Send(context.Background(), outboundGetMsg))
+	<-peer1.inboundMsgChan
+
+	peer1.StartClose()
+	require.NoError(peer0.AwaitClosed(context.Background()))
+	require.NoError(peer1.AwaitClosed(context.Background()))
+	require.NoError(recorder.Close())
+
+	files, err := RecordingFiles(dir)
+	require.NoError(err)
+	records, err := ReadRecordingFiles(files...)
+	require.NoError(err)
+
+	var sent, received bool
+	for _, record := range records {
+		msg, err := sharedConfig.MessageCreator.Parse(record.Bytes, record.NodeID, func() {})
+		require.NoError(err)
+		if msg.Op() != message.GetOp {
+			continue
+		}
+
+		switch record.Direction {
+		case Outbound:
+			require.Equal(peer0.ID(), record.NodeID)
+			sent = true
+		case Inbound:
+			require.Equal(peer1.ID(), record.NodeID)
+			received = true
+		}
+	}
+	require.True(sent)
+	require.True(received)
+}
@@ -14,6 +14,7 @@ import (
 	"github.com/shubhamdubey02/cryftgo/ids"
 	"github.com/shubhamdubey02/cryftgo/message"
 	"github.com/shubhamdubey02/cryftgo/network/throttling"
+	"github.com/shubhamdubey02/cryftgo/snow/networking/reputation"
 	"github.com/shubhamdubey02/cryftgo/snow/networking/router"
 	"github.com/shubhamdubey02/cryftgo/snow/networking/tracker"
 	"github.com/shubhamdubey02/cryftgo/snow/uptime"
@@ -127,11 +128,15 @@ func StartTestPeer(
 			MaxClockDifference:   time.Minute,
 			ResourceTracker:      resourceTracker,
 			UptimeCalculator:     uptime.NoOpCalculator,
+			Reputation:           reputation.NewNoTracker(),
+			Recorder:             NewNoRecorder(),
+			ChainSubnets:         throttling.NewChainSubnets(),
 			IPSigner:             NewIPSigner(signerIP, tlsKey, blsKey),
 		},
 		conn,
 		cert,
 		peerID,
+		false, // inbound
 		NewBlockingMessageQueue(
 			metrics,
 			logging.NoLog{},
@@ -18,6 +18,7 @@ import (
 	"github.com/shubhamdubey02/cryftgo/network/dialer"
 	"github.com/shubhamdubey02/cryftgo/network/peer"
 	"github.com/shubhamdubey02/cryftgo/network/throttling"
+	"github.com/shubhamdubey02/cryftgo/snow/networking/reputation"
 	"github.com/shubhamdubey02/cryftgo/snow/networking/router"
 	"github.com/shubhamdubey02/cryftgo/snow/networking/tracker"
 	"github.com/shubhamdubey02/cryftgo/snow/uptime"
@@ -226,6 +227,9 @@ func NewTestNetwork(
 		currentValidators,
 		networkConfig.ResourceTracker.DiskTracker(),
 	)
+	networkConfig.ReputationTracker = reputation.NewNoTracker()
+	networkConfig.Recorder = peer.NewNoRecorder()
+	networkConfig.ChainSubnets = throttling.NewChainSubnets()
 
 	networkConfig.MyIPPort = ips.NewDynamicIPPort(net.IPv4zero, 1)
 
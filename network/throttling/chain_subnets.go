@@ -0,0 +1,43 @@
+// Copyright (C) 2019-2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package throttling
+
+import (
+	"sync"
+
+	"github.com/shubhamdubey02/cryftgo/ids"
+	"github.com/shubhamdubey02/cryftgo/snow"
+	"github.com/shubhamdubey02/cryftgo/snow/engine/common"
+)
+
+// ChainSubnets tracks the subnet that validates each chain running on this
+// node. It is notified of new chains by registering it with the chain manager.
+type ChainSubnets struct {
+	lock sync.RWMutex
+	// Chain ID --> Subnet ID
+	subnets map[ids.ID]ids.ID
+}
+
+func NewChainSubnets() *ChainSubnets {
+	return &ChainSubnets{
+		subnets: make(map[ids.ID]ids.ID),
+	}
+}
+
+func (c *ChainSubnets) RegisterChain(_ string, ctx *snow.ConsensusContext, _ common.VM) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	c.subnets[ctx.ChainID] = ctx.SubnetID
+}
+
+// SubnetID returns the subnet that validates [chainID]. Returns false if
+// [chainID] isn't running on this node.
+func (c *ChainSubnets) SubnetID(chainID ids.ID) (ids.ID, bool) {
+	c.lock.RLock()
+	defer c.lock.RUnlock()
+
+	subnetID, ok := c.subnets[chainID]
+	return subnetID, ok
+}
@@ -16,6 +16,11 @@ type MsgByteThrottlerConfig struct {
 	VdrAllocSize        uint64 `json:"vdrAllocSize"`
 	AtLargeAllocSize    uint64 `json:"atLargeAllocSize"`
 	NodeMaxAtLargeBytes uint64 `json:"nodeMaxAtLargeBytes"`
+
+	// Subnet ID --> Max number of bytes of messages sent to the chains of the
+	// subnet that can be outstanding at once. Subnets without a quota are only
+	// limited by the allocations above.
+	SubnetQuotas map[ids.ID]uint64 `json:"subnetQuotas"`
 }
 
 // Used by the sybil-safe inbound and outbound message throttlers
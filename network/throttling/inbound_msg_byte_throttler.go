@@ -26,8 +26,19 @@ func newInboundMsgByteThrottler(
 	namespace string,
 	registerer prometheus.Registerer,
 	vdrs validators.Manager,
+	chainSubnets *ChainSubnets,
 	config MsgByteThrottlerConfig,
 ) (*inboundMsgByteThrottler, error) {
+	subnetQuotas, err := newSubnetQuotas(
+		namespace,
+		"byte_throttler_inbound",
+		registerer,
+		chainSubnets,
+		config.SubnetQuotas,
+	)
+	if err != nil {
+		return nil, err
+	}
 	t := &inboundMsgByteThrottler{
 		commonMsgThrottler: commonMsgThrottler{
 			log:                    log,
@@ -39,6 +50,7 @@ func newInboundMsgByteThrottler(
 			nodeToVdrBytesUsed:     make(map[ids.NodeID]uint64),
 			nodeToAtLargeBytesUsed: make(map[ids.NodeID]uint64),
 		},
+		subnetQuotas:       subnetQuotas,
 		waitingToAcquire:   linked.NewHashmap[uint64, *msgMetadata](),
 		nodeToWaitingMsgID: make(map[ids.NodeID]uint64),
 	}
@@ -62,8 +74,9 @@ type msgMetadata struct {
 // acquiring enough bytes to be read.
 type inboundMsgByteThrottler struct {
 	commonMsgThrottler
-	metrics   inboundMsgByteThrottlerMetrics
-	nextMsgID uint64
+	subnetQuotas *subnetQuotas
+	metrics      inboundMsgByteThrottlerMetrics
+	nextMsgID    uint64
 	// Node ID --> Msg ID for a message this node is waiting to acquire
 	nodeToWaitingMsgID map[ids.NodeID]uint64
 	// Msg ID --> *msgMetadata
@@ -199,6 +212,30 @@ func (t *inboundMsgByteThrottler) Acquire(ctx context.Context, msgSize uint64, n
 	}
 }
 
+// Takes [msgSize] bytes from the quota of the subnet that validates [chainID],
+// if it has one. Unlike Acquire, this never blocks. Returns false if the quota
+// is exhausted, in which case the message should be dropped.
+// If this returns true, the returned ReleaseFunc must be called (!) when done
+// with the message.
+func (t *inboundMsgByteThrottler) AcquireChain(chainID ids.ID, msgSize uint64) (ReleaseFunc, bool) {
+	t.lock.Lock()
+	defer t.lock.Unlock()
+
+	subnetID, hasQuota := t.subnetQuotas.subnetID(chainID)
+	if !hasQuota {
+		return noopRelease, true
+	}
+	if !t.subnetQuotas.acquire(subnetID, msgSize) {
+		return nil, false
+	}
+	return func() {
+		t.lock.Lock()
+		defer t.lock.Unlock()
+
+		t.subnetQuotas.release(subnetID, msgSize)
+	}, true
+}
+
 // Must correspond to a previous call of Acquire([msgSize], [nodeID])
 func (t *inboundMsgByteThrottler) release(metadata *msgMetadata, nodeID ids.NodeID) {
 	t.lock.Lock()
@@ -33,6 +33,7 @@ func TestInboundMsgByteThrottlerCancelContextDeadlock(t *testing.T) {
 		"",
 		prometheus.NewRegistry(),
 		vdrs,
+		NewChainSubnets(),
 		config,
 	)
 	require.NoError(err)
@@ -63,6 +64,7 @@ func TestInboundMsgByteThrottlerCancelContext(t *testing.T) {
 		"",
 		prometheus.NewRegistry(),
 		vdrs,
+		NewChainSubnets(),
 		config,
 	)
 	require.NoError(err)
@@ -121,6 +123,7 @@ func TestInboundMsgByteThrottler(t *testing.T) {
 		"",
 		prometheus.NewRegistry(),
 		vdrs,
+		NewChainSubnets(),
 		config,
 	)
 	require.NoError(err)
@@ -336,6 +339,7 @@ func TestSybilMsgThrottlerMaxNonVdr(t *testing.T) {
 		"",
 		prometheus.NewRegistry(),
 		vdrs,
+		NewChainSubnets(),
 		config,
 	)
 	require.NoError(err)
@@ -387,6 +391,7 @@ func TestMsgThrottlerNextMsg(t *testing.T) {
 		"",
 		prometheus.NewRegistry(),
 		vdrs,
+		NewChainSubnets(),
 		config,
 	)
 	require.NoError(err)
@@ -447,3 +452,49 @@ func TestMsgThrottlerNextMsg(t *testing.T) {
 	// next non validator message should finish
 	<-done
 }
+
+// Ensure that messages sent to a subnet are dropped once its quota is used
+func TestInboundMsgByteThrottlerSubnetQuota(t *testing.T) {
+	require := require.New(t)
+	subnetID := ids.GenerateTestID()
+	chainID := ids.GenerateTestID()
+	config := MsgByteThrottlerConfig{
+		VdrAllocSize:        1024,
+		AtLargeAllocSize:    1024,
+		NodeMaxAtLargeBytes: 1024,
+		SubnetQuotas: map[ids.ID]uint64{
+			subnetID: 10,
+		},
+	}
+	chainSubnets := NewChainSubnets()
+	chainSubnets.RegisterChain("", testConsensusContext(subnetID, chainID), nil)
+	throttler, err := newInboundMsgByteThrottler(
+		logging.NoLog{},
+		"",
+		prometheus.NewRegistry(),
+		validators.NewManager(),
+		chainSubnets,
+		config,
+	)
+	require.NoError(err)
+
+	release, ok := throttler.AcquireChain(chainID, 10)
+	require.True(ok)
+	require.Equal(uint64(10), throttler.subnetQuotas.used[subnetID])
+
+	// The subnet's quota is exhausted
+	_, ok = throttler.AcquireChain(chainID, 1)
+	require.False(ok)
+
+	// Chains that aren't running on this node aren't limited
+	otherRelease, ok := throttler.AcquireChain(ids.GenerateTestID(), 1024)
+	require.True(ok)
+	otherRelease()
+
+	// Releasing the message frees the subnet's quota
+	release()
+	require.Empty(throttler.subnetQuotas.used)
+	release, ok = throttler.AcquireChain(chainID, 1)
+	require.True(ok)
+	release()
+}
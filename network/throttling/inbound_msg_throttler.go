@@ -29,6 +29,13 @@ type InboundMsgThrottler interface {
 	//            given nodeID. Callers must enforce this invariant.
 	Acquire(ctx context.Context, msgSize uint64, nodeID ids.NodeID) ReleaseFunc
 
+	// Called once a message of size [msgSize] has been read and is known to
+	// be sent to [chainID]. Never blocks. Returns false if the message should
+	// be dropped because the subnet that validates [chainID] has exhausted its
+	// quota. If this returns true, the returned release function must be
+	// called when done with the message.
+	AcquireChain(chainID ids.ID, msgSize uint64) (ReleaseFunc, bool)
+
 	// Add a new node to this throttler.
 	// Must be called before Acquire(..., [nodeID]) is called.
 	// RemoveNode([nodeID]) must have been called since the last time
@@ -57,6 +64,7 @@ func NewInboundMsgThrottler(
 	namespace string,
 	registerer prometheus.Registerer,
 	vdrs validators.Manager,
+	chainSubnets *ChainSubnets,
 	throttlerConfig InboundMsgThrottlerConfig,
 	resourceTracker tracker.ResourceTracker,
 	cpuTargeter tracker.Targeter,
@@ -67,6 +75,7 @@ func NewInboundMsgThrottler(
 		namespace,
 		registerer,
 		vdrs,
+		chainSubnets,
 		throttlerConfig.MsgByteThrottlerConfig,
 	)
 	if err != nil {
@@ -170,6 +179,12 @@ func (t *inboundMsgThrottler) Acquire(ctx context.Context, msgSize uint64, nodeI
 	}
 }
 
+// Returns false if the subnet that validates [chainID] has exhausted its
+// inbound message byte quota. See inboundMsgByteThrottler.
+func (t *inboundMsgThrottler) AcquireChain(chainID ids.ID, msgSize uint64) (ReleaseFunc, bool) {
+	return t.byteThrottler.AcquireChain(chainID, msgSize)
+}
+
 // See BandwidthThrottler.
 func (t *inboundMsgThrottler) AddNode(nodeID ids.NodeID) {
 	t.bandwidthThrottler.AddNode(nodeID)
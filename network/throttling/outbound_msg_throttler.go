@@ -37,7 +37,18 @@ type OutboundMsgThrottler interface {
 
 type outboundMsgThrottler struct {
 	commonMsgThrottler
-	metrics outboundMsgThrottlerMetrics
+	subnetQuotas *subnetQuotas
+	// Message and node --> Subnets whose quota was taken by each outstanding
+	// Acquire of the message for the node. The subnet is recorded, rather
+	// than recomputed on Release, because the subnet of a chain may be
+	// registered after the message was acquired.
+	subnetAcquisitions map[outboundMsgKey][]ids.ID
+	metrics            outboundMsgThrottlerMetrics
+}
+
+type outboundMsgKey struct {
+	msg    message.OutboundMessage
+	nodeID ids.NodeID
 }
 
 func NewSybilOutboundMsgThrottler(
@@ -45,8 +56,19 @@ func NewSybilOutboundMsgThrottler(
 	namespace string,
 	registerer prometheus.Registerer,
 	vdrs validators.Manager,
+	chainSubnets *ChainSubnets,
 	config MsgByteThrottlerConfig,
 ) (OutboundMsgThrottler, error) {
+	subnetQuotas, err := newSubnetQuotas(
+		namespace,
+		"throttler_outbound",
+		registerer,
+		chainSubnets,
+		config.SubnetQuotas,
+	)
+	if err != nil {
+		return nil, err
+	}
 	t := &outboundMsgThrottler{
 		commonMsgThrottler: commonMsgThrottler{
 			log:                    log,
@@ -58,6 +80,8 @@ func NewSybilOutboundMsgThrottler(
 			nodeToVdrBytesUsed:     make(map[ids.NodeID]uint64),
 			nodeToAtLargeBytesUsed: make(map[ids.NodeID]uint64),
 		},
+		subnetQuotas:       subnetQuotas,
+		subnetAcquisitions: make(map[outboundMsgKey][]ids.ID),
 	}
 	return t, t.metrics.initialize(namespace, registerer)
 }
@@ -71,8 +95,16 @@ func (t *outboundMsgThrottler) Acquire(msg message.OutboundMessage, nodeID ids.N
 	t.lock.Lock()
 	defer t.lock.Unlock()
 
+	// Drop the message if it is sent to a subnet that has exhausted its quota.
+	msgSize := uint64(len(msg.Bytes()))
+	subnetID, hasQuota := t.subnetID(msg)
+	if hasQuota && !t.subnetQuotas.acquire(subnetID, msgSize) {
+		t.metrics.acquireFailures.Inc()
+		return false
+	}
+
 	// Take as many bytes as we can from the at-large allocation.
-	bytesNeeded := uint64(len(msg.Bytes()))
+	bytesNeeded := msgSize
 	atLargeBytesUsed := min(
 		// only give as many bytes as needed
 		bytesNeeded,
@@ -111,11 +143,21 @@ func (t *outboundMsgThrottler) Acquire(msg message.OutboundMessage, nodeID ids.N
 	bytesNeeded -= vdrBytesUsed
 	if bytesNeeded != 0 {
 		// Can't acquire enough bytes to queue this message to be sent
+		if hasQuota {
+			t.subnetQuotas.release(subnetID, msgSize)
+		}
 		t.metrics.acquireFailures.Inc()
 		return false
 	}
 	// Can acquire enough bytes to queue this message to be sent.
 	// Update the state.
+	if hasQuota {
+		key := outboundMsgKey{
+			msg:    msg,
+			nodeID: nodeID,
+		}
+		t.subnetAcquisitions[key] = append(t.subnetAcquisitions[key], subnetID)
+	}
 	if atLargeBytesUsed > 0 {
 		t.remainingAtLargeBytes -= atLargeBytesUsed
 		t.nodeToAtLargeBytesUsed[nodeID] += atLargeBytesUsed
@@ -146,10 +188,23 @@ func (t *outboundMsgThrottler) Release(msg message.OutboundMessage, nodeID ids.N
 		t.lock.Unlock()
 	}()
 
+	msgSize := uint64(len(msg.Bytes()))
+	key := outboundMsgKey{
+		msg:    msg,
+		nodeID: nodeID,
+	}
+	if subnetIDs, ok := t.subnetAcquisitions[key]; ok {
+		t.subnetQuotas.release(subnetIDs[len(subnetIDs)-1], msgSize)
+		if len(subnetIDs) == 1 {
+			delete(t.subnetAcquisitions, key)
+		} else {
+			t.subnetAcquisitions[key] = subnetIDs[:len(subnetIDs)-1]
+		}
+	}
+
 	// [vdrBytesToReturn] is the number of bytes from [msgSize]
 	// that will be given back to [nodeID]'s validator allocation.
 	vdrBytesUsed := t.nodeToVdrBytesUsed[nodeID]
-	msgSize := uint64(len(msg.Bytes()))
 	vdrBytesToReturn := min(msgSize, vdrBytesUsed)
 	t.nodeToVdrBytesUsed[nodeID] -= vdrBytesToReturn
 	if t.nodeToVdrBytesUsed[nodeID] == 0 {
@@ -168,6 +223,20 @@ func (t *outboundMsgThrottler) Release(msg message.OutboundMessage, nodeID ids.N
 	}
 }
 
+// Returns the subnet that [msg] is sent to. Returns false if [msg] isn't sent
+// to a subnet with a quota.
+func (t *outboundMsgThrottler) subnetID(msg message.OutboundMessage) (ids.ID, bool) {
+	// Avoid inspecting [msg] if there are no quotas to enforce.
+	if len(t.subnetQuotas.quotas) == 0 {
+		return ids.Empty, false
+	}
+	chainID, ok := msg.ChainID()
+	if !ok {
+		return ids.Empty, false
+	}
+	return t.subnetQuotas.subnetID(chainID)
+}
+
 type outboundMsgThrottlerMetrics struct {
 	acquireSuccesses      prometheus.Counter
 	acquireFailures       prometheus.Counter
@@ -12,6 +12,7 @@ import (
 
 	"github.com/shubhamdubey02/cryftgo/ids"
 	"github.com/shubhamdubey02/cryftgo/message"
+	"github.com/shubhamdubey02/cryftgo/snow"
 	"github.com/shubhamdubey02/cryftgo/snow/validators"
 	"github.com/shubhamdubey02/cryftgo/utils/constants"
 	"github.com/shubhamdubey02/cryftgo/utils/logging"
@@ -35,6 +36,7 @@ func TestSybilOutboundMsgThrottler(t *testing.T) {
 		"",
 		prometheus.NewRegistry(),
 		vdrs,
+		NewChainSubnets(),
 		config,
 	)
 	require.NoError(err)
@@ -177,6 +179,7 @@ func TestSybilOutboundMsgThrottlerMaxNonVdr(t *testing.T) {
 		"",
 		prometheus.NewRegistry(),
 		vdrs,
+		NewChainSubnets(),
 		config,
 	)
 	require.NoError(err)
@@ -224,6 +227,7 @@ func TestBypassThrottling(t *testing.T) {
 		"",
 		prometheus.NewRegistry(),
 		vdrs,
+		NewChainSubnets(),
 		config,
 	)
 	require.NoError(err)
@@ -268,3 +272,117 @@ func testMsgWithSize(ctrl *gomock.Controller, size uint64) message.OutboundMessa
 	msg.EXPECT().Bytes().Return(make([]byte, size)).AnyTimes()
 	return msg
 }
+
+// Ensure that messages sent to a subnet are dropped once its quota is used
+func TestSybilOutboundMsgThrottlerSubnetQuota(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	require := require.New(t)
+	subnetID := ids.GenerateTestID()
+	chainID := ids.GenerateTestID()
+	otherChainID := ids.GenerateTestID()
+	config := MsgByteThrottlerConfig{
+		VdrAllocSize:        100,
+		AtLargeAllocSize:    100,
+		NodeMaxAtLargeBytes: 100,
+		SubnetQuotas: map[ids.ID]uint64{
+			subnetID: 10,
+		},
+	}
+	chainSubnets := NewChainSubnets()
+	chainSubnets.RegisterChain("", testConsensusContext(subnetID, chainID), nil)
+	throttlerIntf, err := NewSybilOutboundMsgThrottler(
+		logging.NoLog{},
+		"",
+		prometheus.NewRegistry(),
+		validators.NewManager(),
+		chainSubnets,
+		config,
+	)
+	require.NoError(err)
+	throttler := throttlerIntf.(*outboundMsgThrottler)
+	nodeID := ids.GenerateTestNodeID()
+
+	msg := testChainMsgWithSize(ctrl, chainID, 10)
+	require.True(throttlerIntf.Acquire(msg, nodeID))
+	require.Equal(uint64(10), throttler.subnetQuotas.used[subnetID])
+
+	// The subnet's quota is exhausted
+	require.False(throttlerIntf.Acquire(testChainMsgWithSize(ctrl, chainID, 1), nodeID))
+	require.Equal(uint64(10), throttler.subnetQuotas.used[subnetID])
+	require.Equal(config.AtLargeAllocSize-10, throttler.remainingAtLargeBytes)
+
+	// Chains of other subnets aren't limited by the quota
+	otherMsg := testChainMsgWithSize(ctrl, otherChainID, 10)
+	require.True(throttlerIntf.Acquire(otherMsg, nodeID))
+
+	// Releasing the message frees the subnet's quota
+	throttlerIntf.Release(msg, nodeID)
+	require.Empty(throttler.subnetQuotas.used)
+	require.True(throttlerIntf.Acquire(testChainMsgWithSize(ctrl, chainID, 1), nodeID))
+}
+
+// Ensure that releasing a message only returns the subnet quota that acquiring
+// it took, even if the subnet of its chain changed in the meantime
+func TestSybilOutboundMsgThrottlerSubnetQuotaChainRegisteredAfterAcquire(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	require := require.New(t)
+	subnetID := ids.GenerateTestID()
+	chainID := ids.GenerateTestID()
+	config := MsgByteThrottlerConfig{
+		VdrAllocSize:        100,
+		AtLargeAllocSize:    100,
+		NodeMaxAtLargeBytes: 100,
+		SubnetQuotas: map[ids.ID]uint64{
+			subnetID: 10,
+		},
+	}
+	chainSubnets := NewChainSubnets()
+	throttlerIntf, err := NewSybilOutboundMsgThrottler(
+		logging.NoLog{},
+		"",
+		prometheus.NewRegistry(),
+		validators.NewManager(),
+		chainSubnets,
+		config,
+	)
+	require.NoError(err)
+	throttler := throttlerIntf.(*outboundMsgThrottler)
+	nodeID := ids.GenerateTestNodeID()
+
+	// The chain isn't registered yet, so the message doesn't take any of the
+	// subnet's quota
+	msg := testChainMsgWithSize(ctrl, chainID, 5)
+	require.True(throttlerIntf.Acquire(msg, nodeID))
+	require.Empty(throttler.subnetQuotas.used)
+
+	chainSubnets.RegisterChain("", testConsensusContext(subnetID, chainID), nil)
+	quotaMsg := testChainMsgWithSize(ctrl, chainID, 10)
+	require.True(throttlerIntf.Acquire(quotaMsg, nodeID))
+	require.Equal(uint64(10), throttler.subnetQuotas.used[subnetID])
+
+	// Releasing the first message must not release the subnet's quota
+	throttlerIntf.Release(msg, nodeID)
+	require.Equal(uint64(10), throttler.subnetQuotas.used[subnetID])
+
+	throttlerIntf.Release(quotaMsg, nodeID)
+	require.Empty(throttler.subnetQuotas.used)
+	require.Empty(throttler.subnetAcquisitions)
+}
+
+func testChainMsgWithSize(ctrl *gomock.Controller, chainID ids.ID, size uint64) message.OutboundMessage {
+	msg := message.NewMockOutboundMessage(ctrl)
+	msg.EXPECT().BypassThrottling().Return(false).AnyTimes()
+	msg.EXPECT().Op().Return(message.AppGossipOp).AnyTimes()
+	msg.EXPECT().Bytes().Return(make([]byte, size)).AnyTimes()
+	msg.EXPECT().ChainID().Return(chainID, true).AnyTimes()
+	return msg
+}
+
+func testConsensusContext(subnetID ids.ID, chainID ids.ID) *snow.ConsensusContext {
+	return &snow.ConsensusContext{
+		Context: &snow.Context{
+			SubnetID: subnetID,
+			ChainID:  chainID,
+		},
+	}
+}
@@ -0,0 +1,114 @@
+// Copyright (C) 2019-2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package throttling
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+
+	"github.com/shubhamdubey02/cryftgo/ids"
+	"github.com/shubhamdubey02/cryftgo/utils"
+)
+
+const subnetLabel = "subnet"
+
+// Used by the inbound and outbound message byte throttlers to limit the
+// number of bytes of messages sent to the chains of each subnet that can be
+// outstanding at once.
+//
+// Not safe for concurrent use. The throttlers only call it while holding
+// their lock.
+type subnetQuotas struct {
+	chainSubnets *ChainSubnets
+	// Subnet ID --> Max number of bytes that can be outstanding
+	quotas map[ids.ID]uint64
+	// Subnet ID --> Number of bytes that are outstanding
+	used    map[ids.ID]uint64
+	metrics subnetQuotasMetrics
+}
+
+func newSubnetQuotas(
+	namespace string,
+	metricPrefix string,
+	registerer prometheus.Registerer,
+	chainSubnets *ChainSubnets,
+	quotas map[ids.ID]uint64,
+) (*subnetQuotas, error) {
+	q := &subnetQuotas{
+		chainSubnets: chainSubnets,
+		quotas:       quotas,
+		used:         make(map[ids.ID]uint64),
+	}
+	if err := q.metrics.initialize(namespace, metricPrefix, registerer); err != nil {
+		return nil, err
+	}
+	for subnetID, quota := range quotas {
+		q.metrics.remainingBytes.WithLabelValues(subnetID.String()).Set(float64(quota))
+	}
+	return q, nil
+}
+
+// subnetID returns the subnet that validates [chainID]. Returns false if the
+// subnet doesn't have a quota.
+func (q *subnetQuotas) subnetID(chainID ids.ID) (ids.ID, bool) {
+	if len(q.quotas) == 0 {
+		return ids.Empty, false
+	}
+	subnetID, ok := q.chainSubnets.SubnetID(chainID)
+	if !ok {
+		return ids.Empty, false
+	}
+	_, ok = q.quotas[subnetID]
+	return subnetID, ok
+}
+
+// Returns true and takes [msgSize] bytes from the quota of [subnetID] if there
+// are enough bytes left in it. Otherwise, returns false.
+func (q *subnetQuotas) acquire(subnetID ids.ID, msgSize uint64) bool {
+	used := q.used[subnetID]
+	if q.quotas[subnetID]-used < msgSize {
+		q.metrics.acquireFailures.WithLabelValues(subnetID.String()).Inc()
+		return false
+	}
+	q.used[subnetID] = used + msgSize
+	q.metrics.remainingBytes.WithLabelValues(subnetID.String()).Sub(float64(msgSize))
+	return true
+}
+
+// Must correspond to a previous call of acquire([subnetID], [msgSize]) that
+// returned true.
+func (q *subnetQuotas) release(subnetID ids.ID, msgSize uint64) {
+	q.used[subnetID] -= msgSize
+	if q.used[subnetID] == 0 {
+		delete(q.used, subnetID)
+	}
+	q.metrics.remainingBytes.WithLabelValues(subnetID.String()).Add(float64(msgSize))
+}
+
+type subnetQuotasMetrics struct {
+	acquireFailures *prometheus.CounterVec
+	remainingBytes  *prometheus.GaugeVec
+}
+
+func (m *subnetQuotasMetrics) initialize(namespace string, metricPrefix string, registerer prometheus.Registerer) error {
+	m.acquireFailures = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Namespace: namespace,
+			Name:      metricPrefix + "_subnet_acquire_failures",
+			Help:      "Messages dropped because their subnet exhausted its byte quota",
+		},
+		[]string{subnetLabel},
+	)
+	m.remainingBytes = prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Namespace: namespace,
+			Name:      metricPrefix + "_subnet_remaining_bytes",
+			Help:      "Bytes remaining in the byte quota of each subnet",
+		},
+		[]string{subnetLabel},
+	)
+	return utils.Err(
+		registerer.Register(m.acquireFailures),
+		registerer.Register(m.remainingBytes),
+	)
+}
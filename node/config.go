@@ -13,6 +13,7 @@ import (
 	"github.com/shubhamdubey02/cryftgo/ids"
 	"github.com/shubhamdubey02/cryftgo/network"
 	"github.com/shubhamdubey02/cryftgo/snow/networking/benchlist"
+	"github.com/shubhamdubey02/cryftgo/snow/networking/reputation"
 	"github.com/shubhamdubey02/cryftgo/snow/networking/router"
 	"github.com/shubhamdubey02/cryftgo/snow/networking/tracker"
 	"github.com/shubhamdubey02/cryftgo/subnets"
@@ -119,6 +120,9 @@ type DatabaseConfig struct {
 
 	// Path to config file
 	Config []byte `json:"-"`
+
+	// Path to a backup to populate the database with if it is empty
+	RestorePath string `json:"restorePath"`
 }
 
 // Config contains all of the configurations of an Avalanche node.
@@ -148,6 +152,8 @@ type Config struct {
 
 	BenchlistConfig benchlist.Config `json:"benchlistConfig"`
 
+	ReputationConfig reputation.Config `json:"reputationConfig"`
+
 	ProfilerConfig profiler.Config `json:"profilerConfig"`
 
 	LoggingConfig logging.Config `json:"loggingConfig"`
@@ -167,6 +173,9 @@ type Config struct {
 	// ConsensusAppConcurrency defines the maximum number of goroutines to
 	// handle App messages per chain.
 	ConsensusAppConcurrency int `json:"consensusAppConcurrency"`
+	// ConsensusTraceSize is the number of recently decided blocks of each
+	// chain whose consensus polls are kept. If 0, polls aren't traced.
+	ConsensusTraceSize int `json:"consensusTraceSize"`
 
 	TrackedSubnets set.Set[ids.ID] `json:"trackedSubnets"`
 
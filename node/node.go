@@ -33,6 +33,7 @@ import (
 	"github.com/shubhamdubey02/cryftgo/chains"
 	"github.com/shubhamdubey02/cryftgo/chains/atomic"
 	"github.com/shubhamdubey02/cryftgo/database"
+	"github.com/shubhamdubey02/cryftgo/database/backup"
 	"github.com/shubhamdubey02/cryftgo/database/leveldb"
 	"github.com/shubhamdubey02/cryftgo/database/memdb"
 	"github.com/shubhamdubey02/cryftgo/database/meterdb"
@@ -49,7 +50,9 @@ import (
 	"github.com/shubhamdubey02/cryftgo/network/peer"
 	"github.com/shubhamdubey02/cryftgo/network/throttling"
 	"github.com/shubhamdubey02/cryftgo/snow"
+	"github.com/shubhamdubey02/cryftgo/snow/engine/snowman/polltrace"
 	"github.com/shubhamdubey02/cryftgo/snow/networking/benchlist"
+	"github.com/shubhamdubey02/cryftgo/snow/networking/reputation"
 	"github.com/shubhamdubey02/cryftgo/snow/networking/router"
 	"github.com/shubhamdubey02/cryftgo/snow/networking/timeout"
 	"github.com/shubhamdubey02/cryftgo/snow/networking/tracker"
@@ -95,8 +98,9 @@ var (
 	genesisHashKey     = []byte("genesisID")
 	ungracefulShutdown = []byte("ungracefulShutdown")
 
-	indexerDBPrefix  = []byte{0x00}
-	keystoreDBPrefix = []byte("keystore")
+	indexerDBPrefix    = []byte{0x00}
+	keystoreDBPrefix   = []byte("keystore")
+	reputationDBPrefix = []byte("reputation")
 
 	errInvalidTLSKey = errors.New("invalid TLS key")
 	errShuttingDown  = errors.New("server shutting down")
@@ -302,6 +306,21 @@ type Node struct {
 	// Manages validator benching
 	benchlistManager benchlist.Manager
 
+	// Tracks misbehavior of peers and bans peers that misbehave too often
+	reputationTracker reputation.Tracker
+
+	// Records the messages sent to and received from peers
+	recorder peer.Recorder
+
+	// Tracks the subnet of each chain, to account message bytes to subnets
+	chainSubnets *throttling.ChainSubnets
+
+	// Records the polls applied to the blocks of each chain
+	consensusTraces *polltrace.Manager
+
+	// Tracks how long peers take to respond to queries
+	latencyTracker tracker.LatencyTracker
+
 	uptimeCalculator uptime.LockedCalculator
 
 	// dispatcher for events as they happen in consensus
@@ -522,6 +541,18 @@ func (n *Node) initNetworking() error {
 	n.Config.BenchlistConfig.Benchable = n.chainRouter
 	n.benchlistManager = benchlist.NewManager(&n.Config.BenchlistConfig)
 
+	// Configure reputation tracking
+	n.reputationTracker, err = reputation.NewTracker(
+		n.Config.ReputationConfig,
+		n.vdrs,
+		prefixdb.New(reputationDBPrefix, n.DB),
+		n.Log,
+		n.MetricsRegisterer,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to initialize reputation tracker: %w", err)
+	}
+
 	n.uptimeCalculator = uptime.NewLockedCalculator()
 
 	consensusRouter := n.chainRouter
@@ -597,6 +628,21 @@ func (n *Node) initNetworking() error {
 	n.Config.NetworkConfig.UptimeCalculator = n.uptimeCalculator
 	n.Config.NetworkConfig.UptimeRequirement = n.Config.UptimeRequirement
 	n.Config.NetworkConfig.ResourceTracker = n.resourceTracker
+	n.Config.NetworkConfig.ReputationTracker = n.reputationTracker
+
+	n.recorder = peer.NewNoRecorder()
+	if n.Config.NetworkConfig.RecorderEnabled {
+		n.recorder, err = peer.NewFileRecorder(n.Config.NetworkConfig.RecorderConfig, n.Log)
+		if err != nil {
+			return fmt.Errorf("failed to initialize message recorder: %w", err)
+		}
+		n.Log.Warn("recording all messages sent to and received from peers",
+			zap.String("directory", n.Config.NetworkConfig.RecorderConfig.Directory),
+		)
+	}
+	n.Config.NetworkConfig.Recorder = n.recorder
+	n.chainSubnets = throttling.NewChainSubnets()
+	n.Config.NetworkConfig.ChainSubnets = n.chainSubnets
 	n.Config.NetworkConfig.CPUTargeter = n.cpuTargeter
 	n.Config.NetworkConfig.DiskTargeter = n.diskTargeter
 
@@ -719,6 +765,65 @@ func (n *Node) Dispatch() error {
  ******************************************************************************
  */
 
+// restoreDatabase populates the database from the configured backup. The
+// backup is only restored if the database is empty so that the node can be
+// restarted without removing the config.
+//
+// If a previous restore was interrupted, the partially restored database is
+// cleared and the restore is retried. If no backup is configured, the node
+// refuses to start.
+func (n *Node) restoreDatabase() error {
+	restorePath := n.Config.DatabaseConfig.RestorePath
+	if len(restorePath) == 0 {
+		interrupted, err := backup.Interrupted(n.DB)
+		if err != nil {
+			return err
+		}
+		if interrupted {
+			return fmt.Errorf("%w: a backup must be configured to retry it", backup.ErrInterrupted)
+		}
+		return nil
+	}
+
+	cleared, err := backup.ClearInterrupted(n.DB, backup.DefaultBatchSize)
+	if err != nil {
+		return fmt.Errorf("couldn't clear interrupted database restore: %w", err)
+	}
+	if cleared {
+		n.Log.Warn("cleared partially restored database",
+			zap.String("reason", "previous restore was interrupted"),
+			zap.String("path", restorePath),
+		)
+	}
+
+	isEmpty, err := database.IsEmpty(n.DB)
+	if err != nil {
+		return err
+	}
+	if !isEmpty {
+		n.Log.Info("skipping database restore",
+			zap.String("reason", "database is not empty"),
+			zap.String("path", restorePath),
+		)
+		return nil
+	}
+
+	n.Log.Info("restoring database",
+		zap.String("path", restorePath),
+	)
+	info, err := backup.Restore(restorePath, n.DB, backup.DefaultBatchSize)
+	if err != nil {
+		return fmt.Errorf("couldn't restore database from %s: %w", restorePath, err)
+	}
+	n.Log.Info("restored database",
+		zap.String("path", restorePath),
+		zap.Uint64("numKeys", info.NumKeys),
+		zap.Uint64("numBytes", info.NumBytes),
+		zap.Stringer("checksum", info.Checksum),
+	)
+	return nil
+}
+
 func (n *Node) initDatabase() error {
 	// start the db
 	switch n.Config.DatabaseConfig.Name {
@@ -750,6 +855,10 @@ func (n *Node) initDatabase() error {
 		)
 	}
 
+	if err := n.restoreDatabase(); err != nil {
+		return err
+	}
+
 	if n.Config.ReadOnly && n.Config.DatabaseConfig.Name != memdb.Name {
 		n.DB = versiondb.New(n.DB)
 	}
@@ -1021,7 +1130,7 @@ func (n *Node) initChainManager(cryftAssetID ids.ID) error {
 		cChainID,
 	)
 
-	n.timeoutManager, err = timeout.NewManager(
+	timeoutManager, err := timeout.NewManager(
 		&n.Config.AdaptiveTimeoutConfig,
 		n.benchlistManager,
 		"requests",
@@ -1030,6 +1139,8 @@ func (n *Node) initChainManager(cryftAssetID ids.ID) error {
 	if err != nil {
 		return err
 	}
+	n.latencyTracker = tracker.NewLatencyTracker(n.Config.AdaptiveTimeoutConfig.TimeoutHalflife)
+	n.timeoutManager = timeout.NewLatencyTrackingManager(timeoutManager, n.latencyTracker)
 	go n.Log.RecoverAndPanic(n.timeoutManager.Dispatch)
 
 	// Routes incoming messages from peers to the appropriate chain
@@ -1054,6 +1165,9 @@ func (n *Node) initChainManager(cryftAssetID ids.ID) error {
 	if err != nil {
 		return fmt.Errorf("failed to initialize subnets: %w", err)
 	}
+
+	n.consensusTraces = polltrace.NewManager(n.Config.ConsensusTraceSize)
+
 	n.chainManager = chains.New(
 		&chains.ManagerConfig{
 			SybilProtectionEnabled:                  n.Config.SybilProtectionEnabled,
@@ -1096,6 +1210,9 @@ func (n *Node) initChainManager(cryftAssetID ids.ID) error {
 			ApricotPhase4Time:                       version.GetApricotPhase4Time(n.Config.NetworkID),
 			ApricotPhase4MinPChainHeight:            version.ApricotPhase4MinPChainHeight[n.Config.NetworkID],
 			ResourceTracker:                         n.resourceTracker,
+			Reputation:                              n.reputationTracker,
+			ConsensusTraces:                         n.consensusTraces,
+			LatencyTracker:                          n.latencyTracker,
 			StateSyncBeacons:                        n.Config.StateSyncIDs,
 			TracingEnabled:                          n.Config.TraceConfig.Enabled,
 			Tracer:                                  n.tracer,
@@ -1106,6 +1223,8 @@ func (n *Node) initChainManager(cryftAssetID ids.ID) error {
 
 	// Notify the API server when new chains are created
 	n.chainManager.AddRegistrant(n.APIServer)
+	// Notify the network of the subnet of new chains
+	n.chainManager.AddRegistrant(n.chainSubnets)
 	return nil
 }
 
@@ -1271,6 +1390,9 @@ func (n *Node) initAdminAPI() error {
 			NodeConfig:   n.Config,
 			VMManager:    n.VMManager,
 			VMRegistry:   n.VMRegistry,
+			Network:      n.Net,
+
+			ConsensusTraces: n.consensusTraces,
 		},
 	)
 	if err != nil {
@@ -1339,6 +1461,7 @@ func (n *Node) initInfoAPI() error {
 		n.Config.NetworkConfig.MyIPPort,
 		n.Net,
 		n.benchlistManager,
+		n.reputationTracker,
 	)
 	if err != nil {
 		return err
@@ -1599,6 +1722,14 @@ func (n *Node) shutdown() {
 	n.Log.Info("cleaning up plugin runtimes")
 	n.runtimeManager.Stop(context.TODO())
 
+	if n.recorder != nil {
+		if err := n.recorder.Close(); err != nil {
+			n.Log.Debug("error closing message recorder",
+				zap.Error(err),
+			)
+		}
+	}
+
 	if n.DB != nil {
 		if err := n.DB.Delete(ungracefulShutdown); err != nil {
 			n.Log.Error(
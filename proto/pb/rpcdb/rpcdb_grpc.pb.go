@@ -24,6 +24,8 @@ const (
 	Database_Get_FullMethodName                           = "/rpcdb.Database/Get"
 	Database_Put_FullMethodName                           = "/rpcdb.Database/Put"
 	Database_Delete_FullMethodName                        = "/rpcdb.Database/Delete"
+	Database_DeleteRange_FullMethodName                   = "/rpcdb.Database/DeleteRange"
+	Database_Ingest_FullMethodName                        = "/rpcdb.Database/Ingest"
 	Database_Compact_FullMethodName                       = "/rpcdb.Database/Compact"
 	Database_Close_FullMethodName                         = "/rpcdb.Database/Close"
 	Database_HealthCheck_FullMethodName                   = "/rpcdb.Database/HealthCheck"
@@ -42,6 +44,8 @@ type DatabaseClient interface {
 	Get(ctx context.Context, in *GetRequest, opts ...grpc.CallOption) (*GetResponse, error)
 	Put(ctx context.Context, in *PutRequest, opts ...grpc.CallOption) (*PutResponse, error)
 	Delete(ctx context.Context, in *DeleteRequest, opts ...grpc.CallOption) (*DeleteResponse, error)
+	DeleteRange(ctx context.Context, in *DeleteRangeRequest, opts ...grpc.CallOption) (*DeleteRangeResponse, error)
+	Ingest(ctx context.Context, opts ...grpc.CallOption) (Database_IngestClient, error)
 	Compact(ctx context.Context, in *CompactRequest, opts ...grpc.CallOption) (*CompactResponse, error)
 	Close(ctx context.Context, in *CloseRequest, opts ...grpc.CallOption) (*CloseResponse, error)
 	HealthCheck(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*HealthCheckResponse, error)
@@ -96,6 +100,49 @@ func (c *databaseClient) Delete(ctx context.Context, in *DeleteRequest, opts ...
 	return out, nil
 }
 
+func (c *databaseClient) DeleteRange(ctx context.Context, in *DeleteRangeRequest, opts ...grpc.CallOption) (*DeleteRangeResponse, error) {
+	out := new(DeleteRangeResponse)
+	err := c.cc.Invoke(ctx, Database_DeleteRange_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *databaseClient) Ingest(ctx context.Context, opts ...grpc.CallOption) (Database_IngestClient, error) {
+	stream, err := c.cc.NewStream(ctx, &Database_ServiceDesc.Streams[0], Database_Ingest_FullMethodName, opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &databaseIngestClient{stream}
+	return x, nil
+}
+
+type Database_IngestClient interface {
+	Send(*IngestRequest) error
+	CloseAndRecv() (*IngestResponse, error)
+	grpc.ClientStream
+}
+
+type databaseIngestClient struct {
+	grpc.ClientStream
+}
+
+func (x *databaseIngestClient) Send(m *IngestRequest) error {
+	return x.ClientStream.SendMsg(m)
+}
+
+func (x *databaseIngestClient) CloseAndRecv() (*IngestResponse, error) {
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	m := new(IngestResponse)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
 func (c *databaseClient) Compact(ctx context.Context, in *CompactRequest, opts ...grpc.CallOption) (*CompactResponse, error) {
 	out := new(CompactResponse)
 	err := c.cc.Invoke(ctx, Database_Compact_FullMethodName, in, out, opts...)
@@ -176,6 +223,8 @@ type DatabaseServer interface {
 	Get(context.Context, *GetRequest) (*GetResponse, error)
 	Put(context.Context, *PutRequest) (*PutResponse, error)
 	Delete(context.Context, *DeleteRequest) (*DeleteResponse, error)
+	DeleteRange(context.Context, *DeleteRangeRequest) (*DeleteRangeResponse, error)
+	Ingest(Database_IngestServer) error
 	Compact(context.Context, *CompactRequest) (*CompactResponse, error)
 	Close(context.Context, *CloseRequest) (*CloseResponse, error)
 	HealthCheck(context.Context, *emptypb.Empty) (*HealthCheckResponse, error)
@@ -203,6 +252,12 @@ func (UnimplementedDatabaseServer) Put(context.Context, *PutRequest) (*PutRespon
 func (UnimplementedDatabaseServer) Delete(context.Context, *DeleteRequest) (*DeleteResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method Delete not implemented")
 }
+func (UnimplementedDatabaseServer) DeleteRange(context.Context, *DeleteRangeRequest) (*DeleteRangeResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DeleteRange not implemented")
+}
+func (UnimplementedDatabaseServer) Ingest(Database_IngestServer) error {
+	return status.Errorf(codes.Unimplemented, "method Ingest not implemented")
+}
 func (UnimplementedDatabaseServer) Compact(context.Context, *CompactRequest) (*CompactResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method Compact not implemented")
 }
@@ -312,6 +367,50 @@ func _Database_Delete_Handler(srv interface{}, ctx context.Context, dec func(int
 	return interceptor(ctx, in, info, handler)
 }
 
+func _Database_DeleteRange_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DeleteRangeRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DatabaseServer).DeleteRange(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Database_DeleteRange_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DatabaseServer).DeleteRange(ctx, req.(*DeleteRangeRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Database_Ingest_Handler(srv interface{}, stream grpc.ServerStream) error {
+	return srv.(DatabaseServer).Ingest(&databaseIngestServer{stream})
+}
+
+type Database_IngestServer interface {
+	SendAndClose(*IngestResponse) error
+	Recv() (*IngestRequest, error)
+	grpc.ServerStream
+}
+
+type databaseIngestServer struct {
+	grpc.ServerStream
+}
+
+func (x *databaseIngestServer) SendAndClose(m *IngestResponse) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func (x *databaseIngestServer) Recv() (*IngestRequest, error) {
+	m := new(IngestRequest)
+	if err := x.ServerStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
 func _Database_Compact_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(CompactRequest)
 	if err := dec(in); err != nil {
@@ -479,6 +578,10 @@ var Database_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "Delete",
 			Handler:    _Database_Delete_Handler,
 		},
+		{
+			MethodName: "DeleteRange",
+			Handler:    _Database_DeleteRange_Handler,
+		},
 		{
 			MethodName: "Compact",
 			Handler:    _Database_Compact_Handler,
@@ -512,6 +615,12 @@ var Database_ServiceDesc = grpc.ServiceDesc{
 			Handler:    _Database_IteratorRelease_Handler,
 		},
 	},
-	Streams:  []grpc.StreamDesc{},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "Ingest",
+			Handler:       _Database_Ingest_Handler,
+			ClientStreams: true,
+		},
+	},
 	Metadata: "rpcdb/rpcdb.proto",
 }
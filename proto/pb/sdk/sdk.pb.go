@@ -169,6 +169,63 @@ func (x *PushGossip) GetGossip() [][]byte {
 	return nil
 }
 
+type ReconcileGossipRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// Salt used to hash gossip IDs into the sketch
+	Salt []byte `protobuf:"bytes,1,opt,name=salt,proto3" json:"salt,omitempty"`
+	// Invertible bloom lookup table of the requester's gossip IDs
+	Sketch []byte `protobuf:"bytes,2,opt,name=sketch,proto3" json:"sketch,omitempty"`
+}
+
+func (x *ReconcileGossipRequest) Reset() {
+	*x = ReconcileGossipRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_sdk_sdk_proto_msgTypes[3]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ReconcileGossipRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ReconcileGossipRequest) ProtoMessage() {}
+
+func (x *ReconcileGossipRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_sdk_sdk_proto_msgTypes[3]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ReconcileGossipRequest.ProtoReflect.Descriptor instead.
+func (*ReconcileGossipRequest) Descriptor() ([]byte, []int) {
+	return file_sdk_sdk_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *ReconcileGossipRequest) GetSalt() []byte {
+	if x != nil {
+		return x.Salt
+	}
+	return nil
+}
+
+func (x *ReconcileGossipRequest) GetSketch() []byte {
+	if x != nil {
+		return x.Sketch
+	}
+	return nil
+}
+
 var File_sdk_sdk_proto protoreflect.FileDescriptor
 
 var file_sdk_sdk_proto_rawDesc = []byte{
@@ -182,11 +239,15 @@ var file_sdk_sdk_proto_rawDesc = []byte{
 	0x6f, 0x73, 0x73, 0x69, 0x70, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0c, 0x52, 0x06, 0x67, 0x6f, 0x73,
 	0x73, 0x69, 0x70, 0x22, 0x24, 0x0a, 0x0a, 0x50, 0x75, 0x73, 0x68, 0x47, 0x6f, 0x73, 0x73, 0x69,
 	0x70, 0x12, 0x16, 0x0a, 0x06, 0x67, 0x6f, 0x73, 0x73, 0x69, 0x70, 0x18, 0x01, 0x20, 0x03, 0x28,
-	0x0c, 0x52, 0x06, 0x67, 0x6f, 0x73, 0x73, 0x69, 0x70, 0x42, 0x2e, 0x5a, 0x2c, 0x67, 0x69, 0x74,
-	0x68, 0x75, 0x62, 0x2e, 0x63, 0x6f, 0x6d, 0x2f, 0x61, 0x76, 0x61, 0x2d, 0x6c, 0x61, 0x62, 0x73,
-	0x2f, 0x61, 0x76, 0x61, 0x6c, 0x61, 0x6e, 0x63, 0x68, 0x65, 0x67, 0x6f, 0x2f, 0x70, 0x72, 0x6f,
-	0x74, 0x6f, 0x2f, 0x70, 0x62, 0x2f, 0x73, 0x64, 0x6b, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f,
-	0x33,
+	0x0c, 0x52, 0x06, 0x67, 0x6f, 0x73, 0x73, 0x69, 0x70, 0x22, 0x44, 0x0a, 0x16, 0x52, 0x65, 0x63,
+	0x6f, 0x6e, 0x63, 0x69, 0x6c, 0x65, 0x47, 0x6f, 0x73, 0x73, 0x69, 0x70, 0x52, 0x65, 0x71, 0x75,
+	0x65, 0x73, 0x74, 0x12, 0x12, 0x0a, 0x04, 0x73, 0x61, 0x6c, 0x74, 0x18, 0x01, 0x20, 0x01, 0x28,
+	0x0c, 0x52, 0x04, 0x73, 0x61, 0x6c, 0x74, 0x12, 0x16, 0x0a, 0x06, 0x73, 0x6b, 0x65, 0x74, 0x63,
+	0x68, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x06, 0x73, 0x6b, 0x65, 0x74, 0x63, 0x68, 0x42,
+	0x30, 0x5a, 0x2e, 0x67, 0x69, 0x74, 0x68, 0x75, 0x62, 0x2e, 0x63, 0x6f, 0x6d, 0x2f, 0x73, 0x68,
+	0x75, 0x62, 0x68, 0x61, 0x6d, 0x64, 0x75, 0x62, 0x65, 0x79, 0x30, 0x32, 0x2f, 0x63, 0x72, 0x79,
+	0x66, 0x74, 0x67, 0x6f, 0x2f, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2f, 0x70, 0x62, 0x2f, 0x73, 0x64,
+	0x6b, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
 }
 
 var (
@@ -201,11 +262,12 @@ func file_sdk_sdk_proto_rawDescGZIP() []byte {
 	return file_sdk_sdk_proto_rawDescData
 }
 
-var file_sdk_sdk_proto_msgTypes = make([]protoimpl.MessageInfo, 3)
+var file_sdk_sdk_proto_msgTypes = make([]protoimpl.MessageInfo, 4)
 var file_sdk_sdk_proto_goTypes = []interface{}{
-	(*PullGossipRequest)(nil),  // 0: sdk.PullGossipRequest
-	(*PullGossipResponse)(nil), // 1: sdk.PullGossipResponse
-	(*PushGossip)(nil),         // 2: sdk.PushGossip
+	(*PullGossipRequest)(nil),      // 0: sdk.PullGossipRequest
+	(*PullGossipResponse)(nil),     // 1: sdk.PullGossipResponse
+	(*PushGossip)(nil),             // 2: sdk.PushGossip
+	(*ReconcileGossipRequest)(nil), // 3: sdk.ReconcileGossipRequest
 }
 var file_sdk_sdk_proto_depIdxs = []int32{
 	0, // [0:0] is the sub-list for method output_type
@@ -257,6 +319,18 @@ func file_sdk_sdk_proto_init() {
 				return nil
 			}
 		}
+		file_sdk_sdk_proto_msgTypes[3].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ReconcileGossipRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
 	}
 	type x struct{}
 	out := protoimpl.TypeBuilder{
@@ -264,7 +338,7 @@ func file_sdk_sdk_proto_init() {
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: file_sdk_sdk_proto_rawDesc,
 			NumEnums:      0,
-			NumMessages:   3,
+			NumMessages:   4,
 			NumExtensions: 0,
 			NumServices:   0,
 		},
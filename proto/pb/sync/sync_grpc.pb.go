@@ -23,6 +23,7 @@ const (
 	DB_GetMerkleRoot_FullMethodName     = "/sync.DB/GetMerkleRoot"
 	DB_Clear_FullMethodName             = "/sync.DB/Clear"
 	DB_GetProof_FullMethodName          = "/sync.DB/GetProof"
+	DB_GetExclusionProof_FullMethodName = "/sync.DB/GetExclusionProof"
 	DB_GetChangeProof_FullMethodName    = "/sync.DB/GetChangeProof"
 	DB_VerifyChangeProof_FullMethodName = "/sync.DB/VerifyChangeProof"
 	DB_CommitChangeProof_FullMethodName = "/sync.DB/CommitChangeProof"
@@ -37,6 +38,7 @@ type DBClient interface {
 	GetMerkleRoot(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*GetMerkleRootResponse, error)
 	Clear(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*emptypb.Empty, error)
 	GetProof(ctx context.Context, in *GetProofRequest, opts ...grpc.CallOption) (*GetProofResponse, error)
+	GetExclusionProof(ctx context.Context, in *GetExclusionProofRequest, opts ...grpc.CallOption) (*GetExclusionProofResponse, error)
 	GetChangeProof(ctx context.Context, in *GetChangeProofRequest, opts ...grpc.CallOption) (*GetChangeProofResponse, error)
 	VerifyChangeProof(ctx context.Context, in *VerifyChangeProofRequest, opts ...grpc.CallOption) (*VerifyChangeProofResponse, error)
 	CommitChangeProof(ctx context.Context, in *CommitChangeProofRequest, opts ...grpc.CallOption) (*emptypb.Empty, error)
@@ -79,6 +81,15 @@ func (c *dBClient) GetProof(ctx context.Context, in *GetProofRequest, opts ...gr
 	return out, nil
 }
 
+func (c *dBClient) GetExclusionProof(ctx context.Context, in *GetExclusionProofRequest, opts ...grpc.CallOption) (*GetExclusionProofResponse, error) {
+	out := new(GetExclusionProofResponse)
+	err := c.cc.Invoke(ctx, DB_GetExclusionProof_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *dBClient) GetChangeProof(ctx context.Context, in *GetChangeProofRequest, opts ...grpc.CallOption) (*GetChangeProofResponse, error) {
 	out := new(GetChangeProofResponse)
 	err := c.cc.Invoke(ctx, DB_GetChangeProof_FullMethodName, in, out, opts...)
@@ -131,6 +142,7 @@ type DBServer interface {
 	GetMerkleRoot(context.Context, *emptypb.Empty) (*GetMerkleRootResponse, error)
 	Clear(context.Context, *emptypb.Empty) (*emptypb.Empty, error)
 	GetProof(context.Context, *GetProofRequest) (*GetProofResponse, error)
+	GetExclusionProof(context.Context, *GetExclusionProofRequest) (*GetExclusionProofResponse, error)
 	GetChangeProof(context.Context, *GetChangeProofRequest) (*GetChangeProofResponse, error)
 	VerifyChangeProof(context.Context, *VerifyChangeProofRequest) (*VerifyChangeProofResponse, error)
 	CommitChangeProof(context.Context, *CommitChangeProofRequest) (*emptypb.Empty, error)
@@ -152,6 +164,9 @@ func (UnimplementedDBServer) Clear(context.Context, *emptypb.Empty) (*emptypb.Em
 func (UnimplementedDBServer) GetProof(context.Context, *GetProofRequest) (*GetProofResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method GetProof not implemented")
 }
+func (UnimplementedDBServer) GetExclusionProof(context.Context, *GetExclusionProofRequest) (*GetExclusionProofResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetExclusionProof not implemented")
+}
 func (UnimplementedDBServer) GetChangeProof(context.Context, *GetChangeProofRequest) (*GetChangeProofResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method GetChangeProof not implemented")
 }
@@ -234,6 +249,24 @@ func _DB_GetProof_Handler(srv interface{}, ctx context.Context, dec func(interfa
 	return interceptor(ctx, in, info, handler)
 }
 
+func _DB_GetExclusionProof_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetExclusionProofRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DBServer).GetExclusionProof(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: DB_GetExclusionProof_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DBServer).GetExclusionProof(ctx, req.(*GetExclusionProofRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 func _DB_GetChangeProof_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(GetChangeProofRequest)
 	if err := dec(in); err != nil {
@@ -343,6 +376,10 @@ var DB_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "GetProof",
 			Handler:    _DB_GetProof_Handler,
 		},
+		{
+			MethodName: "GetExclusionProof",
+			Handler:    _DB_GetExclusionProof_Handler,
+		},
 		{
 			MethodName: "GetChangeProof",
 			Handler:    _DB_GetChangeProof_Handler,
@@ -8,6 +8,10 @@ import "github.com/shubhamdubey02/cryftgo/ids"
 var (
 	SnowballFactory  Factory = snowballFactory{}
 	SnowflakeFactory Factory = snowflakeFactory{}
+
+	// UnanimousSnowballFactory returns snowball instances that are also
+	// finalized after BetaUnanimous consecutive unanimous polls.
+	UnanimousSnowballFactory Factory = unanimousFactory{factory: SnowballFactory}
 )
 
 type snowballFactory struct{}
@@ -76,6 +76,17 @@ type Parameters struct {
 	// Reports unhealthy if there is an item processing for longer than this
 	// duration.
 	MaxItemProcessingTime time.Duration `json:"maxItemProcessingTime" yaml:"maxItemProcessingTime"`
+
+	// SlowValidatorLatency, if non-zero, enables latency-aware snowman polls.
+	// Sampled validators whose average response latency is above this
+	// duration are considered slow, and polls don't wait for their votes.
+	SlowValidatorLatency time.Duration `json:"slowValidatorLatency" yaml:"slowValidatorLatency"`
+
+	// BetaUnanimous, if non-zero, selects the dynamic Beta mode. In this mode,
+	// a choice is also finalized after BetaUnanimous consecutive polls in
+	// which all K sampled validators voted for it, and polls don't terminate
+	// early while they can still be unanimous.
+	BetaUnanimous int `json:"betaUnanimous" yaml:"betaUnanimous"`
 }
 
 // Verify returns nil if the parameters describe a valid initialization.
@@ -87,9 +98,22 @@ type Parameters struct {
 // - 0 < OptimalProcessing
 // - 0 < MaxOutstandingItems
 // - 0 < MaxItemProcessingTime
+// - 0 <= SlowValidatorLatency
+// - 0 <= BetaUnanimous <= Beta
+// - AlphaConfidence * Beta <= K * BetaUnanimous, if 0 < BetaUnanimous
 //
 // Note: K/2 < K implies that 0 <= K/2, so we don't need an explicit check that
 // AlphaPreference is positive.
+//
+// The last condition ensures that finalizing a choice after BetaUnanimous
+// unanimous polls is at least as safe as finalizing it after Beta polls that
+// reached AlphaConfidence. If each sampled validator votes for a choice with
+// probability p, a poll is unanimous for the choice with probability p^K and
+// reaches AlphaConfidence with probability at least p^AlphaConfidence, the
+// probability that the first AlphaConfidence samples vote for it. So
+// p^(K*BetaUnanimous) <= p^(AlphaConfidence*Beta) bounds the probability of
+// finalizing through unanimous polls by the probability of finalizing through
+// AlphaConfidence polls, for any p.
 func (p Parameters) Verify() error {
 	switch {
 	case p.AlphaPreference <= p.K/2:
@@ -110,6 +134,14 @@ func (p Parameters) Verify() error {
 		return fmt.Errorf("%w: maxOutstandingItems = %d: fails the condition that: 0 < maxOutstandingItems", ErrParametersInvalid, p.MaxOutstandingItems)
 	case p.MaxItemProcessingTime <= 0:
 		return fmt.Errorf("%w: maxItemProcessingTime = %d: fails the condition that: 0 < maxItemProcessingTime", ErrParametersInvalid, p.MaxItemProcessingTime)
+	case p.SlowValidatorLatency < 0:
+		return fmt.Errorf("%w: slowValidatorLatency = %d: fails the condition that: 0 <= slowValidatorLatency", ErrParametersInvalid, p.SlowValidatorLatency)
+	case p.BetaUnanimous < 0:
+		return fmt.Errorf("%w: betaUnanimous = %d: fails the condition that: 0 <= betaUnanimous", ErrParametersInvalid, p.BetaUnanimous)
+	case p.BetaUnanimous > p.Beta:
+		return fmt.Errorf("%w: betaUnanimous = %d, beta = %d: fails the condition that: betaUnanimous <= beta", ErrParametersInvalid, p.BetaUnanimous, p.Beta)
+	case p.BetaUnanimous > 0 && p.AlphaConfidence*p.Beta > p.K*p.BetaUnanimous:
+		return fmt.Errorf("%w: alphaConfidence = %d, beta = %d, k = %d, betaUnanimous = %d: fails the condition that: alphaConfidence * beta <= k * betaUnanimous", ErrParametersInvalid, p.AlphaConfidence, p.Beta, p.K, p.BetaUnanimous)
 	default:
 		return nil
 	}
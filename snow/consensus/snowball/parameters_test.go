@@ -211,6 +211,81 @@ func TestParametersVerify(t *testing.T) {
 			},
 			expectedError: ErrParametersInvalid,
 		},
+		{
+			name: "invalid SlowValidatorLatency",
+			params: Parameters{
+				K:                     1,
+				AlphaPreference:       1,
+				AlphaConfidence:       1,
+				Beta:                  1,
+				ConcurrentRepolls:     1,
+				OptimalProcessing:     1,
+				MaxOutstandingItems:   1,
+				MaxItemProcessingTime: 1,
+				SlowValidatorLatency:  -1,
+			},
+			expectedError: ErrParametersInvalid,
+		},
+		{
+			name: "valid BetaUnanimous",
+			params: Parameters{
+				K:                     20,
+				AlphaPreference:       15,
+				AlphaConfidence:       15,
+				Beta:                  20,
+				ConcurrentRepolls:     1,
+				OptimalProcessing:     1,
+				MaxOutstandingItems:   1,
+				MaxItemProcessingTime: 1,
+				BetaUnanimous:         15,
+			},
+			expectedError: nil,
+		},
+		{
+			name: "negative BetaUnanimous",
+			params: Parameters{
+				K:                     1,
+				AlphaPreference:       1,
+				AlphaConfidence:       1,
+				Beta:                  1,
+				ConcurrentRepolls:     1,
+				OptimalProcessing:     1,
+				MaxOutstandingItems:   1,
+				MaxItemProcessingTime: 1,
+				BetaUnanimous:         -1,
+			},
+			expectedError: ErrParametersInvalid,
+		},
+		{
+			name: "BetaUnanimous > Beta",
+			params: Parameters{
+				K:                     1,
+				AlphaPreference:       1,
+				AlphaConfidence:       1,
+				Beta:                  1,
+				ConcurrentRepolls:     1,
+				OptimalProcessing:     1,
+				MaxOutstandingItems:   1,
+				MaxItemProcessingTime: 1,
+				BetaUnanimous:         2,
+			},
+			expectedError: ErrParametersInvalid,
+		},
+		{
+			name: "BetaUnanimous too small",
+			params: Parameters{
+				K:                     20,
+				AlphaPreference:       15,
+				AlphaConfidence:       15,
+				Beta:                  20,
+				ConcurrentRepolls:     1,
+				OptimalProcessing:     1,
+				MaxOutstandingItems:   1,
+				MaxItemProcessingTime: 1,
+				BetaUnanimous:         14,
+			},
+			expectedError: ErrParametersInvalid,
+		},
 	}
 	for _, test := range tests {
 		t.Run(test.name, func(t *testing.T) {
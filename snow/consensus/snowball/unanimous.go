@@ -0,0 +1,243 @@
+// Copyright (C) 2019-2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package snowball
+
+import (
+	"fmt"
+
+	"github.com/shubhamdubey02/cryftgo/ids"
+)
+
+var (
+	_ Factory = unanimousFactory{}
+	_ Unary   = (*unanimousUnary)(nil)
+	_ Binary  = (*unanimousBinary)(nil)
+	_ Nnary   = (*unanimousNnary)(nil)
+)
+
+// unanimousFactory wraps the instances of [factory] so that they are also
+// finalized after BetaUnanimous consecutive unanimous polls. A poll is
+// unanimous if all K sampled validators voted for the same choice.
+type unanimousFactory struct {
+	factory Factory
+}
+
+func (f unanimousFactory) NewNnary(params Parameters, choice ids.ID) Nnary {
+	return &unanimousNnary{
+		Nnary:           f.factory.NewNnary(params, choice),
+		k:               params.K,
+		betaUnanimous:   params.BetaUnanimous,
+		unanimousChoice: choice,
+	}
+}
+
+func (f unanimousFactory) NewUnary(params Parameters) Unary {
+	return &unanimousUnary{
+		Unary:         f.factory.NewUnary(params),
+		k:             params.K,
+		betaUnanimous: params.BetaUnanimous,
+	}
+}
+
+// unanimousUnary is a unary instance that is also finalized after
+// betaUnanimous consecutive unanimous polls
+type unanimousUnary struct {
+	Unary
+
+	// k is the number of votes of a unanimous poll
+	k int
+
+	// betaUnanimous is the number of consecutive unanimous polls required for
+	// finalization
+	betaUnanimous int
+
+	// unanimousConfidence tracks the number of unanimous polls in a row
+	unanimousConfidence int
+
+	// finalized is true if the instance was finalized by unanimous polls
+	finalized bool
+}
+
+func (sf *unanimousUnary) RecordPoll(count int) {
+	sf.Unary.RecordPoll(count)
+
+	if count < sf.k {
+		sf.unanimousConfidence = 0
+		return
+	}
+
+	sf.unanimousConfidence++
+	sf.finalized = sf.finalized || sf.unanimousConfidence >= sf.betaUnanimous
+}
+
+func (sf *unanimousUnary) RecordUnsuccessfulPoll() {
+	sf.Unary.RecordUnsuccessfulPoll()
+	sf.unanimousConfidence = 0
+}
+
+func (sf *unanimousUnary) Finalized() bool {
+	return sf.finalized || sf.Unary.Finalized()
+}
+
+func (sf *unanimousUnary) Extend(choice int) Binary {
+	return &unanimousBinary{
+		Binary:              sf.Unary.Extend(choice),
+		k:                   sf.k,
+		betaUnanimous:       sf.betaUnanimous,
+		unanimousChoice:     choice,
+		unanimousConfidence: sf.unanimousConfidence,
+		finalized:           sf.finalized,
+	}
+}
+
+func (sf *unanimousUnary) Clone() Unary {
+	newSnowflake := *sf
+	newSnowflake.Unary = sf.Unary.Clone()
+	return &newSnowflake
+}
+
+func (sf *unanimousUnary) String() string {
+	return fmt.Sprintf("SU(UnanimousConfidence = %d, Finalized = %v, %s)",
+		sf.unanimousConfidence,
+		sf.finalized,
+		sf.Unary)
+}
+
+// unanimousBinary is a binary instance that is also finalized after
+// betaUnanimous consecutive unanimous polls for the same choice
+type unanimousBinary struct {
+	Binary
+
+	// k is the number of votes of a unanimous poll
+	k int
+
+	// betaUnanimous is the number of consecutive unanimous polls required for
+	// finalization
+	betaUnanimous int
+
+	// unanimousChoice is the choice the last unanimous poll voted for
+	unanimousChoice int
+
+	// unanimousConfidence tracks the number of unanimous polls in a row that
+	// voted for unanimousChoice
+	unanimousConfidence int
+
+	// finalized is true if the instance was finalized by unanimous polls
+	finalized bool
+}
+
+func (sf *unanimousBinary) Preference() int {
+	// If the instance was finalized by unanimous polls, the preference of the
+	// wrapped instance may not be the choice the polls voted for.
+	if sf.finalized {
+		return sf.unanimousChoice
+	}
+	return sf.Binary.Preference()
+}
+
+func (sf *unanimousBinary) RecordPoll(count, choice int) {
+	if sf.Finalized() {
+		return // This instance is already decided.
+	}
+
+	sf.Binary.RecordPoll(count, choice)
+
+	switch {
+	case count < sf.k:
+		sf.unanimousConfidence = 0
+		return
+	case sf.unanimousChoice == choice:
+		sf.unanimousConfidence++
+	default:
+		sf.unanimousChoice = choice
+		sf.unanimousConfidence = 1
+	}
+
+	sf.finalized = sf.unanimousConfidence >= sf.betaUnanimous
+}
+
+func (sf *unanimousBinary) RecordUnsuccessfulPoll() {
+	sf.Binary.RecordUnsuccessfulPoll()
+	sf.unanimousConfidence = 0
+}
+
+func (sf *unanimousBinary) Finalized() bool {
+	return sf.finalized || sf.Binary.Finalized()
+}
+
+func (sf *unanimousBinary) String() string {
+	return fmt.Sprintf("SU(UnanimousConfidence = %d, Finalized = %v, %s)",
+		sf.unanimousConfidence,
+		sf.finalized,
+		sf.Binary)
+}
+
+// unanimousNnary is an n-nary instance that is also finalized after
+// betaUnanimous consecutive unanimous polls for the same choice
+type unanimousNnary struct {
+	Nnary
+
+	// k is the number of votes of a unanimous poll
+	k int
+
+	// betaUnanimous is the number of consecutive unanimous polls required for
+	// finalization
+	betaUnanimous int
+
+	// unanimousChoice is the choice the last unanimous poll voted for
+	unanimousChoice ids.ID
+
+	// unanimousConfidence tracks the number of unanimous polls in a row that
+	// voted for unanimousChoice
+	unanimousConfidence int
+
+	// finalized is true if the instance was finalized by unanimous polls
+	finalized bool
+}
+
+func (sf *unanimousNnary) Preference() ids.ID {
+	// If the instance was finalized by unanimous polls, the preference of the
+	// wrapped instance may not be the choice the polls voted for.
+	if sf.finalized {
+		return sf.unanimousChoice
+	}
+	return sf.Nnary.Preference()
+}
+
+func (sf *unanimousNnary) RecordPoll(count int, choice ids.ID) {
+	if sf.Finalized() {
+		return // This instance is already decided.
+	}
+
+	sf.Nnary.RecordPoll(count, choice)
+
+	switch {
+	case count < sf.k:
+		sf.unanimousConfidence = 0
+		return
+	case sf.unanimousChoice == choice:
+		sf.unanimousConfidence++
+	default:
+		sf.unanimousChoice = choice
+		sf.unanimousConfidence = 1
+	}
+
+	sf.finalized = sf.unanimousConfidence >= sf.betaUnanimous
+}
+
+func (sf *unanimousNnary) RecordUnsuccessfulPoll() {
+	sf.Nnary.RecordUnsuccessfulPoll()
+	sf.unanimousConfidence = 0
+}
+
+func (sf *unanimousNnary) Finalized() bool {
+	return sf.finalized || sf.Nnary.Finalized()
+}
+
+func (sf *unanimousNnary) String() string {
+	return fmt.Sprintf("SU(UnanimousConfidence = %d, Finalized = %v, %s)",
+		sf.unanimousConfidence,
+		sf.finalized,
+		sf.Nnary)
+}
@@ -0,0 +1,130 @@
+// Copyright (C) 2019-2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package snowball
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/shubhamdubey02/cryftgo/utils/bag"
+)
+
+var unanimousParams = Parameters{
+	K:               5,
+	AlphaPreference: 3,
+	AlphaConfidence: 3,
+	Beta:            5,
+	BetaUnanimous:   3,
+}
+
+func TestUnanimousUnary(t *testing.T) {
+	require := require.New(t)
+
+	sf := UnanimousSnowballFactory.NewUnary(unanimousParams)
+
+	sf.RecordPoll(5)
+	sf.RecordPoll(5)
+	require.False(sf.Finalized())
+
+	// A poll that isn't unanimous resets the unanimous confidence, but not the
+	// confidence.
+	sf.RecordPoll(4)
+	sf.RecordPoll(5)
+	require.False(sf.Finalized())
+
+	sf.RecordUnsuccessfulPoll()
+	sf.RecordPoll(5)
+	sf.RecordPoll(5)
+	require.False(sf.Finalized())
+
+	sf.RecordPoll(5)
+	require.True(sf.Finalized())
+
+	// The unanimous choice of the unary instance is the original preference.
+	binary := sf.Extend(1)
+	require.True(binary.Finalized())
+	require.Equal(1, binary.Preference())
+
+	clone := sf.Clone()
+	require.True(clone.Finalized())
+}
+
+func TestUnanimousTree(t *testing.T) {
+	require := require.New(t)
+
+	tree := NewTree(UnanimousSnowballFactory, unanimousParams, Red)
+	tree.Add(Blue)
+
+	fiveBlue := bag.Of(Blue, Blue, Blue, Blue, Blue)
+	require.True(tree.RecordPoll(fiveBlue))
+	require.True(tree.RecordPoll(fiveBlue))
+	require.Equal(Blue, tree.Preference())
+	require.False(tree.Finalized())
+
+	// Unanimous polls for another choice reset the unanimous confidence.
+	fiveRed := bag.Of(Red, Red, Red, Red, Red)
+	require.True(tree.RecordPoll(fiveRed))
+	require.True(tree.RecordPoll(fiveRed))
+	require.False(tree.Finalized())
+
+	require.True(tree.RecordPoll(fiveRed))
+	require.Equal(Red, tree.Preference())
+	require.True(tree.Finalized())
+}
+
+func TestUnanimousTreePreference(t *testing.T) {
+	require := require.New(t)
+
+	tree := NewTree(UnanimousSnowballFactory, unanimousParams, Red)
+	tree.Add(Blue)
+
+	threeBlue := bag.Of(Blue, Blue, Blue)
+	for i := 0; i < unanimousParams.Beta-1; i++ {
+		require.True(tree.RecordPoll(threeBlue))
+	}
+	require.Equal(Blue, tree.Preference())
+	require.False(tree.Finalized())
+
+	fiveRed := bag.Of(Red, Red, Red, Red, Red)
+	for i := 0; i < unanimousParams.BetaUnanimous; i++ {
+		require.False(tree.Finalized())
+		require.True(tree.RecordPoll(fiveRed))
+	}
+
+	// Snowball still has a stronger preference for blue, but the unanimous
+	// polls finalized red.
+	require.Equal(Red, tree.Preference())
+	require.True(tree.Finalized())
+}
+
+func TestUnanimousFlat(t *testing.T) {
+	require := require.New(t)
+
+	f := NewFlat(UnanimousSnowballFactory, unanimousParams, Red)
+	f.Add(Green)
+	f.Add(Blue)
+
+	fiveBlue := bag.Of(Blue, Blue, Blue, Blue, Blue)
+	require.True(f.RecordPoll(fiveBlue))
+	require.True(f.RecordPoll(fiveBlue))
+	require.False(f.Finalized())
+
+	fourBlue := bag.Of(Blue, Blue, Blue, Blue)
+	require.True(f.RecordPoll(fourBlue))
+	require.True(f.RecordPoll(fiveBlue))
+	require.False(f.Finalized())
+
+	f.RecordUnsuccessfulPoll()
+	require.True(f.RecordPoll(fiveBlue))
+	require.True(f.RecordPoll(fiveBlue))
+	require.False(f.Finalized())
+
+	require.True(f.RecordPoll(fiveBlue))
+	require.Equal(Blue, f.Preference())
+	require.True(f.Finalized())
+
+	expected := "SU(UnanimousConfidence = 3, Finalized = true, SB(Preference = TtF4d2QWbk5vzQGTEPrN48x6vwgAoAmKQ9cbp79inpQmcRKES, PreferenceStrength = 7, SF(Confidence = 3, Finalized = false, SL(Preference = TtF4d2QWbk5vzQGTEPrN48x6vwgAoAmKQ9cbp79inpQmcRKES))))"
+	require.Equal(expected, f.String())
+}
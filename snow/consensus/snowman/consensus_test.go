@@ -59,6 +59,9 @@ var (
 		ErrorOnAddDecidedBlockTest,
 		RecordPollWithDefaultParameters,
 		RecordPollRegressionCalculateInDegreeIndegreeCalculation,
+		RecordPollUnanimousAcceptAndRejectTest,
+		RecordPollUnanimousTransitiveVotingTest,
+		RandomizedConsistencyUnanimousTest,
 	}
 
 	errTest = errors.New("non-nil error")
@@ -1516,3 +1519,148 @@ func RecordPollRegressionCalculateInDegreeIndegreeCalculation(t *testing.T, fact
 	require.Equal(choices.Accepted, blk2.Status())
 	require.Equal(choices.Accepted, blk3.Status())
 }
+
+// With BetaUnanimous set, a block is also accepted after BetaUnanimous
+// consecutive polls in which all K votes were for the block.
+func RecordPollUnanimousAcceptAndRejectTest(t *testing.T, factory Factory) {
+	require := require.New(t)
+
+	sm := factory.New()
+
+	snowCtx := snowtest.Context(t, snowtest.CChainID)
+	ctx := snowtest.ConsensusContext(snowCtx)
+	params := snowball.Parameters{
+		K:                     5,
+		AlphaPreference:       3,
+		AlphaConfidence:       3,
+		Beta:                  5,
+		ConcurrentRepolls:     1,
+		OptimalProcessing:     1,
+		MaxOutstandingItems:   1,
+		MaxItemProcessingTime: 1,
+		BetaUnanimous:         3,
+	}
+	require.NoError(sm.Initialize(
+		ctx,
+		params,
+		snowmantest.GenesisID,
+		snowmantest.GenesisHeight,
+		snowmantest.GenesisTimestamp,
+	))
+
+	firstBlock := snowmantest.BuildChild(snowmantest.Genesis)
+	secondBlock := snowmantest.BuildChild(snowmantest.Genesis)
+
+	require.NoError(sm.Add(context.Background(), firstBlock))
+	require.NoError(sm.Add(context.Background(), secondBlock))
+
+	unanimousVotes := bag.Bag[ids.ID]{}
+	unanimousVotes.AddCount(firstBlock.ID(), params.K)
+	splitVotes := bag.Bag[ids.ID]{}
+	splitVotes.AddCount(firstBlock.ID(), params.K-1)
+	splitVotes.Add(secondBlock.ID())
+
+	require.NoError(sm.RecordPoll(context.Background(), unanimousVotes))
+	require.NoError(sm.RecordPoll(context.Background(), unanimousVotes))
+
+	// A successful poll that isn't unanimous resets the unanimous confidence.
+	require.NoError(sm.RecordPoll(context.Background(), splitVotes))
+	require.NoError(sm.RecordPoll(context.Background(), unanimousVotes))
+	require.Equal(2, sm.NumProcessing())
+
+	// An unsuccessful poll resets both confidences.
+	require.NoError(sm.RecordPoll(context.Background(), bag.Bag[ids.ID]{}))
+
+	for i := 0; i < params.BetaUnanimous; i++ {
+		require.Equal(firstBlock.ID(), sm.Preference())
+		require.Equal(2, sm.NumProcessing())
+		require.NoError(sm.RecordPoll(context.Background(), unanimousVotes))
+	}
+	require.Zero(sm.NumProcessing())
+	require.Equal(choices.Accepted, firstBlock.Status())
+	require.Equal(choices.Rejected, secondBlock.Status())
+}
+
+// Unanimous votes for a block are also unanimous votes for its processing
+// ancestors.
+func RecordPollUnanimousTransitiveVotingTest(t *testing.T, factory Factory) {
+	require := require.New(t)
+
+	sm := factory.New()
+
+	snowCtx := snowtest.Context(t, snowtest.CChainID)
+	ctx := snowtest.ConsensusContext(snowCtx)
+	params := snowball.Parameters{
+		K:                     5,
+		AlphaPreference:       3,
+		AlphaConfidence:       3,
+		Beta:                  5,
+		ConcurrentRepolls:     1,
+		OptimalProcessing:     1,
+		MaxOutstandingItems:   1,
+		MaxItemProcessingTime: 1,
+		BetaUnanimous:         3,
+	}
+	require.NoError(sm.Initialize(
+		ctx,
+		params,
+		snowmantest.GenesisID,
+		snowmantest.GenesisHeight,
+		snowmantest.GenesisTimestamp,
+	))
+
+	block0 := snowmantest.BuildChild(snowmantest.Genesis)
+	block1 := snowmantest.BuildChild(block0)
+	conflictingBlock := snowmantest.BuildChild(snowmantest.Genesis)
+
+	require.NoError(sm.Add(context.Background(), block0))
+	require.NoError(sm.Add(context.Background(), block1))
+	require.NoError(sm.Add(context.Background(), conflictingBlock))
+
+	votes := bag.Bag[ids.ID]{}
+	votes.AddCount(block1.ID(), params.K)
+	for i := 0; i < params.BetaUnanimous; i++ {
+		require.Equal(3, sm.NumProcessing())
+		require.NoError(sm.RecordPoll(context.Background(), votes))
+	}
+	require.Zero(sm.NumProcessing())
+	require.Equal(choices.Accepted, block0.Status())
+	require.Equal(choices.Accepted, block1.Status())
+	require.Equal(choices.Rejected, conflictingBlock.Status())
+}
+
+func RandomizedConsistencyUnanimousTest(t *testing.T, factory Factory) {
+	require := require.New(t)
+
+	var (
+		numColors = 50
+		numNodes  = 100
+		params    = snowball.Parameters{
+			K:                     20,
+			AlphaPreference:       15,
+			AlphaConfidence:       15,
+			Beta:                  20,
+			ConcurrentRepolls:     1,
+			OptimalProcessing:     1,
+			MaxOutstandingItems:   1,
+			MaxItemProcessingTime: 1,
+			BetaUnanimous:         15,
+		}
+		seed   uint64 = 0
+		source        = prng.NewMT19937()
+	)
+
+	source.Seed(seed)
+
+	n := NewNetwork(params, numColors, source)
+
+	for i := 0; i < numNodes; i++ {
+		require.NoError(n.AddNode(t, factory.New()))
+	}
+
+	for !n.Finalized() {
+		require.NoError(n.Round())
+	}
+
+	require.True(n.Agreement())
+}
@@ -0,0 +1,72 @@
+// Copyright (C) 2019-2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package poll
+
+import (
+	"github.com/shubhamdubey02/cryftgo/ids"
+	"github.com/shubhamdubey02/cryftgo/utils/bag"
+)
+
+type earlyTermUnanimousFactory struct {
+	k               int
+	alphaPreference int
+	alphaConfidence int
+}
+
+// NewEarlyTermUnanimousFactory returns a factory that returns polls with early
+// termination that don't terminate while all K validators can still vote for
+// the same block. It is used when consensus finalizes blocks faster after
+// unanimous polls.
+func NewEarlyTermUnanimousFactory(k int, alphaPreference int, alphaConfidence int) Factory {
+	return &earlyTermUnanimousFactory{
+		k:               k,
+		alphaPreference: alphaPreference,
+		alphaConfidence: alphaConfidence,
+	}
+}
+
+func (f *earlyTermUnanimousFactory) New(vdrs bag.Bag[ids.NodeID]) Poll {
+	return &earlyTermUnanimousPoll{
+		earlyTermNoTraversalPoll: earlyTermNoTraversalPoll{
+			polled:          vdrs,
+			alphaPreference: f.alphaPreference,
+			alphaConfidence: f.alphaConfidence,
+		},
+		k: f.k,
+	}
+}
+
+// earlyTermUnanimousPoll finishes when the remaining validators can't change
+// the result of the poll, including whether the poll is unanimous. Votes for
+// different blocks are assumed not to be unanimous, even if they are for
+// blocks of the same chain.
+type earlyTermUnanimousPoll struct {
+	earlyTermNoTraversalPoll
+	k int
+}
+
+// Finished returns true when the poll would finish without early termination
+// for unanimous polls, and one of the following conditions is met.
+//
+//  1. There are no outstanding votes.
+//  2. A validator was dropped, or fewer than k validators were polled.
+//  3. Validators voted for different blocks.
+func (p *earlyTermUnanimousPoll) Finished() bool {
+	if !p.earlyTermNoTraversalPoll.Finished() {
+		return false
+	}
+
+	remaining := p.polled.Len()
+	if remaining == 0 {
+		return true // Case 1
+	}
+
+	received := p.votes.Len()
+	if received+remaining < p.k {
+		return true // Case 2
+	}
+
+	_, freq := p.votes.Mode()
+	return freq < received // Case 3
+}
@@ -0,0 +1,116 @@
+// Copyright (C) 2019-2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package poll
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/shubhamdubey02/cryftgo/utils/bag"
+)
+
+func TestEarlyTermUnanimousWaitsForUnanimity(t *testing.T) {
+	require := require.New(t)
+
+	vdrs := bag.Of(vdr1, vdr2, vdr3, vdr4, vdr5) // k = 5
+	k := 5
+	alpha := 3
+
+	factory := NewEarlyTermUnanimousFactory(k, alpha, alpha)
+	poll := factory.New(vdrs)
+
+	poll.Vote(vdr1, blkID1)
+	poll.Vote(vdr2, blkID1)
+	poll.Vote(vdr3, blkID1)
+	require.False(poll.Finished())
+
+	poll.Vote(vdr4, blkID1)
+	require.False(poll.Finished())
+
+	// Tests case 1
+	poll.Vote(vdr5, blkID1)
+	require.True(poll.Finished())
+
+	result := poll.Result()
+	require.Equal(k, result.Count(blkID1))
+}
+
+// Tests case 2
+func TestEarlyTermUnanimousTerminatesEarlyWithDrop(t *testing.T) {
+	require := require.New(t)
+
+	vdrs := bag.Of(vdr1, vdr2, vdr3, vdr4, vdr5) // k = 5
+	k := 5
+	alpha := 3
+
+	factory := NewEarlyTermUnanimousFactory(k, alpha, alpha)
+	poll := factory.New(vdrs)
+
+	poll.Vote(vdr1, blkID1)
+	poll.Vote(vdr2, blkID1)
+	poll.Vote(vdr3, blkID1)
+	require.False(poll.Finished())
+
+	poll.Drop(vdr4)
+	require.True(poll.Finished())
+}
+
+// Tests case 2
+func TestEarlyTermUnanimousTerminatesEarlyWithoutKValidators(t *testing.T) {
+	require := require.New(t)
+
+	vdrs := bag.Of(vdr1, vdr2, vdr3, vdr4) // k = 5
+	k := 5
+	alpha := 3
+
+	factory := NewEarlyTermUnanimousFactory(k, alpha, alpha)
+	poll := factory.New(vdrs)
+
+	poll.Vote(vdr1, blkID1)
+	poll.Vote(vdr2, blkID1)
+	require.False(poll.Finished())
+
+	poll.Vote(vdr3, blkID1)
+	require.True(poll.Finished())
+}
+
+// Tests case 3
+func TestEarlyTermUnanimousTerminatesEarlyWithDifferentVotes(t *testing.T) {
+	require := require.New(t)
+
+	vdrs := bag.Of(vdr1, vdr2, vdr3, vdr4, vdr5) // k = 5
+	k := 5
+	alpha := 3
+
+	factory := NewEarlyTermUnanimousFactory(k, alpha, alpha)
+	poll := factory.New(vdrs)
+
+	poll.Vote(vdr1, blkID1)
+	poll.Vote(vdr2, blkID2)
+	require.False(poll.Finished())
+
+	poll.Vote(vdr3, blkID1)
+	require.False(poll.Finished())
+
+	poll.Vote(vdr4, blkID1)
+	require.True(poll.Finished())
+}
+
+func TestEarlyTermUnanimousTerminatesEarlyWithoutAlphaPreference(t *testing.T) {
+	require := require.New(t)
+
+	vdrs := bag.Of(vdr1, vdr2, vdr3) // k = 3
+	k := 3
+	alpha := 2
+
+	factory := NewEarlyTermUnanimousFactory(k, alpha, alpha)
+	poll := factory.New(vdrs)
+
+	poll.Drop(vdr1)
+	require.False(poll.Finished())
+
+	poll.Drop(vdr2)
+	require.True(poll.Finished())
+}
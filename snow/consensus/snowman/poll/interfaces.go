@@ -5,6 +5,7 @@ package poll
 
 import (
 	"fmt"
+	"time"
 
 	"github.com/shubhamdubey02/cryftgo/ids"
 	"github.com/shubhamdubey02/cryftgo/utils/bag"
@@ -16,11 +17,28 @@ type Set interface {
 	fmt.Stringer
 
 	Add(requestID uint32, vdrs bag.Bag[ids.NodeID]) bool
-	Vote(requestID uint32, vdr ids.NodeID, vote ids.ID) []bag.Bag[ids.ID]
-	Drop(requestID uint32, vdr ids.NodeID) []bag.Bag[ids.ID]
+	Vote(requestID uint32, vdr ids.NodeID, vote ids.ID) []Result
+	Drop(requestID uint32, vdr ids.NodeID) []Result
 	Len() int
 }
 
+// Result describes a finished poll
+type Result struct {
+	RequestID uint32
+	// Start is the time the poll was created
+	Start time.Time
+	// Duration is the time the poll took to finish
+	Duration time.Duration
+	// Validators that were sampled
+	Validators bag.Bag[ids.NodeID]
+	// Validator --> Vote of the validators that voted before the poll finished
+	Responses map[ids.NodeID]ids.ID
+	// Validators whose responses were dropped before the poll finished
+	Dropped []ids.NodeID
+	// Votes is the result of the poll
+	Votes bag.Bag[ids.ID]
+}
+
 // Poll is an outstanding poll
 type Poll interface {
 	formatting.PrefixedStringer
@@ -6,6 +6,7 @@ package poll
 import (
 	"errors"
 	"fmt"
+	"slices"
 	"strings"
 	"time"
 
@@ -24,22 +25,42 @@ var (
 	errFailedPollDurationMetrics = errors.New("failed to register poll_duration metrics")
 )
 
-type pollHolder interface {
-	GetPoll() Poll
-	StartTime() time.Time
-}
-
+// poll records the responses to a Poll so that they can be reported once the
+// Poll finishes.
 type poll struct {
 	Poll
 	start time.Time
+	// Validators that were sampled. This is a copy of the bag given to the
+	// Poll, which removes validators as they respond.
+	vdrs bag.Bag[ids.NodeID]
+	// Validator --> Vote
+	responses map[ids.NodeID]ids.ID
+	dropped   []ids.NodeID
+}
+
+// canRespond returns true if [vdr] was sampled and hasn't responded yet.
+func (p *poll) canRespond(vdr ids.NodeID) bool {
+	if p.vdrs.Count(vdr) == 0 {
+		return false
+	}
+	if _, ok := p.responses[vdr]; ok {
+		return false
+	}
+	return !slices.Contains(p.dropped, vdr)
 }
 
-func (p poll) GetPoll() Poll {
-	return p
+func (p *poll) vote(vdr ids.NodeID, vote ids.ID) {
+	if p.canRespond(vdr) {
+		p.responses[vdr] = vote
+	}
+	p.Poll.Vote(vdr, vote)
 }
 
-func (p poll) StartTime() time.Time {
-	return p.start
+func (p *poll) drop(vdr ids.NodeID) {
+	if p.canRespond(vdr) {
+		p.dropped = append(p.dropped, vdr)
+	}
+	p.Poll.Drop(vdr)
 }
 
 type set struct {
@@ -48,7 +69,7 @@ type set struct {
 	durPolls metric.Averager
 	factory  Factory
 	// maps requestID -> poll
-	polls *linked.Hashmap[uint32, pollHolder]
+	polls *linked.Hashmap[uint32, *poll]
 }
 
 // NewSet returns a new empty set of polls
@@ -82,7 +103,7 @@ func NewSet(
 		numPolls: numPolls,
 		durPolls: durPolls,
 		factory:  factory,
-		polls:    linked.NewHashmap[uint32, pollHolder](),
+		polls:    linked.NewHashmap[uint32, *poll](),
 	}, nil
 }
 
@@ -103,9 +124,15 @@ func (s *set) Add(requestID uint32, vdrs bag.Bag[ids.NodeID]) bool {
 		zap.Stringer("validators", &vdrs),
 	)
 
-	s.polls.Put(requestID, poll{
-		Poll:  s.factory.New(vdrs), // create the new poll
-		start: time.Now(),
+	var sampled bag.Bag[ids.NodeID]
+	for _, vdr := range vdrs.List() {
+		sampled.AddCount(vdr, vdrs.Count(vdr))
+	}
+	s.polls.Put(requestID, &poll{
+		Poll:      s.factory.New(vdrs), // create the new poll
+		start:     time.Now(),
+		vdrs:      sampled,
+		responses: make(map[ids.NodeID]ids.ID),
 	})
 	s.numPolls.Inc() // increase the metrics
 	return true
@@ -113,8 +140,8 @@ func (s *set) Add(requestID uint32, vdrs bag.Bag[ids.NodeID]) bool {
 
 // Vote registers the connections response to a query for [id]. If there was no
 // query, or the response has already be registered, nothing is performed.
-func (s *set) Vote(requestID uint32, vdr ids.NodeID, vote ids.ID) []bag.Bag[ids.ID] {
-	holder, exists := s.polls.Get(requestID)
+func (s *set) Vote(requestID uint32, vdr ids.NodeID, vote ids.ID) []Result {
+	p, exists := s.polls.Get(requestID)
 	if !exists {
 		s.log.Verbo("dropping vote",
 			zap.String("reason", "unknown poll"),
@@ -124,15 +151,13 @@ func (s *set) Vote(requestID uint32, vdr ids.NodeID, vote ids.ID) []bag.Bag[ids.
 		return nil
 	}
 
-	p := holder.GetPoll()
-
 	s.log.Verbo("processing vote",
 		zap.Stringer("validator", vdr),
 		zap.Uint32("requestID", requestID),
 		zap.Stringer("vote", vote),
 	)
 
-	p.Vote(vdr, vote)
+	p.vote(vdr, vote)
 	if !p.Finished() {
 		return nil
 	}
@@ -141,14 +166,13 @@ func (s *set) Vote(requestID uint32, vdr ids.NodeID, vote ids.ID) []bag.Bag[ids.
 }
 
 // processFinishedPolls checks for other dependent finished polls and returns them all if finished
-func (s *set) processFinishedPolls() []bag.Bag[ids.ID] {
-	var results []bag.Bag[ids.ID]
+func (s *set) processFinishedPolls() []Result {
+	var results []Result
 
 	// iterate from oldest to newest
 	iter := s.polls.NewIterator()
 	for iter.Next() {
-		holder := iter.Value()
-		p := holder.GetPoll()
+		p := iter.Value()
 		if !p.Finished() {
 			// since we're iterating from oldest to newest, if the next poll has not finished,
 			// we can break and return what we have so far
@@ -157,12 +181,21 @@ func (s *set) processFinishedPolls() []bag.Bag[ids.ID] {
 
 		s.log.Verbo("poll finished",
 			zap.Uint32("requestID", iter.Key()),
-			zap.Stringer("poll", holder.GetPoll()),
+			zap.Stringer("poll", p),
 		)
-		s.durPolls.Observe(float64(time.Since(holder.StartTime())))
+		duration := time.Since(p.start)
+		s.durPolls.Observe(float64(duration))
 		s.numPolls.Dec() // decrease the metrics
 
-		results = append(results, p.Result())
+		results = append(results, Result{
+			RequestID:  iter.Key(),
+			Start:      p.start,
+			Duration:   duration,
+			Validators: p.vdrs,
+			Responses:  p.responses,
+			Dropped:    p.dropped,
+			Votes:      p.Result(),
+		})
 		s.polls.Delete(iter.Key())
 	}
 
@@ -173,8 +206,8 @@ func (s *set) processFinishedPolls() []bag.Bag[ids.ID] {
 
 // Drop registers the connections response to a query for [id]. If there was no
 // query, or the response has already be registered, nothing is performed.
-func (s *set) Drop(requestID uint32, vdr ids.NodeID) []bag.Bag[ids.ID] {
-	holder, exists := s.polls.Get(requestID)
+func (s *set) Drop(requestID uint32, vdr ids.NodeID) []Result {
+	p, exists := s.polls.Get(requestID)
 	if !exists {
 		s.log.Verbo("dropping vote",
 			zap.String("reason", "unknown poll"),
@@ -189,10 +222,8 @@ func (s *set) Drop(requestID uint32, vdr ids.NodeID) []bag.Bag[ids.ID] {
 		zap.Uint32("requestID", requestID),
 	)
 
-	poll := holder.GetPoll()
-
-	poll.Drop(vdr)
-	if !poll.Finished() {
+	p.drop(vdr)
+	if !p.Finished() {
 		return nil
 	}
 
@@ -210,8 +241,8 @@ func (s *set) String() string {
 	iter := s.polls.NewIterator()
 	for iter.Next() {
 		requestID := iter.Key()
-		poll := iter.Value().(Poll)
-		sb.WriteString(fmt.Sprintf("\n    RequestID %d:\n        %s", requestID, poll.PrefixedString("        ")))
+		p := iter.Value()
+		sb.WriteString(fmt.Sprintf("\n    RequestID %d:\n        %s", requestID, p.PrefixedString("        ")))
 	}
 	return sb.String()
 }
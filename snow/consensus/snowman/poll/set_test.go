@@ -94,8 +94,8 @@ func TestCreateAndFinishPollOutOfOrder_NewerFinishesFirst(t *testing.T) {
 
 	results := s.Vote(1, vdr3, blkID1) // poll 1 finished, poll 2 should be finished as well
 	require.Len(results, 2)
-	require.Equal(blkID1, results[0].List()[0])
-	require.Equal(blkID2, results[1].List()[0])
+	require.Equal(blkID1, results[0].Votes.List()[0])
+	require.Equal(blkID2, results[1].Votes.List()[0])
 }
 
 func TestCreateAndFinishPollOutOfOrder_OlderFinishesFirst(t *testing.T) {
@@ -128,11 +128,11 @@ func TestCreateAndFinishPollOutOfOrder_OlderFinishesFirst(t *testing.T) {
 
 	results := s.Vote(1, vdr3, blkID1) // poll 1 finished, poll 2 still remaining
 	require.Len(results, 1)            // because 1 is the oldest
-	require.Equal(blkID1, results[0].List()[0])
+	require.Equal(blkID1, results[0].Votes.List()[0])
 
 	results = s.Vote(2, vdr1, blkID2) // poll 2 finished
 	require.Len(results, 1)           // because 2 is the oldest now
-	require.Equal(blkID2, results[0].List()[0])
+	require.Equal(blkID2, results[0].Votes.List()[0])
 }
 
 func TestCreateAndFinishPollOutOfOrder_UnfinishedPollsGaps(t *testing.T) {
@@ -175,9 +175,9 @@ func TestCreateAndFinishPollOutOfOrder_UnfinishedPollsGaps(t *testing.T) {
 	require.Empty(s.Vote(1, vdr2, blkID1))
 	results := s.Vote(1, vdr3, blkID1)
 	require.Len(results, 3)
-	require.Equal(blkID1, results[0].List()[0])
-	require.Equal(blkID2, results[1].List()[0])
-	require.Equal(blkID3, results[2].List()[0])
+	require.Equal(blkID1, results[0].Votes.List()[0])
+	require.Equal(blkID2, results[1].Votes.List()[0])
+	require.Equal(blkID3, results[2].Votes.List()[0])
 }
 
 func TestCreateAndFinishSuccessfulPoll(t *testing.T) {
@@ -207,10 +207,10 @@ func TestCreateAndFinishSuccessfulPoll(t *testing.T) {
 
 	results := s.Vote(0, vdr2, blkID1)
 	require.Len(results, 1)
-	list := results[0].List()
+	list := results[0].Votes.List()
 	require.Len(list, 1)
 	require.Equal(blkID1, list[0])
-	require.Equal(2, results[0].Count(blkID1))
+	require.Equal(2, results[0].Votes.Count(blkID1))
 }
 
 func TestCreateAndFinishFailedPoll(t *testing.T) {
@@ -240,7 +240,7 @@ func TestCreateAndFinishFailedPoll(t *testing.T) {
 
 	results := s.Drop(0, vdr2)
 	require.Len(results, 1)
-	require.Empty(results[0].List())
+	require.Empty(results[0].Votes.List())
 }
 
 func TestSetString(t *testing.T) {
@@ -264,3 +264,41 @@ func TestSetString(t *testing.T) {
 	require.True(s.Add(0, vdrs))
 	require.Equal(expected, s.String())
 }
+
+func TestSetResultResponses(t *testing.T) {
+	require := require.New(t)
+
+	vdrs := bag.Of(vdr1, vdr2, vdr3, vdr4) // k = 4
+	alpha := 2
+
+	factory := NewEarlyTermNoTraversalFactory(alpha, alpha)
+	log := logging.NoLog{}
+	namespace := ""
+	registerer := prometheus.NewRegistry()
+	s, err := NewSet(factory, log, namespace, registerer)
+	require.NoError(err)
+
+	require.True(s.Add(1, vdrs))
+
+	require.Empty(s.Vote(1, vdr5, blkID2)) // vdr5 wasn't sampled
+	require.Empty(s.Drop(1, vdr1))
+	require.Empty(s.Vote(1, vdr1, blkID2)) // vdr1 already responded
+	require.Empty(s.Vote(1, vdr2, blkID1))
+
+	results := s.Vote(1, vdr3, blkID1) // finishes early with vdr4 outstanding
+	require.Len(results, 1)
+
+	result := results[0]
+	require.Equal(uint32(1), result.RequestID)
+	require.Equal(4, result.Validators.Len())
+	require.Equal(
+		map[ids.NodeID]ids.ID{
+			vdr2: blkID1,
+			vdr3: blkID1,
+		},
+		result.Responses,
+	)
+	require.Equal([]ids.NodeID{vdr1}, result.Dropped)
+	require.Equal(2, result.Votes.Count(blkID1))
+	require.Zero(result.Votes.Count(blkID2))
+}
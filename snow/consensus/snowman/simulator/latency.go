@@ -0,0 +1,65 @@
+// Copyright (C) 2019-2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package simulator
+
+import (
+	"math"
+	"time"
+
+	"github.com/shubhamdubey02/cryftgo/utils/sampler"
+)
+
+var (
+	_ Latency = Constant(0)
+	_ Latency = Uniform{}
+	_ Latency = Normal{}
+)
+
+// Latency describes how long messages take to be delivered between nodes.
+type Latency interface {
+	// Sample returns the delay of a message sent from node [from] to node
+	// [to]. [source] must be the only source of randomness used, so that
+	// simulations are deterministic.
+	Sample(source sampler.Source, from, to int) time.Duration
+}
+
+// Constant delivers every message after the same delay.
+type Constant time.Duration
+
+func (c Constant) Sample(sampler.Source, int, int) time.Duration {
+	return time.Duration(c)
+}
+
+// Uniform delivers messages after a delay uniformly distributed in [Min, Max].
+type Uniform struct {
+	Min time.Duration
+	Max time.Duration
+}
+
+func (u Uniform) Sample(source sampler.Source, _, _ int) time.Duration {
+	return u.Min + time.Duration(float64(u.Max-u.Min)*float64Of(source))
+}
+
+// Normal delivers messages after a normally distributed delay. Delays below
+// Min are rounded up to Min.
+type Normal struct {
+	Mean   time.Duration
+	StdDev time.Duration
+	Min    time.Duration
+}
+
+func (n Normal) Sample(source sampler.Source, _, _ int) time.Duration {
+	// Box-Muller transform. [u1] is moved into (0, 1] so that its log is
+	// defined.
+	u1 := 1 - float64Of(source)
+	u2 := float64Of(source)
+	z := math.Sqrt(-2*math.Log(u1)) * math.Cos(2*math.Pi*u2)
+	return max(n.Min, n.Mean+time.Duration(z*float64(n.StdDev)))
+}
+
+// float64Of returns a number in [0, 1) using the 53 high bits of a number read
+// from [source].
+func float64Of(source sampler.Source) float64 {
+	return float64(source.Uint64()>>11) / (1 << 53)
+}
@@ -0,0 +1,60 @@
+// Copyright (C) 2019-2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package simulator
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"gonum.org/v1/gonum/mathext/prng"
+)
+
+func TestLatencyBounds(t *testing.T) {
+	tests := []struct {
+		name    string
+		latency Latency
+		min     time.Duration
+		max     time.Duration
+	}{
+		{
+			name:    "constant",
+			latency: Constant(time.Second),
+			min:     time.Second,
+			max:     time.Second,
+		},
+		{
+			name: "uniform",
+			latency: Uniform{
+				Min: time.Millisecond,
+				Max: time.Second,
+			},
+			min: time.Millisecond,
+			max: time.Second,
+		},
+		{
+			name: "normal",
+			latency: Normal{
+				Mean:   10 * time.Millisecond,
+				StdDev: time.Second,
+				Min:    time.Millisecond,
+			},
+			min: time.Millisecond,
+			max: time.Hour,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			require := require.New(t)
+
+			source := prng.NewMT19937()
+			source.Seed(0)
+			for i := 0; i < 1000; i++ {
+				latency := test.latency.Sample(source, 0, 1)
+				require.GreaterOrEqual(latency, test.min)
+				require.LessOrEqual(latency, test.max)
+			}
+		})
+	}
+}
@@ -0,0 +1,62 @@
+// Copyright (C) 2019-2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package simulator
+
+import (
+	"time"
+
+	"github.com/shubhamdubey02/cryftgo/ids"
+)
+
+// Result describes the outcome of a simulation.
+type Result struct {
+	// FinalityLatencies are the simulated times at which honest nodes decided
+	// every block, sorted in increasing order. Honest nodes that didn't decide
+	// every block before the simulation stopped are omitted.
+	FinalityLatencies []time.Duration
+	// NumUnfinalized is the number of honest nodes that didn't decide every
+	// block before the simulation stopped.
+	NumUnfinalized int
+	// SafetyViolations are the blocks accepted by honest nodes that conflict
+	// with a block accepted by another honest node.
+	SafetyViolations []SafetyViolation
+	// NumPolls is the number of polls recorded by honest nodes.
+	NumPolls uint64
+	// EndTime is the simulated time at which the simulation stopped.
+	EndTime time.Duration
+}
+
+// SafetyViolation describes two honest nodes accepting different blocks at the
+// same height.
+type SafetyViolation struct {
+	Time   time.Duration
+	Height uint64
+	// Node accepted BlkID at Time.
+	Node  int
+	BlkID ids.ID
+	// ConflictingNode accepted ConflictingBlkID earlier.
+	ConflictingNode  int
+	ConflictingBlkID ids.ID
+}
+
+// Finalized returns true if every honest node decided every block.
+func (r *Result) Finalized() bool {
+	return r.NumUnfinalized == 0
+}
+
+// Safe returns true if no honest nodes accepted conflicting blocks.
+func (r *Result) Safe() bool {
+	return len(r.SafetyViolations) == 0
+}
+
+// Percentile returns the finality latency that [p] of the honest nodes that
+// decided every block are at or below. [p] must be in [0, 1]. Returns 0 if no
+// honest node decided every block.
+func (r *Result) Percentile(p float64) time.Duration {
+	if len(r.FinalityLatencies) == 0 {
+		return 0
+	}
+	index := int(p * float64(len(r.FinalityLatencies)-1))
+	return r.FinalityLatencies[index]
+}
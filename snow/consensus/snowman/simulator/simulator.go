@@ -0,0 +1,539 @@
+// Copyright (C) 2019-2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+// Package simulator runs many snowman consensus instances against a simulated
+// network with configurable latencies, partitions, churn and Byzantine nodes.
+// It is intended to evaluate snowball.Parameters before they are deployed.
+//
+// Simulations are deterministic: running the same Config twice produces the
+// same Result.
+package simulator
+
+import (
+	"cmp"
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"gonum.org/v1/gonum/mathext/prng"
+
+	"github.com/shubhamdubey02/cryftgo/ids"
+	"github.com/shubhamdubey02/cryftgo/snow"
+	"github.com/shubhamdubey02/cryftgo/snow/choices"
+	"github.com/shubhamdubey02/cryftgo/snow/consensus/snowball"
+	"github.com/shubhamdubey02/cryftgo/snow/consensus/snowman"
+	"github.com/shubhamdubey02/cryftgo/snow/consensus/snowman/snowmantest"
+	"github.com/shubhamdubey02/cryftgo/utils/bag"
+	"github.com/shubhamdubey02/cryftgo/utils/heap"
+	"github.com/shubhamdubey02/cryftgo/utils/logging"
+	"github.com/shubhamdubey02/cryftgo/utils/sampler"
+	"github.com/shubhamdubey02/cryftgo/utils/set"
+)
+
+var (
+	_ snow.Acceptor = (*acceptor)(nil)
+
+	errTooFewNodes            = errors.New("fewer nodes than the sample size")
+	errNoHonestNodes          = errors.New("no honest nodes")
+	errNegativeNodes          = errors.New("negative number of nodes")
+	errNoBlocks               = errors.New("no blocks to decide")
+	errNoLatency              = errors.New("no latency")
+	errNonPositivePollTimeout = errors.New("non-positive poll timeout")
+	errNonPositiveMaxTime     = errors.New("non-positive max time")
+	errInvalidChurn           = errors.New("invalid churn")
+	errInvalidPartition       = errors.New("invalid partition")
+)
+
+// Behavior describes how a node answers queries.
+type Behavior int
+
+const (
+	// Honest nodes run consensus and answer queries with their preference.
+	Honest Behavior = iota
+	// Equivocating nodes answer each query with a random block, so different
+	// nodes are told different preferences.
+	Equivocating
+	// Withholding nodes never answer queries.
+	Withholding
+)
+
+// Partition isolates [Nodes] from the rest of the network between [Start] and
+// [End]. Messages sent across the partition while it is active are dropped.
+type Partition struct {
+	Start time.Duration
+	End   time.Duration
+	Nodes set.Set[int]
+}
+
+// Churn periodically takes honest nodes offline. Offline nodes don't answer
+// queries and don't poll.
+type Churn struct {
+	// Every Interval, a random honest node goes offline. If 0, nodes never go
+	// offline.
+	Interval time.Duration
+	// Downtime is how long a node stays offline.
+	Downtime time.Duration
+}
+
+type Config struct {
+	Params snowball.Parameters
+	// Factory creates the consensus instance of each honest node. If nil,
+	// snowman.TopologicalFactory is used.
+	Factory snowman.Factory
+
+	// NumNodes is the number of nodes, including Byzantine nodes. Nodes are
+	// sampled uniformly.
+	NumNodes int
+	// NumEquivocating is the number of nodes that behave as Equivocating.
+	NumEquivocating int
+	// NumWithholding is the number of nodes that behave as Withholding.
+	NumWithholding int
+
+	// NumBlocks is the number of conflicting blocks issued to every honest
+	// node at the start of the simulation. Each block builds on the genesis
+	// block or on a random earlier block. Every node is given the blocks in a
+	// different order, so nodes start with different preferences.
+	NumBlocks int
+
+	// Latency of the messages between nodes.
+	Latency Latency
+	// PollTimeout is how long a node waits for the answers to a query before
+	// recording the answers it received.
+	PollTimeout time.Duration
+	Partitions  []Partition
+	Churn       Churn
+
+	// MaxTime is the simulated time after which the simulation is stopped,
+	// even if some honest nodes haven't decided every block.
+	MaxTime time.Duration
+	Seed    uint64
+}
+
+func (c *Config) Verify() error {
+	if err := c.Params.Verify(); err != nil {
+		return err
+	}
+	switch {
+	case c.NumEquivocating < 0 || c.NumWithholding < 0:
+		return errNegativeNodes
+	case c.NumNodes < c.Params.K:
+		return fmt.Errorf("%w: %d < %d", errTooFewNodes, c.NumNodes, c.Params.K)
+	case c.NumNodes-c.NumEquivocating-c.NumWithholding <= 0:
+		return errNoHonestNodes
+	case c.NumBlocks <= 0:
+		return errNoBlocks
+	case c.Latency == nil:
+		return errNoLatency
+	case c.PollTimeout <= 0:
+		return errNonPositivePollTimeout
+	case c.MaxTime <= 0:
+		return errNonPositiveMaxTime
+	case c.Churn.Interval < 0 || c.Churn.Downtime < 0:
+		return errInvalidChurn
+	}
+	for i, p := range c.Partitions {
+		if p.Start >= p.End {
+			return fmt.Errorf("%w %d: start %s >= end %s", errInvalidPartition, i, p.Start, p.End)
+		}
+		for nodeIndex := range p.Nodes {
+			if nodeIndex < 0 || nodeIndex >= c.NumNodes {
+				return fmt.Errorf("%w %d: unknown node %d", errInvalidPartition, i, nodeIndex)
+			}
+		}
+	}
+	return nil
+}
+
+// Run simulates [config] until every honest node has decided every block or
+// until [config.MaxTime] is reached.
+func Run(config Config) (*Result, error) {
+	if err := config.Verify(); err != nil {
+		return nil, err
+	}
+	s, err := newSimulation(config)
+	if err != nil {
+		return nil, err
+	}
+	return s.run()
+}
+
+type node struct {
+	index     int
+	behavior  Behavior
+	consensus snowman.Consensus
+
+	online      bool
+	finalized   bool
+	finalizedAt time.Duration
+
+	// The current poll, if [polling].
+	polling bool
+	pollID  uint64
+	votes   bag.Bag[ids.ID]
+	// Number of answers to the current poll that haven't been received
+	numPending int
+}
+
+type block struct {
+	id       ids.ID
+	parentID ids.ID
+	height   uint64
+}
+
+type event struct {
+	time time.Duration
+	// Orders events that happen at the same time
+	seq uint64
+	run func() error
+}
+
+type acceptance struct {
+	node  int
+	blkID ids.ID
+}
+
+type simulation struct {
+	config  Config
+	source  sampler.Source
+	sampler sampler.Uniform
+
+	nodes       []*node
+	honestNodes []*node
+	blocks      []block
+	heights     map[ids.ID]uint64
+
+	now     time.Duration
+	nextSeq uint64
+	events  heap.Queue[*event]
+
+	// Height --> The first block accepted at that height
+	accepted map[uint64]acceptance
+
+	numUnfinalized int
+	result         Result
+}
+
+func newSimulation(config Config) (*simulation, error) {
+	source := prng.NewMT19937()
+	source.Seed(config.Seed)
+
+	s := &simulation{
+		config:  config,
+		source:  source,
+		sampler: sampler.NewDeterministicUniform(source),
+		heights: make(map[ids.ID]uint64),
+		events: heap.NewQueue(func(a, b *event) bool {
+			if a.time != b.time {
+				return a.time < b.time
+			}
+			return a.seq < b.seq
+		}),
+		accepted: make(map[uint64]acceptance),
+	}
+
+	genesisID := ids.Empty.Prefix(source.Uint64())
+	s.heights[genesisID] = 0
+	for i := 0; i < config.NumBlocks; i++ {
+		s.sampler.Initialize(uint64(len(s.blocks) + 1))
+		parentIndex, _ := s.sampler.Next()
+		parent := block{id: genesisID}
+		if parentIndex > 0 {
+			parent = s.blocks[parentIndex-1]
+		}
+		blk := block{
+			id:       ids.Empty.Prefix(source.Uint64()),
+			parentID: parent.id,
+			height:   parent.height + 1,
+		}
+		s.blocks = append(s.blocks, blk)
+		s.heights[blk.id] = blk.height
+	}
+
+	factory := config.Factory
+	if factory == nil {
+		factory = snowman.TopologicalFactory{}
+	}
+	numHonest := config.NumNodes - config.NumEquivocating - config.NumWithholding
+	for i := 0; i < config.NumNodes; i++ {
+		n := &node{
+			index:    i,
+			behavior: Honest,
+			online:   true,
+		}
+		switch {
+		case i >= numHonest+config.NumEquivocating:
+			n.behavior = Withholding
+		case i >= numHonest:
+			n.behavior = Equivocating
+		}
+		s.nodes = append(s.nodes, n)
+		if n.behavior != Honest {
+			continue
+		}
+
+		if err := s.initConsensus(n, factory, genesisID); err != nil {
+			return nil, err
+		}
+		s.honestNodes = append(s.honestNodes, n)
+		s.numUnfinalized++
+	}
+	return s, nil
+}
+
+// initConsensus issues every block to [n], in a random order.
+func (s *simulation) initConsensus(n *node, factory snowman.Factory, genesisID ids.ID) error {
+	ctx := &snow.ConsensusContext{
+		Context: &snow.Context{
+			Log: logging.NoLog{},
+		},
+		Registerer:    prometheus.NewRegistry(),
+		BlockAcceptor: &acceptor{s: s, node: n},
+	}
+	n.consensus = factory.New()
+	if err := n.consensus.Initialize(ctx, s.config.Params, genesisID, 0, time.Time{}); err != nil {
+		return err
+	}
+
+	s.sampler.Initialize(uint64(len(s.blocks)))
+	indices, _ := s.sampler.Sample(len(s.blocks))
+	blocks := make([]block, len(indices))
+	for i, index := range indices {
+		blocks[i] = s.blocks[index]
+	}
+	// Parents must be issued before their children.
+	slices.SortStableFunc(blocks, func(a, b block) int {
+		return cmp.Compare(a.height, b.height)
+	})
+	for _, blk := range blocks {
+		err := n.consensus.Add(context.Background(), &snowmantest.Block{
+			TestDecidable: choices.TestDecidable{
+				IDV:     blk.id,
+				StatusV: choices.Processing,
+			},
+			ParentV: blk.parentID,
+			HeightV: blk.height,
+			BytesV:  blk.id[:],
+		})
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (s *simulation) run() (*Result, error) {
+	for _, n := range s.honestNodes {
+		s.schedule(0, s.startPollFunc(n))
+	}
+	if s.config.Churn.Interval > 0 {
+		s.schedule(s.config.Churn.Interval, s.churn)
+	}
+
+	for s.numUnfinalized > 0 {
+		e, ok := s.events.Pop()
+		if !ok || e.time > s.config.MaxTime {
+			break
+		}
+		s.now = e.time
+		if err := e.run(); err != nil {
+			return nil, err
+		}
+	}
+
+	s.result.EndTime = s.now
+	for _, n := range s.honestNodes {
+		if n.finalized {
+			s.result.FinalityLatencies = append(s.result.FinalityLatencies, n.finalizedAt)
+		}
+	}
+	slices.Sort(s.result.FinalityLatencies)
+	s.result.NumUnfinalized = s.numUnfinalized
+	return &s.result, nil
+}
+
+// schedule [run] to be executed [delay] after the current time.
+func (s *simulation) schedule(delay time.Duration, run func() error) {
+	s.events.Push(&event{
+		time: s.now + delay,
+		seq:  s.nextSeq,
+		run:  run,
+	})
+	s.nextSeq++
+}
+
+// send a message from [from] to [to], which is handled by [handle] when it is
+// delivered. Messages sent across an active partition are dropped.
+func (s *simulation) send(from, to *node, handle func() error) {
+	if from == to {
+		s.schedule(0, handle)
+		return
+	}
+	if !s.connected(from.index, to.index) {
+		return
+	}
+	s.schedule(s.config.Latency.Sample(s.source, from.index, to.index), handle)
+}
+
+func (s *simulation) connected(a, b int) bool {
+	for _, p := range s.config.Partitions {
+		if s.now < p.Start || s.now >= p.End {
+			continue
+		}
+		if p.Nodes.Contains(a) != p.Nodes.Contains(b) {
+			return false
+		}
+	}
+	return true
+}
+
+func (s *simulation) startPollFunc(n *node) func() error {
+	return func() error {
+		s.startPoll(n)
+		return nil
+	}
+}
+
+// startPoll queries K nodes, sampled uniformly, for their preference.
+func (s *simulation) startPoll(n *node) {
+	if !n.online || n.finalized || n.polling {
+		return
+	}
+
+	n.polling = true
+	n.pollID++
+	n.votes = bag.Bag[ids.ID]{}
+	n.numPending = s.config.Params.K
+
+	pollID := n.pollID
+	s.sampler.Initialize(uint64(len(s.nodes)))
+	indices, _ := s.sampler.Sample(s.config.Params.K)
+	for _, index := range indices {
+		peer := s.nodes[index]
+		s.send(n, peer, func() error {
+			s.handleQuery(n, peer, pollID)
+			return nil
+		})
+	}
+	s.schedule(s.config.PollTimeout, func() error {
+		return s.finishPoll(n, pollID)
+	})
+}
+
+// handleQuery answers the query [pollID] that [peer] received from [n].
+func (s *simulation) handleQuery(n *node, peer *node, pollID uint64) {
+	if !peer.online {
+		return
+	}
+
+	var vote ids.ID
+	switch peer.behavior {
+	case Honest:
+		vote = peer.consensus.Preference()
+	case Equivocating:
+		s.sampler.Initialize(uint64(len(s.blocks)))
+		index, _ := s.sampler.Next()
+		vote = s.blocks[index].id
+	case Withholding:
+		return
+	}
+
+	s.send(peer, n, func() error {
+		return s.handleVote(n, pollID, vote)
+	})
+}
+
+func (s *simulation) handleVote(n *node, pollID uint64, vote ids.ID) error {
+	if !n.polling || n.pollID != pollID {
+		// The poll already finished
+		return nil
+	}
+
+	n.votes.Add(vote)
+	n.numPending--
+	if n.numPending > 0 {
+		return nil
+	}
+	return s.finishPoll(n, pollID)
+}
+
+// finishPoll records the votes received for [pollID] and starts the next poll.
+func (s *simulation) finishPoll(n *node, pollID uint64) error {
+	if !n.polling || n.pollID != pollID {
+		// The poll already finished
+		return nil
+	}
+
+	n.polling = false
+	s.result.NumPolls++
+	if err := n.consensus.RecordPoll(context.Background(), n.votes); err != nil {
+		return err
+	}
+	if n.consensus.NumProcessing() == 0 {
+		n.finalized = true
+		n.finalizedAt = s.now
+		s.numUnfinalized--
+		return nil
+	}
+
+	s.startPoll(n)
+	return nil
+}
+
+// churn takes a random honest node offline for [Churn.Downtime].
+func (s *simulation) churn() error {
+	s.sampler.Initialize(uint64(len(s.honestNodes)))
+	index, _ := s.sampler.Next()
+	n := s.honestNodes[index]
+	if n.online {
+		// The poll that was outstanding when the node went offline, if any,
+		// is dropped.
+		n.online = false
+		n.polling = false
+		s.schedule(s.config.Churn.Downtime, func() error {
+			n.online = true
+			s.startPoll(n)
+			return nil
+		})
+	}
+
+	s.schedule(s.config.Churn.Interval, s.churn)
+	return nil
+}
+
+// accept is called when [n] accepts [blkID]. If another honest node accepted a
+// different block at the same height, a safety violation is recorded.
+func (s *simulation) accept(n *node, blkID ids.ID) {
+	height := s.heights[blkID]
+	first, ok := s.accepted[height]
+	if !ok {
+		s.accepted[height] = acceptance{
+			node:  n.index,
+			blkID: blkID,
+		}
+		return
+	}
+	if first.blkID == blkID {
+		return
+	}
+	s.result.SafetyViolations = append(s.result.SafetyViolations, SafetyViolation{
+		Time:             s.now,
+		Height:           height,
+		Node:             n.index,
+		BlkID:            blkID,
+		ConflictingNode:  first.node,
+		ConflictingBlkID: first.blkID,
+	})
+}
+
+type acceptor struct {
+	s    *simulation
+	node *node
+}
+
+func (a *acceptor) Accept(_ *snow.ConsensusContext, blkID ids.ID, _ []byte) error {
+	a.s.accept(a.node, blkID)
+	return nil
+}
@@ -0,0 +1,245 @@
+// Copyright (C) 2019-2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package simulator
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/shubhamdubey02/cryftgo/snow/consensus/snowball"
+	"github.com/shubhamdubey02/cryftgo/utils/set"
+)
+
+func newTestConfig() Config {
+	return Config{
+		Params:    snowball.DefaultParameters,
+		NumNodes:  50,
+		NumBlocks: 5,
+		Latency: Uniform{
+			Min: 10 * time.Millisecond,
+			Max: 100 * time.Millisecond,
+		},
+		PollTimeout: time.Second,
+		MaxTime:     time.Minute,
+	}
+}
+
+func TestRun(t *testing.T) {
+	tests := []struct {
+		name              string
+		modifyConfig      func(*Config)
+		expectedFinalized bool
+		minFinalityTime   time.Duration
+	}{
+		{
+			name:              "honest",
+			modifyConfig:      func(*Config) {},
+			expectedFinalized: true,
+		},
+		{
+			name: "normal latency",
+			modifyConfig: func(c *Config) {
+				c.Latency = Normal{
+					Mean:   50 * time.Millisecond,
+					StdDev: 20 * time.Millisecond,
+					Min:    time.Millisecond,
+				}
+			},
+			expectedFinalized: true,
+		},
+		{
+			name: "equivocating minority",
+			modifyConfig: func(c *Config) {
+				c.NumEquivocating = 5
+			},
+			expectedFinalized: true,
+		},
+		{
+			name: "withholding minority",
+			modifyConfig: func(c *Config) {
+				c.NumWithholding = 5
+			},
+			expectedFinalized: true,
+		},
+		{
+			name: "withholding half",
+			modifyConfig: func(c *Config) {
+				c.NumWithholding = 25
+			},
+			expectedFinalized: false,
+		},
+		{
+			name: "churn",
+			modifyConfig: func(c *Config) {
+				c.Churn = Churn{
+					Interval: 500 * time.Millisecond,
+					Downtime: 2 * time.Second,
+				}
+			},
+			expectedFinalized: true,
+		},
+		{
+			name: "healed partition",
+			modifyConfig: func(c *Config) {
+				nodes := set.NewSet[int](25)
+				for i := 0; i < 25; i++ {
+					nodes.Add(i)
+				}
+				c.Partitions = []Partition{
+					{
+						Start: 0,
+						End:   10 * time.Second,
+						Nodes: nodes,
+					},
+				}
+			},
+			expectedFinalized: true,
+			minFinalityTime:   10 * time.Second,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			require := require.New(t)
+
+			config := newTestConfig()
+			test.modifyConfig(&config)
+
+			result, err := Run(config)
+			require.NoError(err)
+			require.True(result.Safe())
+			require.Equal(test.expectedFinalized, result.Finalized())
+			require.Positive(result.NumPolls)
+			if !test.expectedFinalized {
+				require.Equal(config.MaxTime, result.EndTime)
+				return
+			}
+
+			numHonest := config.NumNodes - config.NumEquivocating - config.NumWithholding
+			require.Len(result.FinalityLatencies, numHonest)
+			require.GreaterOrEqual(result.Percentile(0), test.minFinalityTime)
+			require.Equal(result.EndTime, result.Percentile(1))
+		})
+	}
+}
+
+func TestRunDeterministic(t *testing.T) {
+	require := require.New(t)
+
+	config := newTestConfig()
+	config.NumEquivocating = 5
+	config.Seed = 1
+
+	expected, err := Run(config)
+	require.NoError(err)
+	result, err := Run(config)
+	require.NoError(err)
+	require.Equal(expected, result)
+}
+
+func TestConfigVerify(t *testing.T) {
+	tests := []struct {
+		name         string
+		modifyConfig func(*Config)
+		expectedErr  error
+	}{
+		{
+			name:         "valid",
+			modifyConfig: func(*Config) {},
+			expectedErr:  nil,
+		},
+		{
+			name: "invalid parameters",
+			modifyConfig: func(c *Config) {
+				c.Params.K = 0
+			},
+			expectedErr: snowball.ErrParametersInvalid,
+		},
+		{
+			name: "fewer nodes than K",
+			modifyConfig: func(c *Config) {
+				c.NumNodes = c.Params.K - 1
+			},
+			expectedErr: errTooFewNodes,
+		},
+		{
+			name: "negative byzantine nodes",
+			modifyConfig: func(c *Config) {
+				c.NumWithholding = -1
+			},
+			expectedErr: errNegativeNodes,
+		},
+		{
+			name: "no honest nodes",
+			modifyConfig: func(c *Config) {
+				c.NumEquivocating = 25
+				c.NumWithholding = 25
+			},
+			expectedErr: errNoHonestNodes,
+		},
+		{
+			name: "no blocks",
+			modifyConfig: func(c *Config) {
+				c.NumBlocks = 0
+			},
+			expectedErr: errNoBlocks,
+		},
+		{
+			name: "no latency",
+			modifyConfig: func(c *Config) {
+				c.Latency = nil
+			},
+			expectedErr: errNoLatency,
+		},
+		{
+			name: "zero poll timeout",
+			modifyConfig: func(c *Config) {
+				c.PollTimeout = 0
+			},
+			expectedErr: errNonPositivePollTimeout,
+		},
+		{
+			name: "zero max time",
+			modifyConfig: func(c *Config) {
+				c.MaxTime = 0
+			},
+			expectedErr: errNonPositiveMaxTime,
+		},
+		{
+			name: "negative churn downtime",
+			modifyConfig: func(c *Config) {
+				c.Churn.Downtime = -1
+			},
+			expectedErr: errInvalidChurn,
+		},
+		{
+			name: "empty partition",
+			modifyConfig: func(c *Config) {
+				c.Partitions = []Partition{{
+					Start: time.Second,
+					End:   time.Second,
+				}}
+			},
+			expectedErr: errInvalidPartition,
+		},
+		{
+			name: "partition of unknown node",
+			modifyConfig: func(c *Config) {
+				c.Partitions = []Partition{{
+					End:   time.Second,
+					Nodes: set.Of(c.NumNodes),
+				}}
+			},
+			expectedErr: errInvalidPartition,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			config := newTestConfig()
+			test.modifyConfig(&config)
+			require.ErrorIs(t, config.Verify(), test.expectedErr)
+		})
+	}
+}
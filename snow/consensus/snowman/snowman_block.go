@@ -38,7 +38,11 @@ func (n *snowmanBlock) AddChild(child Block) {
 	// if the snowball instance is nil, this is the first child. So the instance
 	// should be initialized.
 	if n.sb == nil {
-		n.sb = snowball.NewTree(snowball.SnowballFactory, n.params, childID)
+		factory := snowball.SnowballFactory
+		if n.params.BetaUnanimous > 0 {
+			factory = snowball.UnanimousSnowballFactory
+		}
+		n.sb = snowball.NewTree(factory, n.params, childID)
 		n.children = make(map[ids.ID]Block)
 	} else {
 		n.sb.Add(childID)
@@ -0,0 +1,116 @@
+// Copyright (C) 2019-2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package snowman
+
+import (
+	"math"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"gonum.org/v1/gonum/mathext/prng"
+
+	"github.com/shubhamdubey02/cryftgo/snow/consensus/snowball"
+)
+
+// logBinomialTail returns the log of the probability that at least [alpha] of
+// [k] samples vote for a choice, if each sample votes for it with probability
+// [p].
+func logBinomialTail(k, alpha int, p float64) float64 {
+	var (
+		logP    = math.Log(p)
+		logNotP = math.Log1p(-p)
+		tail    float64
+	)
+	for i := alpha; i <= k; i++ {
+		logChoose := lgamma(k+1) - lgamma(i+1) - lgamma(k-i+1)
+		tail += math.Exp(logChoose + float64(i)*logP + float64(k-i)*logNotP)
+	}
+	return math.Log(tail)
+}
+
+func lgamma(n int) float64 {
+	v, _ := math.Lgamma(float64(n))
+	return v
+}
+
+// Checks, for every valid set of parameters with BetaUnanimous set, that the
+// probability of finalizing a choice after BetaUnanimous unanimous polls is at
+// most the probability of finalizing it after Beta polls that reached
+// AlphaConfidence. This must hold for any share p of the sampled stake that
+// votes for the choice, including the shares controlled by an adversary.
+//
+// Only the smallest valid BetaUnanimous is checked, as larger values make
+// finalizing through unanimous polls less likely.
+func TestBetaUnanimousSafetyBound(t *testing.T) {
+	require := require.New(t)
+
+	const epsilon = 1e-9
+	for k := 1; k <= 30; k++ {
+		for alpha := k/2 + 1; alpha <= k; alpha++ {
+			for beta := 1; beta <= 30; beta++ {
+				params := snowball.Parameters{
+					K:                     k,
+					AlphaPreference:       alpha,
+					AlphaConfidence:       alpha,
+					Beta:                  beta,
+					ConcurrentRepolls:     1,
+					OptimalProcessing:     1,
+					MaxOutstandingItems:   1,
+					MaxItemProcessingTime: 1,
+					BetaUnanimous:         (alpha*beta + k - 1) / k,
+				}
+				require.NoError(params.Verify())
+
+				smaller := params
+				smaller.BetaUnanimous--
+				if smaller.BetaUnanimous > 0 {
+					err := smaller.Verify()
+					require.ErrorIs(err, snowball.ErrParametersInvalid)
+				}
+
+				for i := 1; i < 100; i++ {
+					p := float64(i) / 100
+					logUnanimous := float64(k*params.BetaUnanimous) * math.Log(p)
+					logConfidence := float64(beta) * logBinomialTail(k, alpha, p)
+					require.LessOrEqual(
+						logUnanimous,
+						logConfidence+epsilon,
+						"k = %d, alpha = %d, beta = %d, betaUnanimous = %d, p = %f",
+						k, alpha, beta, params.BetaUnanimous, p,
+					)
+				}
+			}
+		}
+	}
+}
+
+// Counts the rounds a network takes to finalize when every poll is unanimous.
+func TestBetaUnanimousFinalizesFaster(t *testing.T) {
+	require := require.New(t)
+
+	numRounds := func(betaUnanimous int) int {
+		params := snowball.DefaultParameters
+		params.BetaUnanimous = betaUnanimous
+
+		source := prng.NewMT19937()
+		source.Seed(0)
+
+		n := NewNetwork(params, 1, source)
+		for i := 0; i < 50; i++ {
+			require.NoError(n.AddNode(t, TopologicalFactory{}.New()))
+		}
+
+		rounds := 0
+		for !n.Finalized() {
+			require.NoError(n.Round())
+			rounds++
+		}
+		require.True(n.Agreement())
+		return rounds
+	}
+
+	withoutUnanimous := numRounds(0)
+	withUnanimous := numRounds(15)
+	require.Less(withUnanimous, withoutUnanimous)
+}
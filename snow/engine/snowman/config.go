@@ -10,7 +10,11 @@ import (
 	"github.com/shubhamdubey02/cryftgo/snow/engine/common"
 	"github.com/shubhamdubey02/cryftgo/snow/engine/common/tracker"
 	"github.com/shubhamdubey02/cryftgo/snow/engine/snowman/block"
+	"github.com/shubhamdubey02/cryftgo/snow/engine/snowman/polltrace"
+	"github.com/shubhamdubey02/cryftgo/snow/networking/reputation"
 	"github.com/shubhamdubey02/cryftgo/snow/validators"
+
+	timetracker "github.com/shubhamdubey02/cryftgo/snow/networking/tracker"
 )
 
 // Config wraps all the parameters needed for a snowman engine
@@ -24,5 +28,8 @@ type Config struct {
 	ConnectedValidators tracker.Peers
 	Params              snowball.Parameters
 	Consensus           snowman.Consensus
+	Reputation          reputation.Reporter
+	PollTracer          polltrace.Tracer
+	LatencyTracker      timetracker.LatencyTracker
 	PartialSync         bool
 }
@@ -5,14 +5,19 @@ package snowman
 
 import (
 	"testing"
+	"time"
 
 	"github.com/shubhamdubey02/cryftgo/snow/consensus/snowball"
 	"github.com/shubhamdubey02/cryftgo/snow/consensus/snowman"
 	"github.com/shubhamdubey02/cryftgo/snow/engine/common"
 	"github.com/shubhamdubey02/cryftgo/snow/engine/common/tracker"
 	"github.com/shubhamdubey02/cryftgo/snow/engine/snowman/block"
+	"github.com/shubhamdubey02/cryftgo/snow/engine/snowman/polltrace"
+	"github.com/shubhamdubey02/cryftgo/snow/networking/reputation"
 	"github.com/shubhamdubey02/cryftgo/snow/snowtest"
 	"github.com/shubhamdubey02/cryftgo/snow/validators"
+
+	timetracker "github.com/shubhamdubey02/cryftgo/snow/networking/tracker"
 )
 
 func DefaultConfig(t testing.TB) Config {
@@ -34,6 +39,9 @@ func DefaultConfig(t testing.TB) Config {
 			MaxOutstandingItems:   1,
 			MaxItemProcessingTime: 1,
 		},
-		Consensus: &snowman.Topological{},
+		Consensus:      &snowman.Topological{},
+		Reputation:     reputation.NewNoTracker(),
+		PollTracer:     polltrace.NewNoTracer(),
+		LatencyTracker: timetracker.NewLatencyTracker(time.Minute),
 	}
 }
@@ -6,8 +6,10 @@ package snowman
 import (
 	"context"
 
+	"github.com/shubhamdubey02/cryftgo/snow/choices"
 	"github.com/shubhamdubey02/cryftgo/snow/consensus/snowman"
 	"github.com/shubhamdubey02/cryftgo/snow/engine/snowman/ancestor"
+	"github.com/shubhamdubey02/cryftgo/snow/engine/snowman/polltrace"
 )
 
 var _ snowman.Block = (*memoryBlock)(nil)
@@ -18,18 +20,27 @@ type memoryBlock struct {
 
 	tree    ancestor.Tree
 	metrics *metrics
+	tracer  polltrace.Tracer
 }
 
 // Accept accepts the underlying block & removes sibling subtrees
 func (mb *memoryBlock) Accept(ctx context.Context) error {
 	mb.tree.RemoveDescendants(mb.Parent())
 	mb.metrics.numNonVerifieds.Set(float64(mb.tree.Len()))
-	return mb.Block.Accept(ctx)
+	if err := mb.Block.Accept(ctx); err != nil {
+		return err
+	}
+	mb.tracer.Decided(mb.ID(), choices.Accepted)
+	return nil
 }
 
 // Reject rejects the underlying block & removes child subtrees
 func (mb *memoryBlock) Reject(ctx context.Context) error {
 	mb.tree.RemoveDescendants(mb.ID())
 	mb.metrics.numNonVerifieds.Set(float64(mb.tree.Len()))
-	return mb.Block.Reject(ctx)
+	if err := mb.Block.Reject(ctx); err != nil {
+		return err
+	}
+	mb.tracer.Decided(mb.ID(), choices.Rejected)
+	return nil
 }
@@ -36,6 +36,8 @@ type metrics struct {
 	selectedVoteIndex                     metric.Averager
 	issuerStake                           metric.Averager
 	issued                                *prometheus.CounterVec
+	numSlowValidatorsExcluded             prometheus.Counter
+	pollCompletionTime                    prometheus.Histogram
 }
 
 func newMetrics(namespace string, reg prometheus.Registerer) (*metrics, error) {
@@ -137,6 +139,16 @@ func newMetrics(namespace string, reg prometheus.Registerer) (*metrics, error) {
 			Name:      "blks_issued",
 			Help:      "number of blocks that have been issued into consensus by discovery mechanism",
 		}, []string{"source"}),
+		numSlowValidatorsExcluded: prometheus.NewCounter(prometheus.CounterOpts{
+			Namespace: namespace,
+			Name:      "slow_validators_excluded",
+			Help:      "Number of slow validators that were queried without waiting for their votes",
+		}),
+		pollCompletionTime: prometheus.NewHistogram(prometheus.HistogramOpts{
+			Namespace: namespace,
+			Name:      "poll_completion_time",
+			Help:      "time polls took to finish (s)",
+		}),
 	}
 
 	// Register the labels
@@ -161,6 +173,8 @@ func newMetrics(namespace string, reg prometheus.Registerer) (*metrics, error) {
 		reg.Register(m.numProcessingAncestorFetchesSucceeded),
 		reg.Register(m.numProcessingAncestorFetchesUnneeded),
 		reg.Register(m.issued),
+		reg.Register(m.numSlowValidatorsExcluded),
+		reg.Register(m.pollCompletionTime),
 	)
 	return m, errs.Err
 }
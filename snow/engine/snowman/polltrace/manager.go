@@ -0,0 +1,65 @@
+// Copyright (C) 2019-2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package polltrace
+
+import (
+	"sync"
+
+	"github.com/shubhamdubey02/cryftgo/ids"
+	"github.com/shubhamdubey02/cryftgo/snow/consensus/snowball"
+)
+
+// Manager keeps the tracers of the snowman engines running on this node so
+// that their traces can be looked up by block ID.
+type Manager struct {
+	// Number of decided blocks whose traces are kept by each tracer. If 0,
+	// no traces are recorded.
+	size int
+
+	lock sync.RWMutex
+	// Chain ID --> Tracer of the chain
+	tracers map[ids.ID]Tracer
+}
+
+// NewManager returns a manager whose tracers keep the traces of the [size]
+// most recently decided blocks of each chain. If [size] is 0, tracing is
+// disabled.
+func NewManager(size int) *Manager {
+	return &Manager{
+		size:    size,
+		tracers: make(map[ids.ID]Tracer),
+	}
+}
+
+// NewTracer returns the tracer to be used by the snowman engine of [chainID].
+func (m *Manager) NewTracer(chainID ids.ID, params snowball.Parameters) (Tracer, error) {
+	if m.size == 0 {
+		return NewNoTracer(), nil
+	}
+
+	tracer, err := New(params.AlphaConfidence, params.Beta, m.size)
+	if err != nil {
+		return nil, err
+	}
+
+	m.lock.Lock()
+	defer m.lock.Unlock()
+
+	m.tracers[chainID] = tracer
+	return tracer, nil
+}
+
+// Trace returns the trace of [blkID] and the chain it was issued on. Returns
+// false if no chain has a trace of [blkID].
+func (m *Manager) Trace(blkID ids.ID) (ids.ID, Trace, bool) {
+	m.lock.RLock()
+	defer m.lock.RUnlock()
+
+	for chainID, tracer := range m.tracers {
+		if trace, ok := tracer.Trace(blkID); ok {
+			return chainID, trace, true
+		}
+	}
+	return ids.Empty, Trace{}, false
+}
@@ -0,0 +1,41 @@
+// Copyright (C) 2019-2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package polltrace
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/shubhamdubey02/cryftgo/ids"
+	"github.com/shubhamdubey02/cryftgo/snow/consensus/snowball"
+	"github.com/shubhamdubey02/cryftgo/utils/json"
+)
+
+func TestManager(t *testing.T) {
+	require := require.New(t)
+
+	var (
+		chainID = ids.GenerateTestID()
+		blkID   = ids.GenerateTestID()
+		params  = snowball.DefaultParameters
+	)
+
+	disabled := NewManager(0)
+	tracer, err := disabled.NewTracer(chainID, params)
+	require.NoError(err)
+	tracer.Issued(blkID, ids.Empty, 1)
+	_, _, ok := disabled.Trace(blkID)
+	require.False(ok)
+
+	enabled := NewManager(1)
+	tracer, err = enabled.NewTracer(chainID, params)
+	require.NoError(err)
+	tracer.Issued(blkID, ids.Empty, 1)
+	traceChainID, trace, ok := enabled.Trace(blkID)
+	require.True(ok)
+	require.Equal(chainID, traceChainID)
+	require.Equal(blkID, trace.BlkID)
+	require.Equal(json.Uint32(params.Beta), trace.Beta)
+}
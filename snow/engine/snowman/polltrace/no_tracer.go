@@ -0,0 +1,31 @@
+// Copyright (C) 2019-2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package polltrace
+
+import (
+	"github.com/shubhamdubey02/cryftgo/ids"
+	"github.com/shubhamdubey02/cryftgo/snow/choices"
+	"github.com/shubhamdubey02/cryftgo/snow/consensus/snowman/poll"
+)
+
+var _ Tracer = noTracer{}
+
+type noTracer struct{}
+
+// NewNoTracer returns a tracer that doesn't record any traces
+func NewNoTracer() Tracer {
+	return noTracer{}
+}
+
+func (noTracer) Issued(ids.ID, ids.ID, uint64) {}
+
+func (noTracer) Queried(uint32, ids.ID) {}
+
+func (noTracer) Finished(poll.Result) {}
+
+func (noTracer) Decided(ids.ID, choices.Status) {}
+
+func (noTracer) Trace(ids.ID) (Trace, bool) {
+	return Trace{}, false
+}
@@ -0,0 +1,61 @@
+// Copyright (C) 2019-2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package polltrace
+
+import (
+	"time"
+
+	"github.com/shubhamdubey02/cryftgo/ids"
+	"github.com/shubhamdubey02/cryftgo/snow/choices"
+	"github.com/shubhamdubey02/cryftgo/utils/json"
+)
+
+// Trace describes the polls that finished while a block was processing.
+type Trace struct {
+	BlkID    ids.ID         `json:"blockID"`
+	ParentID ids.ID         `json:"parentID"`
+	Height   json.Uint64    `json:"height"`
+	Status   choices.Status `json:"status"`
+	// Issued is the time the block was added to consensus
+	Issued time.Time `json:"issued"`
+	// Decided is the time the block was accepted or rejected. Zero while the
+	// block is processing.
+	Decided time.Time `json:"decided"`
+	// Confidence is the number of consecutive successful polls of the block.
+	// The block can be accepted once it reaches Beta.
+	Confidence json.Uint32 `json:"confidence"`
+	Beta       json.Uint32 `json:"beta"`
+	// NumPolls is the number of polls that finished while the block was
+	// processing. Only the most recent of them are kept in Polls.
+	NumPolls json.Uint64 `json:"numPolls"`
+	Polls    []Poll      `json:"polls"`
+}
+
+// Poll describes a finished poll from the point of view of a block.
+type Poll struct {
+	RequestID json.Uint32 `json:"requestID"`
+	// QueriedID is the block that was sent in the query. Votes for it also
+	// count for its ancestors.
+	QueriedID ids.ID        `json:"queriedID"`
+	Start     time.Time     `json:"start"`
+	Duration  time.Duration `json:"duration"`
+	Responses []Response    `json:"responses"`
+	// Votes is the number of votes for the block or one of its descendants.
+	Votes json.Uint32 `json:"votes"`
+	// Successful is true if Votes reached AlphaConfidence.
+	Successful bool `json:"successful"`
+	// Confidence is the confidence of the block after the poll.
+	Confidence json.Uint32 `json:"confidence"`
+}
+
+// Response describes how a sampled validator responded to a poll. Validators
+// that neither voted nor were dropped hadn't responded when the poll finished.
+type Response struct {
+	NodeID ids.NodeID `json:"nodeID"`
+	// Weight is the number of times the validator was sampled
+	Weight  json.Uint32 `json:"weight"`
+	Voted   bool        `json:"voted"`
+	Vote    ids.ID      `json:"vote"`
+	Dropped bool        `json:"dropped"`
+}
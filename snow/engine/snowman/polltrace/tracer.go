@@ -0,0 +1,200 @@
+// Copyright (C) 2019-2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package polltrace
+
+import (
+	"slices"
+	"sync"
+
+	"github.com/shubhamdubey02/cryftgo/ids"
+	"github.com/shubhamdubey02/cryftgo/snow/choices"
+	"github.com/shubhamdubey02/cryftgo/snow/consensus/snowman/poll"
+	"github.com/shubhamdubey02/cryftgo/utils/buffer"
+	"github.com/shubhamdubey02/cryftgo/utils/json"
+	"github.com/shubhamdubey02/cryftgo/utils/timer/mockable"
+)
+
+// MaxPollsPerTrace is the maximum number of polls kept in the trace of a
+// block. Older polls are only counted.
+const MaxPollsPerTrace = 64
+
+var _ Tracer = (*tracer)(nil)
+
+// Tracer records the polls applied to each block of a snowman engine.
+type Tracer interface {
+	// Issued is called when [blkID] is added to consensus.
+	Issued(blkID ids.ID, parentID ids.ID, height uint64)
+
+	// Queried is called when the poll [requestID] is sent to query [blkID].
+	Queried(requestID uint32, blkID ids.ID)
+
+	// Finished is called with every finished poll before it is applied to
+	// consensus.
+	Finished(result poll.Result)
+
+	// Decided is called when [blkID] is accepted or rejected.
+	Decided(blkID ids.ID, status choices.Status)
+
+	// Trace returns the trace of [blkID]. Returns false if [blkID] isn't
+	// processing and isn't one of the most recently decided blocks.
+	Trace(blkID ids.ID) (Trace, bool)
+}
+
+type tracer struct {
+	alphaConfidence int
+	beta            int
+
+	// Tells the time. Can be faked for testing.
+	clock mockable.Clock
+
+	lock sync.RWMutex
+	// Request ID --> Block that was queried
+	queried map[uint32]ids.ID
+	// Block ID --> Trace of the block
+	processing map[ids.ID]*Trace
+	// Block ID --> Trace of the block
+	decided map[ids.ID]*Trace
+	// Most recently decided blocks. When a block is evicted, its trace is
+	// removed from [decided].
+	recentlyDecided buffer.Queue[ids.ID]
+}
+
+// New returns a tracer that keeps the traces of the processing blocks and of
+// the [size] most recently decided blocks.
+func New(alphaConfidence, beta, size int) (Tracer, error) {
+	t := &tracer{
+		alphaConfidence: alphaConfidence,
+		beta:            beta,
+		queried:         make(map[uint32]ids.ID),
+		processing:      make(map[ids.ID]*Trace),
+		decided:         make(map[ids.ID]*Trace),
+	}
+	var err error
+	t.recentlyDecided, err = buffer.NewBoundedQueue(size, func(blkID ids.ID) {
+		delete(t.decided, blkID)
+	})
+	return t, err
+}
+
+func (t *tracer) Issued(blkID ids.ID, parentID ids.ID, height uint64) {
+	t.lock.Lock()
+	defer t.lock.Unlock()
+
+	t.processing[blkID] = &Trace{
+		BlkID:    blkID,
+		ParentID: parentID,
+		Height:   json.Uint64(height),
+		Status:   choices.Processing,
+		Issued:   t.clock.Time(),
+		Beta:     json.Uint32(t.beta),
+	}
+}
+
+func (t *tracer) Queried(requestID uint32, blkID ids.ID) {
+	t.lock.Lock()
+	defer t.lock.Unlock()
+
+	t.queried[requestID] = blkID
+}
+
+func (t *tracer) Finished(result poll.Result) {
+	t.lock.Lock()
+	defer t.lock.Unlock()
+
+	queriedID := t.queried[result.RequestID]
+	delete(t.queried, result.RequestID)
+
+	if len(t.processing) == 0 {
+		return
+	}
+
+	// Votes for a block are also votes for its processing ancestors.
+	votes := make(map[ids.ID]int)
+	for _, vote := range result.Votes.List() {
+		count := result.Votes.Count(vote)
+		for blkID := vote; ; {
+			trace, ok := t.processing[blkID]
+			if !ok {
+				break
+			}
+			votes[blkID] += count
+			blkID = trace.ParentID
+		}
+	}
+
+	// The responses are shared by the traces of every processing block, so
+	// they must not be modified once created.
+	responses := make([]Response, 0, result.Validators.Len())
+	for _, nodeID := range result.Validators.List() {
+		vote, voted := result.Responses[nodeID]
+		responses = append(responses, Response{
+			NodeID:  nodeID,
+			Weight:  json.Uint32(result.Validators.Count(nodeID)),
+			Voted:   voted,
+			Vote:    vote,
+			Dropped: slices.Contains(result.Dropped, nodeID),
+		})
+	}
+	slices.SortFunc(responses, func(a, b Response) int {
+		return a.NodeID.Compare(b.NodeID)
+	})
+
+	for blkID, trace := range t.processing {
+		numVotes := votes[blkID]
+		successful := numVotes >= t.alphaConfidence
+		if successful {
+			trace.Confidence++
+		} else {
+			trace.Confidence = 0
+		}
+
+		if len(trace.Polls) == MaxPollsPerTrace {
+			trace.Polls = slices.Delete(trace.Polls, 0, 1)
+		}
+		trace.NumPolls++
+		trace.Polls = append(trace.Polls, Poll{
+			RequestID:  json.Uint32(result.RequestID),
+			QueriedID:  queriedID,
+			Start:      result.Start,
+			Duration:   result.Duration,
+			Responses:  responses,
+			Votes:      json.Uint32(numVotes),
+			Successful: successful,
+			Confidence: trace.Confidence,
+		})
+	}
+}
+
+func (t *tracer) Decided(blkID ids.ID, status choices.Status) {
+	t.lock.Lock()
+	defer t.lock.Unlock()
+
+	trace, ok := t.processing[blkID]
+	if !ok {
+		return
+	}
+	delete(t.processing, blkID)
+
+	trace.Status = status
+	trace.Decided = t.clock.Time()
+	t.decided[blkID] = trace
+	t.recentlyDecided.Push(blkID)
+}
+
+func (t *tracer) Trace(blkID ids.ID) (Trace, bool) {
+	t.lock.RLock()
+	defer t.lock.RUnlock()
+
+	trace, ok := t.processing[blkID]
+	if !ok {
+		trace, ok = t.decided[blkID]
+		if !ok {
+			return Trace{}, false
+		}
+	}
+
+	traceCopy := *trace
+	traceCopy.Polls = slices.Clone(trace.Polls)
+	return traceCopy, true
+}
@@ -0,0 +1,157 @@
+// Copyright (C) 2019-2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package polltrace
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/shubhamdubey02/cryftgo/ids"
+	"github.com/shubhamdubey02/cryftgo/snow/choices"
+	"github.com/shubhamdubey02/cryftgo/snow/consensus/snowman/poll"
+	"github.com/shubhamdubey02/cryftgo/utils/bag"
+	"github.com/shubhamdubey02/cryftgo/utils/json"
+)
+
+func TestTracerConfidence(t *testing.T) {
+	require := require.New(t)
+
+	var (
+		parentID = ids.GenerateTestID()
+		blkID    = ids.GenerateTestID()
+		childID  = ids.GenerateTestID()
+		vdr0     = ids.GenerateTestNodeID()
+		vdr1     = ids.GenerateTestNodeID()
+	)
+
+	tracer, err := New(2, 3, 1)
+	require.NoError(err)
+
+	tracer.Issued(blkID, parentID, 1)
+	tracer.Issued(childID, blkID, 2)
+
+	// Votes for [childID] also count for [blkID].
+	tracer.Queried(1, childID)
+	tracer.Finished(poll.Result{
+		RequestID:  1,
+		Validators: bag.Of(vdr0, vdr1),
+		Responses: map[ids.NodeID]ids.ID{
+			vdr0: childID,
+			vdr1: blkID,
+		},
+		Votes: bag.Of(childID, blkID),
+	})
+
+	// A poll that doesn't reach alpha resets the confidence.
+	tracer.Queried(2, childID)
+	tracer.Finished(poll.Result{
+		RequestID:  2,
+		Validators: bag.Of(vdr0, vdr1),
+		Responses: map[ids.NodeID]ids.ID{
+			vdr0: childID,
+		},
+		Dropped: []ids.NodeID{vdr1},
+		Votes:   bag.Of(childID),
+	})
+
+	trace, ok := tracer.Trace(blkID)
+	require.True(ok)
+	require.Equal(choices.Processing, trace.Status)
+	require.Equal(json.Uint32(3), trace.Beta)
+	require.Equal(json.Uint64(2), trace.NumPolls)
+	require.Len(trace.Polls, 2)
+	require.Equal(childID, trace.Polls[0].QueriedID)
+	require.Equal(json.Uint32(2), trace.Polls[0].Votes)
+	require.True(trace.Polls[0].Successful)
+	require.Equal(json.Uint32(1), trace.Polls[0].Confidence)
+	require.Equal(json.Uint32(1), trace.Polls[1].Votes)
+	require.False(trace.Polls[1].Successful)
+	require.Zero(trace.Polls[1].Confidence)
+	require.Zero(trace.Confidence)
+
+	responses := trace.Polls[1].Responses
+	require.Len(responses, 2)
+	for _, response := range responses {
+		switch response.NodeID {
+		case vdr0:
+			require.True(response.Voted)
+			require.Equal(childID, response.Vote)
+			require.False(response.Dropped)
+		case vdr1:
+			require.False(response.Voted)
+			require.True(response.Dropped)
+		}
+	}
+
+	trace, ok = tracer.Trace(childID)
+	require.True(ok)
+	require.Equal(json.Uint32(1), trace.Polls[0].Votes)
+	require.False(trace.Polls[0].Successful)
+}
+
+func TestTracerDecided(t *testing.T) {
+	require := require.New(t)
+
+	var (
+		parentID = ids.GenerateTestID()
+		blkID0   = ids.GenerateTestID()
+		blkID1   = ids.GenerateTestID()
+	)
+
+	tracer, err := New(1, 1, 1)
+	require.NoError(err)
+
+	_, ok := tracer.Trace(blkID0)
+	require.False(ok)
+
+	tracer.Issued(blkID0, parentID, 1)
+	tracer.Issued(blkID1, parentID, 1)
+
+	tracer.Decided(blkID0, choices.Accepted)
+	trace, ok := tracer.Trace(blkID0)
+	require.True(ok)
+	require.Equal(choices.Accepted, trace.Status)
+	require.False(trace.Decided.IsZero())
+
+	// Only the most recently decided block is kept.
+	tracer.Decided(blkID1, choices.Rejected)
+	_, ok = tracer.Trace(blkID0)
+	require.False(ok)
+	trace, ok = tracer.Trace(blkID1)
+	require.True(ok)
+	require.Equal(choices.Rejected, trace.Status)
+
+	// Polls finishing after a block was decided aren't recorded.
+	tracer.Finished(poll.Result{
+		RequestID: 1,
+		Votes:     bag.Of(blkID1),
+	})
+	trace, ok = tracer.Trace(blkID1)
+	require.True(ok)
+	require.Empty(trace.Polls)
+}
+
+func TestTracerMaxPolls(t *testing.T) {
+	require := require.New(t)
+
+	blkID := ids.GenerateTestID()
+
+	tracer, err := New(1, 1, 1)
+	require.NoError(err)
+
+	tracer.Issued(blkID, ids.GenerateTestID(), 1)
+	for requestID := uint32(0); requestID < MaxPollsPerTrace+1; requestID++ {
+		tracer.Finished(poll.Result{
+			RequestID: requestID,
+		})
+	}
+
+	trace, ok := tracer.Trace(blkID)
+	require.True(ok)
+	require.Equal(json.Uint64(MaxPollsPerTrace+1), trace.NumPolls)
+	require.Len(trace.Polls, MaxPollsPerTrace)
+	require.Equal(json.Uint32(1), trace.Polls[0].RequestID)
+	require.Equal(json.Uint32(MaxPollsPerTrace), trace.Polls[MaxPollsPerTrace-1].RequestID)
+}
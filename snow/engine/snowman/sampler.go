@@ -0,0 +1,67 @@
+// Copyright (C) 2019-2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package snowman
+
+import (
+	"github.com/shubhamdubey02/cryftgo/ids"
+	"github.com/shubhamdubey02/cryftgo/utils/set"
+)
+
+// sampleValidators samples K validators, weighted by stake, to query in a
+// poll. It also returns the sampled validators the poll shouldn't wait for.
+//
+// The sample is always the stake-weighted sample used by snowball, so latency
+// never changes which validators are queried, or how often a validator is
+// sampled. If SlowValidatorLatency is non-zero, latency is only used to
+// terminate polls early: sampled validators whose average response latency
+// exceeds SlowValidatorLatency are queried, but up to K-AlphaConfidence of
+// their samples are excluded from the poll. An excluded sample is handled like
+// a dropped query: it never counts as a vote, so a poll can only succeed if
+// AlphaConfidence of the remaining samples vote for the same block. Excluding
+// samples therefore never makes a poll succeed that wouldn't have succeeded if
+// the slow validators failed to respond.
+func (t *Transitive) sampleValidators() ([]ids.NodeID, set.Set[ids.NodeID], error) {
+	vdrIDs, err := t.Validators.Sample(t.Ctx.SubnetID, t.Params.K)
+	if err != nil || t.Params.SlowValidatorLatency == 0 {
+		return vdrIDs, nil, err
+	}
+
+	var (
+		slowVdrs    set.Set[ids.NodeID]
+		numExcluded int
+		maxExcluded = t.Params.K - t.Params.AlphaConfidence
+	)
+	for _, vdrID := range vdrIDs {
+		if numExcluded == maxExcluded {
+			break
+		}
+		if slowVdrs.Contains(vdrID) || !t.isSlow(vdrID) {
+			continue
+		}
+
+		weight := 0
+		for _, otherID := range vdrIDs {
+			if otherID == vdrID {
+				weight++
+			}
+		}
+		if numExcluded+weight > maxExcluded {
+			continue
+		}
+		slowVdrs.Add(vdrID)
+		numExcluded += weight
+		t.numSlowValidatorsExcluded.Inc()
+	}
+	return vdrIDs, slowVdrs, nil
+}
+
+// isSlow returns true if the average latency of [nodeID]'s responses exceeds
+// SlowValidatorLatency. Nodes whose latency is unknown aren't slow.
+func (t *Transitive) isSlow(nodeID ids.NodeID) bool {
+	if nodeID == t.Ctx.NodeID {
+		return false
+	}
+	latency, ok := t.LatencyTracker.Latency(nodeID)
+	return ok && latency > t.Params.SlowValidatorLatency
+}
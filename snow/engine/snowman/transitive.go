@@ -22,6 +22,7 @@ import (
 	"github.com/shubhamdubey02/cryftgo/snow/engine/common/tracker"
 	"github.com/shubhamdubey02/cryftgo/snow/engine/snowman/ancestor"
 	"github.com/shubhamdubey02/cryftgo/snow/event"
+	"github.com/shubhamdubey02/cryftgo/snow/networking/reputation"
 	"github.com/shubhamdubey02/cryftgo/snow/validators"
 	"github.com/shubhamdubey02/cryftgo/utils/bag"
 	"github.com/shubhamdubey02/cryftgo/utils/bimap"
@@ -115,6 +116,13 @@ func New(config Config) (*Transitive, error) {
 		config.Params.AlphaPreference,
 		config.Params.AlphaConfidence,
 	)
+	if config.Params.BetaUnanimous > 0 {
+		factory = poll.NewEarlyTermUnanimousFactory(
+			config.Params.K,
+			config.Params.AlphaPreference,
+			config.Params.AlphaConfidence,
+		)
+	}
 	polls, err := poll.NewSet(
 		factory,
 		config.Ctx.Log,
@@ -218,6 +226,8 @@ func (t *Transitive) Put(ctx context.Context, nodeID ids.NodeID, requestID uint3
 				zap.Error(err),
 			)
 		}
+		t.Reputation.Report(nodeID, reputation.InvalidBlock)
+
 		// because GetFailed doesn't utilize the assumption that we actually
 		// sent a Get message, we can safely call GetFailed here to potentially
 		// abandon the request.
@@ -329,6 +339,7 @@ func (t *Transitive) PushQuery(ctx context.Context, nodeID ids.NodeID, requestID
 				zap.Error(err),
 			)
 		}
+		t.Reputation.Report(nodeID, reputation.InvalidBlock)
 		return nil
 	}
 
@@ -899,7 +910,7 @@ func (t *Transitive) sendQuery(
 		zap.Stringer("validators", t.Validators),
 	)
 
-	vdrIDs, err := t.Validators.Sample(t.Ctx.SubnetID, t.Params.K)
+	vdrIDs, slowVdrs, err := t.sampleValidators()
 	if err != nil {
 		t.Ctx.Log.Warn("dropped query for block",
 			zap.String("reason", "insufficient number of validators"),
@@ -921,7 +932,11 @@ func (t *Transitive) sendQuery(
 		return
 	}
 
+	// Slow validators are queried, but the poll doesn't wait for their votes.
 	vdrBag := bag.Of(vdrIDs...)
+	vdrBag = vdrBag.Filter(func(vdrID ids.NodeID) bool {
+		return !slowVdrs.Contains(vdrID)
+	})
 	t.requestID++
 	if !t.polls.Add(t.requestID, vdrBag) {
 		t.Ctx.Log.Error("dropped query for block",
@@ -931,6 +946,7 @@ func (t *Transitive) sendQuery(
 		)
 		return
 	}
+	t.PollTracer.Queried(t.requestID, blkID)
 
 	vdrSet := set.Of(vdrIDs...)
 	if push {
@@ -1102,6 +1118,8 @@ func (t *Transitive) addUnverifiedBlockToConsensus(
 			zap.Uint64("height", blkHeight),
 			zap.Error(err),
 		)
+		// Verification failures aren't reported to the reputation tracker,
+		// as a peer may relay a block it hasn't verified yet.
 
 		// if verify fails, then all descendants are also invalid
 		t.addToNonVerifieds(blk)
@@ -1117,10 +1135,12 @@ func (t *Transitive) addUnverifiedBlockToConsensus(
 		zap.Stringer("blkID", blkID),
 		zap.Uint64("height", blkHeight),
 	)
+	t.PollTracer.Issued(blkID, blk.Parent(), blkHeight)
 	return true, t.Consensus.Add(ctx, &memoryBlock{
 		Block:   blk,
 		metrics: t.metrics,
 		tree:    t.nonVerifieds,
+		tracer:  t.PollTracer,
 	})
 }
 
@@ -7,6 +7,7 @@ import (
 	"bytes"
 	"context"
 	"errors"
+	"math"
 	"testing"
 	"time"
 
@@ -25,8 +26,12 @@ import (
 	"github.com/shubhamdubey02/cryftgo/snow/engine/snowman/ancestor"
 	"github.com/shubhamdubey02/cryftgo/snow/engine/snowman/block"
 	"github.com/shubhamdubey02/cryftgo/snow/engine/snowman/getter"
+	"github.com/shubhamdubey02/cryftgo/snow/engine/snowman/polltrace"
+	"github.com/shubhamdubey02/cryftgo/snow/networking/reputation"
 	"github.com/shubhamdubey02/cryftgo/snow/snowtest"
 	"github.com/shubhamdubey02/cryftgo/snow/validators"
+	"github.com/shubhamdubey02/cryftgo/utils/bag"
+	"github.com/shubhamdubey02/cryftgo/utils/json"
 	"github.com/shubhamdubey02/cryftgo/utils/set"
 	"github.com/shubhamdubey02/cryftgo/version"
 )
@@ -3023,3 +3028,225 @@ func TestGetProcessingAncestor(t *testing.T) {
 		})
 	}
 }
+
+type testReporter struct {
+	offenses map[ids.NodeID][]reputation.Offense
+}
+
+func (r *testReporter) Report(nodeID ids.NodeID, offense reputation.Offense) {
+	r.offenses[nodeID] = append(r.offenses[nodeID], offense)
+}
+
+func TestEngineReportsInvalidBlocks(t *testing.T) {
+	require := require.New(t)
+
+	reporter := &testReporter{
+		offenses: make(map[ids.NodeID][]reputation.Offense),
+	}
+	config := DefaultConfig(t)
+	config.Reputation = reporter
+	vdr, _, sender, vm, te := setup(t, config)
+
+	sender.Default(true)
+	sender.SendChitsF = func(context.Context, ids.NodeID, uint32, ids.ID, ids.ID, ids.ID) {}
+
+	blk := snowmantest.BuildChild(snowmantest.Genesis)
+	blk.VerifyV = errInvalid
+
+	vm.ParseBlockF = func(_ context.Context, b []byte) (snowman.Block, error) {
+		if bytes.Equal(b, blk.Bytes()) {
+			return blk, nil
+		}
+		return nil, errUnknownBytes
+	}
+	vm.GetBlockF = func(_ context.Context, blkID ids.ID) (snowman.Block, error) {
+		switch blkID {
+		case snowmantest.GenesisID:
+			return snowmantest.Genesis, nil
+		default:
+			return nil, errUnknownBlock
+		}
+	}
+
+	// An unparsable block should be reported.
+	require.NoError(te.PushQuery(context.Background(), vdr, 0, []byte{1}, 1))
+	require.Equal([]reputation.Offense{reputation.InvalidBlock}, reporter.offenses[vdr])
+
+	// A block that fails verification may have been relayed without being
+	// verified, so it shouldn't be reported.
+	require.NoError(te.PushQuery(context.Background(), vdr, 1, blk.Bytes(), 1))
+	require.Equal([]reputation.Offense{reputation.InvalidBlock}, reporter.offenses[vdr])
+
+	// An unparsable block sent in response to a Get should be reported.
+	require.NoError(te.Put(context.Background(), vdr, 2, []byte{2}))
+	require.Equal([]reputation.Offense{reputation.InvalidBlock, reputation.InvalidBlock}, reporter.offenses[vdr])
+}
+
+func TestEngineTracesPolls(t *testing.T) {
+	require := require.New(t)
+
+	tracer, err := polltrace.New(1, 1, 16)
+	require.NoError(err)
+
+	config := DefaultConfig(t)
+	config.PollTracer = tracer
+	vdr, _, sender, vm, te := setup(t, config)
+
+	blk := snowmantest.BuildChild(snowmantest.Genesis)
+
+	vm.GetBlockF = func(_ context.Context, blkID ids.ID) (snowman.Block, error) {
+		switch blkID {
+		case snowmantest.GenesisID:
+			return snowmantest.Genesis, nil
+		case blk.ID():
+			return blk, nil
+		default:
+			return nil, errUnknownBlock
+		}
+	}
+
+	var requestID uint32
+	sender.SendPushQueryF = func(_ context.Context, _ set.Set[ids.NodeID], reqID uint32, _ []byte, _ uint64) {
+		requestID = reqID
+	}
+
+	vm.BuildBlockF = func(context.Context) (snowman.Block, error) {
+		return blk, nil
+	}
+	require.NoError(te.Notify(context.Background(), common.PendingTxs))
+
+	trace, ok := tracer.Trace(blk.ID())
+	require.True(ok)
+	require.Equal(choices.Processing, trace.Status)
+	require.Empty(trace.Polls)
+
+	require.NoError(te.Chits(context.Background(), vdr, requestID, blk.ID(), blk.ID(), blk.ID()))
+	require.Equal(choices.Accepted, blk.Status())
+
+	trace, ok = tracer.Trace(blk.ID())
+	require.True(ok)
+	require.Equal(choices.Accepted, trace.Status)
+	require.Equal(snowmantest.GenesisID, trace.ParentID)
+	require.Equal(json.Uint64(1), trace.NumPolls)
+	require.Len(trace.Polls, 1)
+
+	p := trace.Polls[0]
+	require.Equal(json.Uint32(requestID), p.RequestID)
+	require.Equal(blk.ID(), p.QueriedID)
+	require.Equal(
+		[]polltrace.Response{{
+			NodeID: vdr,
+			Weight: 1,
+			Voted:  true,
+			Vote:   blk.ID(),
+		}},
+		p.Responses,
+	)
+	require.Equal(json.Uint32(1), p.Votes)
+	require.True(p.Successful)
+	require.Equal(json.Uint32(1), p.Confidence)
+}
+
+func TestSampleValidatorsDoesNotFavorFastValidators(t *testing.T) {
+	require := require.New(t)
+
+	const (
+		numPolls     = 1000
+		numFastVdrs  = 5
+		fastVdrStake = 20
+		numSlowVdrs  = 10
+		slowVdrStake = 40
+	)
+
+	config := DefaultConfig(t)
+	config.Params.K = 20
+	config.Params.AlphaPreference = 15
+	config.Params.AlphaConfidence = 15
+	config.Params.SlowValidatorLatency = 100 * time.Millisecond
+
+	// The fast validators could be byzantine, so they must not be sampled
+	// more often than their stake warrants.
+	fastVdrs := set.NewSet[ids.NodeID](numFastVdrs)
+	for i := 0; i < numFastVdrs; i++ {
+		vdrID := ids.GenerateTestNodeID()
+		require.NoError(config.Validators.AddStaker(config.Ctx.SubnetID, vdrID, nil, ids.Empty, fastVdrStake))
+		config.LatencyTracker.Observe(vdrID, time.Millisecond)
+		fastVdrs.Add(vdrID)
+	}
+	for i := 0; i < numSlowVdrs; i++ {
+		vdrID := ids.GenerateTestNodeID()
+		require.NoError(config.Validators.AddStaker(config.Ctx.SubnetID, vdrID, nil, ids.Empty, slowVdrStake))
+		config.LatencyTracker.Observe(vdrID, time.Second)
+	}
+
+	_, _, _, _, te := setup(t, config)
+
+	totalWeight, err := config.Validators.TotalWeight(config.Ctx.SubnetID)
+	require.NoError(err)
+
+	numFastSamples := 0
+	for i := 0; i < numPolls; i++ {
+		vdrIDs, slowVdrs, err := te.sampleValidators()
+		require.NoError(err)
+		require.Len(vdrIDs, config.Params.K)
+
+		numExcluded := 0
+		for _, vdrID := range vdrIDs {
+			if fastVdrs.Contains(vdrID) {
+				numFastSamples++
+			}
+			if slowVdrs.Contains(vdrID) {
+				numExcluded++
+			}
+		}
+		require.LessOrEqual(numExcluded, config.Params.K-config.Params.AlphaConfidence)
+	}
+
+	// The number of samples of the fast validators is binomially distributed,
+	// so it is within 5 standard deviations of its mean with overwhelming
+	// probability.
+	var (
+		numSamples = float64(numPolls * config.Params.K)
+		fastShare  = float64(numFastVdrs*fastVdrStake) / float64(totalWeight)
+		expected   = numSamples * fastShare
+		stdDev     = math.Sqrt(numSamples * fastShare * (1 - fastShare))
+	)
+	require.InDelta(expected, float64(numFastSamples), 5*stdDev)
+}
+
+func TestEngineExcludesSlowValidators(t *testing.T) {
+	require := require.New(t)
+
+	config := DefaultConfig(t)
+	config.Params.K = 3
+	config.Params.AlphaPreference = 2
+	config.Params.AlphaConfidence = 2
+	config.Params.SlowValidatorLatency = 100 * time.Millisecond
+
+	fastVdr := ids.GenerateTestNodeID()
+	require.NoError(config.Validators.AddStaker(config.Ctx.SubnetID, fastVdr, nil, ids.Empty, 10))
+	slowVdr := ids.GenerateTestNodeID()
+	require.NoError(config.Validators.AddStaker(config.Ctx.SubnetID, slowVdr, nil, ids.Empty, 1))
+	config.LatencyTracker.Observe(slowVdr, time.Second)
+
+	vdr, _, _, _, te := setup(t, config)
+	config.LatencyTracker.Observe(vdr, time.Millisecond)
+
+	for i := 0; i < 100; i++ {
+		vdrIDs, slowVdrs, err := te.sampleValidators()
+		require.NoError(err)
+		require.Len(vdrIDs, config.Params.K)
+
+		sampled := bag.Of(vdrIDs...)
+		switch sampled.Count(slowVdr) {
+		case 1:
+			// Excluding the slow validator leaves enough samples to reach
+			// AlphaConfidence.
+			require.Equal(set.Of(slowVdr), slowVdrs)
+		default:
+			// Excluding the slow validator would leave fewer samples than
+			// AlphaConfidence.
+			require.Empty(slowVdrs)
+		}
+	}
+}
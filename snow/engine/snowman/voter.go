@@ -9,7 +9,7 @@ import (
 	"go.uber.org/zap"
 
 	"github.com/shubhamdubey02/cryftgo/ids"
-	"github.com/shubhamdubey02/cryftgo/utils/bag"
+	"github.com/shubhamdubey02/cryftgo/snow/consensus/snowman/poll"
 	"github.com/shubhamdubey02/cryftgo/utils/set"
 )
 
@@ -57,7 +57,7 @@ func (v *voter) Update(ctx context.Context) {
 		}
 	}
 
-	var results []bag.Bag[ids.ID]
+	var results []poll.Result
 	if shouldVote {
 		v.t.selectedVoteIndex.Observe(float64(voteIndex))
 		results = v.t.polls.Vote(v.requestID, v.vdr, vote)
@@ -72,9 +72,12 @@ func (v *voter) Update(ctx context.Context) {
 	for _, result := range results {
 		result := result
 		v.t.Ctx.Log.Debug("finishing poll",
-			zap.Stringer("result", &result),
+			zap.Uint32("requestID", result.RequestID),
+			zap.Stringer("result", &result.Votes),
 		)
-		if err := v.t.Consensus.RecordPoll(ctx, result); err != nil {
+		v.t.pollCompletionTime.Observe(result.Duration.Seconds())
+		v.t.PollTracer.Finished(result)
+		if err := v.t.Consensus.RecordPoll(ctx, result.Votes); err != nil {
 			v.t.errs.Add(err)
 		}
 	}
@@ -0,0 +1,220 @@
+// Copyright (C) 2019-2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+// Package replay feeds messages recorded by a peer.Recorder into a chain
+// handler, so that the sequence of messages a node received can be
+// reproduced after the fact.
+package replay
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"go.uber.org/zap"
+
+	"github.com/shubhamdubey02/cryftgo/message"
+	"github.com/shubhamdubey02/cryftgo/network/p2p"
+	"github.com/shubhamdubey02/cryftgo/network/peer"
+	"github.com/shubhamdubey02/cryftgo/snow"
+	"github.com/shubhamdubey02/cryftgo/snow/consensus/snowball"
+	"github.com/shubhamdubey02/cryftgo/snow/engine/common"
+	"github.com/shubhamdubey02/cryftgo/snow/engine/common/tracker"
+	"github.com/shubhamdubey02/cryftgo/snow/engine/snowman/block"
+	"github.com/shubhamdubey02/cryftgo/snow/engine/snowman/polltrace"
+	"github.com/shubhamdubey02/cryftgo/snow/networking/handler"
+	"github.com/shubhamdubey02/cryftgo/snow/networking/reputation"
+	"github.com/shubhamdubey02/cryftgo/snow/validators"
+	"github.com/shubhamdubey02/cryftgo/subnets"
+	"github.com/shubhamdubey02/cryftgo/utils/math/meter"
+	"github.com/shubhamdubey02/cryftgo/utils/resource"
+	"github.com/shubhamdubey02/cryftgo/version"
+
+	p2ppb "github.com/shubhamdubey02/cryftgo/proto/pb/p2p"
+	smcon "github.com/shubhamdubey02/cryftgo/snow/consensus/snowman"
+	smeng "github.com/shubhamdubey02/cryftgo/snow/engine/snowman"
+	snowgetter "github.com/shubhamdubey02/cryftgo/snow/engine/snowman/getter"
+	timetracker "github.com/shubhamdubey02/cryftgo/snow/networking/tracker"
+)
+
+const (
+	gossipFrequency           = time.Second
+	threadPoolSize            = 1
+	resourceTrackerHalflife   = time.Second
+	maxTimeGetAncestors       = time.Second
+	maxContainersGetAncestors = 2000
+)
+
+var _ common.BootstrapableEngine = (*bootstrapper)(nil)
+
+// Config describes the chain that a recording is replayed into.
+type Config struct {
+	// Ctx of the chain. Only messages sent to Ctx.ChainID are replayed.
+	Ctx *snow.ConsensusContext
+	// VM the chain runs. It must already be initialized.
+	VM block.ChainVM
+	// Sender receives the messages sent by the engine while replaying.
+	Sender     common.Sender
+	Validators validators.Manager
+	Params     snowball.Parameters
+}
+
+// NewHandler returns a handler that runs a snowman engine on top of
+// [config.VM]. The chain is assumed to be bootstrapped, so the engine starts in
+// normal operation once the handler is started.
+//
+// Messages sent by the VM to the engine are not delivered.
+func NewHandler(config Config) (handler.Handler, error) {
+	resourceTracker, err := timetracker.NewResourceTracker(
+		prometheus.NewRegistry(),
+		resource.NoUsage,
+		meter.ContinuousFactory{},
+		resourceTrackerHalflife,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	peerTracker, err := p2p.NewPeerTracker(
+		config.Ctx.Log,
+		"",
+		prometheus.NewRegistry(),
+		nil,
+		version.CurrentApp,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	h, err := handler.New(
+		config.Ctx,
+		config.Validators,
+		make(chan common.Message),
+		gossipFrequency,
+		threadPoolSize,
+		resourceTracker,
+		validators.UnhandledSubnetConnector,
+		subnets.New(config.Ctx.NodeID, subnets.Config{}),
+		tracker.NewPeers(),
+		peerTracker,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	getHandler, err := snowgetter.New(
+		config.VM,
+		config.Sender,
+		config.Ctx.Log,
+		maxTimeGetAncestors,
+		maxContainersGetAncestors,
+		config.Ctx.Registerer,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	engine, err := smeng.New(smeng.Config{
+		AllGetsServer:       getHandler,
+		Ctx:                 config.Ctx,
+		VM:                  config.VM,
+		Sender:              config.Sender,
+		Validators:          config.Validators,
+		ConnectedValidators: tracker.NewPeers(),
+		Params:              config.Params,
+		Consensus:           &smcon.Topological{},
+		Reputation:          reputation.NewNoTracker(),
+		PollTracer:          polltrace.NewNoTracer(),
+		LatencyTracker:      timetracker.NewLatencyTracker(time.Minute),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	h.SetEngineManager(&handler.EngineManager{
+		Snowman: &handler.Engine{
+			Bootstrapper: &bootstrapper{Engine: engine},
+			Consensus:    engine,
+		},
+	})
+	config.Ctx.State.Set(snow.EngineState{
+		Type:  p2ppb.EngineType_ENGINE_TYPE_SNOWMAN,
+		State: snow.Bootstrapping,
+	})
+	return h, nil
+}
+
+// bootstrapper skips bootstrapping by starting the consensus engine directly.
+type bootstrapper struct {
+	common.Engine
+}
+
+func (*bootstrapper) Clear(context.Context) error {
+	return nil
+}
+
+// Replay pushes the inbound messages in [records] that were sent to the chain
+// of [h] into [h], in order, and waits until they have all been handled. [h]
+// must have been started.
+//
+// Messages that can't be parsed, that aren't sent to a chain, or that are sent
+// to a different chain are skipped. Returns the number of messages replayed.
+func Replay(
+	ctx context.Context,
+	h handler.Handler,
+	parser message.InboundMsgBuilder,
+	records []*peer.Record,
+) (int, error) {
+	var (
+		chainCtx = h.Context()
+		handled  sync.WaitGroup
+		replayed int
+	)
+	for _, record := range records {
+		if record.Direction != peer.Inbound {
+			continue
+		}
+
+		handled.Add(1)
+		msg, err := parser.Parse(record.Bytes, record.NodeID, handled.Done)
+		if err != nil {
+			handled.Done()
+			chainCtx.Log.Debug("skipping message",
+				zap.Stringer("nodeID", record.NodeID),
+				zap.Time("time", record.Time),
+				zap.Error(err),
+			)
+			continue
+		}
+
+		m := msg.Message()
+		chainID, err := message.GetChainID(m)
+		if err != nil || chainID != chainCtx.ChainID {
+			msg.OnFinishedHandling()
+			continue
+		}
+
+		// Note: engineType is not guaranteed to be one of the explicitly named
+		// enum values. If it was not specified it defaults to UNSPECIFIED.
+		engineType, _ := message.GetEngineType(m)
+		h.Push(ctx, handler.Message{
+			InboundMessage: msg,
+			EngineType:     engineType,
+		})
+		replayed++
+	}
+
+	done := make(chan struct{})
+	go func() {
+		handled.Wait()
+		close(done)
+	}()
+
+	select {
+	case <-done:
+		return replayed, nil
+	case <-ctx.Done():
+		return replayed, ctx.Err()
+	}
+}
@@ -0,0 +1,139 @@
+// Copyright (C) 2019-2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package replay
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/stretchr/testify/require"
+
+	"github.com/shubhamdubey02/cryftgo/ids"
+	"github.com/shubhamdubey02/cryftgo/message"
+	"github.com/shubhamdubey02/cryftgo/network/peer"
+	"github.com/shubhamdubey02/cryftgo/snow/consensus/snowball"
+	"github.com/shubhamdubey02/cryftgo/snow/consensus/snowman"
+	"github.com/shubhamdubey02/cryftgo/snow/consensus/snowman/snowmantest"
+	"github.com/shubhamdubey02/cryftgo/snow/engine/common"
+	"github.com/shubhamdubey02/cryftgo/snow/engine/snowman/block"
+	"github.com/shubhamdubey02/cryftgo/snow/snowtest"
+	"github.com/shubhamdubey02/cryftgo/snow/validators"
+	"github.com/shubhamdubey02/cryftgo/utils/compression"
+	"github.com/shubhamdubey02/cryftgo/utils/logging"
+)
+
+var errUnknownBlock = errors.New("unknown block")
+
+func TestReplay(t *testing.T) {
+	require := require.New(t)
+
+	snowCtx := snowtest.Context(t, snowtest.CChainID)
+	ctx := snowtest.ConsensusContext(snowCtx)
+
+	nodeID := ids.GenerateTestNodeID()
+	vdrs := validators.NewManager()
+	require.NoError(vdrs.AddStaker(ctx.SubnetID, nodeID, nil, ids.Empty, 1))
+
+	blk := snowmantest.BuildChild(snowmantest.Genesis)
+	vm := &block.TestVM{
+		TestVM: common.TestVM{T: t},
+		LastAcceptedF: func(context.Context) (ids.ID, error) {
+			return snowmantest.GenesisID, nil
+		},
+		GetBlockF: func(_ context.Context, blkID ids.ID) (snowman.Block, error) {
+			switch blkID {
+			case snowmantest.GenesisID:
+				return snowmantest.Genesis, nil
+			case blk.ID():
+				return blk, nil
+			default:
+				return nil, errUnknownBlock
+			}
+		},
+		ParseBlockF: func(_ context.Context, blkBytes []byte) (snowman.Block, error) {
+			require.Equal(blk.Bytes(), blkBytes)
+			return blk, nil
+		},
+		SetPreferenceF: func(context.Context, ids.ID) error {
+			return nil
+		},
+	}
+
+	var chits []uint32
+	sender := &common.SenderTest{
+		SendChitsF: func(_ context.Context, gotNodeID ids.NodeID, requestID uint32, _ ids.ID, _ ids.ID, _ ids.ID) {
+			require.Equal(nodeID, gotNodeID)
+			chits = append(chits, requestID)
+		},
+	}
+
+	h, err := NewHandler(Config{
+		Ctx:        ctx,
+		VM:         vm,
+		Sender:     sender,
+		Validators: vdrs,
+		Params:     snowball.DefaultParameters,
+	})
+	require.NoError(err)
+	h.Start(context.Background(), false)
+
+	mc, err := message.NewCreator(
+		logging.NoLog{},
+		prometheus.NewRegistry(),
+		"",
+		compression.TypeZstd,
+		10*time.Second,
+	)
+	require.NoError(err)
+
+	pushQuery, err := mc.PushQuery(ctx.ChainID, 1, time.Minute, blk.Bytes(), 0)
+	require.NoError(err)
+	otherChainPushQuery, err := mc.PushQuery(ids.GenerateTestID(), 2, time.Minute, blk.Bytes(), 0)
+	require.NoError(err)
+	sentChits, err := mc.Chits(ctx.ChainID, 3, blk.ID(), blk.ID(), snowmantest.GenesisID)
+	require.NoError(err)
+	ping, err := mc.Ping(0, nil)
+	require.NoError(err)
+
+	records := []*peer.Record{
+		{
+			Direction: peer.Inbound,
+			NodeID:    nodeID,
+			Bytes:     pushQuery.Bytes(),
+		},
+		{
+			Direction: peer.Inbound,
+			NodeID:    nodeID,
+			Bytes:     otherChainPushQuery.Bytes(),
+		},
+		{
+			Direction: peer.Outbound,
+			NodeID:    nodeID,
+			Bytes:     sentChits.Bytes(),
+		},
+		{
+			Direction: peer.Inbound,
+			NodeID:    nodeID,
+			Bytes:     ping.Bytes(),
+		},
+		{
+			Direction: peer.Inbound,
+			NodeID:    nodeID,
+			Bytes:     bytes.Repeat([]byte{0xff}, 32),
+		},
+	}
+
+	replayed, err := Replay(context.Background(), h, mc, records)
+	require.NoError(err)
+	require.Equal(1, replayed)
+	require.Equal([]uint32{1}, chits)
+
+	h.Stop(context.Background())
+	_, err = h.AwaitStopped(context.Background())
+	require.NoError(err)
+}
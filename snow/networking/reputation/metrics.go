@@ -0,0 +1,49 @@
+// Copyright (C) 2019-2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package reputation
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+
+	"github.com/shubhamdubey02/cryftgo/utils"
+)
+
+const (
+	namespace    = "reputation"
+	offenseLabel = "offense"
+)
+
+type metrics struct {
+	offenses    *prometheus.CounterVec
+	disconnects prometheus.Counter
+	banned      prometheus.Gauge
+}
+
+func newMetrics(registerer prometheus.Registerer) (*metrics, error) {
+	m := &metrics{
+		offenses: prometheus.NewCounterVec(
+			prometheus.CounterOpts{
+				Namespace: namespace,
+				Name:      "offenses",
+				Help:      "Number of offenses reported (by offense)",
+			},
+			[]string{offenseLabel},
+		),
+		disconnects: prometheus.NewCounter(prometheus.CounterOpts{
+			Namespace: namespace,
+			Name:      "disconnects",
+			Help:      "Number of times a peer was disconnected because of its score",
+		}),
+		banned: prometheus.NewGauge(prometheus.GaugeOpts{
+			Namespace: namespace,
+			Name:      "banned",
+			Help:      "Number of currently banned peers",
+		}),
+	}
+	return m, utils.Err(
+		registerer.Register(m.offenses),
+		registerer.Register(m.disconnects),
+		registerer.Register(m.banned),
+	)
+}
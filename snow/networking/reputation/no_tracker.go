@@ -0,0 +1,36 @@
+// Copyright (C) 2019-2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package reputation
+
+import (
+	"time"
+
+	"github.com/shubhamdubey02/cryftgo/ids"
+)
+
+var _ Tracker = noTracker{}
+
+type noTracker struct{}
+
+// NewNoTracker returns a tracker that ignores all reported offenses and never
+// bans any peers
+func NewNoTracker() Tracker {
+	return noTracker{}
+}
+
+func (noTracker) Report(ids.NodeID, Offense) {}
+
+func (noTracker) Score(ids.NodeID) float64 {
+	return 0
+}
+
+func (noTracker) BannedUntil(ids.NodeID) (time.Time, bool) {
+	return time.Time{}, false
+}
+
+func (noTracker) Bans() map[ids.NodeID]time.Time {
+	return map[ids.NodeID]time.Time{}
+}
+
+func (noTracker) RegisterListener(Listener) {}
@@ -0,0 +1,148 @@
+// Copyright (C) 2019-2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package reputation
+
+import (
+	"errors"
+	"time"
+
+	"github.com/shubhamdubey02/cryftgo/ids"
+)
+
+var (
+	errNegativePenalty     = errors.New("penalty must be >= 0")
+	errNonPositiveHalflife = errors.New("halflife must be > 0")
+	errNegativeThreshold   = errors.New("threshold must be >= 0")
+	errNegativeBanDuration = errors.New("ban duration must be >= 0")
+	errBanBelowDisconnect  = errors.New("ban threshold must be >= disconnect threshold")
+)
+
+// Reporter is notified of misbehavior by peers.
+type Reporter interface {
+	// Report that [nodeID] committed [offense].
+	Report(nodeID ids.NodeID, offense Offense)
+}
+
+// Listener is notified when a peer should be disconnected because of its
+// misbehavior.
+type Listener interface {
+	// Misbehaving is called when the score of [nodeID] reaches the disconnect
+	// threshold or the ban threshold.
+	Misbehaving(nodeID ids.NodeID)
+}
+
+// Tracker tracks the reputation of peers and bans peers that misbehave too
+// often.
+type Tracker interface {
+	Reporter
+
+	// Score returns the current score of [nodeID]. A higher score means that
+	// [nodeID] misbehaved more often or more recently.
+	Score(nodeID ids.NodeID) float64
+
+	// BannedUntil returns the time at which the ban of [nodeID] expires, and
+	// false if [nodeID] isn't banned.
+	BannedUntil(nodeID ids.NodeID) (time.Time, bool)
+
+	// Bans returns the expiry time of every current ban.
+	Bans() map[ids.NodeID]time.Time
+
+	// RegisterListener registers [listener] to be notified when a peer should
+	// be disconnected.
+	RegisterListener(listener Listener)
+}
+
+// Offense is a kind of misbehavior that lowers the reputation of a peer.
+type Offense byte
+
+const (
+	// InvalidMessage is reported when a peer sends a message that can't be
+	// parsed.
+	InvalidMessage Offense = iota
+	// InvalidBlock is reported when a peer sends a block that can't be parsed.
+	InvalidBlock
+	// InvalidSignature is reported when a peer sends an invalid signature.
+	InvalidSignature
+	// InvalidAppRequest is reported when a p2p handler fails to handle a
+	// request sent by a peer.
+	InvalidAppRequest
+)
+
+func (o Offense) String() string {
+	switch o {
+	case InvalidMessage:
+		return "invalid_message"
+	case InvalidBlock:
+		return "invalid_block"
+	case InvalidSignature:
+		return "invalid_signature"
+	case InvalidAppRequest:
+		return "invalid_app_request"
+	default:
+		return "unknown"
+	}
+}
+
+// Config describes how misbehavior affects the score of a peer.
+//
+// Every reported offense adds its penalty to the score of the peer, and the
+// score decays exponentially over time. A peer whose score is 0 has not
+// misbehaved recently.
+type Config struct {
+	// Penalties added to the score of a peer when it commits an offense.
+	InvalidMessagePenalty    float64 `json:"invalidMessagePenalty"`
+	InvalidBlockPenalty      float64 `json:"invalidBlockPenalty"`
+	InvalidSignaturePenalty  float64 `json:"invalidSignaturePenalty"`
+	InvalidAppRequestPenalty float64 `json:"invalidAppRequestPenalty"`
+
+	// Halflife is the time it takes for the score of a peer to halve.
+	Halflife time.Duration `json:"halflife"`
+
+	// Peers whose score reaches DisconnectThreshold are disconnected. If 0,
+	// peers are never disconnected because of their score. Validators of the
+	// primary network are never disconnected because of their score.
+	DisconnectThreshold float64 `json:"disconnectThreshold"`
+
+	// Peers whose score reaches BanThreshold are disconnected and banned for
+	// BanDuration. Bans are persisted across restarts. If 0, peers are never
+	// banned. Validators of the primary network are never banned.
+	BanThreshold float64       `json:"banThreshold"`
+	BanDuration  time.Duration `json:"banDuration"`
+}
+
+// Verify returns an error if the config is invalid.
+func (c *Config) Verify() error {
+	switch {
+	case c.InvalidMessagePenalty < 0,
+		c.InvalidBlockPenalty < 0,
+		c.InvalidSignaturePenalty < 0,
+		c.InvalidAppRequestPenalty < 0:
+		return errNegativePenalty
+	case c.Halflife <= 0:
+		return errNonPositiveHalflife
+	case c.DisconnectThreshold < 0 || c.BanThreshold < 0:
+		return errNegativeThreshold
+	case c.BanDuration < 0:
+		return errNegativeBanDuration
+	case c.BanThreshold != 0 && c.DisconnectThreshold != 0 && c.BanThreshold < c.DisconnectThreshold:
+		return errBanBelowDisconnect
+	default:
+		return nil
+	}
+}
+
+func (c *Config) penalty(offense Offense) float64 {
+	switch offense {
+	case InvalidMessage:
+		return c.InvalidMessagePenalty
+	case InvalidBlock:
+		return c.InvalidBlockPenalty
+	case InvalidSignature:
+		return c.InvalidSignaturePenalty
+	case InvalidAppRequest:
+		return c.InvalidAppRequestPenalty
+	default:
+		return 0
+	}
+}
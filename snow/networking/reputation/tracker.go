@@ -0,0 +1,283 @@
+// Copyright (C) 2019-2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package reputation
+
+import (
+	"math"
+	"sync"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"go.uber.org/zap"
+
+	"github.com/shubhamdubey02/cryftgo/database"
+	"github.com/shubhamdubey02/cryftgo/ids"
+	"github.com/shubhamdubey02/cryftgo/snow/validators"
+	"github.com/shubhamdubey02/cryftgo/utils/constants"
+	"github.com/shubhamdubey02/cryftgo/utils/logging"
+	"github.com/shubhamdubey02/cryftgo/utils/timer/mockable"
+)
+
+// Scores below minScore are treated as 0 and are no longer tracked.
+const minScore = 1e-3
+
+var _ Tracker = (*tracker)(nil)
+
+type score struct {
+	value       float64
+	lastUpdated time.Time
+}
+
+type tracker struct {
+	config  Config
+	log     logging.Logger
+	metrics *metrics
+
+	// Validators of the primary network are never disconnected or banned
+	vdrs validators.Manager
+
+	// Stores the expiry time of every ban, keyed by NodeID
+	db database.Database
+
+	// Tells the time. Can be faked for testing.
+	clock mockable.Clock
+
+	lock      sync.Mutex
+	scores    map[ids.NodeID]*score
+	bans      map[ids.NodeID]time.Time
+	listeners []Listener
+}
+
+// NewTracker returns a new reputation tracker. Bans are persisted in [db], and
+// bans that were persisted by a previous tracker are restored. Current
+// validators of the primary network in [vdrs] are exempt from disconnects and
+// bans.
+func NewTracker(
+	config Config,
+	vdrs validators.Manager,
+	db database.Database,
+	log logging.Logger,
+	registerer prometheus.Registerer,
+) (Tracker, error) {
+	if err := config.Verify(); err != nil {
+		return nil, err
+	}
+
+	metrics, err := newMetrics(registerer)
+	if err != nil {
+		return nil, err
+	}
+
+	t := &tracker{
+		config:  config,
+		log:     log,
+		metrics: metrics,
+		vdrs:    vdrs,
+		db:      db,
+		scores:  make(map[ids.NodeID]*score),
+		bans:    make(map[ids.NodeID]time.Time),
+	}
+	return t, t.loadBans()
+}
+
+// loadBans restores the bans persisted in the database and deletes the ones
+// that have expired.
+func (t *tracker) loadBans() error {
+	it := t.db.NewIterator()
+	defer it.Release()
+
+	var (
+		now     = t.clock.Time()
+		expired [][]byte
+	)
+	for it.Next() {
+		key := it.Key()
+		nodeID, err := ids.ToNodeID(key)
+		if err != nil {
+			return err
+		}
+		until, err := database.ParseTimestamp(it.Value())
+		if err != nil {
+			return err
+		}
+
+		if !now.Before(until) {
+			expired = append(expired, key)
+			continue
+		}
+		t.bans[nodeID] = until
+	}
+	if err := it.Error(); err != nil {
+		return err
+	}
+
+	for _, key := range expired {
+		if err := t.db.Delete(key); err != nil {
+			return err
+		}
+	}
+
+	t.metrics.banned.Set(float64(len(t.bans)))
+	return nil
+}
+
+func (t *tracker) Report(nodeID ids.NodeID, offense Offense) {
+	t.metrics.offenses.WithLabelValues(offense.String()).Inc()
+
+	penalty := t.config.penalty(offense)
+	if penalty == 0 {
+		return
+	}
+
+	t.lock.Lock()
+	now := t.clock.Time()
+	s, ok := t.scores[nodeID]
+	if !ok {
+		s = &score{}
+		t.scores[nodeID] = s
+	}
+	s.value = t.decay(s, now) + penalty
+	s.lastUpdated = now
+
+	if t.isValidator(nodeID) {
+		t.lock.Unlock()
+		return
+	}
+
+	misbehaving := t.config.DisconnectThreshold > 0 && s.value >= t.config.DisconnectThreshold
+	if t.config.BanThreshold > 0 && s.value >= t.config.BanThreshold {
+		misbehaving = true
+		if _, banned := t.bannedUntil(nodeID, now); !banned {
+			t.ban(nodeID, now, s.value)
+		}
+	}
+	listeners := t.listeners
+	t.lock.Unlock()
+
+	if !misbehaving {
+		return
+	}
+
+	t.log.Debug("disconnecting misbehaving peer",
+		zap.Stringer("nodeID", nodeID),
+		zap.Stringer("offense", offense),
+	)
+	t.metrics.disconnects.Inc()
+	for _, listener := range listeners {
+		listener.Misbehaving(nodeID)
+	}
+}
+
+func (t *tracker) Score(nodeID ids.NodeID) float64 {
+	t.lock.Lock()
+	defer t.lock.Unlock()
+
+	s, ok := t.scores[nodeID]
+	if !ok {
+		return 0
+	}
+
+	value := t.decay(s, t.clock.Time())
+	if value < minScore {
+		delete(t.scores, nodeID)
+		return 0
+	}
+	return value
+}
+
+func (t *tracker) BannedUntil(nodeID ids.NodeID) (time.Time, bool) {
+	if t.isValidator(nodeID) {
+		// A peer may have become a validator after it was banned.
+		return time.Time{}, false
+	}
+
+	t.lock.Lock()
+	defer t.lock.Unlock()
+
+	return t.bannedUntil(nodeID, t.clock.Time())
+}
+
+func (t *tracker) Bans() map[ids.NodeID]time.Time {
+	t.lock.Lock()
+	defer t.lock.Unlock()
+
+	now := t.clock.Time()
+	bans := make(map[ids.NodeID]time.Time, len(t.bans))
+	for nodeID := range t.bans {
+		if t.isValidator(nodeID) {
+			continue
+		}
+		if until, banned := t.bannedUntil(nodeID, now); banned {
+			bans[nodeID] = until
+		}
+	}
+	return bans
+}
+
+func (t *tracker) RegisterListener(listener Listener) {
+	t.lock.Lock()
+	defer t.lock.Unlock()
+
+	t.listeners = append(t.listeners, listener)
+}
+
+func (t *tracker) isValidator(nodeID ids.NodeID) bool {
+	return t.vdrs.GetWeight(constants.PrimaryNetworkID, nodeID) > 0
+}
+
+// decay returns the value of [s] at [now].
+//
+// Assumes [t.lock] is held.
+func (t *tracker) decay(s *score, now time.Time) float64 {
+	elapsed := now.Sub(s.lastUpdated)
+	if elapsed <= 0 {
+		return s.value
+	}
+	return s.value * math.Exp2(-float64(elapsed)/float64(t.config.Halflife))
+}
+
+// bannedUntil returns the expiry time of the ban of [nodeID], if [nodeID] is
+// banned at [now]. Expired bans are removed.
+//
+// Assumes [t.lock] is held.
+func (t *tracker) bannedUntil(nodeID ids.NodeID, now time.Time) (time.Time, bool) {
+	until, ok := t.bans[nodeID]
+	if !ok {
+		return time.Time{}, false
+	}
+	if now.Before(until) {
+		return until, true
+	}
+
+	delete(t.bans, nodeID)
+	t.metrics.banned.Set(float64(len(t.bans)))
+	if err := t.db.Delete(nodeID.Bytes()); err != nil {
+		t.log.Error("failed to delete expired ban",
+			zap.Stringer("nodeID", nodeID),
+			zap.Error(err),
+		)
+	}
+	return time.Time{}, false
+}
+
+// ban bans [nodeID] for the configured ban duration starting at [now].
+//
+// Assumes [t.lock] is held.
+func (t *tracker) ban(nodeID ids.NodeID, now time.Time, score float64) {
+	until := now.Add(t.config.BanDuration)
+	t.bans[nodeID] = until
+	t.metrics.banned.Set(float64(len(t.bans)))
+
+	t.log.Info("banning misbehaving peer",
+		zap.Stringer("nodeID", nodeID),
+		zap.Float64("score", score),
+		zap.Time("until", until),
+	)
+	if err := database.PutTimestamp(t.db, nodeID.Bytes(), until); err != nil {
+		t.log.Error("failed to persist ban",
+			zap.Stringer("nodeID", nodeID),
+			zap.Error(err),
+		)
+	}
+}
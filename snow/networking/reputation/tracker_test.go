@@ -0,0 +1,253 @@
+// Copyright (C) 2019-2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package reputation
+
+import (
+	"testing"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/stretchr/testify/require"
+
+	"github.com/shubhamdubey02/cryftgo/database/memdb"
+	"github.com/shubhamdubey02/cryftgo/ids"
+	"github.com/shubhamdubey02/cryftgo/snow/validators"
+	"github.com/shubhamdubey02/cryftgo/utils/constants"
+	"github.com/shubhamdubey02/cryftgo/utils/logging"
+	"github.com/shubhamdubey02/cryftgo/utils/set"
+)
+
+var testConfig = Config{
+	InvalidMessagePenalty:    1,
+	InvalidSignaturePenalty:  10,
+	InvalidAppRequestPenalty: 1,
+	Halflife:                 time.Minute,
+	DisconnectThreshold:      10,
+	BanThreshold:             20,
+	BanDuration:              time.Hour,
+}
+
+type testListener struct {
+	misbehaving set.Set[ids.NodeID]
+}
+
+func (l *testListener) Misbehaving(nodeID ids.NodeID) {
+	l.misbehaving.Add(nodeID)
+}
+
+func newTestTracker(t *testing.T, db *memdb.Database, now time.Time) *tracker {
+	require := require.New(t)
+
+	tr := &tracker{
+		config: testConfig,
+		log:    logging.NoLog{},
+		vdrs:   validators.NewManager(),
+		db:     db,
+		scores: make(map[ids.NodeID]*score),
+		bans:   make(map[ids.NodeID]time.Time),
+	}
+	var err error
+	tr.metrics, err = newMetrics(prometheus.NewRegistry())
+	require.NoError(err)
+
+	tr.clock.Set(now)
+	require.NoError(tr.loadBans())
+	return tr
+}
+
+func TestConfigVerify(t *testing.T) {
+	tests := []struct {
+		name        string
+		modify      func(*Config)
+		expectedErr error
+	}{
+		{
+			name:   "valid",
+			modify: func(*Config) {},
+		},
+		{
+			name: "negative penalty",
+			modify: func(c *Config) {
+				c.InvalidSignaturePenalty = -1
+			},
+			expectedErr: errNegativePenalty,
+		},
+		{
+			name: "zero halflife",
+			modify: func(c *Config) {
+				c.Halflife = 0
+			},
+			expectedErr: errNonPositiveHalflife,
+		},
+		{
+			name: "negative threshold",
+			modify: func(c *Config) {
+				c.DisconnectThreshold = -1
+			},
+			expectedErr: errNegativeThreshold,
+		},
+		{
+			name: "negative ban duration",
+			modify: func(c *Config) {
+				c.BanDuration = -1
+			},
+			expectedErr: errNegativeBanDuration,
+		},
+		{
+			name: "ban threshold below disconnect threshold",
+			modify: func(c *Config) {
+				c.BanThreshold = 5
+			},
+			expectedErr: errBanBelowDisconnect,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			config := testConfig
+			test.modify(&config)
+			require.ErrorIs(t, config.Verify(), test.expectedErr)
+		})
+	}
+}
+
+func TestTrackerScoreDecays(t *testing.T) {
+	require := require.New(t)
+
+	now := time.Unix(1000, 0)
+	tr := newTestTracker(t, memdb.New(), now)
+	nodeID := ids.GenerateTestNodeID()
+
+	require.Zero(tr.Score(nodeID))
+
+	tr.Report(nodeID, InvalidSignature)
+	require.InDelta(10, tr.Score(nodeID), 1e-9)
+
+	// After one halflife, the score should have halved.
+	tr.clock.Set(now.Add(testConfig.Halflife))
+	require.InDelta(5, tr.Score(nodeID), 1e-9)
+
+	tr.Report(nodeID, InvalidMessage)
+	require.InDelta(6, tr.Score(nodeID), 1e-9)
+
+	// Eventually, the score should be forgotten.
+	tr.clock.Set(now.Add(100 * testConfig.Halflife))
+	require.Zero(tr.Score(nodeID))
+	require.NotContains(tr.scores, nodeID)
+}
+
+func TestTrackerDisconnectAndBan(t *testing.T) {
+	require := require.New(t)
+
+	now := time.Unix(1000, 0)
+	db := memdb.New()
+	tr := newTestTracker(t, db, now)
+	listener := &testListener{}
+	tr.RegisterListener(listener)
+
+	nodeID := ids.GenerateTestNodeID()
+
+	// Below the disconnect threshold
+	tr.Report(nodeID, InvalidMessage)
+	require.False(listener.misbehaving.Contains(nodeID))
+
+	// Reaching the disconnect threshold
+	tr.Report(nodeID, InvalidSignature)
+	require.True(listener.misbehaving.Contains(nodeID))
+	_, banned := tr.BannedUntil(nodeID)
+	require.False(banned)
+
+	// Reaching the ban threshold
+	tr.Report(nodeID, InvalidSignature)
+	until, banned := tr.BannedUntil(nodeID)
+	require.True(banned)
+	require.Equal(now.Add(testConfig.BanDuration), until)
+	require.Equal(map[ids.NodeID]time.Time{nodeID: until}, tr.Bans())
+
+	// The ban should be restored by a new tracker.
+	restored := newTestTracker(t, db, now.Add(time.Minute))
+	until, banned = restored.BannedUntil(nodeID)
+	require.True(banned)
+	require.Equal(now.Add(testConfig.BanDuration), until)
+
+	// The ban should expire.
+	restored.clock.Set(until)
+	_, banned = restored.BannedUntil(nodeID)
+	require.False(banned)
+	require.Empty(restored.Bans())
+
+	has, err := db.Has(nodeID.Bytes())
+	require.NoError(err)
+	require.False(has)
+}
+
+func TestTrackerDropsExpiredBans(t *testing.T) {
+	require := require.New(t)
+
+	now := time.Unix(1000, 0)
+	db := memdb.New()
+	tr := newTestTracker(t, db, now)
+
+	nodeID := ids.GenerateTestNodeID()
+	tr.Report(nodeID, InvalidSignature)
+	tr.Report(nodeID, InvalidSignature)
+	_, banned := tr.BannedUntil(nodeID)
+	require.True(banned)
+
+	restored := newTestTracker(t, db, now.Add(testConfig.BanDuration))
+	require.Empty(restored.bans)
+
+	has, err := db.Has(nodeID.Bytes())
+	require.NoError(err)
+	require.False(has)
+}
+
+func TestTrackerDisabledThresholds(t *testing.T) {
+	require := require.New(t)
+
+	tr := newTestTracker(t, memdb.New(), time.Unix(1000, 0))
+	tr.config.DisconnectThreshold = 0
+	tr.config.BanThreshold = 0
+	listener := &testListener{}
+	tr.RegisterListener(listener)
+
+	nodeID := ids.GenerateTestNodeID()
+	for i := 0; i < 10; i++ {
+		tr.Report(nodeID, InvalidSignature)
+	}
+	require.Empty(listener.misbehaving)
+	require.Empty(tr.Bans())
+}
+
+func TestTrackerExemptsValidators(t *testing.T) {
+	require := require.New(t)
+
+	now := time.Unix(1000, 0)
+	db := memdb.New()
+	tr := newTestTracker(t, db, now)
+	listener := &testListener{}
+	tr.RegisterListener(listener)
+
+	nodeID := ids.GenerateTestNodeID()
+	require.NoError(tr.vdrs.AddStaker(constants.PrimaryNetworkID, nodeID, nil, ids.Empty, 1))
+
+	// Validators are scored, but never disconnected or banned.
+	tr.Report(nodeID, InvalidSignature)
+	tr.Report(nodeID, InvalidSignature)
+	require.InDelta(20, tr.Score(nodeID), 1e-9)
+	require.Empty(listener.misbehaving)
+	_, banned := tr.BannedUntil(nodeID)
+	require.False(banned)
+
+	// A peer that was banned before it became a validator is no longer banned.
+	bannedID := ids.GenerateTestNodeID()
+	tr.Report(bannedID, InvalidSignature)
+	tr.Report(bannedID, InvalidSignature)
+	_, banned = tr.BannedUntil(bannedID)
+	require.True(banned)
+
+	require.NoError(tr.vdrs.AddStaker(constants.PrimaryNetworkID, bannedID, nil, ids.Empty, 1))
+	_, banned = tr.BannedUntil(bannedID)
+	require.False(banned)
+	require.Empty(tr.Bans())
+}
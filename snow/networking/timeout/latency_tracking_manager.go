@@ -0,0 +1,61 @@
+// Copyright (C) 2019-2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package timeout
+
+import (
+	"time"
+
+	"github.com/shubhamdubey02/cryftgo/ids"
+	"github.com/shubhamdubey02/cryftgo/message"
+	"github.com/shubhamdubey02/cryftgo/snow/networking/tracker"
+)
+
+var _ Manager = (*latencyTrackingManager)(nil)
+
+// latencyTrackingManager reports how long peers take to respond to consensus
+// queries to a latency tracker. A query that times out is reported as taking
+// the current timeout duration.
+type latencyTrackingManager struct {
+	Manager
+	latencyTracker tracker.LatencyTracker
+}
+
+// NewLatencyTrackingManager returns a manager that wraps [manager] and reports
+// the latencies of the responses to consensus queries to [latencyTracker].
+func NewLatencyTrackingManager(manager Manager, latencyTracker tracker.LatencyTracker) Manager {
+	return &latencyTrackingManager{
+		Manager:        manager,
+		latencyTracker: latencyTracker,
+	}
+}
+
+func (m *latencyTrackingManager) RegisterRequest(
+	nodeID ids.NodeID,
+	chainID ids.ID,
+	measureLatency bool,
+	requestID ids.RequestID,
+	timeoutHandler func(),
+) {
+	if requestID.Op == byte(message.ChitsOp) {
+		handler := timeoutHandler
+		timeoutHandler = func() {
+			m.latencyTracker.Observe(nodeID, m.Manager.TimeoutDuration())
+			handler()
+		}
+	}
+	m.Manager.RegisterRequest(nodeID, chainID, measureLatency, requestID, timeoutHandler)
+}
+
+func (m *latencyTrackingManager) RegisterResponse(
+	nodeID ids.NodeID,
+	chainID ids.ID,
+	requestID ids.RequestID,
+	op message.Op,
+	latency time.Duration,
+) {
+	if op == message.ChitsOp {
+		m.latencyTracker.Observe(nodeID, latency)
+	}
+	m.Manager.RegisterResponse(nodeID, chainID, requestID, op, latency)
+}
@@ -0,0 +1,87 @@
+// Copyright (C) 2019-2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package timeout
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+
+	"github.com/shubhamdubey02/cryftgo/ids"
+	"github.com/shubhamdubey02/cryftgo/message"
+	"github.com/shubhamdubey02/cryftgo/snow/networking/tracker"
+)
+
+func TestLatencyTrackingManagerResponses(t *testing.T) {
+	require := require.New(t)
+	ctrl := gomock.NewController(t)
+
+	nodeID := ids.GenerateTestNodeID()
+	chainID := ids.GenerateTestID()
+	requestID := ids.RequestID{
+		NodeID:             nodeID,
+		SourceChainID:      chainID,
+		DestinationChainID: chainID,
+		RequestID:          1,
+	}
+
+	innerManager := NewMockManager(ctrl)
+	latencyTracker := tracker.NewLatencyTracker(time.Minute)
+	manager := NewLatencyTrackingManager(innerManager, latencyTracker)
+
+	// Responses to other requests than queries aren't tracked.
+	innerManager.EXPECT().RegisterResponse(nodeID, chainID, requestID, message.AncestorsOp, time.Second)
+	manager.RegisterResponse(nodeID, chainID, requestID, message.AncestorsOp, time.Second)
+	_, ok := latencyTracker.Latency(nodeID)
+	require.False(ok)
+
+	innerManager.EXPECT().RegisterResponse(nodeID, chainID, requestID, message.ChitsOp, time.Second)
+	manager.RegisterResponse(nodeID, chainID, requestID, message.ChitsOp, time.Second)
+	latency, ok := latencyTracker.Latency(nodeID)
+	require.True(ok)
+	require.Equal(time.Second, latency)
+}
+
+func TestLatencyTrackingManagerTimeouts(t *testing.T) {
+	require := require.New(t)
+	ctrl := gomock.NewController(t)
+
+	nodeID := ids.GenerateTestNodeID()
+	chainID := ids.GenerateTestID()
+	requestID := ids.RequestID{
+		NodeID:             nodeID,
+		SourceChainID:      chainID,
+		DestinationChainID: chainID,
+		RequestID:          1,
+		Op:                 byte(message.ChitsOp),
+	}
+
+	innerManager := NewMockManager(ctrl)
+	latencyTracker := tracker.NewLatencyTracker(time.Minute)
+	manager := NewLatencyTrackingManager(innerManager, latencyTracker)
+
+	var timeoutHandler func()
+	innerManager.EXPECT().RegisterRequest(nodeID, chainID, true, requestID, gomock.Any()).DoAndReturn(
+		func(_ ids.NodeID, _ ids.ID, _ bool, _ ids.RequestID, handler func()) {
+			timeoutHandler = handler
+		},
+	)
+	innerManager.EXPECT().TimeoutDuration().Return(2 * time.Second)
+
+	timedOut := false
+	manager.RegisterRequest(nodeID, chainID, true, requestID, func() {
+		timedOut = true
+	})
+	_, ok := latencyTracker.Latency(nodeID)
+	require.False(ok)
+
+	// A query that times out is tracked as taking the timeout duration.
+	timeoutHandler()
+	require.True(timedOut)
+	latency, ok := latencyTracker.Latency(nodeID)
+	require.True(ok)
+	require.Equal(2*time.Second, latency)
+}
@@ -0,0 +1,69 @@
+// Copyright (C) 2019-2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package tracker
+
+import (
+	"sync"
+	"time"
+
+	"github.com/shubhamdubey02/cryftgo/ids"
+	"github.com/shubhamdubey02/cryftgo/utils/math"
+	"github.com/shubhamdubey02/cryftgo/utils/timer/mockable"
+)
+
+var _ LatencyTracker = (*latencyTracker)(nil)
+
+// LatencyTracker tracks how long peers take to respond to our queries.
+type LatencyTracker interface {
+	// Observe that [nodeID] responded to a query after [latency]. Queries that
+	// time out should be observed with the timeout as their latency.
+	Observe(nodeID ids.NodeID, latency time.Duration)
+	// Latency returns the average latency of the responses of [nodeID].
+	// Returns false if no response of [nodeID] was observed.
+	Latency(nodeID ids.NodeID) (time.Duration, bool)
+}
+
+type latencyTracker struct {
+	halflife time.Duration
+
+	// Tells the time. Can be faked for testing.
+	clock mockable.Clock
+
+	lock sync.Mutex
+	// Node ID --> Average latency of the node's responses
+	latencies map[ids.NodeID]math.Averager
+}
+
+// NewLatencyTracker returns a tracker that averages the latencies of each peer
+// with an exponential moving average with the given [halflife].
+func NewLatencyTracker(halflife time.Duration) LatencyTracker {
+	return &latencyTracker{
+		halflife:  halflife,
+		latencies: make(map[ids.NodeID]math.Averager),
+	}
+}
+
+func (t *latencyTracker) Observe(nodeID ids.NodeID, latency time.Duration) {
+	t.lock.Lock()
+	defer t.lock.Unlock()
+
+	now := t.clock.Time()
+	averager, ok := t.latencies[nodeID]
+	if !ok {
+		t.latencies[nodeID] = math.NewAverager(float64(latency), t.halflife, now)
+		return
+	}
+	averager.Observe(float64(latency), now)
+}
+
+func (t *latencyTracker) Latency(nodeID ids.NodeID) (time.Duration, bool) {
+	t.lock.Lock()
+	defer t.lock.Unlock()
+
+	averager, ok := t.latencies[nodeID]
+	if !ok {
+		return 0, false
+	}
+	return time.Duration(averager.Read()), true
+}
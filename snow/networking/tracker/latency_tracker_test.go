@@ -0,0 +1,52 @@
+// Copyright (C) 2019-2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package tracker
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/shubhamdubey02/cryftgo/ids"
+)
+
+func TestLatencyTracker(t *testing.T) {
+	require := require.New(t)
+
+	trackerIntf := NewLatencyTracker(time.Minute)
+	require.IsType(&latencyTracker{}, trackerIntf)
+	tracker := trackerIntf.(*latencyTracker)
+	now := time.Now()
+	tracker.clock.Set(now)
+
+	nodeID0 := ids.GenerateTestNodeID()
+	nodeID1 := ids.GenerateTestNodeID()
+
+	_, ok := tracker.Latency(nodeID0)
+	require.False(ok)
+
+	tracker.Observe(nodeID0, 100*time.Millisecond)
+	latency, ok := tracker.Latency(nodeID0)
+	require.True(ok)
+	require.Equal(100*time.Millisecond, latency)
+
+	// Observations at the same time are weighted equally.
+	tracker.Observe(nodeID0, 300*time.Millisecond)
+	latency, ok = tracker.Latency(nodeID0)
+	require.True(ok)
+	require.Equal(200*time.Millisecond, latency)
+
+	// Latencies are tracked per node.
+	_, ok = tracker.Latency(nodeID1)
+	require.False(ok)
+
+	// Recent observations are weighted more than older ones.
+	tracker.clock.Set(now.Add(time.Minute))
+	tracker.Observe(nodeID0, time.Second)
+	latency, ok = tracker.Latency(nodeID0)
+	require.True(ok)
+	require.Greater(latency, 500*time.Millisecond)
+	require.Less(latency, time.Second)
+}
@@ -11,6 +11,7 @@ import (
 	"github.com/shubhamdubey02/cryftgo/ids"
 	"github.com/shubhamdubey02/cryftgo/snow/consensus/snowball"
 	"github.com/shubhamdubey02/cryftgo/utils/set"
+	"github.com/shubhamdubey02/cryftgo/vms/proposervm/proposer"
 )
 
 var errAllowedNodesWhenNotValidatorOnly = errors.New("allowedNodes can only be set when ValidatorOnly is true")
@@ -49,6 +50,27 @@ type Config struct {
 	// TODO: Move this flag once the proposervm is configurable on a per-chain
 	// basis.
 	ProposerNumHistoricalBlocks uint64 `json:"proposerNumHistoricalBlocks" yaml:"proposerNumHistoricalBlocks"`
+	// ProposerPolicy selects the validators that are allowed to propose
+	// snowman++ blocks, and when. Every validator of this Subnet must use the
+	// same policy. If empty, proposers are sampled weighted by stake.
+	ProposerPolicy string `json:"proposerPolicy" yaml:"proposerPolicy"`
+	// ProposerLeaderSlots is the number of slots the leader is the only
+	// proposer of a block when ProposerPolicy is "leader".
+	ProposerLeaderSlots uint64 `json:"proposerLeaderSlots" yaml:"proposerLeaderSlots"`
+	// ProposerMinUptime is the minimum uptime, in [0, 1], of a proposer when
+	// ProposerPolicy is "uptime".
+	ProposerMinUptime float64 `json:"proposerMinUptime" yaml:"proposerMinUptime"`
+
+	// InboundMsgByteQuota is the maximum number of bytes of messages sent to
+	// this Subnet's chains by peers that this node will process at once.
+	// Messages received once the quota is used are dropped. If 0, there is no
+	// quota.
+	InboundMsgByteQuota uint64 `json:"inboundMsgByteQuota" yaml:"inboundMsgByteQuota"`
+	// OutboundMsgByteQuota is the maximum number of bytes of messages sent by
+	// this Subnet's chains that this node will queue to be sent at once.
+	// Messages sent once the quota is used are dropped. If 0, there is no
+	// quota.
+	OutboundMsgByteQuota uint64 `json:"outboundMsgByteQuota" yaml:"outboundMsgByteQuota"`
 }
 
 func (c *Config) Valid() error {
@@ -58,5 +80,15 @@ func (c *Config) Valid() error {
 	if !c.ValidatorOnly && c.AllowedNodes.Len() > 0 {
 		return errAllowedNodesWhenNotValidatorOnly
 	}
+	// The uptime source is only known once the chains are created.
+	if _, err := c.Policy(nil); err != nil && !errors.Is(err, proposer.ErrNoUptimeSource) {
+		return err
+	}
 	return nil
 }
+
+// Policy returns the proposer policy of this Subnet's chains. [uptimes] is only
+// used if ProposerPolicy is "uptime".
+func (c *Config) Policy(uptimes proposer.UptimeState) (proposer.Policy, error) {
+	return proposer.NewPolicy(c.ProposerPolicy, c.ProposerLeaderSlots, c.ProposerMinUptime, uptimes)
+}
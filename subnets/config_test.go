@@ -11,6 +11,7 @@ import (
 	"github.com/shubhamdubey02/cryftgo/ids"
 	"github.com/shubhamdubey02/cryftgo/snow/consensus/snowball"
 	"github.com/shubhamdubey02/cryftgo/utils/set"
+	"github.com/shubhamdubey02/cryftgo/vms/proposervm/proposer"
 )
 
 var validParameters = snowball.Parameters{
@@ -49,6 +50,32 @@ func TestValid(t *testing.T) {
 			},
 			expectedErr: errAllowedNodesWhenNotValidatorOnly,
 		},
+		{
+			name: "invalid proposer policy",
+			s: Config{
+				ConsensusParameters: validParameters,
+				ProposerPolicy:      "unknown",
+			},
+			expectedErr: proposer.ErrUnknownPolicy,
+		},
+		{
+			name: "invalid proposer min uptime",
+			s: Config{
+				ConsensusParameters: validParameters,
+				ProposerPolicy:      proposer.UptimePolicyName,
+				ProposerMinUptime:   2,
+			},
+			expectedErr: proposer.ErrInvalidMinUptime,
+		},
+		{
+			name: "uptime proposer policy",
+			s: Config{
+				ConsensusParameters: validParameters,
+				ProposerPolicy:      proposer.UptimePolicyName,
+				ProposerMinUptime:   .8,
+			},
+			expectedErr: nil,
+		},
 		{
 			name: "valid",
 			s: Config{
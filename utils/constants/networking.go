@@ -61,15 +61,30 @@ const (
 	// a timeout of 0 should generally not be provided.
 	DefaultNetworkTCPProxyReadTimeout = 3 * time.Second
 
+	// Message recording
+	DefaultNetworkRecorderMaxSize  = 64 // in megabytes
+	DefaultNetworkRecorderMaxFiles = 10
+
 	// Benchlist
 	DefaultBenchlistFailThreshold      = 10
 	DefaultBenchlistDuration           = 15 * time.Minute
 	DefaultBenchlistMinFailingDuration = 2*time.Minute + 30*time.Second
 
+	// Reputation
+	DefaultReputationInvalidMessagePenalty    = 1
+	DefaultReputationInvalidBlockPenalty      = 2
+	DefaultReputationInvalidSignaturePenalty  = 10
+	DefaultReputationInvalidAppRequestPenalty = 1
+	DefaultReputationHalflife                 = 10 * time.Minute
+	DefaultReputationDisconnectThreshold      = 20
+	DefaultReputationBanThreshold             = 50
+	DefaultReputationBanDuration              = time.Hour
+
 	// Router
 	DefaultConsensusAppConcurrency  = 2
 	DefaultConsensusShutdownTimeout = time.Minute
 	DefaultFrontierPollFrequency    = 100 * time.Millisecond
+	DefaultConsensusTraceSize       = 256
 
 	// Inbound Throttling
 	DefaultInboundThrottlerAtLargeAllocSize         = 6 * units.MiB
@@ -26,8 +26,10 @@ import (
 	"github.com/shubhamdubey02/cryftgo/snow/engine/common"
 	"github.com/shubhamdubey02/cryftgo/snow/engine/common/tracker"
 	"github.com/shubhamdubey02/cryftgo/snow/engine/snowman/bootstrap"
+	"github.com/shubhamdubey02/cryftgo/snow/engine/snowman/polltrace"
 	"github.com/shubhamdubey02/cryftgo/snow/networking/benchlist"
 	"github.com/shubhamdubey02/cryftgo/snow/networking/handler"
+	"github.com/shubhamdubey02/cryftgo/snow/networking/reputation"
 	"github.com/shubhamdubey02/cryftgo/snow/networking/router"
 	"github.com/shubhamdubey02/cryftgo/snow/networking/sender"
 	"github.com/shubhamdubey02/cryftgo/snow/networking/timeout"
@@ -1547,7 +1549,10 @@ func TestBootstrapPartiallyAccepted(t *testing.T) {
 			MaxOutstandingItems:   1,
 			MaxItemProcessingTime: 1,
 		},
-		Consensus: &smcon.Topological{},
+		Consensus:      &smcon.Topological{},
+		Reputation:     reputation.NewNoTracker(),
+		PollTracer:     polltrace.NewNoTracer(),
+		LatencyTracker: timetracker.NewLatencyTracker(time.Minute),
 	}
 	engine, err := smeng.New(engineConfig)
 	require.NoError(err)
@@ -8,6 +8,7 @@ import (
 	"time"
 
 	"github.com/shubhamdubey02/cryftgo/staking"
+	"github.com/shubhamdubey02/cryftgo/vms/proposervm/proposer"
 )
 
 type Config struct {
@@ -32,6 +33,11 @@ type Config struct {
 
 	// Block certificate
 	StakingCertLeaf *staking.Certificate
+
+	// Selects the validators allowed to propose blocks. Every node of the
+	// chain must use the same policy.
+	// Nil signals proposers are sampled weighted by stake.
+	ProposerPolicy proposer.Policy
 }
 
 func (c *Config) IsDurangoActivated(timestamp time.Time) bool {
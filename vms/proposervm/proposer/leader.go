@@ -0,0 +1,138 @@
+// Copyright (C) 2019-2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package proposer
+
+import (
+	"context"
+	"time"
+
+	"gonum.org/v1/gonum/mathext/prng"
+
+	"github.com/shubhamdubey02/cryftgo/ids"
+	"github.com/shubhamdubey02/cryftgo/snow/validators"
+)
+
+var _ Windower = (*leaderWindower)(nil)
+
+// leaderWindower gives the first leaderSlots windows or slots of a block to
+// the validator the stake-weighted windower schedules first. The following
+// windows and slots fall back to the stake-weighted schedule.
+type leaderWindower struct {
+	*windower
+	leaderSlots uint64
+}
+
+// NewLeader returns a Windower that only allows a single leader, sampled by
+// stake, to propose a block during the first [leaderSlots] windows or slots.
+// If [leaderSlots] is 0, the leader only has the first window or slot.
+func NewLeader(state validators.State, subnetID, chainID ids.ID, leaderSlots uint64) Windower {
+	return &leaderWindower{
+		windower:    New(state, subnetID, chainID).(*windower),
+		leaderSlots: max(leaderSlots, 1),
+	}
+}
+
+func (w *leaderWindower) Proposers(ctx context.Context, blockHeight, pChainHeight uint64, maxWindows int) ([]ids.NodeID, error) {
+	proposers, err := w.windower.Proposers(ctx, blockHeight, pChainHeight, maxWindows)
+	if err != nil || len(proposers) == 0 {
+		return proposers, err
+	}
+
+	// The leader is repeated so that the first fallback proposer's delay
+	// starts after the leader's windows.
+	leaderProposers := make([]ids.NodeID, 0, maxWindows)
+	for i := uint64(0); i < w.leaderSlots && len(leaderProposers) < maxWindows; i++ {
+		leaderProposers = append(leaderProposers, proposers[0])
+	}
+	for _, nodeID := range proposers[1:] {
+		if len(leaderProposers) == maxWindows {
+			break
+		}
+		leaderProposers = append(leaderProposers, nodeID)
+	}
+	return leaderProposers, nil
+}
+
+func (w *leaderWindower) Delay(ctx context.Context, blockHeight, pChainHeight uint64, validatorID ids.NodeID, maxWindows int) (time.Duration, error) {
+	if validatorID == ids.EmptyNodeID {
+		return time.Duration(maxWindows) * WindowDuration, nil
+	}
+
+	proposers, err := w.Proposers(ctx, blockHeight, pChainHeight, maxWindows)
+	if err != nil {
+		return 0, err
+	}
+	return proposerDelay(proposers, validatorID), nil
+}
+
+func (w *leaderWindower) ExpectedProposer(
+	ctx context.Context,
+	blockHeight,
+	pChainHeight,
+	slot uint64,
+) (ids.NodeID, error) {
+	source := prng.NewMT19937_64()
+	sampler, validators, err := w.makeSampler(ctx, pChainHeight, source)
+	if err != nil {
+		return ids.EmptyNodeID, err
+	}
+	if len(validators) == 0 {
+		return ids.EmptyNodeID, ErrAnyoneCanPropose
+	}
+
+	return w.expectedProposer(
+		validators,
+		source,
+		sampler,
+		blockHeight,
+		w.scheduledSlot(slot),
+	)
+}
+
+func (w *leaderWindower) MinDelayForProposer(
+	ctx context.Context,
+	blockHeight,
+	pChainHeight uint64,
+	nodeID ids.NodeID,
+	startSlot uint64,
+) (time.Duration, error) {
+	source := prng.NewMT19937_64()
+	sampler, validators, err := w.makeSampler(ctx, pChainHeight, source)
+	if err != nil {
+		return 0, err
+	}
+	if len(validators) == 0 {
+		return 0, ErrAnyoneCanPropose
+	}
+
+	maxSlot := startSlot + MaxLookAheadSlots
+	for slot := startSlot; slot < maxSlot; slot++ {
+		expectedNodeID, err := w.expectedProposer(
+			validators,
+			source,
+			sampler,
+			blockHeight,
+			w.scheduledSlot(slot),
+		)
+		if err != nil {
+			return 0, err
+		}
+
+		if expectedNodeID == nodeID {
+			return time.Duration(slot) * WindowDuration, nil
+		}
+	}
+
+	// no slots scheduled for the max window we inspect. Return max delay
+	return time.Duration(maxSlot) * WindowDuration, nil
+}
+
+// scheduledSlot returns the slot of the stake-weighted schedule whose proposer
+// proposes in [slot]. The leader is the proposer of the first slot.
+func (w *leaderWindower) scheduledSlot(slot uint64) uint64 {
+	if slot < w.leaderSlots {
+		return 0
+	}
+	return slot
+}
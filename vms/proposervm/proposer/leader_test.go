@@ -0,0 +1,160 @@
+// Copyright (C) 2019-2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package proposer
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/shubhamdubey02/cryftgo/ids"
+)
+
+func TestLeaderNoValidators(t *testing.T) {
+	require := require.New(t)
+
+	_, vdrState := makeValidators(t, 0)
+	w := NewLeader(vdrState, subnetID, randomChainID, 3)
+
+	var (
+		chainHeight  uint64 = 1
+		pChainHeight uint64 = 0
+		nodeID              = ids.GenerateTestNodeID()
+		slot         uint64 = 1
+	)
+	delay, err := w.Delay(context.Background(), chainHeight, pChainHeight, nodeID, MaxVerifyWindows)
+	require.NoError(err)
+	require.Zero(delay)
+
+	proposer, err := w.ExpectedProposer(context.Background(), chainHeight, pChainHeight, slot)
+	require.ErrorIs(err, ErrAnyoneCanPropose)
+	require.Equal(ids.EmptyNodeID, proposer)
+
+	delay, err = w.MinDelayForProposer(context.Background(), chainHeight, pChainHeight, nodeID, slot)
+	require.ErrorIs(err, ErrAnyoneCanPropose)
+	require.Zero(delay)
+}
+
+func TestLeaderExpectedProposer(t *testing.T) {
+	require := require.New(t)
+
+	const leaderSlots = 3
+
+	_, vdrState := makeValidators(t, 10)
+	var (
+		stakeWeighted = New(vdrState, subnetID, fixedChainID)
+		w             = NewLeader(vdrState, subnetID, fixedChainID, leaderSlots)
+
+		dummyCtx            = context.Background()
+		pChainHeight uint64 = 0
+	)
+
+	for chainHeight := uint64(0); chainHeight < 10; chainHeight++ {
+		leaderID, err := stakeWeighted.ExpectedProposer(dummyCtx, chainHeight, pChainHeight, 0)
+		require.NoError(err)
+
+		for slot := uint64(0); slot < leaderSlots; slot++ {
+			proposerID, err := w.ExpectedProposer(dummyCtx, chainHeight, pChainHeight, slot)
+			require.NoError(err)
+			require.Equal(leaderID, proposerID)
+		}
+
+		for slot := uint64(leaderSlots); slot < 2*MaxVerifyWindows; slot++ {
+			expectedProposerID, err := stakeWeighted.ExpectedProposer(dummyCtx, chainHeight, pChainHeight, slot)
+			require.NoError(err)
+
+			proposerID, err := w.ExpectedProposer(dummyCtx, chainHeight, pChainHeight, slot)
+			require.NoError(err)
+			require.Equal(expectedProposerID, proposerID)
+		}
+	}
+}
+
+func TestLeaderZeroSlots(t *testing.T) {
+	require := require.New(t)
+
+	_, vdrState := makeValidators(t, 10)
+	var (
+		stakeWeighted = New(vdrState, subnetID, fixedChainID)
+		w             = NewLeader(vdrState, subnetID, fixedChainID, 0)
+
+		dummyCtx            = context.Background()
+		chainHeight  uint64 = 1
+		pChainHeight uint64 = 0
+	)
+
+	for slot := uint64(0); slot < MaxVerifyWindows; slot++ {
+		expectedProposerID, err := stakeWeighted.ExpectedProposer(dummyCtx, chainHeight, pChainHeight, slot)
+		require.NoError(err)
+
+		proposerID, err := w.ExpectedProposer(dummyCtx, chainHeight, pChainHeight, slot)
+		require.NoError(err)
+		require.Equal(expectedProposerID, proposerID)
+	}
+}
+
+func TestLeaderProposers(t *testing.T) {
+	require := require.New(t)
+
+	const leaderSlots = 3
+
+	_, vdrState := makeValidators(t, 10)
+	var (
+		stakeWeighted = New(vdrState, subnetID, fixedChainID)
+		w             = NewLeader(vdrState, subnetID, fixedChainID, leaderSlots)
+
+		dummyCtx            = context.Background()
+		chainHeight  uint64 = 1
+		pChainHeight uint64 = 0
+	)
+
+	expectedProposers, err := stakeWeighted.Proposers(dummyCtx, chainHeight, pChainHeight, MaxVerifyWindows)
+	require.NoError(err)
+
+	proposers, err := w.Proposers(dummyCtx, chainHeight, pChainHeight, MaxVerifyWindows)
+	require.NoError(err)
+	require.Len(proposers, MaxVerifyWindows)
+	for i := 0; i < leaderSlots; i++ {
+		require.Equal(expectedProposers[0], proposers[i])
+	}
+	require.Equal(expectedProposers[1:MaxVerifyWindows-leaderSlots+1], proposers[leaderSlots:])
+
+	leaderDelay, err := w.Delay(dummyCtx, chainHeight, pChainHeight, expectedProposers[0], MaxVerifyWindows)
+	require.NoError(err)
+	require.Zero(leaderDelay)
+
+	fallbackDelay, err := w.Delay(dummyCtx, chainHeight, pChainHeight, expectedProposers[1], MaxVerifyWindows)
+	require.NoError(err)
+	require.Equal(leaderSlots*WindowDuration, fallbackDelay)
+
+	proposers, err = w.Proposers(dummyCtx, chainHeight, pChainHeight, 2)
+	require.NoError(err)
+	require.Equal([]ids.NodeID{expectedProposers[0], expectedProposers[0]}, proposers)
+}
+
+func TestLeaderCoherenceOfExpectedProposerAndMinDelayForProposer(t *testing.T) {
+	require := require.New(t)
+
+	_, vdrState := makeValidators(t, 10)
+	w := NewLeader(vdrState, subnetID, fixedChainID, 5)
+
+	var (
+		dummyCtx            = context.Background()
+		chainHeight  uint64 = 1
+		pChainHeight uint64 = 0
+	)
+
+	for slot := uint64(0); slot < 3*MaxLookAheadSlots; slot++ {
+		proposerID, err := w.ExpectedProposer(dummyCtx, chainHeight, pChainHeight, slot)
+		require.NoError(err)
+
+		// proposerID is the scheduled proposer. It should start with the
+		// expected delay
+		delay, err := w.MinDelayForProposer(dummyCtx, chainHeight, pChainHeight, proposerID, slot)
+		require.NoError(err)
+		require.Equal(time.Duration(slot)*WindowDuration, delay)
+	}
+}
@@ -0,0 +1,128 @@
+// Copyright (C) 2019-2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package proposer
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/shubhamdubey02/cryftgo/ids"
+	"github.com/shubhamdubey02/cryftgo/snow/validators"
+)
+
+var (
+	_ Policy = StakeWeightedPolicy{}
+	_ Policy = RoundRobinPolicy{}
+	_ Policy = LeaderPolicy{}
+	_ Policy = UptimePolicy{}
+
+	ErrUnknownPolicy    = errors.New("unknown proposer policy")
+	ErrNoUptimeSource   = errors.New("uptime proposer policy requires a deterministic uptime source")
+	ErrInvalidMinUptime = errors.New("minimum uptime must be in [0, 1]")
+)
+
+// Names of the policies, as used in configs
+const (
+	StakeWeightedPolicyName = "stake-weighted"
+	RoundRobinPolicyName    = "round-robin"
+	LeaderPolicyName        = "leader"
+	UptimePolicyName        = "uptime"
+)
+
+// Policy selects the validators that are allowed to propose blocks, and when.
+//
+// Every node of a chain must use the same policy, and the Windower it creates
+// must only depend on its arguments, so that all nodes agree on the proposers.
+type Policy interface {
+	// New returns a Windower that selects the proposers of the chain [chainID]
+	// among the validators of [subnetID] tracked by [state].
+	New(state validators.State, subnetID, chainID ids.ID) Windower
+}
+
+// StakeWeightedPolicy samples the proposers of each block weighted by stake.
+// This is the default policy.
+type StakeWeightedPolicy struct{}
+
+func (StakeWeightedPolicy) New(state validators.State, subnetID, chainID ids.ID) Windower {
+	return New(state, subnetID, chainID)
+}
+
+// RoundRobinPolicy rotates through the validators, ignoring their stake.
+type RoundRobinPolicy struct{}
+
+func (RoundRobinPolicy) New(state validators.State, subnetID, chainID ids.ID) Windower {
+	return NewRoundRobin(state, subnetID, chainID)
+}
+
+// LeaderPolicy samples a single leader for each block, weighted by stake. The
+// leader is the only validator allowed to propose the block during the first
+// LeaderSlots slots. Afterwards, the proposers are sampled as with
+// StakeWeightedPolicy.
+type LeaderPolicy struct {
+	// LeaderSlots is the number of slots the leader is the only proposer of a
+	// block. If zero, the leader only has the first slot.
+	LeaderSlots uint64
+}
+
+func (p LeaderPolicy) New(state validators.State, subnetID, chainID ids.ID) Windower {
+	return NewLeader(state, subnetID, chainID, p.LeaderSlots)
+}
+
+// UptimePolicy excludes the validators whose uptime is below MinUptime from
+// the proposers selected by Policy.
+//
+// Uptimes must report the same uptimes on every node, so the uptimes measured
+// locally by a node can't be used.
+type UptimePolicy struct {
+	// Uptimes reports the uptimes of the validators
+	Uptimes UptimeState
+	// MinUptime is the minimum uptime, in [0, 1], of a proposer
+	MinUptime float64
+	// Policy selects the proposers among the remaining validators. If nil,
+	// StakeWeightedPolicy is used.
+	Policy Policy
+}
+
+func (p UptimePolicy) New(state validators.State, subnetID, chainID ids.ID) Windower {
+	policy := p.Policy
+	if policy == nil {
+		policy = StakeWeightedPolicy{}
+	}
+	return policy.New(
+		NewUptimeState(state, p.Uptimes, p.MinUptime),
+		subnetID,
+		chainID,
+	)
+}
+
+// NewPolicy returns the policy called [name]. If [name] is empty,
+// StakeWeightedPolicy is returned. [leaderSlots] is only used by LeaderPolicy.
+// [minUptime] and [uptimes] are only used by UptimePolicy, which selects the
+// proposers among the remaining validators as StakeWeightedPolicy does.
+//
+// Returns ErrNoUptimeSource if [name] is UptimePolicyName and [uptimes] is
+// nil.
+func NewPolicy(name string, leaderSlots uint64, minUptime float64, uptimes UptimeState) (Policy, error) {
+	switch name {
+	case "", StakeWeightedPolicyName:
+		return StakeWeightedPolicy{}, nil
+	case RoundRobinPolicyName:
+		return RoundRobinPolicy{}, nil
+	case LeaderPolicyName:
+		return LeaderPolicy{LeaderSlots: leaderSlots}, nil
+	case UptimePolicyName:
+		if minUptime < 0 || minUptime > 1 {
+			return nil, fmt.Errorf("%w: %f", ErrInvalidMinUptime, minUptime)
+		}
+		if uptimes == nil {
+			return nil, ErrNoUptimeSource
+		}
+		return UptimePolicy{
+			Uptimes:   uptimes,
+			MinUptime: minUptime,
+		}, nil
+	default:
+		return nil, fmt.Errorf("%w: %q", ErrUnknownPolicy, name)
+	}
+}
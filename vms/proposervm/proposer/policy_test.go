@@ -0,0 +1,81 @@
+// Copyright (C) 2019-2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package proposer
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewPolicy(t *testing.T) {
+	tests := []struct {
+		name           string
+		policyName     string
+		leaderSlots    uint64
+		minUptime      float64
+		uptimes        UptimeState
+		expectedPolicy Policy
+		expectedErr    error
+	}{
+		{
+			name:           "default",
+			policyName:     "",
+			expectedPolicy: StakeWeightedPolicy{},
+		},
+		{
+			name:           "stake weighted",
+			policyName:     StakeWeightedPolicyName,
+			expectedPolicy: StakeWeightedPolicy{},
+		},
+		{
+			name:           "round robin",
+			policyName:     RoundRobinPolicyName,
+			expectedPolicy: RoundRobinPolicy{},
+		},
+		{
+			name:           "leader",
+			policyName:     LeaderPolicyName,
+			leaderSlots:    3,
+			expectedPolicy: LeaderPolicy{LeaderSlots: 3},
+		},
+		{
+			name:       "uptime",
+			policyName: UptimePolicyName,
+			minUptime:  .8,
+			uptimes:    &testUptimeState{},
+			expectedPolicy: UptimePolicy{
+				Uptimes:   &testUptimeState{},
+				MinUptime: .8,
+			},
+		},
+		{
+			name:        "uptime without uptime source",
+			policyName:  UptimePolicyName,
+			minUptime:   .8,
+			expectedErr: ErrNoUptimeSource,
+		},
+		{
+			name:        "uptime with invalid min uptime",
+			policyName:  UptimePolicyName,
+			minUptime:   1.1,
+			uptimes:     &testUptimeState{},
+			expectedErr: ErrInvalidMinUptime,
+		},
+		{
+			name:        "unknown",
+			policyName:  "unknown",
+			expectedErr: ErrUnknownPolicy,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			require := require.New(t)
+
+			policy, err := NewPolicy(test.policyName, test.leaderSlots, test.minUptime, test.uptimes)
+			require.ErrorIs(err, test.expectedErr)
+			require.Equal(test.expectedPolicy, policy)
+		})
+	}
+}
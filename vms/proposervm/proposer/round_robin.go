@@ -0,0 +1,134 @@
+// Copyright (C) 2019-2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package proposer
+
+import (
+	"context"
+	"slices"
+	"time"
+
+	"github.com/shubhamdubey02/cryftgo/ids"
+	"github.com/shubhamdubey02/cryftgo/snow/validators"
+	"github.com/shubhamdubey02/cryftgo/utils"
+	"github.com/shubhamdubey02/cryftgo/utils/wrappers"
+)
+
+var _ Windower = (*roundRobinWindower)(nil)
+
+// roundRobinWindower rotates through the validators sorted by ID. The
+// validator proposing first at a height is the one after the validator that
+// proposed first at the previous height, and each later window or slot is
+// given to the next validator.
+type roundRobinWindower struct {
+	state       validators.State
+	subnetID    ids.ID
+	chainSource uint64
+}
+
+// NewRoundRobin returns a Windower that gives every validator the same number
+// of windows and slots, regardless of its stake.
+func NewRoundRobin(state validators.State, subnetID, chainID ids.ID) Windower {
+	w := wrappers.Packer{Bytes: chainID[:]}
+	return &roundRobinWindower{
+		state:       state,
+		subnetID:    subnetID,
+		chainSource: w.UnpackLong(),
+	}
+}
+
+func (w *roundRobinWindower) Proposers(ctx context.Context, blockHeight, pChainHeight uint64, maxWindows int) ([]ids.NodeID, error) {
+	nodeIDs, err := w.validators(ctx, pChainHeight)
+	if err != nil {
+		return nil, err
+	}
+
+	numProposers := min(maxWindows, len(nodeIDs))
+	proposers := make([]ids.NodeID, numProposers)
+	for i := range proposers {
+		proposers[i] = nodeIDs[w.index(len(nodeIDs), blockHeight, uint64(i))]
+	}
+	return proposers, nil
+}
+
+func (w *roundRobinWindower) Delay(ctx context.Context, blockHeight, pChainHeight uint64, validatorID ids.NodeID, maxWindows int) (time.Duration, error) {
+	if validatorID == ids.EmptyNodeID {
+		return time.Duration(maxWindows) * WindowDuration, nil
+	}
+
+	proposers, err := w.Proposers(ctx, blockHeight, pChainHeight, maxWindows)
+	if err != nil {
+		return 0, err
+	}
+	return proposerDelay(proposers, validatorID), nil
+}
+
+func (w *roundRobinWindower) ExpectedProposer(
+	ctx context.Context,
+	blockHeight,
+	pChainHeight,
+	slot uint64,
+) (ids.NodeID, error) {
+	nodeIDs, err := w.validators(ctx, pChainHeight)
+	if err != nil {
+		return ids.EmptyNodeID, err
+	}
+	if len(nodeIDs) == 0 {
+		return ids.EmptyNodeID, ErrAnyoneCanPropose
+	}
+	return nodeIDs[w.index(len(nodeIDs), blockHeight, slot)], nil
+}
+
+func (w *roundRobinWindower) MinDelayForProposer(
+	ctx context.Context,
+	blockHeight,
+	pChainHeight uint64,
+	nodeID ids.NodeID,
+	startSlot uint64,
+) (time.Duration, error) {
+	nodeIDs, err := w.validators(ctx, pChainHeight)
+	if err != nil {
+		return 0, err
+	}
+	if len(nodeIDs) == 0 {
+		return 0, ErrAnyoneCanPropose
+	}
+
+	maxSlot := startSlot + MaxLookAheadSlots
+	nodeIndex, ok := slices.BinarySearchFunc(nodeIDs, nodeID, ids.NodeID.Compare)
+	if !ok {
+		// no slots scheduled for the max window we inspect. Return max delay
+		return time.Duration(maxSlot) * WindowDuration, nil
+	}
+
+	// The validator proposes every len(nodeIDs) slots. Find how many slots
+	// after [startSlot] its next slot is.
+	var (
+		numValidators = uint64(len(nodeIDs))
+		startIndex    = uint64(w.index(len(nodeIDs), blockHeight, startSlot))
+		slot          = startSlot + (uint64(nodeIndex)+numValidators-startIndex)%numValidators
+	)
+	return time.Duration(min(slot, maxSlot)) * WindowDuration, nil
+}
+
+// validators returns the IDs of the validators at [pChainHeight], sorted.
+func (w *roundRobinWindower) validators(ctx context.Context, pChainHeight uint64) ([]ids.NodeID, error) {
+	validatorsMap, err := w.state.GetValidatorSet(ctx, pChainHeight, w.subnetID)
+	if err != nil {
+		return nil, err
+	}
+
+	nodeIDs := make([]ids.NodeID, 0, len(validatorsMap))
+	for nodeID := range validatorsMap {
+		nodeIDs = append(nodeIDs, nodeID)
+	}
+	utils.Sort(nodeIDs)
+	return nodeIDs, nil
+}
+
+// index returns the index of the validator given the [offset]th window or slot
+// of [blockHeight], among [numValidators] validators.
+func (w *roundRobinWindower) index(numValidators int, blockHeight, offset uint64) int {
+	n := uint64(numValidators)
+	return int((w.chainSource%n + blockHeight%n + offset%n) % n)
+}
@@ -0,0 +1,174 @@
+// Copyright (C) 2019-2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package proposer
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/shubhamdubey02/cryftgo/ids"
+)
+
+func TestRoundRobinNoValidators(t *testing.T) {
+	require := require.New(t)
+
+	_, vdrState := makeValidators(t, 0)
+	w := NewRoundRobin(vdrState, subnetID, randomChainID)
+
+	var (
+		chainHeight  uint64 = 1
+		pChainHeight uint64 = 0
+		nodeID              = ids.GenerateTestNodeID()
+		slot         uint64 = 1
+	)
+	delay, err := w.Delay(context.Background(), chainHeight, pChainHeight, nodeID, MaxVerifyWindows)
+	require.NoError(err)
+	require.Zero(delay)
+
+	proposer, err := w.ExpectedProposer(context.Background(), chainHeight, pChainHeight, slot)
+	require.ErrorIs(err, ErrAnyoneCanPropose)
+	require.Equal(ids.EmptyNodeID, proposer)
+
+	delay, err = w.MinDelayForProposer(context.Background(), chainHeight, pChainHeight, nodeID, slot)
+	require.ErrorIs(err, ErrAnyoneCanPropose)
+	require.Zero(delay)
+}
+
+func TestRoundRobinProposersRotateByHeight(t *testing.T) {
+	require := require.New(t)
+
+	validatorIDs, vdrState := makeValidators(t, 5)
+	w := NewRoundRobin(vdrState, subnetID, fixedChainID)
+
+	var (
+		dummyCtx            = context.Background()
+		pChainHeight uint64 = 0
+	)
+
+	proposers, err := w.Proposers(dummyCtx, 1, pChainHeight, MaxBuildWindows)
+	require.NoError(err)
+	require.Len(proposers, len(validatorIDs))
+	require.ElementsMatch(validatorIDs, proposers)
+
+	nextProposers, err := w.Proposers(dummyCtx, 2, pChainHeight, MaxBuildWindows)
+	require.NoError(err)
+	require.Equal(append(proposers[1:], proposers[0]), nextProposers)
+
+	proposers, err = w.Proposers(dummyCtx, 1, pChainHeight, 2)
+	require.NoError(err)
+	require.Len(proposers, 2)
+}
+
+func TestRoundRobinExpectedProposerEqualShare(t *testing.T) {
+	require := require.New(t)
+
+	validatorIDs, vdrState := makeValidators(t, 7)
+	w := NewRoundRobin(vdrState, subnetID, randomChainID)
+
+	var (
+		dummyCtx            = context.Background()
+		pChainHeight uint64 = 0
+		numHeights   uint64 = 3
+		numSlots            = uint64(len(validatorIDs))
+	)
+
+	proposerFrequency := make(map[ids.NodeID]uint64)
+	for chainHeight := uint64(0); chainHeight < numHeights; chainHeight++ {
+		for slot := uint64(0); slot < numSlots; slot++ {
+			proposerID, err := w.ExpectedProposer(dummyCtx, chainHeight, pChainHeight, slot)
+			require.NoError(err)
+			proposerFrequency[proposerID]++
+		}
+	}
+
+	require.Len(proposerFrequency, len(validatorIDs))
+	for _, nodeID := range validatorIDs {
+		require.Equal(numHeights, proposerFrequency[nodeID])
+	}
+}
+
+func TestRoundRobinDelay(t *testing.T) {
+	require := require.New(t)
+
+	_, vdrState := makeValidators(t, 5)
+	w := NewRoundRobin(vdrState, subnetID, fixedChainID)
+
+	var (
+		dummyCtx            = context.Background()
+		chainHeight  uint64 = 1
+		pChainHeight uint64 = 0
+	)
+
+	proposers, err := w.Proposers(dummyCtx, chainHeight, pChainHeight, MaxVerifyWindows)
+	require.NoError(err)
+
+	for i, nodeID := range proposers {
+		delay, err := w.Delay(dummyCtx, chainHeight, pChainHeight, nodeID, MaxVerifyWindows)
+		require.NoError(err)
+		require.Equal(time.Duration(i)*WindowDuration, delay)
+	}
+
+	nonValidatorDelay, err := w.Delay(dummyCtx, chainHeight, pChainHeight, ids.GenerateTestNodeID(), MaxVerifyWindows)
+	require.NoError(err)
+	require.Equal(time.Duration(len(proposers))*WindowDuration, nonValidatorDelay)
+
+	emptyDelay, err := w.Delay(dummyCtx, chainHeight, pChainHeight, ids.EmptyNodeID, MaxVerifyWindows)
+	require.NoError(err)
+	require.Equal(MaxVerifyDelay, emptyDelay)
+}
+
+func TestRoundRobinCoherenceOfExpectedProposerAndMinDelayForProposer(t *testing.T) {
+	require := require.New(t)
+
+	_, vdrState := makeValidators(t, 10)
+	w := NewRoundRobin(vdrState, subnetID, fixedChainID)
+
+	var (
+		dummyCtx            = context.Background()
+		chainHeight  uint64 = 1
+		pChainHeight uint64 = 0
+	)
+
+	for slot := uint64(0); slot < 3*MaxLookAheadSlots; slot++ {
+		proposerID, err := w.ExpectedProposer(dummyCtx, chainHeight, pChainHeight, slot)
+		require.NoError(err)
+
+		// proposerID is the scheduled proposer. It should start with the
+		// expected delay
+		delay, err := w.MinDelayForProposer(dummyCtx, chainHeight, pChainHeight, proposerID, slot)
+		require.NoError(err)
+		require.Equal(time.Duration(slot)*WindowDuration, delay)
+	}
+}
+
+func TestRoundRobinMinDelayForProposer(t *testing.T) {
+	require := require.New(t)
+
+	validatorIDs, vdrState := makeValidators(t, 10)
+	w := NewRoundRobin(vdrState, subnetID, fixedChainID)
+
+	var (
+		dummyCtx            = context.Background()
+		chainHeight  uint64 = 1
+		pChainHeight uint64 = 0
+		slot         uint64 = 0
+	)
+
+	// Every validator is scheduled once in the first len(validatorIDs) slots
+	delays := make(map[time.Duration]struct{}, len(validatorIDs))
+	for _, nodeID := range validatorIDs {
+		delay, err := w.MinDelayForProposer(dummyCtx, chainHeight, pChainHeight, nodeID, slot)
+		require.NoError(err)
+		require.Less(delay, time.Duration(len(validatorIDs))*WindowDuration)
+		delays[delay] = struct{}{}
+	}
+	require.Len(delays, len(validatorIDs))
+
+	delay, err := w.MinDelayForProposer(dummyCtx, chainHeight, pChainHeight, ids.GenerateTestNodeID(), slot)
+	require.NoError(err)
+	require.Equal(MaxLookAheadWindow, delay)
+}
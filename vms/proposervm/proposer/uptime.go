@@ -0,0 +1,77 @@
+// Copyright (C) 2019-2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package proposer
+
+import (
+	"context"
+	"maps"
+
+	"github.com/shubhamdubey02/cryftgo/ids"
+	"github.com/shubhamdubey02/cryftgo/snow/validators"
+)
+
+var _ validators.State = (*uptimeState)(nil)
+
+// UptimeState reports the uptimes of validators.
+//
+// The uptimes must be the same on every node for the same P-chain height, so
+// that all nodes agree on the proposers. The uptimes measured locally by a node
+// don't meet this requirement.
+type UptimeState interface {
+	// GetUptimes returns the uptimes, in [0, 1], of the validators of
+	// [subnetID] at [pChainHeight].
+	GetUptimes(
+		ctx context.Context,
+		pChainHeight uint64,
+		subnetID ids.ID,
+	) (map[ids.NodeID]float64, error)
+}
+
+// uptimeState removes the validators whose uptime is below minUptime from the
+// validator sets of the wrapped state
+type uptimeState struct {
+	validators.State
+	uptimes   UptimeState
+	minUptime float64
+}
+
+// NewUptimeState returns a state whose validator sets exclude the validators
+// of [state] with an uptime below [minUptime]. Validators without a reported
+// uptime aren't excluded. If every validator would be excluded, the validator
+// set is returned unchanged, so that blocks can still be proposed.
+func NewUptimeState(state validators.State, uptimes UptimeState, minUptime float64) validators.State {
+	return &uptimeState{
+		State:     state,
+		uptimes:   uptimes,
+		minUptime: minUptime,
+	}
+}
+
+func (s *uptimeState) GetValidatorSet(
+	ctx context.Context,
+	height uint64,
+	subnetID ids.ID,
+) (map[ids.NodeID]*validators.GetValidatorOutput, error) {
+	vdrs, err := s.State.GetValidatorSet(ctx, height, subnetID)
+	if err != nil {
+		return nil, err
+	}
+
+	uptimes, err := s.uptimes.GetUptimes(ctx, height, subnetID)
+	if err != nil {
+		return nil, err
+	}
+
+	// The returned validator set may be cached by the wrapped state, so it
+	// must not be modified.
+	filteredVdrs := maps.Clone(vdrs)
+	maps.DeleteFunc(filteredVdrs, func(nodeID ids.NodeID, _ *validators.GetValidatorOutput) bool {
+		uptime, ok := uptimes[nodeID]
+		return ok && uptime < s.minUptime
+	})
+	if len(filteredVdrs) == 0 {
+		return vdrs, nil
+	}
+	return filteredVdrs, nil
+}
@@ -0,0 +1,289 @@
+// Copyright (C) 2019-2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package proposer
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/shubhamdubey02/cryftgo/ids"
+	"github.com/shubhamdubey02/cryftgo/snow/validators"
+)
+
+var errTestUptimes = errors.New("non-nil uptimes error")
+
+type testUptimeState struct {
+	uptimes map[ids.NodeID]float64
+	err     error
+}
+
+func (s *testUptimeState) GetUptimes(context.Context, uint64, ids.ID) (map[ids.NodeID]float64, error) {
+	return s.uptimes, s.err
+}
+
+func TestUptimeStateGetValidatorSet(t *testing.T) {
+	validatorIDs, vdrState := makeValidators(t, 3)
+
+	tests := []struct {
+		name         string
+		uptimes      map[ids.NodeID]float64
+		uptimesErr   error
+		expectedVdrs []ids.NodeID
+		expectedErr  error
+	}{
+		{
+			name: "excludes low uptime",
+			uptimes: map[ids.NodeID]float64{
+				validatorIDs[0]: 1,
+				validatorIDs[1]: .5,
+				validatorIDs[2]: .8,
+			},
+			expectedVdrs: []ids.NodeID{validatorIDs[0], validatorIDs[2]},
+		},
+		{
+			name: "keeps unknown uptime",
+			uptimes: map[ids.NodeID]float64{
+				validatorIDs[1]: .5,
+			},
+			expectedVdrs: []ids.NodeID{validatorIDs[0], validatorIDs[2]},
+		},
+		{
+			name: "all excluded",
+			uptimes: map[ids.NodeID]float64{
+				validatorIDs[0]: 0,
+				validatorIDs[1]: .5,
+				validatorIDs[2]: .7,
+			},
+			expectedVdrs: validatorIDs,
+		},
+		{
+			name:        "uptimes error",
+			uptimesErr:  errTestUptimes,
+			expectedErr: errTestUptimes,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			require := require.New(t)
+
+			state := NewUptimeState(
+				vdrState,
+				&testUptimeState{
+					uptimes: test.uptimes,
+					err:     test.uptimesErr,
+				},
+				.8,
+			)
+
+			vdrs, err := state.GetValidatorSet(context.Background(), 0, subnetID)
+			require.ErrorIs(err, test.expectedErr)
+			if test.expectedErr != nil {
+				return
+			}
+
+			nodeIDs := make([]ids.NodeID, 0, len(vdrs))
+			for nodeID := range vdrs {
+				nodeIDs = append(nodeIDs, nodeID)
+			}
+			require.ElementsMatch(test.expectedVdrs, nodeIDs)
+		})
+	}
+}
+
+func TestUptimePolicy(t *testing.T) {
+	require := require.New(t)
+
+	validatorIDs, vdrState := makeValidators(t, 5)
+	uptimes := &testUptimeState{
+		uptimes: map[ids.NodeID]float64{
+			validatorIDs[0]: 0,
+			validatorIDs[3]: .1,
+		},
+	}
+
+	var (
+		dummyCtx            = context.Background()
+		pChainHeight uint64 = 0
+	)
+
+	policies := []Policy{
+		UptimePolicy{
+			Uptimes:   uptimes,
+			MinUptime: .8,
+		},
+		UptimePolicy{
+			Uptimes:   uptimes,
+			MinUptime: .8,
+			Policy:    RoundRobinPolicy{},
+		},
+		UptimePolicy{
+			Uptimes:   uptimes,
+			MinUptime: .8,
+			Policy:    LeaderPolicy{LeaderSlots: 2},
+		},
+	}
+	for _, policy := range policies {
+		w := policy.New(vdrState, subnetID, fixedChainID)
+		for chainHeight := uint64(0); chainHeight < 10; chainHeight++ {
+			for slot := uint64(0); slot < MaxVerifyWindows; slot++ {
+				proposerID, err := w.ExpectedProposer(dummyCtx, chainHeight, pChainHeight, slot)
+				require.NoError(err)
+				require.NotEqual(validatorIDs[0], proposerID)
+				require.NotEqual(validatorIDs[3], proposerID)
+			}
+		}
+
+		proposers, err := w.Proposers(dummyCtx, 1, pChainHeight, MaxVerifyWindows)
+		require.NoError(err)
+		require.NotContains(proposers, validatorIDs[0])
+		require.NotContains(proposers, validatorIDs[3])
+	}
+}
+
+func TestUptimeCoherenceOfExpectedProposerAndMinDelayForProposer(t *testing.T) {
+	require := require.New(t)
+
+	validatorIDs, vdrState := makeValidators(t, 10)
+	w := UptimePolicy{
+		Uptimes: &testUptimeState{
+			uptimes: map[ids.NodeID]float64{
+				validatorIDs[2]: .5,
+			},
+		},
+		MinUptime: .8,
+	}.New(vdrState, subnetID, fixedChainID)
+
+	var (
+		dummyCtx            = context.Background()
+		chainHeight  uint64 = 1
+		pChainHeight uint64 = 0
+	)
+
+	for slot := uint64(0); slot < 3*MaxLookAheadSlots; slot++ {
+		proposerID, err := w.ExpectedProposer(dummyCtx, chainHeight, pChainHeight, slot)
+		require.NoError(err)
+
+		// proposerID is the scheduled proposer. It should start with the
+		// expected delay
+		delay, err := w.MinDelayForProposer(dummyCtx, chainHeight, pChainHeight, proposerID, slot)
+		require.NoError(err)
+		require.Equal(time.Duration(slot)*WindowDuration, delay)
+	}
+}
+
+func TestUptimeMinDelayForProposer(t *testing.T) {
+	require := require.New(t)
+
+	validatorIDs, vdrState := makeValidators(t, 10)
+	w := UptimePolicy{
+		Uptimes: &testUptimeState{
+			uptimes: map[ids.NodeID]float64{
+				validatorIDs[2]: .5,
+			},
+		},
+		MinUptime: .8,
+	}.New(vdrState, subnetID, fixedChainID)
+
+	var (
+		dummyCtx            = context.Background()
+		chainHeight  uint64 = 1
+		pChainHeight uint64 = 0
+		slot         uint64 = 0
+	)
+
+	// The excluded validator is never scheduled, like a non-validator.
+	delay, err := w.MinDelayForProposer(dummyCtx, chainHeight, pChainHeight, validatorIDs[2], slot)
+	require.NoError(err)
+	require.Equal(MaxLookAheadWindow, delay)
+
+	delay, err = w.Delay(dummyCtx, chainHeight, pChainHeight, validatorIDs[2], MaxVerifyWindows)
+	require.NoError(err)
+	require.Equal(MaxVerifyDelay, delay)
+}
+
+// heightUptimeState reports the uptimes at each P-chain height
+type heightUptimeState map[uint64]map[ids.NodeID]float64
+
+func (s heightUptimeState) GetUptimes(_ context.Context, pChainHeight uint64, _ ids.ID) (map[ids.NodeID]float64, error) {
+	return s[pChainHeight], nil
+}
+
+func TestUptimeProposersChangeByPChainHeight(t *testing.T) {
+	require := require.New(t)
+
+	validatorIDs, vdrState := makeValidators(t, 5)
+	uptimes := heightUptimeState{
+		1: {
+			validatorIDs[0]: 0,
+		},
+	}
+	policy := UptimePolicy{
+		Uptimes:   uptimes,
+		MinUptime: .8,
+		Policy:    RoundRobinPolicy{},
+	}
+
+	var (
+		dummyCtx           = context.Background()
+		chainHeight uint64 = 1
+	)
+
+	// Windowers created independently, as on different nodes, agree on the
+	// proposers.
+	w := policy.New(vdrState, subnetID, fixedChainID)
+	otherW := policy.New(vdrState, subnetID, fixedChainID)
+
+	proposers, err := w.Proposers(dummyCtx, chainHeight, 0, MaxVerifyWindows)
+	require.NoError(err)
+	require.ElementsMatch(validatorIDs, proposers)
+
+	proposers, err = w.Proposers(dummyCtx, chainHeight, 1, MaxVerifyWindows)
+	require.NoError(err)
+	require.ElementsMatch(validatorIDs[1:], proposers)
+
+	otherProposers, err := otherW.Proposers(dummyCtx, chainHeight, 1, MaxVerifyWindows)
+	require.NoError(err)
+	require.Equal(proposers, otherProposers)
+}
+
+func TestUptimeStateDoesNotModifyValidatorSet(t *testing.T) {
+	require := require.New(t)
+
+	nodeID := ids.GenerateTestNodeID()
+	vdrs := map[ids.NodeID]*validators.GetValidatorOutput{
+		nodeID: {
+			NodeID: nodeID,
+			Weight: 1,
+		},
+		ids.GenerateTestNodeID(): {
+			Weight: 1,
+		},
+	}
+	vdrState := &validators.TestState{
+		T: t,
+		GetValidatorSetF: func(context.Context, uint64, ids.ID) (map[ids.NodeID]*validators.GetValidatorOutput, error) {
+			return vdrs, nil
+		},
+	}
+
+	state := NewUptimeState(
+		vdrState,
+		&testUptimeState{
+			uptimes: map[ids.NodeID]float64{
+				nodeID: 0,
+			},
+		},
+		1,
+	)
+
+	filteredVdrs, err := state.GetValidatorSet(context.Background(), 0, subnetID)
+	require.NoError(err)
+	require.Len(filteredVdrs, 1)
+	require.NotContains(filteredVdrs, nodeID)
+	require.Len(vdrs, 2)
+}
@@ -153,15 +153,7 @@ func (w *windower) Delay(ctx context.Context, blockHeight, pChainHeight uint64,
 	if err != nil {
 		return 0, err
 	}
-
-	delay := time.Duration(0)
-	for _, nodeID := range proposers {
-		if nodeID == validatorID {
-			return delay, nil
-		}
-		delay += WindowDuration
-	}
-	return delay, nil
+	return proposerDelay(proposers, validatorID), nil
 }
 
 func (w *windower) ExpectedProposer(
@@ -278,6 +270,20 @@ func (w *windower) expectedProposer(
 	return validators[indices[0]].id, nil
 }
 
+// proposerDelay returns the delay of [validatorID] in the proposer list
+// [proposers]. Validators that aren't in the list must wait for every window of
+// the list.
+func proposerDelay(proposers []ids.NodeID, validatorID ids.NodeID) time.Duration {
+	delay := time.Duration(0)
+	for _, nodeID := range proposers {
+		if nodeID == validatorID {
+			return delay
+		}
+		delay += WindowDuration
+	}
+	return delay
+}
+
 func TimeToSlot(start, now time.Time) uint64 {
 	if now.Before(start) {
 		return 0
@@ -154,7 +154,11 @@ func (vm *VM) Initialize(
 		return err
 	}
 	vm.State = baseState
-	vm.Windower = proposer.New(chainCtx.ValidatorState, chainCtx.SubnetID, chainCtx.ChainID)
+	proposerPolicy := vm.ProposerPolicy
+	if proposerPolicy == nil {
+		proposerPolicy = proposer.StakeWeightedPolicy{}
+	}
+	vm.Windower = proposerPolicy.New(chainCtx.ValidatorState, chainCtx.SubnetID, chainCtx.ChainID)
 	vm.Tree = tree.New()
 	innerBlkCache, err := metercacher.New(
 		"inner_block_cache",
@@ -4,17 +4,25 @@
 package archivedb
 
 import (
+	"bytes"
 	"context"
 	"errors"
+	"fmt"
 	"io"
+	"slices"
 
 	"github.com/shubhamdubey02/cryftgo/api/health"
 	"github.com/shubhamdubey02/cryftgo/database"
+	"github.com/shubhamdubey02/cryftgo/utils/units"
 )
 
+// pruneBatchSize is the size of the batches written while pruning.
+const pruneBatchSize = units.MiB
+
 var (
-	ErrNotImplemented = errors.New("feature not implemented")
-	ErrInvalidValue   = errors.New("invalid data value")
+	ErrNotImplemented     = errors.New("feature not implemented")
+	ErrInvalidValue       = errors.New("invalid data value")
+	ErrInvalidHeightRange = errors.New("start height is after end height")
 
 	_ database.Compacter = (*Database)(nil)
 	_ health.Checker     = (*Database)(nil)
@@ -92,6 +100,165 @@ func (db *Database) NewBatch(height uint64) *batch {
 	}
 }
 
+// Version is the modification of a key at a height.
+type Version struct {
+	Height uint64
+	// Nil if the key was deleted.
+	Value []byte
+	// False if the key was deleted.
+	Exists bool
+}
+
+// History returns the modifications of [key] at heights in
+// [startHeight, endHeight], sorted by increasing height.
+func (db *Database) History(key []byte, startHeight, endHeight uint64) ([]Version, error) {
+	if startHeight > endHeight {
+		return nil, fmt.Errorf("%w: %d > %d", ErrInvalidHeightRange, startHeight, endHeight)
+	}
+
+	it := db.db.NewIteratorWithStartAndPrefix(newDBKeyFromUser(key, endHeight))
+	defer it.Release()
+
+	var versions []Version
+	for it.Next() {
+		_, height, err := parseDBKeyFromUser(it.Key())
+		if err != nil {
+			return nil, err
+		}
+		if height < startHeight {
+			break
+		}
+
+		value, exists := parseDBValue(it.Value())
+		versions = append(versions, Version{
+			Height: height,
+			Value:  slices.Clone(value),
+			Exists: exists,
+		})
+	}
+	if err := it.Error(); err != nil {
+		return nil, err
+	}
+
+	// Versions are stored by decreasing height.
+	slices.Reverse(versions)
+	return versions, nil
+}
+
+// PrunedHeight returns the height that the database was last pruned to.
+// Returns database.ErrNotFound if the database has never been pruned.
+func (db *Database) PrunedHeight() (uint64, error) {
+	return database.GetUInt64(db.db, prunedHeightKey)
+}
+
+// Prune removes the modifications made before [height] that aren't needed to
+// read the state at [height] or later. That is, for each key, every
+// modification made before [height] is removed except for the latest one at
+// or below [height]. If that modification is a deletion, it is removed as
+// well.
+//
+// After pruning, readers opened at heights below [height] may return
+// incorrect results. It is left up to the caller to avoid opening them,
+// possibly using [PrunedHeight].
+//
+// Pruning isn't atomic. If it is interrupted, the state at [height] and later
+// is still correct and Prune can be called again.
+func (db *Database) Prune(height uint64) error {
+	prunedHeight, err := db.PrunedHeight()
+	switch {
+	case err == nil && height <= prunedHeight:
+		return nil
+	case err != nil && err != database.ErrNotFound:
+		return err
+	}
+
+	var (
+		batch = db.db.NewBatch()
+		it    = db.db.NewIterator()
+
+		// The user key currently being pruned, if its latest modification at
+		// or below [height] has been found.
+		currentKey []byte
+		// The deletion of [currentKey] at or below [height], which is removed
+		// after all the modifications before it so that an interrupted prune
+		// never exposes an older value.
+		currentTombstone []byte
+	)
+	// Defer the release of the iterator inside a closure to guarantee that the
+	// latest, not the first, iterator is released on return.
+	defer func() {
+		it.Release()
+	}()
+
+	for it.Next() {
+		dbKey := it.Key()
+		key, keyHeight, err := parseDBKeyFromUser(dbKey)
+		if errors.Is(err, ErrIncorrectKeyLength) {
+			// Skip metadata keys.
+			continue
+		}
+		if err != nil {
+			return err
+		}
+
+		if currentKey == nil || !bytes.Equal(key, currentKey) {
+			if currentTombstone != nil {
+				if err := batch.Delete(currentTombstone); err != nil {
+					return err
+				}
+			}
+			currentKey = nil
+			currentTombstone = nil
+		}
+
+		switch {
+		case keyHeight > height:
+			// Needed to read the state after [height].
+		case currentKey == nil:
+			// The latest modification at or below [height].
+			currentKey = slices.Clone(key)
+			if _, exists := parseDBValue(it.Value()); !exists {
+				currentTombstone = slices.Clone(dbKey)
+			}
+		default:
+			if err := batch.Delete(dbKey); err != nil {
+				return err
+			}
+		}
+
+		// Avoid too much memory pressure by periodically writing to the
+		// database.
+		if batch.Size() < pruneBatchSize {
+			continue
+		}
+		if err := batch.Write(); err != nil {
+			return err
+		}
+		batch.Reset()
+
+		// Reset the iterator to release references to now deleted keys.
+		if err := it.Error(); err != nil {
+			return err
+		}
+		nextKey := append(slices.Clone(dbKey), 0)
+		it.Release()
+		it = db.db.NewIteratorWithStart(nextKey)
+	}
+	if err := it.Error(); err != nil {
+		return err
+	}
+
+	if currentTombstone != nil {
+		if err := batch.Delete(currentTombstone); err != nil {
+			return err
+		}
+	}
+	if err := database.PutUInt64(batch, prunedHeightKey, height); err != nil {
+		return err
+	}
+	return batch.Write()
+}
+
 func (db *Database) Compact(start []byte, limit []byte) error {
 	return db.db.Compact(start, limit)
 }
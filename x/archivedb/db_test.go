@@ -225,3 +225,134 @@ func TestSkipHeight(t *testing.T) {
 	require.NoError(err)
 	require.Equal(uint64(10), height)
 }
+
+func TestHistory(t *testing.T) {
+	require := require.New(t)
+
+	db := New(memdb.New())
+
+	batch := db.NewBatch(1)
+	require.NoError(batch.Put([]byte("key"), []byte("value@1")))
+	require.NoError(batch.Put([]byte("key2"), []byte("value2@1")))
+	require.NoError(batch.Write())
+
+	batch = db.NewBatch(3)
+	require.NoError(batch.Delete([]byte("key")))
+	require.NoError(batch.Write())
+
+	batch = db.NewBatch(5)
+	require.NoError(batch.Put([]byte("key"), []byte("value@5")))
+	require.NoError(batch.Write())
+
+	versions, err := db.History([]byte("key"), 0, 10)
+	require.NoError(err)
+	require.Equal(
+		[]Version{
+			{Height: 1, Value: []byte("value@1"), Exists: true},
+			{Height: 3},
+			{Height: 5, Value: []byte("value@5"), Exists: true},
+		},
+		versions,
+	)
+
+	versions, err = db.History([]byte("key"), 2, 4)
+	require.NoError(err)
+	require.Equal([]Version{{Height: 3}}, versions)
+
+	versions, err = db.History([]byte("key"), 6, 10)
+	require.NoError(err)
+	require.Empty(versions)
+
+	versions, err = db.History([]byte("missing"), 0, 10)
+	require.NoError(err)
+	require.Empty(versions)
+
+	_, err = db.History([]byte("key"), 2, 1)
+	require.ErrorIs(err, ErrInvalidHeightRange)
+}
+
+func TestPrune(t *testing.T) {
+	require := require.New(t)
+
+	db := New(memdb.New())
+
+	_, err := db.PrunedHeight()
+	require.ErrorIs(err, database.ErrNotFound)
+
+	batch := db.NewBatch(1)
+	require.NoError(batch.Put([]byte("a"), []byte("a@1")))
+	require.NoError(batch.Put([]byte("b"), []byte("b@1")))
+	require.NoError(batch.Put([]byte("c"), []byte("c@1")))
+	require.NoError(batch.Write())
+
+	batch = db.NewBatch(2)
+	require.NoError(batch.Put([]byte("a"), []byte("a@2")))
+	require.NoError(batch.Delete([]byte("b")))
+	require.NoError(batch.Write())
+
+	batch = db.NewBatch(3)
+	require.NoError(batch.Put([]byte("a"), []byte("a@3")))
+	require.NoError(batch.Put([]byte("c"), []byte("c@3")))
+	require.NoError(batch.Write())
+
+	require.NoError(db.Prune(2))
+
+	prunedHeight, err := db.PrunedHeight()
+	require.NoError(err)
+	require.Equal(uint64(2), prunedHeight)
+
+	// The state at and above the pruned height is unchanged.
+	for _, test := range []struct {
+		height   uint64
+		expected []database.BatchOp
+	}{
+		{
+			height: 2,
+			expected: []database.BatchOp{
+				{Key: []byte("a"), Value: []byte("a@2")},
+				{Key: []byte("c"), Value: []byte("c@1")},
+			},
+		},
+		{
+			height: 3,
+			expected: []database.BatchOp{
+				{Key: []byte("a"), Value: []byte("a@3")},
+				{Key: []byte("c"), Value: []byte("c@3")},
+			},
+		},
+	} {
+		it := db.Open(test.height).NewIterator()
+		require.Equal(test.expected, iterate(t, it))
+		it.Release()
+	}
+
+	// Older modifications and deletions are removed.
+	versions, err := db.History([]byte("a"), 0, 3)
+	require.NoError(err)
+	require.Equal(
+		[]Version{
+			{Height: 2, Value: []byte("a@2"), Exists: true},
+			{Height: 3, Value: []byte("a@3"), Exists: true},
+		},
+		versions,
+	)
+
+	versions, err = db.History([]byte("b"), 0, 3)
+	require.NoError(err)
+	require.Empty(versions)
+
+	versions, err = db.History([]byte("c"), 0, 3)
+	require.NoError(err)
+	require.Len(versions, 2)
+
+	// Pruning to a lower height is a no-op.
+	require.NoError(db.Prune(1))
+
+	prunedHeight, err = db.PrunedHeight()
+	require.NoError(err)
+	require.Equal(uint64(2), prunedHeight)
+
+	height, err := db.Height()
+	require.NoError(err)
+	require.Equal(uint64(3), height)
+}
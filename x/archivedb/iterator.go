@@ -0,0 +1,214 @@
+// Copyright (C) 2019-2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package archivedb
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"slices"
+
+	"github.com/shubhamdubey02/cryftgo/database"
+	"github.com/shubhamdubey02/cryftgo/utils/heap"
+)
+
+var _ database.Iterator = (*iterator)(nil)
+
+// iterator iterates over the keys with a value at a height in increasing key
+// order.
+//
+// Database keys are sorted by the length of the user key before the user key
+// itself, so the keys of each length are iterated separately and merged.
+type iterator struct {
+	lengths heap.Queue[*lengthIterator]
+
+	key, value []byte
+	err        error
+	released   bool
+}
+
+func newIterator(db database.Database, height uint64, start, prefix []byte) *iterator {
+	it := &iterator{
+		lengths: heap.NewQueue(func(a, b *lengthIterator) bool {
+			return bytes.Compare(a.key, b.key) < 0
+		}),
+	}
+
+	lengths, err := keyLengths(db, len(prefix))
+	if err != nil {
+		it.err = err
+		return it
+	}
+	for _, length := range lengths {
+		lengthIt := newLengthIterator(db, height, length, start, prefix)
+		if lengthIt.next() {
+			it.lengths.Push(lengthIt)
+			continue
+		}
+		lengthIt.it.Release()
+		if lengthIt.err != nil {
+			it.err = lengthIt.err
+			return it
+		}
+	}
+	return it
+}
+
+func (it *iterator) Next() bool {
+	if it.err != nil || it.released {
+		it.key = nil
+		it.value = nil
+		return false
+	}
+
+	lengthIt, ok := it.lengths.Peek()
+	if !ok {
+		it.key = nil
+		it.value = nil
+		return false
+	}
+
+	it.key = lengthIt.key
+	it.value = lengthIt.value
+	if lengthIt.next() {
+		it.lengths.Fix(0)
+		return true
+	}
+
+	_, _ = it.lengths.Pop()
+	lengthIt.it.Release()
+	if lengthIt.err != nil {
+		it.err = lengthIt.err
+		it.key = nil
+		it.value = nil
+		return false
+	}
+	return true
+}
+
+func (it *iterator) Error() error {
+	return it.err
+}
+
+func (it *iterator) Key() []byte {
+	return it.key
+}
+
+func (it *iterator) Value() []byte {
+	return it.value
+}
+
+func (it *iterator) Release() {
+	it.released = true
+	for {
+		lengthIt, ok := it.lengths.Pop()
+		if !ok {
+			return
+		}
+		lengthIt.it.Release()
+	}
+}
+
+// lengthIterator iterates over the keys of a single length with a value at a
+// height in increasing key order.
+type lengthIterator struct {
+	it     database.Iterator
+	height uint64
+	start  []byte
+
+	// The most recent user key whose value at [height] was found.
+	lastKey []byte
+
+	key, value []byte
+	err        error
+}
+
+func newLengthIterator(db database.Database, height uint64, length int, start, prefix []byte) *lengthIterator {
+	// Seek to the larger of [prefix] and [start] truncated to [length].
+	seek := prefix
+	if truncatedStart := start[:min(length, len(start))]; bytes.Compare(truncatedStart, seek) > 0 {
+		seek = truncatedStart
+	}
+
+	lengthPrefix := binary.AppendUvarint(nil, uint64(length))
+	return &lengthIterator{
+		it: db.NewIteratorWithStartAndPrefix(
+			append(slices.Clone(lengthPrefix), seek...),
+			append(lengthPrefix, prefix...),
+		),
+		height: height,
+		start:  start,
+	}
+}
+
+// next moves to the next user key with a value at [height]. The key and value
+// are copied because the underlying iterator will be advanced before they are
+// used.
+func (it *lengthIterator) next() bool {
+	for it.it.Next() {
+		key, height, err := parseDBKeyFromUser(it.it.Key())
+		if errors.Is(err, ErrIncorrectKeyLength) {
+			// Metadata keys share their prefix with user keys of the same
+			// length, but never have the length of a user key.
+			continue
+		}
+		if err != nil {
+			it.err = err
+			return false
+		}
+
+		// Versions of a user key are sorted by decreasing height, so the first
+		// version at or below [height] is the value at [height].
+		if height > it.height || (it.lastKey != nil && bytes.Equal(key, it.lastKey)) {
+			continue
+		}
+		it.lastKey = slices.Clone(key)
+		if bytes.Compare(key, it.start) < 0 {
+			continue
+		}
+
+		value, exists := parseDBValue(it.it.Value())
+		if !exists {
+			continue
+		}
+		it.key = it.lastKey
+		it.value = slices.Clone(value)
+		return true
+	}
+	it.err = it.it.Error()
+	return false
+}
+
+// keyLengths returns the lengths of the user keys in [db] that are at least
+// [minLength].
+func keyLengths(db database.Database, minLength int) ([]int, error) {
+	var (
+		lengths []int
+		start   []byte
+	)
+	for {
+		it := db.NewIteratorWithStart(start)
+		if !it.Next() {
+			err := it.Error()
+			it.Release()
+			return lengths, err
+		}
+
+		length, offset := binary.Uvarint(it.Key())
+		if offset <= 0 {
+			it.Release()
+			return nil, ErrParsingKeyLength
+		}
+
+		// Skip the remaining keys with this length. The last byte of a uvarint
+		// never has the high bit set, so incrementing it doesn't overflow.
+		start = slices.Clone(it.Key()[:offset])
+		start[offset-1]++
+		it.Release()
+
+		if length >= uint64(minLength) {
+			lengths = append(lengths, int(length))
+		}
+	}
+}
@@ -0,0 +1,185 @@
+// Copyright (C) 2019-2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package archivedb
+
+import (
+	"bytes"
+	"math/rand"
+	"slices"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/shubhamdubey02/cryftgo/database"
+	"github.com/shubhamdubey02/cryftgo/database/memdb"
+)
+
+func newIteratorTestDB(t *testing.T) *Database {
+	require := require.New(t)
+
+	db := New(memdb.New())
+
+	batch := db.NewBatch(1)
+	require.NoError(batch.Put([]byte("ab"), []byte("ab@1")))
+	require.NoError(batch.Put([]byte("a"), []byte("a@1")))
+	require.NoError(batch.Put([]byte("b"), []byte("b@1")))
+	require.NoError(batch.Put([]byte("abc"), []byte("abc@1")))
+	require.NoError(batch.Write())
+
+	batch = db.NewBatch(2)
+	require.NoError(batch.Put([]byte("ab"), []byte("ab@2")))
+	require.NoError(batch.Delete([]byte("b")))
+	require.NoError(batch.Put([]byte{}, []byte("empty@2")))
+	require.NoError(batch.Write())
+	return db
+}
+
+func TestIterator(t *testing.T) {
+	db := newIteratorTestDB(t)
+
+	tests := []struct {
+		name     string
+		height   uint64
+		start    []byte
+		prefix   []byte
+		expected []database.BatchOp
+	}{
+		{
+			name:     "before first height",
+			height:   0,
+			expected: nil,
+		},
+		{
+			name:   "all at height 1",
+			height: 1,
+			expected: []database.BatchOp{
+				{Key: []byte("a"), Value: []byte("a@1")},
+				{Key: []byte("ab"), Value: []byte("ab@1")},
+				{Key: []byte("abc"), Value: []byte("abc@1")},
+				{Key: []byte("b"), Value: []byte("b@1")},
+			},
+		},
+		{
+			name:   "all at height 2",
+			height: 2,
+			expected: []database.BatchOp{
+				{Key: []byte{}, Value: []byte("empty@2")},
+				{Key: []byte("a"), Value: []byte("a@1")},
+				{Key: []byte("ab"), Value: []byte("ab@2")},
+				{Key: []byte("abc"), Value: []byte("abc@1")},
+			},
+		},
+		{
+			name:   "prefix",
+			height: 2,
+			prefix: []byte("ab"),
+			expected: []database.BatchOp{
+				{Key: []byte("ab"), Value: []byte("ab@2")},
+				{Key: []byte("abc"), Value: []byte("abc@1")},
+			},
+		},
+		{
+			name:   "start",
+			height: 1,
+			start:  []byte("aa"),
+			expected: []database.BatchOp{
+				{Key: []byte("ab"), Value: []byte("ab@1")},
+				{Key: []byte("abc"), Value: []byte("abc@1")},
+				{Key: []byte("b"), Value: []byte("b@1")},
+			},
+		},
+		{
+			name:   "start and prefix",
+			height: 1,
+			start:  []byte("abb"),
+			prefix: []byte("a"),
+			expected: []database.BatchOp{
+				{Key: []byte("abc"), Value: []byte("abc@1")},
+			},
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			it := db.Open(test.height).NewIteratorWithStartAndPrefix(test.start, test.prefix)
+			defer it.Release()
+
+			require.Equal(t, test.expected, iterate(t, it))
+		})
+	}
+}
+
+func TestIteratorMatchesReader(t *testing.T) {
+	require := require.New(t)
+
+	r := rand.New(rand.NewSource(0)) // #nosec G404
+	db := New(memdb.New())
+
+	// Use keys that are long enough for their lengths to be encoded with
+	// multi-byte varints.
+	keys := make([][]byte, 100)
+	for i := range keys {
+		keys[i] = make([]byte, 1+r.Intn(300))
+		_, _ = r.Read(keys[i])
+		keys[i][0] = byte(r.Intn(2))
+	}
+
+	const numHeights = 10
+	for height := uint64(1); height <= numHeights; height++ {
+		batch := db.NewBatch(height)
+		for i := 0; i < 20; i++ {
+			key := keys[r.Intn(len(keys))]
+			if r.Intn(4) == 0 {
+				require.NoError(batch.Delete(key))
+				continue
+			}
+			value := make([]byte, r.Intn(10))
+			_, _ = r.Read(value)
+			require.NoError(batch.Put(key, value))
+		}
+		require.NoError(batch.Write())
+	}
+
+	for height := uint64(0); height <= numHeights; height++ {
+		reader := db.Open(height)
+		for _, prefix := range [][]byte{nil, {0}, {1}} {
+			var expected []database.BatchOp
+			for _, key := range keys {
+				if !bytes.HasPrefix(key, prefix) {
+					continue
+				}
+				value, err := reader.Get(key)
+				if err == database.ErrNotFound {
+					continue
+				}
+				require.NoError(err)
+				expected = append(expected, database.BatchOp{
+					Key:   key,
+					Value: value,
+				})
+			}
+			slices.SortFunc(expected, func(a, b database.BatchOp) int {
+				return bytes.Compare(a.Key, b.Key)
+			})
+			expected = slices.CompactFunc(expected, func(a, b database.BatchOp) bool {
+				return bytes.Equal(a.Key, b.Key)
+			})
+
+			it := reader.NewIteratorWithPrefix(prefix)
+			require.Equal(expected, iterate(t, it))
+			it.Release()
+		}
+	}
+}
+
+func iterate(t *testing.T, it database.Iterator) []database.BatchOp {
+	var ops []database.BatchOp
+	for it.Next() {
+		ops = append(ops, database.BatchOp{
+			Key:   it.Key(),
+			Value: it.Value(),
+		})
+	}
+	require.NoError(t, it.Error())
+	return ops
+}
@@ -14,7 +14,8 @@ var (
 	ErrParsingKeyLength   = errors.New("failed reading key length")
 	ErrIncorrectKeyLength = errors.New("incorrect key length")
 
-	heightKey = newDBKeyFromMetadata([]byte{})
+	heightKey       = newDBKeyFromMetadata([]byte{})
+	prunedHeightKey = newDBKeyFromMetadata([]byte("pruned"))
 )
 
 // The requirements of a database key are:
@@ -0,0 +1,323 @@
+// Copyright (C) 2019-2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package archivedb
+
+import (
+	"context"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+
+	"github.com/shubhamdubey02/cryftgo/api/health"
+	"github.com/shubhamdubey02/cryftgo/database"
+	"github.com/shubhamdubey02/cryftgo/utils"
+)
+
+const methodLabel = "method"
+
+var (
+	_ database.Compacter      = (*MeterDatabase)(nil)
+	_ health.Checker          = (*MeterDatabase)(nil)
+	_ database.KeyValueReader = (*MeterReader)(nil)
+	_ database.Iteratee       = (*MeterReader)(nil)
+	_ database.Batch          = (*meterBatch)(nil)
+	_ database.Iterator       = (*meterIterator)(nil)
+
+	methodLabels = []string{methodLabel}
+	heightLabel  = prometheus.Labels{
+		methodLabel: "height",
+	}
+	prunedHeightLabel = prometheus.Labels{
+		methodLabel: "pruned_height",
+	}
+	historyLabel = prometheus.Labels{
+		methodLabel: "history",
+	}
+	pruneLabel = prometheus.Labels{
+		methodLabel: "prune",
+	}
+	compactLabel = prometheus.Labels{
+		methodLabel: "compact",
+	}
+	closeLabel = prometheus.Labels{
+		methodLabel: "close",
+	}
+	healthCheckLabel = prometheus.Labels{
+		methodLabel: "health_check",
+	}
+	hasLabel = prometheus.Labels{
+		methodLabel: "has",
+	}
+	getLabel = prometheus.Labels{
+		methodLabel: "get",
+	}
+	getEntryLabel = prometheus.Labels{
+		methodLabel: "get_entry",
+	}
+	newIteratorLabel = prometheus.Labels{
+		methodLabel: "new_iterator",
+	}
+	batchPutLabel = prometheus.Labels{
+		methodLabel: "batch_put",
+	}
+	batchDeleteLabel = prometheus.Labels{
+		methodLabel: "batch_delete",
+	}
+	batchWriteLabel = prometheus.Labels{
+		methodLabel: "batch_write",
+	}
+	iteratorNextLabel = prometheus.Labels{
+		methodLabel: "iterator_next",
+	}
+)
+
+// MeterDatabase tracks the amount of time each operation on a [Database] takes
+// and how many bytes are read/written by it.
+type MeterDatabase struct {
+	db *Database
+
+	calls    *prometheus.CounterVec
+	duration *prometheus.GaugeVec
+	size     *prometheus.CounterVec
+}
+
+// NewMeterDatabase returns a new database with added metrics
+func NewMeterDatabase(
+	namespace string,
+	reg prometheus.Registerer,
+	db *Database,
+) (*MeterDatabase, error) {
+	meterDB := &MeterDatabase{
+		db: db,
+		calls: prometheus.NewCounterVec(
+			prometheus.CounterOpts{
+				Namespace: namespace,
+				Name:      "calls",
+				Help:      "number of calls to the database",
+			},
+			methodLabels,
+		),
+		duration: prometheus.NewGaugeVec(
+			prometheus.GaugeOpts{
+				Namespace: namespace,
+				Name:      "duration",
+				Help:      "time spent in database calls (ns)",
+			},
+			methodLabels,
+		),
+		size: prometheus.NewCounterVec(
+			prometheus.CounterOpts{
+				Namespace: namespace,
+				Name:      "size",
+				Help:      "size of data passed in database calls",
+			},
+			methodLabels,
+		),
+	}
+	return meterDB, utils.Err(
+		reg.Register(meterDB.calls),
+		reg.Register(meterDB.duration),
+		reg.Register(meterDB.size),
+	)
+}
+
+// observe records a call that started at [start] and passed [size] bytes.
+func (db *MeterDatabase) observe(labels prometheus.Labels, start time.Time, size int) {
+	duration := time.Since(start)
+
+	db.calls.With(labels).Inc()
+	db.duration.With(labels).Add(float64(duration))
+	if size > 0 {
+		db.size.With(labels).Add(float64(size))
+	}
+}
+
+func (db *MeterDatabase) Height() (uint64, error) {
+	start := time.Now()
+	height, err := db.db.Height()
+	db.observe(heightLabel, start, 0)
+	return height, err
+}
+
+func (db *MeterDatabase) PrunedHeight() (uint64, error) {
+	start := time.Now()
+	height, err := db.db.PrunedHeight()
+	db.observe(prunedHeightLabel, start, 0)
+	return height, err
+}
+
+// Open returns a reader for the state at the given height. Reads from the
+// reader are metered.
+func (db *MeterDatabase) Open(height uint64) *MeterReader {
+	return &MeterReader{
+		reader: db.db.Open(height),
+		db:     db,
+	}
+}
+
+// NewBatch creates a write batch to perform changes at a given height. Writes
+// to the batch are metered.
+func (db *MeterDatabase) NewBatch(height uint64) database.Batch {
+	return &meterBatch{
+		batch: db.db.NewBatch(height),
+		db:    db,
+	}
+}
+
+func (db *MeterDatabase) History(key []byte, startHeight, endHeight uint64) ([]Version, error) {
+	start := time.Now()
+	versions, err := db.db.History(key, startHeight, endHeight)
+	size := len(key)
+	for _, version := range versions {
+		size += len(version.Value)
+	}
+	db.observe(historyLabel, start, size)
+	return versions, err
+}
+
+func (db *MeterDatabase) Prune(height uint64) error {
+	start := time.Now()
+	err := db.db.Prune(height)
+	db.observe(pruneLabel, start, 0)
+	return err
+}
+
+func (db *MeterDatabase) Compact(start, limit []byte) error {
+	startTime := time.Now()
+	err := db.db.Compact(start, limit)
+	db.observe(compactLabel, startTime, 0)
+	return err
+}
+
+func (db *MeterDatabase) HealthCheck(ctx context.Context) (interface{}, error) {
+	start := time.Now()
+	result, err := db.db.HealthCheck(ctx)
+	db.observe(healthCheckLabel, start, 0)
+	return result, err
+}
+
+func (db *MeterDatabase) Close() error {
+	start := time.Now()
+	err := db.db.Close()
+	db.observe(closeLabel, start, 0)
+	return err
+}
+
+type MeterReader struct {
+	reader *Reader
+	db     *MeterDatabase
+}
+
+func (r *MeterReader) Has(key []byte) (bool, error) {
+	start := time.Now()
+	has, err := r.reader.Has(key)
+	r.db.observe(hasLabel, start, len(key))
+	return has, err
+}
+
+func (r *MeterReader) Get(key []byte) ([]byte, error) {
+	start := time.Now()
+	value, err := r.reader.Get(key)
+	r.db.observe(getLabel, start, len(key)+len(value))
+	return value, err
+}
+
+func (r *MeterReader) GetEntry(key []byte) ([]byte, uint64, bool, error) {
+	start := time.Now()
+	value, height, exists, err := r.reader.GetEntry(key)
+	r.db.observe(getEntryLabel, start, len(key)+len(value))
+	return value, height, exists, err
+}
+
+func (r *MeterReader) NewIterator() database.Iterator {
+	return r.NewIteratorWithStartAndPrefix(nil, nil)
+}
+
+func (r *MeterReader) NewIteratorWithStart(start []byte) database.Iterator {
+	return r.NewIteratorWithStartAndPrefix(start, nil)
+}
+
+func (r *MeterReader) NewIteratorWithPrefix(prefix []byte) database.Iterator {
+	return r.NewIteratorWithStartAndPrefix(nil, prefix)
+}
+
+func (r *MeterReader) NewIteratorWithStartAndPrefix(start, prefix []byte) database.Iterator {
+	startTime := time.Now()
+	it := &meterIterator{
+		iterator: r.reader.NewIteratorWithStartAndPrefix(start, prefix),
+		db:       r.db,
+	}
+	r.db.observe(newIteratorLabel, startTime, 0)
+	return it
+}
+
+type meterBatch struct {
+	batch database.Batch
+	db    *MeterDatabase
+}
+
+func (b *meterBatch) Put(key, value []byte) error {
+	start := time.Now()
+	err := b.batch.Put(key, value)
+	b.db.observe(batchPutLabel, start, len(key)+len(value))
+	return err
+}
+
+func (b *meterBatch) Delete(key []byte) error {
+	start := time.Now()
+	err := b.batch.Delete(key)
+	b.db.observe(batchDeleteLabel, start, len(key))
+	return err
+}
+
+func (b *meterBatch) Size() int {
+	return b.batch.Size()
+}
+
+func (b *meterBatch) Write() error {
+	start := time.Now()
+	err := b.batch.Write()
+	b.db.observe(batchWriteLabel, start, b.batch.Size())
+	return err
+}
+
+func (b *meterBatch) Reset() {
+	b.batch.Reset()
+}
+
+func (b *meterBatch) Replay(w database.KeyValueWriterDeleter) error {
+	return b.batch.Replay(w)
+}
+
+func (b *meterBatch) Inner() database.Batch {
+	return b.batch
+}
+
+type meterIterator struct {
+	iterator database.Iterator
+	db       *MeterDatabase
+}
+
+func (it *meterIterator) Next() bool {
+	start := time.Now()
+	next := it.iterator.Next()
+	it.db.observe(iteratorNextLabel, start, len(it.iterator.Key())+len(it.iterator.Value()))
+	return next
+}
+
+func (it *meterIterator) Error() error {
+	return it.iterator.Error()
+}
+
+func (it *meterIterator) Key() []byte {
+	return it.iterator.Key()
+}
+
+func (it *meterIterator) Value() []byte {
+	return it.iterator.Value()
+}
+
+func (it *meterIterator) Release() {
+	it.iterator.Release()
+}
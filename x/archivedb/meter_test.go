@@ -0,0 +1,43 @@
+// Copyright (C) 2019-2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package archivedb
+
+import (
+	"testing"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/require"
+
+	"github.com/shubhamdubey02/cryftgo/database/memdb"
+)
+
+func TestMeterDatabase(t *testing.T) {
+	require := require.New(t)
+
+	reg := prometheus.NewRegistry()
+	db, err := NewMeterDatabase("", reg, New(memdb.New()))
+	require.NoError(err)
+
+	batch := db.NewBatch(1)
+	require.NoError(batch.Put([]byte("key"), []byte("value")))
+	require.NoError(batch.Write())
+
+	reader := db.Open(1)
+	value, err := reader.Get([]byte("key"))
+	require.NoError(err)
+	require.Equal([]byte("value"), value)
+
+	it := reader.NewIterator()
+	require.True(it.Next())
+	require.False(it.Next())
+	require.NoError(it.Error())
+	it.Release()
+
+	require.Equal(float64(1), testutil.ToFloat64(db.calls.With(batchPutLabel)))
+	require.Equal(float64(len("key")+len("value")), testutil.ToFloat64(db.size.With(batchPutLabel)))
+	require.Equal(float64(1), testutil.ToFloat64(db.calls.With(getLabel)))
+	require.Equal(float64(1), testutil.ToFloat64(db.calls.With(newIteratorLabel)))
+	require.Equal(float64(2), testutil.ToFloat64(db.calls.With(iteratorNextLabel)))
+}
@@ -5,7 +5,10 @@ package archivedb
 
 import "github.com/shubhamdubey02/cryftgo/database"
 
-var _ database.KeyValueReader = (*Reader)(nil)
+var (
+	_ database.KeyValueReader = (*Reader)(nil)
+	_ database.Iteratee       = (*Reader)(nil)
+)
 
 type Reader struct {
 	db     *Database
@@ -59,3 +62,24 @@ func (r *Reader) GetEntry(key []byte) ([]byte, uint64, bool, error) {
 	}
 	return value, height, true, nil
 }
+
+// NewIterator iterates over the keys that have a value at the height of the
+// reader.
+//
+// Because the database isn't sorted by user key, creating an iterator
+// requires a lookup for each distinct key length in the database.
+func (r *Reader) NewIterator() database.Iterator {
+	return r.NewIteratorWithStartAndPrefix(nil, nil)
+}
+
+func (r *Reader) NewIteratorWithStart(start []byte) database.Iterator {
+	return r.NewIteratorWithStartAndPrefix(start, nil)
+}
+
+func (r *Reader) NewIteratorWithPrefix(prefix []byte) database.Iterator {
+	return r.NewIteratorWithStartAndPrefix(nil, prefix)
+}
+
+func (r *Reader) NewIteratorWithStartAndPrefix(start, prefix []byte) database.Iterator {
+	return newIterator(r.db.db, r.height, start, prefix)
+}
@@ -11,6 +11,7 @@ import (
 	"runtime"
 	"slices"
 	"sync"
+	"time"
 
 	"github.com/prometheus/client_golang/prometheus"
 	"go.opentelemetry.io/otel/attribute"
@@ -154,6 +155,7 @@ type MerkleDB interface {
 	Trie
 	MerkleRootGetter
 	ProofGetter
+	MultiProofGetter
 	ChangeProofer
 	RangeProofer
 	Prefetcher
@@ -171,12 +173,27 @@ type Config struct {
 	// RootGenConcurrency is the number of goroutines to use when
 	// generating a new state root.
 	//
+	// Changed subtries are hashed concurrently while fewer than
+	// [RootGenConcurrency] goroutines are hashing. Otherwise, they are hashed
+	// by the goroutine that reached them. The resulting nodes don't depend on
+	// the concurrency. If 1 is specified, nodes are hashed serially.
+	//
 	// If 0 is specified, [runtime.NumCPU] will be used.
 	RootGenConcurrency uint
 
 	// The number of changes to the database that we store in memory in order to
 	// serve change proofs.
 	HistoryLength uint
+	// The maximum number of changes to the database that we store on disk in
+	// order to serve change proofs for roots that are no longer in memory.
+	// The changes on disk are kept across restarts.
+	//
+	// If both [HistoryDiskLength] and [HistoryDiskRetention] are 0, changes
+	// aren't stored on disk. If only one of them is 0, it doesn't limit the
+	// changes stored on disk.
+	HistoryDiskLength uint
+	// The maximum age of changes to the database that we store on disk.
+	HistoryDiskRetention time.Duration
 	// The number of bytes used to cache nodes with values.
 	ValueNodeCacheSize uint
 	// The number of bytes used to cache nodes without values.
@@ -216,6 +233,11 @@ type merkleDB struct {
 	// historical views of the trie.
 	history *trieHistory
 
+	// Stores change lists on disk. Used when [history] doesn't contain the
+	// requested roots.
+	// Nil if changes aren't stored on disk.
+	historyDB *historyDB
+
 	// True iff the db has been closed.
 	closed bool
 
@@ -325,6 +347,28 @@ func newDatabase(
 		}
 	}
 
+	// The history on disk is only opened after the trie has been rebuilt
+	// because the changes made while rebuilding aren't relative to the
+	// previous trie.
+	if config.HistoryDiskLength > 0 || config.HistoryDiskRetention > 0 {
+		trieDB.historyDB, err = newHistoryDB(
+			db,
+			hasher,
+			int(config.HistoryDiskLength),
+			config.HistoryDiskRetention,
+			trieDB.root,
+			trieDB.rootID,
+		)
+		if err != nil {
+			return nil, err
+		}
+	} else if err := database.DeletePrefix(db, historyPrefix); err != nil {
+		// Remove any history from when changes were previously stored on
+		// disk, since it can't be used to reach the current trie once changes
+		// are made without being recorded.
+		return nil, err
+	}
+
 	// add current root to history (has no changes)
 	trieDB.history.record(&changeSummary{
 		rootID: trieDB.rootID,
@@ -656,6 +700,34 @@ func (db *merkleDB) GetProof(ctx context.Context, key []byte) (*Proof, error) {
 	return getProof(db, key)
 }
 
+func (db *merkleDB) GetExclusionProof(ctx context.Context, key []byte) (*Proof, error) {
+	db.commitLock.RLock()
+	defer db.commitLock.RUnlock()
+
+	_, span := db.infoTracer.Start(ctx, "MerkleDB.GetExclusionProof")
+	defer span.End()
+
+	if db.closed {
+		return nil, database.ErrClosed
+	}
+
+	return getExclusionProof(db, key)
+}
+
+func (db *merkleDB) GetMultiProof(ctx context.Context, keys [][]byte) (*MultiProof, error) {
+	db.commitLock.RLock()
+	defer db.commitLock.RUnlock()
+
+	_, span := db.infoTracer.Start(ctx, "MerkleDB.GetMultiProof")
+	defer span.End()
+
+	if db.closed {
+		return nil, database.ErrClosed
+	}
+
+	return getMultiProof(db, keys)
+}
+
 func (db *merkleDB) GetRangeProof(
 	ctx context.Context,
 	start maybe.Maybe[[]byte],
@@ -732,6 +804,9 @@ func (db *merkleDB) GetChangeProof(
 	}
 
 	changes, err := db.history.getValueChanges(startRootID, endRootID, start, end, maxLength)
+	if errors.Is(err, ErrInsufficientHistory) && db.historyDB != nil {
+		changes, err = db.historyDB.getValueChanges(startRootID, endRootID, start, end, maxLength)
+	}
 	if err != nil {
 		return nil, err
 	}
@@ -988,11 +1063,23 @@ func (db *merkleDB) commitView(ctx context.Context, trieToCommit *view) error {
 		return err
 	}
 
+	var pendingHistory historyWrite
+	if db.historyDB != nil {
+		var err error
+		pendingHistory, err = db.historyDB.write(valueNodeBatch, changes)
+		if err != nil {
+			return err
+		}
+	}
+
 	if err := db.commitValueChanges(ctx, valueNodeBatch); err != nil {
 		return err
 	}
 
 	db.history.record(changes)
+	if db.historyDB != nil {
+		db.historyDB.apply(pendingHistory)
+	}
 
 	// Update root in database.
 	db.root = changes.rootChange.after
@@ -1277,6 +1364,9 @@ func (db *merkleDB) getTrieAtRootForRange(
 	}
 
 	changeHistory, err := db.history.getChangesToGetToRoot(rootID, start, end)
+	if errors.Is(err, ErrInsufficientHistory) && db.historyDB != nil {
+		changeHistory, err = db.historyDB.getChangesToGetToRoot(rootID, start, end)
+	}
 	if err != nil {
 		return nil, err
 	}
@@ -1366,11 +1456,28 @@ func (db *merkleDB) Clear() error {
 
 	// Clear history
 	db.history = newTrieHistory(db.history.maxHistoryLen)
-	db.history.record(&changeSummary{
+	emptyChanges := &changeSummary{
 		rootID: db.rootID,
 		values: map[Key]*change[maybe.Maybe[[]byte]]{},
 		nodes:  map[Key]*change[*node]{},
-	})
+	}
+	db.history.record(emptyChanges)
+	if db.historyDB == nil {
+		return nil
+	}
+	if err := db.historyDB.clear(); err != nil {
+		return err
+	}
+
+	batch := db.baseDB.NewBatch()
+	pendingHistory, err := db.historyDB.write(batch, emptyChanges)
+	if err != nil {
+		return err
+	}
+	if err := batch.Write(); err != nil {
+		return err
+	}
+	db.historyDB.apply(pendingHistory)
 	return nil
 }
 
@@ -1378,6 +1485,18 @@ func (db *merkleDB) getTokenSize() int {
 	return db.tokenSize
 }
 
+// clearPrefix removes all keys with the given [prefix] from [db].
+//
+// If [db] implements database.RangeDeleter, the keys are removed with a single
+// range deletion. Otherwise, they are removed in a single batch so that the
+// removal is still atomic.
+func clearPrefix(db database.Database, prefix []byte) error {
+	if deleter, ok := db.(database.RangeDeleter); ok {
+		return deleter.DeleteRange(prefix, database.PrefixLimit(prefix))
+	}
+	return database.AtomicClearPrefix(db, db, prefix)
+}
+
 // Returns [key] prefixed by [prefix].
 // The returned *[]byte is taken from [bufferPool] and should be returned to it
 // when the caller is done with it.
@@ -781,46 +781,55 @@ func Test_MerkleDB_Random_Insert_Ordering(t *testing.T) {
 }
 
 func TestMerkleDBClear(t *testing.T) {
-	require := require.New(t)
+	for _, test := range clearTests {
+		t.Run(test.name, func(t *testing.T) {
+			require := require.New(t)
 
-	// Make a database and insert some key-value pairs.
-	db, err := getBasicDB()
-	require.NoError(err)
+			// Make a database and insert some key-value pairs.
+			db, err := newDatabase(
+				context.Background(),
+				test.newBaseDB(),
+				newDefaultConfig(),
+				&mockMetrics{},
+			)
+			require.NoError(err)
 
-	emptyRootID := db.getMerkleRoot()
+			emptyRootID := db.getMerkleRoot()
 
-	now := time.Now().UnixNano()
-	t.Logf("seed: %d", now)
-	r := rand.New(rand.NewSource(now)) // #nosec G404
+			now := time.Now().UnixNano()
+			t.Logf("seed: %d", now)
+			r := rand.New(rand.NewSource(now)) // #nosec G404
 
-	insertRandomKeyValues(
-		require,
-		r,
-		[]database.Database{db},
-		1_000,
-		0.25,
-	)
+			insertRandomKeyValues(
+				require,
+				r,
+				[]database.Database{db},
+				1_000,
+				0.25,
+			)
 
-	// Clear the database.
-	require.NoError(db.Clear())
-
-	// Assert that the database is empty.
-	iter := db.NewIterator()
-	defer iter.Release()
-	require.False(iter.Next())
-	require.Equal(ids.Empty, db.getMerkleRoot())
-	require.True(db.root.IsNothing())
-
-	// Assert caches are empty.
-	require.Zero(db.valueNodeDB.nodeCache.Len())
-	require.Zero(db.intermediateNodeDB.writeBuffer.currentSize)
-
-	// Assert history has only the clearing change.
-	require.Len(db.history.lastChanges, 1)
-	change, ok := db.history.lastChanges[emptyRootID]
-	require.True(ok)
-	require.Empty(change.nodes)
-	require.Empty(change.values)
+			// Clear the database.
+			require.NoError(db.Clear())
+
+			// Assert that the database is empty.
+			iter := db.NewIterator()
+			defer iter.Release()
+			require.False(iter.Next())
+			require.Equal(ids.Empty, db.getMerkleRoot())
+			require.True(db.root.IsNothing())
+
+			// Assert caches are empty.
+			require.Zero(db.valueNodeDB.nodeCache.Len())
+			require.Zero(db.intermediateNodeDB.writeBuffer.currentSize)
+
+			// Assert history has only the clearing change.
+			require.Len(db.history.lastChanges, 1)
+			change, ok := db.history.lastChanges[emptyRootID]
+			require.True(ok)
+			require.Empty(change.nodes)
+			require.Empty(change.values)
+		})
+	}
 }
 
 func FuzzMerkleDBEmptyRandomizedActions(f *testing.F) {
@@ -835,18 +844,21 @@ func FuzzMerkleDBEmptyRandomizedActions(f *testing.F) {
 			}
 			require := require.New(t)
 			r := rand.New(rand.NewSource(randSeed)) // #nosec G404
-			for _, ts := range validTokenSizes {
-				runRandDBTest(
-					require,
-					r,
-					generateRandTest(
+			for _, historyOnDisk := range []bool{false, true} {
+				for _, ts := range validTokenSizes {
+					runRandDBTest(
 						require,
 						r,
-						size,
-						0.01, /*checkHashProbability*/
-					),
-					ts,
-				)
+						generateRandTest(
+							require,
+							r,
+							size,
+							0.01, /*checkHashProbability*/
+							historyOnDisk,
+						),
+						ts,
+					)
+				}
 			}
 		})
 }
@@ -863,26 +875,34 @@ func FuzzMerkleDBInitialValuesRandomizedActions(f *testing.F) {
 		}
 		require := require.New(t)
 		r := rand.New(rand.NewSource(randSeed)) // #nosec G404
-		for _, ts := range validTokenSizes {
-			runRandDBTest(
-				require,
-				r,
-				generateInitialValues(
+		for _, historyOnDisk := range []bool{false, true} {
+			for _, ts := range validTokenSizes {
+				runRandDBTest(
 					require,
 					r,
-					initialValues,
-					numSteps,
-					0.001, /*checkHashProbability*/
-				),
-				ts,
-			)
+					generateInitialValues(
+						require,
+						r,
+						initialValues,
+						numSteps,
+						0.001, /*checkHashProbability*/
+						historyOnDisk,
+					),
+					ts,
+				)
+			}
 		}
 	})
 }
 
 // randTest performs random trie operations.
 // Instances of this test are created by Generate.
-type randTest []randTestStep
+type randTest struct {
+	steps []randTestStep
+	// historyOnDisk stores the change history on disk and allows the test to
+	// restart the database between steps.
+	historyOnDisk bool
+}
 
 type randTestStep struct {
 	op    int
@@ -898,11 +918,20 @@ const (
 	opGenerateRangeProof
 	opGenerateChangeProof
 	opCheckhash
-	opMax // boundary value, not an actual op
+	opRestart // only generated when the history is stored on disk
+	opMax     // boundary value, not an actual op
 )
 
 func runRandDBTest(require *require.Assertions, r *rand.Rand, rt randTest, tokenSize int) {
-	db, err := getBasicDBWithBranchFactor(tokenSizeToBranchFactor[tokenSize])
+	baseDB := memdb.New()
+	config := newDefaultConfig()
+	config.BranchFactor = tokenSizeToBranchFactor[tokenSize]
+	if rt.historyOnDisk {
+		// Changes are stored on disk so that proofs can still be generated for
+		// past roots after restarting.
+		config.HistoryDiskLength = defaultHistoryLength
+	}
+	db, err := newDatabase(context.Background(), baseDB, config, &mockMetrics{})
 	require.NoError(err)
 
 	const (
@@ -921,8 +950,8 @@ func runRandDBTest(require *require.Assertions, r *rand.Rand, rt randTest, token
 	startRoot, err := db.GetMerkleRoot(context.Background())
 	require.NoError(err)
 
-	for i, step := range rt {
-		require.LessOrEqual(i, len(rt))
+	for i, step := range rt.steps {
+		require.LessOrEqual(i, len(rt.steps))
 		switch step.op {
 		case opUpdate:
 			require.NoError(currentBatch.Put(step.key, step.value))
@@ -1076,6 +1105,17 @@ func runRandDBTest(require *require.Assertions, r *rand.Rand, rt randTest, token
 			dbRoot, err := db.GetMerkleRoot(context.Background())
 			require.NoError(err)
 			require.Equal(dbRoot, newRoot)
+		case opRestart:
+			require.True(rt.historyOnDisk)
+			require.NoError(db.Close())
+
+			// Uncommitted changes are lost when the database is closed.
+			clear(uncommittedKeyValues)
+			uncommittedDeletes.Clear()
+
+			db, err = newDatabase(context.Background(), baseDB, config, &mockMetrics{})
+			require.NoError(err)
+			currentBatch = db.NewBatch()
 		default:
 			require.FailNow("unknown op")
 		}
@@ -1088,8 +1128,18 @@ func generateRandTestWithKeys(
 	allKeys [][]byte,
 	size uint,
 	checkHashProbability float64,
-) randTest {
-	const nilEndProbability = 0.1
+	historyOnDisk bool,
+) []randTestStep {
+	const (
+		nilEndProbability  = 0.1
+		restartProbability = 0.1
+	)
+
+	// Restarting is only meaningful when the history survives it.
+	maxOp := opRestart
+	if historyOnDisk {
+		maxOp = opMax
+	}
 
 	genKey := func() []byte {
 		if len(allKeys) < 2 || r.Intn(100) < 10 {
@@ -1133,9 +1183,9 @@ func generateRandTestWithKeys(
 		return endKey
 	}
 
-	var steps randTest
+	var steps []randTestStep
 	for i := uint(0); i < size-1; {
-		step := randTestStep{op: r.Intn(opMax)}
+		step := randTestStep{op: r.Intn(maxOp)}
 		switch step.op {
 		case opUpdate:
 			step.key = genKey()
@@ -1156,6 +1206,10 @@ func generateRandTestWithKeys(
 			if r.Float64() > checkHashProbability {
 				continue
 			}
+		case opRestart:
+			if r.Float64() > restartProbability {
+				continue
+			}
 		}
 		steps = append(steps, step)
 		i++
@@ -1171,6 +1225,7 @@ func generateInitialValues(
 	numInitialKeyValues uint,
 	size uint,
 	percentChanceToFullHash float64,
+	historyOnDisk bool,
 ) randTest {
 	const (
 		prefixProbability   = 0.1
@@ -1196,7 +1251,7 @@ func generateInitialValues(
 		return key
 	}
 
-	var steps randTest
+	var steps []randTestStep
 	for i := uint(0); i < numInitialKeyValues; i++ {
 		step := randTestStep{
 			op:    opUpdate,
@@ -1214,12 +1269,18 @@ func generateInitialValues(
 		steps = append(steps, step)
 	}
 	steps = append(steps, randTestStep{op: opWriteBatch})
-	steps = append(steps, generateRandTestWithKeys(require, r, allKeys, size, percentChanceToFullHash)...)
-	return steps
+	steps = append(steps, generateRandTestWithKeys(require, r, allKeys, size, percentChanceToFullHash, historyOnDisk)...)
+	return randTest{
+		steps:         steps,
+		historyOnDisk: historyOnDisk,
+	}
 }
 
-func generateRandTest(require *require.Assertions, r *rand.Rand, size uint, percentChanceToFullHash float64) randTest {
-	return generateRandTestWithKeys(require, r, [][]byte{}, size, percentChanceToFullHash)
+func generateRandTest(require *require.Assertions, r *rand.Rand, size uint, percentChanceToFullHash float64, historyOnDisk bool) randTest {
+	return randTest{
+		steps:         generateRandTestWithKeys(require, r, [][]byte{}, size, percentChanceToFullHash, historyOnDisk),
+		historyOnDisk: historyOnDisk,
+	}
 }
 
 // Inserts [n] random key/value pairs into each database.
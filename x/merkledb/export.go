@@ -0,0 +1,279 @@
+// Copyright (C) 2019-2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package merkledb
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+
+	"google.golang.org/protobuf/proto"
+
+	"github.com/shubhamdubey02/cryftgo/ids"
+	"github.com/shubhamdubey02/cryftgo/utils/maybe"
+	"github.com/shubhamdubey02/cryftgo/utils/units"
+
+	pb "github.com/shubhamdubey02/cryftgo/proto/pb/sync"
+)
+
+const (
+	exportVersion uint16 = 0
+
+	// maxExportChunkSize is the maximum size of a chunk that will be read
+	// during an import.
+	maxExportChunkSize = 256 * units.MiB
+)
+
+var (
+	// exportMagic is written at the start of every export.
+	exportMagic = []byte("merkledb")
+
+	ErrInvalidExport      = errors.New("invalid export")
+	ErrUnexpectedRoot     = errors.New("unexpected root")
+	errEmptyExportChunk   = fmt.Errorf("%w: chunk has no key/values", ErrInvalidExport)
+	errExportChunkTooLong = fmt.Errorf("%w: chunk exceeds maximum size", ErrInvalidExport)
+)
+
+// ImportConfig describes the trie that an export is expected to contain.
+type ImportConfig struct {
+	// BranchFactor must be the branch factor of the trie that was exported.
+	BranchFactor BranchFactor
+	// Hasher must be the hasher of the trie that was exported.
+	//
+	// If not specified, [DefaultHasher] will be used.
+	Hasher Hasher
+}
+
+// Export writes the key/value pairs of [db] as they were when its root was
+// [rootID] to [w].
+//
+// The export consists of a header followed by chunks of up to [maxLength]
+// key/value pairs in increasing order of key. Each chunk is a range proof for
+// its key/value pairs against [rootID], so the export can be imported without
+// trusting its source. The export is terminated by an empty chunk.
+//
+// Chunks are generated with [RangeProofer.GetRangeProofAtRoot], so [rootID]
+// must either be the current root of [db] or be in its history.
+//
+// The export format is:
+//   - "merkledb" magic bytes
+//   - 2 byte big endian version
+//   - 32 byte root ID
+//   - for each chunk, the uvarint length of the chunk followed by the
+//     protobuf encoded range proof
+//   - a uvarint 0 to mark the end of the export
+func Export(ctx context.Context, db RangeProofer, rootID ids.ID, maxLength int, w io.Writer) error {
+	if maxLength <= 0 {
+		return fmt.Errorf("%w but was %d", ErrInvalidMaxLength, maxLength)
+	}
+
+	bw := bufio.NewWriter(w)
+	header := make([]byte, 0, len(exportMagic)+2+ids.IDLen)
+	header = append(header, exportMagic...)
+	header = binary.BigEndian.AppendUint16(header, exportVersion)
+	header = append(header, rootID[:]...)
+	if _, err := bw.Write(header); err != nil {
+		return err
+	}
+
+	// The empty trie has no key/value pairs to export.
+	start := maybe.Nothing[[]byte]()
+	for rootID != ids.Empty {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+
+		proof, err := db.GetRangeProofAtRoot(ctx, rootID, start, maybe.Nothing[[]byte](), maxLength)
+		if err != nil {
+			return err
+		}
+		if len(proof.KeyValues) == 0 {
+			break
+		}
+
+		if err := writeExportChunk(bw, proof); err != nil {
+			return err
+		}
+		if len(proof.KeyValues) < maxLength {
+			break
+		}
+
+		// The next chunk starts immediately after the largest key in this
+		// chunk.
+		start = maybe.Some(nextKey(proof.KeyValues[len(proof.KeyValues)-1].Key))
+	}
+
+	if _, err := bw.Write(binary.AppendUvarint(nil, 0)); err != nil {
+		return err
+	}
+	return bw.Flush()
+}
+
+// Import replaces the key/value pairs in [db] with the key/value pairs in the
+// export read from [r].
+//
+// Each chunk of the export is verified to be a valid range proof against
+// [expectedRootID] before it is committed to [db]. Once every chunk has been
+// committed, the root of [db] is verified to be [expectedRootID].
+//
+// If Import returns an error, [db] may contain a subset of the key/value
+// pairs in the export.
+func Import(ctx context.Context, db MerkleDB, r io.Reader, expectedRootID ids.ID, config ImportConfig) error {
+	if err := config.BranchFactor.Valid(); err != nil {
+		return err
+	}
+	hasher := config.Hasher
+	if hasher == nil {
+		hasher = DefaultHasher
+	}
+	tokenSize := BranchFactorToTokenSize[config.BranchFactor]
+
+	br := bufio.NewReader(r)
+	if err := readExportHeader(br, expectedRootID); err != nil {
+		return err
+	}
+
+	if expectedRootID == ids.Empty {
+		if err := readExportEnd(br); err != nil {
+			return err
+		}
+		return db.Clear()
+	}
+
+	start := maybe.Nothing[[]byte]()
+	for {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+
+		proof, err := readExportChunk(br)
+		if err != nil {
+			return err
+		}
+		if proof == nil {
+			break
+		}
+
+		if err := proof.Verify(
+			ctx,
+			start,
+			maybe.Nothing[[]byte](),
+			expectedRootID,
+			tokenSize,
+			hasher,
+		); err != nil {
+			return fmt.Errorf("%w: %w", ErrInvalidExport, err)
+		}
+
+		// Committing the chunk also removes any keys in [db] in
+		// [start, last key of the chunk] that aren't in the chunk.
+		if err := db.CommitRangeProof(ctx, start, maybe.Nothing[[]byte](), proof); err != nil {
+			return err
+		}
+
+		start = maybe.Some(nextKey(proof.KeyValues[len(proof.KeyValues)-1].Key))
+	}
+
+	// Remove the keys in [db] after the last key of the export. Committing an
+	// empty proof removes every key in [start, ∞).
+	if err := db.CommitRangeProof(ctx, start, maybe.Nothing[[]byte](), &RangeProof{}); err != nil {
+		return err
+	}
+
+	// Each chunk only proves the key/value pairs it contains, so verify that
+	// no chunks were omitted.
+	rootID, err := db.GetMerkleRoot(ctx)
+	if err != nil {
+		return err
+	}
+	if rootID != expectedRootID {
+		return fmt.Errorf("%w: expected %s but got %s", ErrUnexpectedRoot, expectedRootID, rootID)
+	}
+	return nil
+}
+
+func writeExportChunk(w io.Writer, proof *RangeProof) error {
+	proofBytes, err := proto.Marshal(proof.ToProto())
+	if err != nil {
+		return err
+	}
+	if _, err := w.Write(binary.AppendUvarint(nil, uint64(len(proofBytes)))); err != nil {
+		return err
+	}
+	_, err = w.Write(proofBytes)
+	return err
+}
+
+func readExportHeader(r io.Reader, expectedRootID ids.ID) error {
+	header := make([]byte, len(exportMagic)+2+ids.IDLen)
+	if _, err := io.ReadFull(r, header); err != nil {
+		return fmt.Errorf("%w: couldn't read header: %w", ErrInvalidExport, err)
+	}
+	if !bytes.Equal(header[:len(exportMagic)], exportMagic) {
+		return fmt.Errorf("%w: unknown format", ErrInvalidExport)
+	}
+	header = header[len(exportMagic):]
+	if version := binary.BigEndian.Uint16(header); version != exportVersion {
+		return fmt.Errorf("%w: unsupported version %d", ErrInvalidExport, version)
+	}
+	if rootID := ids.ID(header[2:]); rootID != expectedRootID {
+		return fmt.Errorf("%w: export has root %s but expected %s", ErrUnexpectedRoot, rootID, expectedRootID)
+	}
+	return nil
+}
+
+// readExportChunk returns the next chunk in [r] or nil if the end of the
+// export has been reached.
+func readExportChunk(r *bufio.Reader) (*RangeProof, error) {
+	chunkLen, err := binary.ReadUvarint(r)
+	if err != nil {
+		return nil, fmt.Errorf("%w: couldn't read chunk length: %w", ErrInvalidExport, err)
+	}
+	if chunkLen == 0 {
+		return nil, nil
+	}
+	if chunkLen > maxExportChunkSize {
+		return nil, errExportChunkTooLong
+	}
+
+	chunkBytes := make([]byte, chunkLen)
+	if _, err := io.ReadFull(r, chunkBytes); err != nil {
+		return nil, fmt.Errorf("%w: couldn't read chunk: %w", ErrInvalidExport, err)
+	}
+
+	var pbProof pb.RangeProof
+	if err := proto.Unmarshal(chunkBytes, &pbProof); err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrInvalidExport, err)
+	}
+	var proof RangeProof
+	if err := proof.UnmarshalProto(&pbProof); err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrInvalidExport, err)
+	}
+	if len(proof.KeyValues) == 0 {
+		return nil, errEmptyExportChunk
+	}
+	return &proof, nil
+}
+
+func readExportEnd(r *bufio.Reader) error {
+	proof, err := readExportChunk(r)
+	if err != nil {
+		return err
+	}
+	if proof != nil {
+		return fmt.Errorf("%w: empty trie has key/values", ErrInvalidExport)
+	}
+	return nil
+}
+
+// nextKey returns the smallest key that is greater than [key].
+func nextKey(key []byte) []byte {
+	next := make([]byte, len(key)+1)
+	copy(next, key)
+	return next
+}
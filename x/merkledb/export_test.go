@@ -0,0 +1,257 @@
+// Copyright (C) 2019-2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package merkledb
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"io"
+	"math/rand"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/shubhamdubey02/cryftgo/database"
+	"github.com/shubhamdubey02/cryftgo/database/memdb"
+	"github.com/shubhamdubey02/cryftgo/ids"
+)
+
+func newExportTestDB(require *require.Assertions, numKeyValues uint) *merkleDB {
+	db, err := getBasicDB()
+	require.NoError(err)
+
+	r := rand.New(rand.NewSource(0)) // #nosec G404
+	insertRandomKeyValues(
+		require,
+		r,
+		[]database.Database{db},
+		numKeyValues,
+		0.25,
+	)
+	return db
+}
+
+func importConfig() ImportConfig {
+	return ImportConfig{
+		BranchFactor: BranchFactor16,
+		Hasher:       DefaultHasher,
+	}
+}
+
+func TestExportImport(t *testing.T) {
+	tests := []struct {
+		name         string
+		numKeyValues uint
+		maxLength    int
+	}{
+		{
+			name:         "empty",
+			numKeyValues: 0,
+			maxLength:    10,
+		},
+		{
+			name:         "single chunk",
+			numKeyValues: 10,
+			maxLength:    1000,
+		},
+		{
+			name:         "many chunks",
+			numKeyValues: 1000,
+			maxLength:    10,
+		},
+		{
+			name:         "one key per chunk",
+			numKeyValues: 20,
+			maxLength:    1,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			require := require.New(t)
+
+			db := newExportTestDB(require, test.numKeyValues)
+			rootID, err := db.GetMerkleRoot(context.Background())
+			require.NoError(err)
+
+			var export bytes.Buffer
+			require.NoError(Export(context.Background(), db, rootID, test.maxLength, &export))
+
+			// Importing replaces any existing key/values.
+			importDB, err := getBasicDB()
+			require.NoError(err)
+			require.NoError(importDB.Put([]byte("stale"), []byte("value")))
+
+			require.NoError(Import(context.Background(), importDB, &export, rootID, importConfig()))
+
+			importRootID, err := importDB.GetMerkleRoot(context.Background())
+			require.NoError(err)
+			require.Equal(rootID, importRootID)
+		})
+	}
+}
+
+func TestExportHistoricalRoot(t *testing.T) {
+	require := require.New(t)
+
+	db := newExportTestDB(require, 100)
+	rootID, err := db.GetMerkleRoot(context.Background())
+	require.NoError(err)
+
+	// Modify the trie after [rootID].
+	r := rand.New(rand.NewSource(1)) // #nosec G404
+	insertRandomKeyValues(require, r, []database.Database{db}, 100, 0.25)
+
+	var export bytes.Buffer
+	require.NoError(Export(context.Background(), db, rootID, 16, &export))
+
+	importDB, err := getBasicDB()
+	require.NoError(err)
+	require.NoError(Import(context.Background(), importDB, &export, rootID, importConfig()))
+
+	importRootID, err := importDB.GetMerkleRoot(context.Background())
+	require.NoError(err)
+	require.Equal(rootID, importRootID)
+}
+
+func TestImportInvalid(t *testing.T) {
+	db := newExportTestDB(require.New(t), 100)
+	rootID, err := db.GetMerkleRoot(context.Background())
+	require.NoError(t, err)
+
+	var export bytes.Buffer
+	require.NoError(t, Export(context.Background(), db, rootID, 10, &export))
+	exportBytes := export.Bytes()
+
+	tests := []struct {
+		name        string
+		export      func() []byte
+		rootID      ids.ID
+		expectedErr error
+	}{
+		{
+			name: "wrong root",
+			export: func() []byte {
+				return exportBytes
+			},
+			rootID:      ids.GenerateTestID(),
+			expectedErr: ErrUnexpectedRoot,
+		},
+		{
+			name: "invalid magic",
+			export: func() []byte {
+				b := bytes.Clone(exportBytes)
+				b[0]++
+				return b
+			},
+			rootID:      rootID,
+			expectedErr: ErrInvalidExport,
+		},
+		{
+			name: "truncated",
+			export: func() []byte {
+				return exportBytes[:len(exportBytes)/2]
+			},
+			rootID:      rootID,
+			expectedErr: ErrInvalidExport,
+		},
+		{
+			name: "missing chunks",
+			export: func() []byte {
+				// Remove every chunk after the first one.
+				r := bytes.NewReader(exportBytes)
+				header := make([]byte, len(exportMagic)+2+ids.IDLen)
+				_, _ = io.ReadFull(r, header)
+
+				var b bytes.Buffer
+				b.Write(header)
+				chunk, err := readExportChunk(bufio.NewReader(r))
+				require.NoError(t, err)
+				require.NoError(t, writeExportChunk(&b, chunk))
+				b.WriteByte(0)
+				return b.Bytes()
+			},
+			rootID:      rootID,
+			expectedErr: ErrUnexpectedRoot,
+		},
+		{
+			name: "modified value",
+			export: func() []byte {
+				r := bytes.NewReader(exportBytes)
+				header := make([]byte, len(exportMagic)+2+ids.IDLen)
+				_, _ = io.ReadFull(r, header)
+
+				var b bytes.Buffer
+				b.Write(header)
+				chunk, err := readExportChunk(bufio.NewReader(r))
+				require.NoError(t, err)
+				chunk.KeyValues[1].Value = append(chunk.KeyValues[1].Value, 0)
+				require.NoError(t, writeExportChunk(&b, chunk))
+				b.WriteByte(0)
+				return b.Bytes()
+			},
+			rootID:      rootID,
+			expectedErr: ErrInvalidExport,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			require := require.New(t)
+
+			importDB, err := getBasicDB()
+			require.NoError(err)
+
+			err = Import(
+				context.Background(),
+				importDB,
+				bytes.NewReader(test.export()),
+				test.rootID,
+				importConfig(),
+			)
+			require.ErrorIs(err, test.expectedErr)
+		})
+	}
+}
+
+func TestImportRemovesKeysAfterExport(t *testing.T) {
+	require := require.New(t)
+
+	db := newExportTestDB(require, 100)
+	rootID, err := db.GetMerkleRoot(context.Background())
+	require.NoError(err)
+
+	var export bytes.Buffer
+	require.NoError(Export(context.Background(), db, rootID, 10, &export))
+
+	// The import target has keys before, within and after the exported keys.
+	importDB := newExportTestDB(require, 50)
+	trailingKey := bytes.Repeat([]byte{0xFF}, 64)
+	require.NoError(importDB.Put(nil, []byte("value")))
+	require.NoError(importDB.Put(trailingKey, []byte("value")))
+
+	require.NoError(Import(context.Background(), importDB, &export, rootID, importConfig()))
+
+	importRootID, err := importDB.GetMerkleRoot(context.Background())
+	require.NoError(err)
+	require.Equal(rootID, importRootID)
+
+	_, err = importDB.Get(trailingKey)
+	require.ErrorIs(err, database.ErrNotFound)
+}
+
+func TestImportEmptyClearsDB(t *testing.T) {
+	require := require.New(t)
+
+	var export bytes.Buffer
+	db, err := newDB(context.Background(), memdb.New(), newDefaultConfig())
+	require.NoError(err)
+	require.NoError(Export(context.Background(), db, ids.Empty, 10, &export))
+
+	importDB := newExportTestDB(require, 10)
+	require.NoError(Import(context.Background(), importDB, &export, ids.Empty, importConfig()))
+
+	rootID, err := importDB.GetMerkleRoot(context.Background())
+	require.NoError(err)
+	require.Equal(ids.Empty, rootID)
+}
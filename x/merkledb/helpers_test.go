@@ -10,12 +10,38 @@ import (
 
 	"github.com/stretchr/testify/require"
 
+	"github.com/shubhamdubey02/cryftgo/database"
 	"github.com/shubhamdubey02/cryftgo/database/memdb"
 	"github.com/shubhamdubey02/cryftgo/ids"
 	"github.com/shubhamdubey02/cryftgo/utils/hashing"
 	"github.com/shubhamdubey02/cryftgo/utils/maybe"
 )
 
+// noRangeDeleterDB hides the DeleteRange method of the wrapped database.
+type noRangeDeleterDB struct {
+	database.Database
+}
+
+// clearTests are the base databases that clearing is tested with, so that
+// clearing is tested with and without range deletion.
+var clearTests = []struct {
+	name      string
+	newBaseDB func() database.Database
+}{
+	{
+		name: "range deletion",
+		newBaseDB: func() database.Database {
+			return memdb.New()
+		},
+	},
+	{
+		name: "batched deletion",
+		newBaseDB: func() database.Database {
+			return &noRangeDeleterDB{Database: memdb.New()}
+		},
+	},
+}
+
 func getBasicDB() (*merkleDB, error) {
 	return newDatabase(
 		context.Background(),
@@ -146,13 +146,13 @@ func (th *trieHistory) getValueChanges(
 				startRootChanges = changes
 				break
 			}
+		}
 
-			if i == 0 {
-				return nil, fmt.Errorf(
-					"%w: start root %s not found before end root %s",
-					ErrInsufficientHistory, startRoot, endRoot,
-				)
-			}
+		if startRootChanges.insertNumber > endRootChanges.insertNumber {
+			return nil, fmt.Errorf(
+				"%w: start root %s not found before end root %s",
+				ErrInsufficientHistory, startRoot, endRoot,
+			)
 		}
 	}
 
@@ -184,36 +184,56 @@ func (th *trieHistory) getValueChanges(
 		changes, _ := th.history.Index(i)
 
 		// Add the changes from this commit to [combinedChanges].
-		for key, valueChange := range changes.values {
-			// The key is outside the range [start, end].
-			if (startKey.HasValue() && key.Less(startKey.Value())) ||
-				(end.HasValue() && key.Greater(endKey.Value())) {
-				continue
-			}
+		combineValueChanges(combinedChanges, changedKeys, changes.changeSummary, startKey, endKey)
+	}
 
-			// A change to this key already exists in [combinedChanges]
-			// so update its before value with the earlier before value
-			if existing, ok := combinedChanges.values[key]; ok {
-				existing.after = valueChange.after
-				if existing.before.HasValue() == existing.after.HasValue() &&
-					bytes.Equal(existing.before.Value(), existing.after.Value()) {
-					// The change to this key is a no-op, so remove it from [combinedChanges].
-					delete(combinedChanges.values, key)
-					changedKeys.Remove(key)
-				}
-			} else {
-				combinedChanges.values[key] = &change[maybe.Maybe[[]byte]]{
-					before: valueChange.before,
-					after:  valueChange.after,
-				}
-				changedKeys.Add(key)
+	trimValueChanges(combinedChanges, changedKeys, maxLength)
+	return combinedChanges, nil
+}
+
+// combineValueChanges adds the changes to keys in [startKey, endKey] in
+// [changes] to [combinedChanges], which contains the changes that occurred
+// before [changes]. [changedKeys] tracks the keys in [combinedChanges].
+func combineValueChanges(
+	combinedChanges *changeSummary,
+	changedKeys set.Set[Key],
+	changes *changeSummary,
+	startKey maybe.Maybe[Key],
+	endKey maybe.Maybe[Key],
+) {
+	for key, valueChange := range changes.values {
+		// The key is outside the range [start, end].
+		if (startKey.HasValue() && key.Less(startKey.Value())) ||
+			(endKey.HasValue() && key.Greater(endKey.Value())) {
+			continue
+		}
+
+		// A change to this key already exists in [combinedChanges]
+		// so update its before value with the earlier before value
+		if existing, ok := combinedChanges.values[key]; ok {
+			existing.after = valueChange.after
+			if existing.before.HasValue() == existing.after.HasValue() &&
+				bytes.Equal(existing.before.Value(), existing.after.Value()) {
+				// The change to this key is a no-op, so remove it from [combinedChanges].
+				delete(combinedChanges.values, key)
+				changedKeys.Remove(key)
+			}
+		} else {
+			combinedChanges.values[key] = &change[maybe.Maybe[[]byte]]{
+				before: valueChange.before,
+				after:  valueChange.after,
 			}
+			changedKeys.Add(key)
 		}
 	}
+}
 
+// trimValueChanges keeps only the changes to the smallest [maxLength] keys in
+// [combinedChanges].
+func trimValueChanges(combinedChanges *changeSummary, changedKeys set.Set[Key], maxLength int) {
 	// If we have <= [maxLength] elements, we're done.
 	if changedKeys.Len() <= maxLength {
-		return combinedChanges, nil
+		return
 	}
 
 	// Keep only the smallest [maxLength] items in [combinedChanges.values].
@@ -224,8 +244,6 @@ func (th *trieHistory) getValueChanges(
 		sortedChangedKeys = sortedChangedKeys[:len(sortedChangedKeys)-1]
 		delete(combinedChanges.values, greatestKey)
 	}
-
-	return combinedChanges, nil
 }
 
 // Returns the changes to go from the current trie state back to the requested [rootID]
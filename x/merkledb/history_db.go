@@ -0,0 +1,588 @@
+// Copyright (C) 2019-2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package merkledb
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"slices"
+	"time"
+
+	"github.com/shubhamdubey02/cryftgo/database"
+	"github.com/shubhamdubey02/cryftgo/ids"
+	"github.com/shubhamdubey02/cryftgo/utils/buffer"
+	"github.com/shubhamdubey02/cryftgo/utils/maybe"
+	"github.com/shubhamdubey02/cryftgo/utils/set"
+	"github.com/shubhamdubey02/cryftgo/utils/timer/mockable"
+	"github.com/shubhamdubey02/cryftgo/utils/wrappers"
+)
+
+const historyKeyLen = 2 + wrappers.LongLen
+
+var (
+	// Keys written by [historyDB] are prefixed with [historyPrefix].
+	//
+	// Changes are stored under [historyChangesPrefix] and the root ID and
+	// timestamp of each change are stored under [historyMetadataPrefix]. Both
+	// are keyed by the big endian insert number of the change.
+	historyPrefix         = []byte{3}
+	historyChangesPrefix  = []byte{3, 0}
+	historyMetadataPrefix = []byte{3, 1}
+
+	errMissingHistory    = errors.New("history is missing changes")
+	errInvalidHistoryKey = errors.New("invalid history key")
+)
+
+// historyDB persists the changes made to the trie so that change proofs and
+// historical views can be served for roots that are no longer in the
+// in-memory [trieHistory], including after a restart.
+//
+// Only the root ID and timestamp of each change are kept in memory. The
+// changes themselves are read from disk when they are needed.
+//
+// Changes are removed once there are more than [maxLength] of them or once
+// they are older than [maxAge]. A zero [maxLength] or [maxAge] disables the
+// corresponding limit.
+type historyDB struct {
+	baseDB database.Database
+	hasher Hasher
+
+	maxLength int
+	maxAge    time.Duration
+	clock     mockable.Clock
+
+	// Contains the metadata of the changes on disk.
+	// Sorted by increasing insert number.
+	entries buffer.Deque[historyEntry]
+
+	// Root ID --> The insert numbers of the changes resulting in the root ID,
+	// in increasing order.
+	roots map[ids.ID][]uint64
+
+	// The insert number of the next change.
+	nextInsertNumber uint64
+}
+
+type historyEntry struct {
+	insertNumber uint64
+	rootID       ids.ID
+	// Unix time, in seconds, that the change was recorded.
+	timestamp uint64
+}
+
+// historyWrite is the result of [historyDB.write] which must be applied once
+// the batch it was written to has been committed.
+type historyWrite struct {
+	entry     historyEntry
+	numPruned int
+}
+
+// newHistoryDB loads the metadata of the changes stored in [db].
+//
+// If the most recent change doesn't result in [rootID], the stored changes
+// can't be used to reach the current trie and are removed. If no changes are
+// stored, an empty change resulting in [root] is recorded so that [rootID]
+// can be used as the start root of change proofs.
+func newHistoryDB(
+	db database.Database,
+	hasher Hasher,
+	maxLength int,
+	maxAge time.Duration,
+	root maybe.Maybe[*node],
+	rootID ids.ID,
+) (*historyDB, error) {
+	h := &historyDB{
+		baseDB:    db,
+		hasher:    hasher,
+		maxLength: maxLength,
+		maxAge:    maxAge,
+		entries:   buffer.NewUnboundedDeque[historyEntry](maxLength),
+		roots:     make(map[ids.ID][]uint64),
+	}
+
+	it := db.NewIteratorWithPrefix(historyMetadataPrefix)
+	defer it.Release()
+
+	for it.Next() {
+		entry, err := parseHistoryEntry(it.Key(), it.Value())
+		if err != nil {
+			return nil, err
+		}
+		h.push(entry)
+	}
+	if err := it.Error(); err != nil {
+		return nil, err
+	}
+
+	if last, ok := h.entries.PeekRight(); ok && last.rootID != rootID {
+		// The trie was modified without recording the changes, so the
+		// history can't be used to reach the current trie.
+		if err := h.clear(); err != nil {
+			return nil, err
+		}
+	}
+
+	// The in-memory metadata is updated before [batch] is written because
+	// the [historyDB] isn't used if writing [batch] fails.
+	batch := db.NewBatch()
+	numPruned, err := h.prune(batch, h.clock.Unix(), 0)
+	if err != nil {
+		return nil, err
+	}
+	h.pop(numPruned)
+
+	var (
+		w     historyWrite
+		empty = h.entries.Len() == 0
+	)
+	if empty {
+		w, err = h.write(batch, &changeSummary{
+			rootID: rootID,
+			rootChange: change[maybe.Maybe[*node]]{
+				after: root,
+			},
+			values: map[Key]*change[maybe.Maybe[[]byte]]{},
+			nodes:  map[Key]*change[*node]{},
+		})
+		if err != nil {
+			return nil, err
+		}
+	}
+	if err := batch.Write(); err != nil {
+		return nil, err
+	}
+
+	if empty {
+		h.apply(w)
+	}
+	return h, nil
+}
+
+// write adds [changes] to [batch] along with the removal of any changes that
+// are no longer retained. The returned [historyWrite] must be applied after
+// [batch] has been written.
+func (h *historyDB) write(batch database.KeyValueWriterDeleter, changes *changeSummary) (historyWrite, error) {
+	entry := historyEntry{
+		insertNumber: h.nextInsertNumber,
+		rootID:       changes.rootID,
+		timestamp:    h.clock.Unix(),
+	}
+	if err := batch.Put(historyKey(historyChangesPrefix, entry.insertNumber), encodeChangeSummary(changes)); err != nil {
+		return historyWrite{}, err
+	}
+	if err := batch.Put(historyKey(historyMetadataPrefix, entry.insertNumber), encodeHistoryEntry(entry)); err != nil {
+		return historyWrite{}, err
+	}
+
+	numPruned, err := h.prune(batch, entry.timestamp, 1)
+	return historyWrite{
+		entry:     entry,
+		numPruned: numPruned,
+	}, err
+}
+
+// apply updates the in-memory metadata after the batch passed to [write] has
+// been written.
+func (h *historyDB) apply(w historyWrite) {
+	h.pop(w.numPruned)
+	h.push(w.entry)
+}
+
+// prune adds the removal of the oldest changes that are no longer retained to
+// [batch], assuming [numAdded] changes are being added at time [now].
+// Returns the number of changes removed.
+func (h *historyDB) prune(batch database.KeyValueDeleter, now uint64, numAdded int) (int, error) {
+	numPruned := 0
+	for ; numPruned < h.entries.Len(); numPruned++ {
+		entry, _ := h.entries.Index(numPruned)
+		var (
+			remaining = h.entries.Len() - numPruned + numAdded
+			tooMany   = h.maxLength > 0 && remaining > h.maxLength
+			tooOld    = h.maxAge > 0 && now > entry.timestamp &&
+				time.Duration(now-entry.timestamp)*time.Second > h.maxAge
+		)
+		if !tooMany && !tooOld {
+			break
+		}
+
+		if err := batch.Delete(historyKey(historyChangesPrefix, entry.insertNumber)); err != nil {
+			return 0, err
+		}
+		if err := batch.Delete(historyKey(historyMetadataPrefix, entry.insertNumber)); err != nil {
+			return 0, err
+		}
+	}
+	return numPruned, nil
+}
+
+func (h *historyDB) push(entry historyEntry) {
+	_ = h.entries.PushRight(entry)
+	h.roots[entry.rootID] = append(h.roots[entry.rootID], entry.insertNumber)
+	h.nextInsertNumber = entry.insertNumber + 1
+}
+
+// pop removes the metadata of the [n] oldest changes.
+func (h *historyDB) pop(n int) {
+	for i := 0; i < n; i++ {
+		entry, _ := h.entries.PopLeft()
+
+		// The oldest change is always the first change resulting in its root.
+		insertNumbers := h.roots[entry.rootID][1:]
+		if len(insertNumbers) == 0 {
+			delete(h.roots, entry.rootID)
+		} else {
+			h.roots[entry.rootID] = insertNumbers
+		}
+	}
+}
+
+// clear removes all changes from disk.
+func (h *historyDB) clear() error {
+	if err := clearPrefix(h.baseDB, historyPrefix); err != nil {
+		return err
+	}
+	h.entries = buffer.NewUnboundedDeque[historyEntry](h.maxLength)
+	h.roots = make(map[ids.ID][]uint64)
+	return nil
+}
+
+// Returns up to [maxLength] key-value pair changes with keys in
+// [start, end] that occurred between [startRoot] and [endRoot].
+// See [trieHistory.getValueChanges].
+func (h *historyDB) getValueChanges(
+	startRoot ids.ID,
+	endRoot ids.ID,
+	start maybe.Maybe[[]byte],
+	end maybe.Maybe[[]byte],
+	maxLength int,
+) (*changeSummary, error) {
+	endInsertNumbers, ok := h.roots[endRoot]
+	if !ok {
+		return nil, fmt.Errorf("%w: %s", ErrNoEndRoot, endRoot)
+	}
+	endInsertNumber := endInsertNumbers[len(endInsertNumbers)-1]
+
+	// Find the last change resulting in [startRoot] before [endInsertNumber].
+	startInsertNumbers, ok := h.roots[startRoot]
+	if !ok {
+		return nil, fmt.Errorf("%w: start root %s not found", ErrInsufficientHistory, startRoot)
+	}
+	index, _ := slices.BinarySearch(startInsertNumbers, endInsertNumber)
+	if index == 0 {
+		return nil, fmt.Errorf(
+			"%w: start root %s not found before end root %s",
+			ErrInsufficientHistory, startRoot, endRoot,
+		)
+	}
+	startInsertNumber := startInsertNumbers[index-1]
+
+	var (
+		changedKeys     = set.Set[Key]{}
+		startKey        = maybe.Bind(start, ToKey)
+		endKey          = maybe.Bind(end, ToKey)
+		combinedChanges = newChangeSummary(maxLength)
+	)
+	err := h.iterate(startInsertNumber+1, endInsertNumber, func(changes *changeSummary) {
+		combineValueChanges(combinedChanges, changedKeys, changes, startKey, endKey)
+	})
+	if err != nil {
+		return nil, err
+	}
+	trimValueChanges(combinedChanges, changedKeys, maxLength)
+	return combinedChanges, nil
+}
+
+// Returns the changes to go from the current trie state back to the requested
+// [rootID] for the keys in [start, end].
+// See [trieHistory.getChangesToGetToRoot].
+func (h *historyDB) getChangesToGetToRoot(rootID ids.ID, start maybe.Maybe[[]byte], end maybe.Maybe[[]byte]) (*changeSummary, error) {
+	insertNumbers, ok := h.roots[rootID]
+	if !ok {
+		return nil, ErrInsufficientHistory
+	}
+
+	var (
+		startKey        = maybe.Bind(start, ToKey)
+		endKey          = maybe.Bind(end, ToKey)
+		combinedChanges = newChangeSummary(defaultPreallocationSize)
+		first           = true
+	)
+
+	// Go forward from the change after the last change resulting in [rootID]
+	// up to the most recent change. Since the changes are applied in the
+	// opposite order of [trieHistory.getChangesToGetToRoot], the earliest
+	// change to each node and value takes precedence.
+	err := h.iterate(insertNumbers[len(insertNumbers)-1]+1, h.nextInsertNumber-1, func(changes *changeSummary) {
+		if first {
+			combinedChanges.rootChange.after = changes.rootChange.before
+			first = false
+		}
+		combinedChanges.rootChange.before = changes.rootChange.after
+
+		for key, changedNode := range changes.nodes {
+			if _, ok := combinedChanges.nodes[key]; !ok {
+				combinedChanges.nodes[key] = &change[*node]{
+					after: changedNode.before,
+				}
+			}
+		}
+
+		for key, valueChange := range changes.values {
+			if (startKey.HasValue() && key.Less(startKey.Value())) ||
+				(endKey.HasValue() && key.Greater(endKey.Value())) {
+				continue
+			}
+			if existing, ok := combinedChanges.values[key]; ok {
+				existing.before = valueChange.after
+			} else {
+				combinedChanges.values[key] = &change[maybe.Maybe[[]byte]]{
+					before: valueChange.after,
+					after:  valueChange.before,
+				}
+			}
+		}
+	})
+	return combinedChanges, err
+}
+
+// iterate calls [f] with the changes with insert numbers in [first, last] in
+// increasing order.
+func (h *historyDB) iterate(first, last uint64, f func(*changeSummary)) error {
+	if first > last {
+		return nil
+	}
+
+	it := h.baseDB.NewIteratorWithStartAndPrefix(
+		historyKey(historyChangesPrefix, first),
+		historyChangesPrefix,
+	)
+	defer it.Release()
+
+	expected := first
+	for expected <= last && it.Next() {
+		insertNumber := binary.BigEndian.Uint64(it.Key()[len(historyChangesPrefix):])
+		if insertNumber != expected {
+			return fmt.Errorf("%w: expected %d but found %d", errMissingHistory, expected, insertNumber)
+		}
+		changes, err := decodeChangeSummary(h.hasher, it.Value())
+		if err != nil {
+			return err
+		}
+		f(changes)
+		expected++
+	}
+	if err := it.Error(); err != nil {
+		return err
+	}
+	if expected <= last {
+		return fmt.Errorf("%w: expected %d", errMissingHistory, expected)
+	}
+	return nil
+}
+
+func historyKey(prefix []byte, insertNumber uint64) []byte {
+	key := make([]byte, historyKeyLen)
+	copy(key, prefix)
+	binary.BigEndian.PutUint64(key[len(prefix):], insertNumber)
+	return key
+}
+
+func encodeHistoryEntry(entry historyEntry) []byte {
+	w := codecWriter{
+		b: make([]byte, 0, ids.IDLen+binary.MaxVarintLen64),
+	}
+	w.ID(entry.rootID)
+	w.Uvarint(entry.timestamp)
+	return w.b
+}
+
+func parseHistoryEntry(key []byte, value []byte) (historyEntry, error) {
+	if len(key) != historyKeyLen {
+		return historyEntry{}, fmt.Errorf("%w: length %d", errInvalidHistoryKey, len(key))
+	}
+	r := codecReader{
+		b: value,
+	}
+	rootID, err := r.ID()
+	if err != nil {
+		return historyEntry{}, err
+	}
+	timestamp, err := r.Uvarint()
+	if err != nil {
+		return historyEntry{}, err
+	}
+	if len(r.b) != 0 {
+		return historyEntry{}, errExtraSpace
+	}
+	return historyEntry{
+		insertNumber: binary.BigEndian.Uint64(key[len(historyMetadataPrefix):]),
+		rootID:       rootID,
+		timestamp:    timestamp,
+	}, nil
+}
+
+// encodeChangeSummary serializes [changes] as:
+//   - the root ID
+//   - the root before and after the changes
+//   - the number of changed nodes, followed by the key of each node and its
+//     bytes before and after the changes
+//   - the number of changed values, followed by each key and its value
+//     before and after the changes
+func encodeChangeSummary(changes *changeSummary) []byte {
+	w := codecWriter{
+		b: make([]byte, 0, ids.IDLen),
+	}
+	w.ID(changes.rootID)
+	writeRoot(&w, changes.rootChange.before)
+	writeRoot(&w, changes.rootChange.after)
+
+	w.Uvarint(uint64(len(changes.nodes)))
+	for key, nodeChange := range changes.nodes {
+		w.Key(key)
+		w.MaybeBytes(nodeBytes(nodeChange.before))
+		w.MaybeBytes(nodeBytes(nodeChange.after))
+	}
+
+	w.Uvarint(uint64(len(changes.values)))
+	for key, valueChange := range changes.values {
+		w.Key(key)
+		w.MaybeBytes(valueChange.before)
+		w.MaybeBytes(valueChange.after)
+	}
+	return w.b
+}
+
+func decodeChangeSummary(hasher Hasher, b []byte) (*changeSummary, error) {
+	r := codecReader{
+		b:    b,
+		copy: true,
+	}
+	rootID, err := r.ID()
+	if err != nil {
+		return nil, err
+	}
+	rootBefore, err := readRoot(&r, hasher)
+	if err != nil {
+		return nil, err
+	}
+	rootAfter, err := readRoot(&r, hasher)
+	if err != nil {
+		return nil, err
+	}
+
+	numNodes, err := r.Uvarint()
+	if err != nil {
+		return nil, err
+	}
+	// Each node change is at least 3 bytes.
+	if numNodes > uint64(len(r.b)) {
+		return nil, io.ErrUnexpectedEOF
+	}
+	nodes := make(map[Key]*change[*node], numNodes)
+	for i := uint64(0); i < numNodes; i++ {
+		key, err := r.Key()
+		if err != nil {
+			return nil, err
+		}
+		before, err := readNode(&r, hasher, key)
+		if err != nil {
+			return nil, err
+		}
+		after, err := readNode(&r, hasher, key)
+		if err != nil {
+			return nil, err
+		}
+		nodes[key] = &change[*node]{
+			before: before,
+			after:  after,
+		}
+	}
+
+	numValues, err := r.Uvarint()
+	if err != nil {
+		return nil, err
+	}
+	// Each value change is at least 3 bytes.
+	if numValues > uint64(len(r.b)) {
+		return nil, io.ErrUnexpectedEOF
+	}
+	values := make(map[Key]*change[maybe.Maybe[[]byte]], numValues)
+	for i := uint64(0); i < numValues; i++ {
+		key, err := r.Key()
+		if err != nil {
+			return nil, err
+		}
+		before, err := r.MaybeBytes()
+		if err != nil {
+			return nil, err
+		}
+		after, err := r.MaybeBytes()
+		if err != nil {
+			return nil, err
+		}
+		values[key] = &change[maybe.Maybe[[]byte]]{
+			before: before,
+			after:  after,
+		}
+	}
+	if len(r.b) != 0 {
+		return nil, errExtraSpace
+	}
+
+	return &changeSummary{
+		rootID: rootID,
+		rootChange: change[maybe.Maybe[*node]]{
+			before: rootBefore,
+			after:  rootAfter,
+		},
+		nodes:  nodes,
+		values: values,
+	}, nil
+}
+
+func nodeBytes(n *node) maybe.Maybe[[]byte] {
+	if n == nil {
+		return maybe.Nothing[[]byte]()
+	}
+	return maybe.Some(n.bytes())
+}
+
+func readNode(r *codecReader, hasher Hasher, key Key) (*node, error) {
+	b, err := r.MaybeBytes()
+	if err != nil || b.IsNothing() {
+		return nil, err
+	}
+	return parseNode(hasher, key, b.Value())
+}
+
+func writeRoot(w *codecWriter, root maybe.Maybe[*node]) {
+	w.Bool(root.HasValue())
+	if root.HasValue() {
+		n := root.Value()
+		w.Key(n.key)
+		w.Bytes(n.bytes())
+	}
+}
+
+func readRoot(r *codecReader, hasher Hasher) (maybe.Maybe[*node], error) {
+	hasRoot, err := r.Bool()
+	if err != nil || !hasRoot {
+		return maybe.Nothing[*node](), err
+	}
+	key, err := r.Key()
+	if err != nil {
+		return maybe.Nothing[*node](), err
+	}
+	b, err := r.Bytes()
+	if err != nil {
+		return maybe.Nothing[*node](), err
+	}
+	n, err := parseNode(hasher, key, b)
+	if err != nil {
+		return maybe.Nothing[*node](), err
+	}
+	return maybe.Some(n), nil
+}
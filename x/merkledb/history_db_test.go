@@ -0,0 +1,364 @@
+// Copyright (C) 2019-2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package merkledb
+
+import (
+	"context"
+	"math/rand"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/shubhamdubey02/cryftgo/database"
+	"github.com/shubhamdubey02/cryftgo/database/memdb"
+	"github.com/shubhamdubey02/cryftgo/ids"
+	"github.com/shubhamdubey02/cryftgo/utils/maybe"
+)
+
+func newHistoryDBConfig(historyDiskLength uint) Config {
+	config := newDefaultConfig()
+	// Keep only the most recent change in memory so that older roots must be
+	// served from disk.
+	config.HistoryLength = 1
+	config.HistoryDiskLength = historyDiskLength
+	return config
+}
+
+// writeRandomChanges commits [numChanges] random batches to [db] and returns
+// the root and key/values after each commit.
+func writeRandomChanges(
+	require *require.Assertions,
+	r *rand.Rand,
+	db *merkleDB,
+	numChanges int,
+) ([]ids.ID, [][]database.BatchOp) {
+	var (
+		roots  = make([]ids.ID, 0, numChanges)
+		states = make([][]database.BatchOp, 0, numChanges)
+		values = make(map[string][]byte)
+	)
+	for i := 0; i < numChanges; i++ {
+		batch := db.NewBatch()
+		for j := 0; j < 5; j++ {
+			key := []byte{byte(r.Intn(64))}
+			if r.Intn(4) == 0 {
+				require.NoError(batch.Delete(key))
+				delete(values, string(key))
+				continue
+			}
+			value := make([]byte, r.Intn(64))
+			_, _ = r.Read(value)
+			require.NoError(batch.Put(key, value))
+			values[string(key)] = value
+		}
+		require.NoError(batch.Write())
+
+		root, err := db.GetMerkleRoot(context.Background())
+		require.NoError(err)
+		roots = append(roots, root)
+
+		state := make([]database.BatchOp, 0, len(values))
+		for key, value := range values {
+			state = append(state, database.BatchOp{
+				Key:   []byte(key),
+				Value: value,
+			})
+		}
+		states = append(states, state)
+	}
+	return roots, states
+}
+
+func TestHistoryDBChangeProofAfterRestart(t *testing.T) {
+	require := require.New(t)
+
+	now := time.Now().UnixNano()
+	t.Logf("seed: %d", now)
+	r := rand.New(rand.NewSource(now)) // #nosec G404
+
+	baseDB := memdb.New()
+	config := newHistoryDBConfig(100)
+	db, err := newDatabase(context.Background(), baseDB, config, &mockMetrics{})
+	require.NoError(err)
+
+	roots, states := writeRandomChanges(require, r, db, 20)
+	require.NoError(db.Close())
+
+	db, err = newDatabase(context.Background(), baseDB, config, &mockMetrics{})
+	require.NoError(err)
+
+	for i := 0; i < 20; i++ {
+		startIndex := r.Intn(len(roots) - 1)
+		endIndex := startIndex + 1 + r.Intn(len(roots)-startIndex-1)
+		startRoot, endRoot := roots[startIndex], roots[endIndex]
+		if startRoot == endRoot {
+			continue
+		}
+
+		changeProof, err := db.GetChangeProof(
+			context.Background(),
+			startRoot,
+			endRoot,
+			maybe.Nothing[[]byte](),
+			maybe.Nothing[[]byte](),
+			100,
+		)
+		require.NoError(err)
+
+		// Verify the proof against a database at [startRoot].
+		startDB, err := getBasicDB()
+		require.NoError(err)
+		view, err := startDB.NewView(context.Background(), ViewChanges{BatchOps: states[startIndex]})
+		require.NoError(err)
+		require.NoError(view.CommitToDB(context.Background()))
+		require.NoError(startDB.VerifyChangeProof(
+			context.Background(),
+			changeProof,
+			maybe.Nothing[[]byte](),
+			maybe.Nothing[[]byte](),
+			endRoot,
+		))
+		require.NoError(startDB.CommitChangeProof(context.Background(), changeProof))
+		gotRoot, err := startDB.GetMerkleRoot(context.Background())
+		require.NoError(err)
+		require.Equal(endRoot, gotRoot)
+
+		rangeProof, err := db.GetRangeProofAtRoot(
+			context.Background(),
+			startRoot,
+			maybe.Nothing[[]byte](),
+			maybe.Nothing[[]byte](),
+			100,
+		)
+		require.NoError(err)
+		require.NoError(rangeProof.Verify(
+			context.Background(),
+			maybe.Nothing[[]byte](),
+			maybe.Nothing[[]byte](),
+			startRoot,
+			db.tokenSize,
+			db.hasher,
+		))
+	}
+}
+
+func TestHistoryDBCrashRecovery(t *testing.T) {
+	require := require.New(t)
+
+	r := rand.New(rand.NewSource(0)) // #nosec G404
+
+	baseDB := memdb.New()
+	config := newHistoryDBConfig(100)
+	db, err := newDatabase(context.Background(), baseDB, config, &mockMetrics{})
+	require.NoError(err)
+
+	roots, _ := writeRandomChanges(require, r, db, 10)
+
+	// Do not `.Close()` the database to simulate a process crash.
+
+	db, err = newDatabase(context.Background(), baseDB, config, &mockMetrics{})
+	require.NoError(err)
+
+	rootAfterRecovery, err := db.GetMerkleRoot(context.Background())
+	require.NoError(err)
+	require.Equal(roots[len(roots)-1], rootAfterRecovery)
+
+	_, err = db.GetChangeProof(
+		context.Background(),
+		roots[0],
+		roots[len(roots)-1],
+		maybe.Nothing[[]byte](),
+		maybe.Nothing[[]byte](),
+		100,
+	)
+	require.NoError(err)
+}
+
+func TestHistoryDBPruneByLength(t *testing.T) {
+	require := require.New(t)
+
+	r := rand.New(rand.NewSource(0)) // #nosec G404
+
+	baseDB := memdb.New()
+	config := newHistoryDBConfig(5)
+	db, err := newDatabase(context.Background(), baseDB, config, &mockMetrics{})
+	require.NoError(err)
+
+	roots, _ := writeRandomChanges(require, r, db, 10)
+	require.Equal(5, db.historyDB.entries.Len())
+
+	_, err = db.GetChangeProof(
+		context.Background(),
+		roots[0],
+		roots[9],
+		maybe.Nothing[[]byte](),
+		maybe.Nothing[[]byte](),
+		100,
+	)
+	require.ErrorIs(err, ErrInsufficientHistory)
+
+	_, err = db.GetChangeProof(
+		context.Background(),
+		roots[5],
+		roots[9],
+		maybe.Nothing[[]byte](),
+		maybe.Nothing[[]byte](),
+		100,
+	)
+	require.NoError(err)
+
+	// Reducing the retention on restart removes the oldest changes.
+	require.NoError(db.Close())
+	config.HistoryDiskLength = 2
+	db, err = newDatabase(context.Background(), baseDB, config, &mockMetrics{})
+	require.NoError(err)
+	require.Equal(2, db.historyDB.entries.Len())
+
+	it := baseDB.NewIteratorWithPrefix(historyChangesPrefix)
+	defer it.Release()
+	numChanges := 0
+	for it.Next() {
+		numChanges++
+	}
+	require.NoError(it.Error())
+	require.Equal(2, numChanges)
+}
+
+func TestHistoryDBPruneByAge(t *testing.T) {
+	require := require.New(t)
+
+	r := rand.New(rand.NewSource(0)) // #nosec G404
+
+	config := newHistoryDBConfig(0)
+	config.HistoryDiskRetention = time.Hour
+	db, err := newDatabase(context.Background(), memdb.New(), config, &mockMetrics{})
+	require.NoError(err)
+
+	now := time.Now()
+	db.historyDB.clock.Set(now)
+	oldRoots, _ := writeRandomChanges(require, r, db, 5)
+
+	db.historyDB.clock.Set(now.Add(30 * time.Minute))
+	roots, _ := writeRandomChanges(require, r, db, 5)
+
+	// The first changes are still retained.
+	_, err = db.GetChangeProof(
+		context.Background(),
+		oldRoots[0],
+		roots[4],
+		maybe.Nothing[[]byte](),
+		maybe.Nothing[[]byte](),
+		100,
+	)
+	require.NoError(err)
+
+	db.historyDB.clock.Set(now.Add(90 * time.Minute))
+	newRoots, _ := writeRandomChanges(require, r, db, 1)
+
+	_, err = db.GetChangeProof(
+		context.Background(),
+		oldRoots[0],
+		newRoots[0],
+		maybe.Nothing[[]byte](),
+		maybe.Nothing[[]byte](),
+		100,
+	)
+	require.ErrorIs(err, ErrInsufficientHistory)
+
+	_, err = db.GetChangeProof(
+		context.Background(),
+		roots[0],
+		newRoots[0],
+		maybe.Nothing[[]byte](),
+		maybe.Nothing[[]byte](),
+		100,
+	)
+	require.NoError(err)
+}
+
+func TestHistoryDBDisabled(t *testing.T) {
+	require := require.New(t)
+
+	r := rand.New(rand.NewSource(0)) // #nosec G404
+
+	baseDB := memdb.New()
+	config := newHistoryDBConfig(100)
+	db, err := newDatabase(context.Background(), baseDB, config, &mockMetrics{})
+	require.NoError(err)
+
+	_, _ = writeRandomChanges(require, r, db, 5)
+	require.NoError(db.Close())
+
+	// Opening the database without storing changes on disk removes the
+	// existing changes.
+	config.HistoryDiskLength = 0
+	db, err = newDatabase(context.Background(), baseDB, config, &mockMetrics{})
+	require.NoError(err)
+	require.Nil(db.historyDB)
+
+	_, _ = writeRandomChanges(require, r, db, 5)
+	require.NoError(db.Close())
+
+	it := baseDB.NewIteratorWithPrefix(historyPrefix)
+	defer it.Release()
+	require.False(it.Next())
+	require.NoError(it.Error())
+
+	// Changes recorded after storing changes on disk again can be used.
+	config.HistoryDiskLength = 100
+	db, err = newDatabase(context.Background(), baseDB, config, &mockMetrics{})
+	require.NoError(err)
+
+	startRoot, err := db.GetMerkleRoot(context.Background())
+	require.NoError(err)
+	roots, _ := writeRandomChanges(require, r, db, 5)
+	_, err = db.GetChangeProof(
+		context.Background(),
+		startRoot,
+		roots[4],
+		maybe.Nothing[[]byte](),
+		maybe.Nothing[[]byte](),
+		100,
+	)
+	require.NoError(err)
+}
+
+func TestHistoryDBClear(t *testing.T) {
+	for _, test := range clearTests {
+		t.Run(test.name, func(t *testing.T) {
+			require := require.New(t)
+
+			r := rand.New(rand.NewSource(0)) // #nosec G404
+
+			db, err := newDatabase(context.Background(), test.newBaseDB(), newHistoryDBConfig(100), &mockMetrics{})
+			require.NoError(err)
+
+			roots, _ := writeRandomChanges(require, r, db, 5)
+			require.NoError(db.Clear())
+			require.Equal(1, db.historyDB.entries.Len())
+
+			newRoots, _ := writeRandomChanges(require, r, db, 5)
+			_, err = db.GetChangeProof(
+				context.Background(),
+				roots[0],
+				newRoots[4],
+				maybe.Nothing[[]byte](),
+				maybe.Nothing[[]byte](),
+				100,
+			)
+			require.ErrorIs(err, ErrInsufficientHistory)
+
+			_, err = db.GetChangeProof(
+				context.Background(),
+				ids.Empty,
+				newRoots[4],
+				maybe.Nothing[[]byte](),
+				maybe.Nothing[[]byte](),
+				100,
+			)
+			require.NoError(err)
+		})
+	}
+}
@@ -365,6 +365,30 @@ func Test_History_RepeatedRoot(t *testing.T) {
 	require.NoError(newProof.Verify(context.Background(), maybe.Some([]byte("k")), maybe.Some([]byte("key3")), origRootID, db.tokenSize, db.hasher))
 }
 
+func Test_History_StartRootAfterOldestEndRoot(t *testing.T) {
+	require := require.New(t)
+
+	config := newDefaultConfig()
+	config.HistoryLength = 2
+	db, err := newDB(
+		context.Background(),
+		memdb.New(),
+		config,
+	)
+	require.NoError(err)
+	startRoot := db.rootID
+
+	require.NoError(db.Put([]byte("key"), []byte("value")))
+	endRoot := db.rootID
+
+	// Return to [startRoot], evicting its original change from the history.
+	require.NoError(db.Delete([]byte("key")))
+	require.Equal(startRoot, db.rootID)
+
+	_, err = db.history.getValueChanges(startRoot, endRoot, maybe.Nothing[[]byte](), maybe.Nothing[[]byte](), 10)
+	require.ErrorIs(err, ErrInsufficientHistory)
+}
+
 func Test_History_ExcessDeletes(t *testing.T) {
 	require := require.New(t)
 
@@ -192,5 +192,5 @@ func (db *intermediateNodeDB) Clear() error {
 		db.writeBuffer.size,
 		db.writeBuffer.onEviction,
 	)
-	return database.AtomicClearPrefix(db.baseDB, db.baseDB, intermediateNodePrefix)
+	return clearPrefix(db.baseDB, intermediateNodePrefix)
 }
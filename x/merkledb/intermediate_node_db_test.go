@@ -217,33 +217,37 @@ func Test_IntermediateNodeDB_ConstructDBKey_DirtyBuffer(t *testing.T) {
 }
 
 func TestIntermediateNodeDBClear(t *testing.T) {
-	require := require.New(t)
-	cacheSize := 200
-	bufferSize := 200
-	evictionBatchSize := bufferSize
-	baseDB := memdb.New()
-	db := newIntermediateNodeDB(
-		baseDB,
-		utils.NewBytesPool(),
-		&mockMetrics{},
-		cacheSize,
-		bufferSize,
-		evictionBatchSize,
-		4,
-		DefaultHasher,
-	)
+	for _, test := range clearTests {
+		t.Run(test.name, func(t *testing.T) {
+			require := require.New(t)
+			cacheSize := 200
+			bufferSize := 200
+			evictionBatchSize := bufferSize
+			baseDB := test.newBaseDB()
+			db := newIntermediateNodeDB(
+				baseDB,
+				utils.NewBytesPool(),
+				&mockMetrics{},
+				cacheSize,
+				bufferSize,
+				evictionBatchSize,
+				4,
+				DefaultHasher,
+			)
 
-	for _, b := range [][]byte{{1}, {2}, {3}} {
-		require.NoError(db.Put(ToKey(b), newNode(ToKey(b))))
-	}
+			for _, b := range [][]byte{{1}, {2}, {3}} {
+				require.NoError(db.Put(ToKey(b), newNode(ToKey(b))))
+			}
 
-	require.NoError(db.Clear())
+			require.NoError(db.Clear())
 
-	iter := baseDB.NewIteratorWithPrefix(intermediateNodePrefix)
-	defer iter.Release()
-	require.False(iter.Next())
+			iter := baseDB.NewIteratorWithPrefix(intermediateNodePrefix)
+			defer iter.Release()
+			require.False(iter.Next())
 
-	require.Zero(db.writeBuffer.currentSize)
+			require.Zero(db.writeBuffer.currentSize)
+		})
+	}
 }
 
 // Test that deleting the empty key and flushing works correctly.
@@ -156,6 +156,21 @@ func (mr *MockMerkleDBMockRecorder) GetChangeProof(ctx, startRootID, endRootID,
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetChangeProof", reflect.TypeOf((*MockMerkleDB)(nil).GetChangeProof), ctx, startRootID, endRootID, start, end, maxLength)
 }
 
+// GetExclusionProof mocks base method.
+func (m *MockMerkleDB) GetExclusionProof(ctx context.Context, keyBytes []byte) (*Proof, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetExclusionProof", ctx, keyBytes)
+	ret0, _ := ret[0].(*Proof)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetExclusionProof indicates an expected call of GetExclusionProof.
+func (mr *MockMerkleDBMockRecorder) GetExclusionProof(ctx, keyBytes any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetExclusionProof", reflect.TypeOf((*MockMerkleDB)(nil).GetExclusionProof), ctx, keyBytes)
+}
+
 // GetMerkleRoot mocks base method.
 func (m *MockMerkleDB) GetMerkleRoot(ctx context.Context) (ids.ID, error) {
 	m.ctrl.T.Helper()
@@ -171,6 +186,21 @@ func (mr *MockMerkleDBMockRecorder) GetMerkleRoot(ctx any) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetMerkleRoot", reflect.TypeOf((*MockMerkleDB)(nil).GetMerkleRoot), ctx)
 }
 
+// GetMultiProof mocks base method.
+func (m *MockMerkleDB) GetMultiProof(ctx context.Context, keys [][]byte) (*MultiProof, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetMultiProof", ctx, keys)
+	ret0, _ := ret[0].(*MultiProof)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetMultiProof indicates an expected call of GetMultiProof.
+func (mr *MockMerkleDBMockRecorder) GetMultiProof(ctx, keys any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetMultiProof", reflect.TypeOf((*MockMerkleDB)(nil).GetMultiProof), ctx, keys)
+}
+
 // GetProof mocks base method.
 func (m *MockMerkleDB) GetProof(ctx context.Context, keyBytes []byte) (*Proof, error) {
 	m.ctrl.T.Helper()
@@ -0,0 +1,281 @@
+// Copyright (C) 2019-2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package merkledb
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+
+	"github.com/shubhamdubey02/cryftgo/ids"
+	"github.com/shubhamdubey02/cryftgo/utils/maybe"
+
+	pb "github.com/shubhamdubey02/cryftgo/proto/pb/sync"
+)
+
+var (
+	ErrNilMultiProof          = errors.New("multi-proof is nil")
+	ErrNoKeys                 = errors.New("no keys to prove")
+	ErrNonIncreasingNodes     = errors.New("proof nodes are not in increasing order")
+	ErrProofNodeNotInTrie     = errors.New("proof node isn't a descendant of the root")
+	ErrMissingProofNode       = errors.New("proof is missing a node on the path to a key")
+	ErrUnreferencedProofNodes = errors.New("proof contains nodes that aren't on the path to any key")
+)
+
+// MultiProof is an inclusion/exclusion proof of several keys.
+//
+// The proof paths of the keys share the nodes near the root, so each node is
+// included only once.
+type MultiProof struct {
+	// The union of the proof paths of the keys in [KeyValues].
+	// Sorted by increasing key with no duplicates.
+	// The first node is the root.
+	Nodes []ProofNode
+
+	// The keys being proven and their values.
+	// Sorted by increasing key with no duplicates.
+	// A value is Nothing if its key isn't in the trie.
+	KeyValues []KeyChange
+}
+
+// Verify returns nil if the trie given in [proof] has root [expectedRootID].
+// That is, this is a valid proof that each key in [proof.KeyValues]
+// exists/doesn't exist in the trie with root [expectedRootID].
+//
+// Unlike [Proof.Verify], the root is calculated by hashing each proof node
+// and checking that it matches the ID its parent has for it.
+func (proof *MultiProof) Verify(
+	ctx context.Context,
+	expectedRootID ids.ID,
+	tokenSize int,
+	hasher Hasher,
+) error {
+	switch {
+	case len(proof.KeyValues) == 0:
+		return ErrNoKeys
+	case len(proof.Nodes) == 0:
+		return ErrEmptyProof
+	}
+	for i := 0; i < len(proof.KeyValues)-1; i++ {
+		if bytes.Compare(proof.KeyValues[i].Key, proof.KeyValues[i+1].Key) >= 0 {
+			return ErrNonIncreasingValues
+		}
+	}
+	for i := 0; i < len(proof.Nodes)-1; i++ {
+		if proof.Nodes[i].Key.Compare(proof.Nodes[i+1].Key) >= 0 {
+			return ErrNonIncreasingNodes
+		}
+	}
+
+	// children[i] maps each child index of proof.Nodes[i] to the index of
+	// the child in proof.Nodes, if it's in the proof.
+	children := make([]map[byte]int, len(proof.Nodes))
+
+	// Since the nodes are sorted by key, each node's ancestors come before
+	// it and are on [ancestors].
+	ancestors := make([]int, 0, len(proof.Nodes))
+	for i := range proof.Nodes {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+
+		proofNode := &proof.Nodes[i]
+		if proofNode.Key.hasPartialByte() && proofNode.ValueOrHash.HasValue() {
+			return ErrPartialByteLengthWithValue
+		}
+
+		nodeID := hashProofNode(hasher, proofNode)
+		if i == 0 {
+			if nodeID != expectedRootID {
+				return fmt.Errorf("%w:[%s], expected:[%s]", ErrInvalidProof, nodeID, expectedRootID)
+			}
+			ancestors = append(ancestors, i)
+			continue
+		}
+
+		for len(ancestors) > 0 && !proofNode.Key.HasStrictPrefix(proof.Nodes[ancestors[len(ancestors)-1]].Key) {
+			ancestors = ancestors[:len(ancestors)-1]
+		}
+		if len(ancestors) == 0 {
+			return ErrProofNodeNotInTrie
+		}
+
+		// The closest ancestor in the proof must be the parent of this node.
+		// Otherwise, the parent is missing and the IDs won't match.
+		parentIndex := ancestors[len(ancestors)-1]
+		parent := &proof.Nodes[parentIndex]
+		childIndex := proofNode.Key.Token(parent.Key.length, tokenSize)
+		if childID, ok := parent.Children[childIndex]; !ok || childID != nodeID {
+			return fmt.Errorf("%w: node %x isn't a child of %x", ErrInvalidProof, proofNode.Key.Bytes(), parent.Key.Bytes())
+		}
+		if children[parentIndex] == nil {
+			children[parentIndex] = make(map[byte]int)
+		}
+		children[parentIndex][childIndex] = i
+		ancestors = append(ancestors, i)
+	}
+
+	// Every node is now known to be in the trie, so check that each key's
+	// path proves its value.
+	used := make([]bool, len(proof.Nodes))
+	used[0] = true
+	for _, kv := range proof.KeyValues {
+		key := ToKey(kv.Key)
+
+		// If the root isn't a prefix of [key], the root alone proves
+		// that [key] isn't in the trie.
+		lastIndex := 0
+		lastNode := &proof.Nodes[lastIndex]
+		for key.HasPrefix(lastNode.Key) && lastNode.Key != key {
+			childIndex := key.Token(lastNode.Key.length, tokenSize)
+			if _, ok := lastNode.Children[childIndex]; !ok {
+				// There is no child along the path to [key].
+				break
+			}
+			nextIndex, ok := children[lastIndex][childIndex]
+			if !ok {
+				return fmt.Errorf("%w: %x", ErrMissingProofNode, kv.Key)
+			}
+			lastIndex = nextIndex
+			used[lastIndex] = true
+			lastNode = &proof.Nodes[lastIndex]
+		}
+
+		if lastNode.Key == key {
+			if !valueOrHashMatches(hasher, kv.Value, lastNode.ValueOrHash) {
+				return ErrProofValueDoesntMatch
+			}
+			continue
+		}
+		if kv.Value.HasValue() {
+			return ErrProofValueDoesntMatch
+		}
+	}
+
+	if slices.Contains(used, false) {
+		return ErrUnreferencedProofNodes
+	}
+	return nil
+}
+
+func (proof *MultiProof) ToProto() *pb.MultiProof {
+	nodes := make([]*pb.ProofNode, len(proof.Nodes))
+	for i, node := range proof.Nodes {
+		nodes[i] = node.ToProto()
+	}
+
+	keyValues := make([]*pb.KeyChange, len(proof.KeyValues))
+	for i, kv := range proof.KeyValues {
+		keyValues[i] = &pb.KeyChange{
+			Key: kv.Key,
+			Value: &pb.MaybeBytes{
+				Value:     kv.Value.Value(),
+				IsNothing: kv.Value.IsNothing(),
+			},
+		}
+	}
+
+	return &pb.MultiProof{
+		Nodes:     nodes,
+		KeyValues: keyValues,
+	}
+}
+
+func (proof *MultiProof) UnmarshalProto(pbProof *pb.MultiProof) error {
+	if pbProof == nil {
+		return ErrNilMultiProof
+	}
+
+	proof.Nodes = make([]ProofNode, len(pbProof.Nodes))
+	for i, protoNode := range pbProof.Nodes {
+		if err := proof.Nodes[i].UnmarshalProto(protoNode); err != nil {
+			return err
+		}
+	}
+
+	proof.KeyValues = make([]KeyChange, len(pbProof.KeyValues))
+	for i, kv := range pbProof.KeyValues {
+		if kv.Value == nil {
+			return ErrNilMaybeBytes
+		}
+
+		if kv.Value.IsNothing && len(kv.Value.Value) != 0 {
+			return ErrInvalidMaybe
+		}
+
+		value := maybe.Nothing[[]byte]()
+		if !kv.Value.IsNothing {
+			value = maybe.Some(kv.Value.Value)
+		}
+		proof.KeyValues[i] = KeyChange{
+			Key:   kv.Key,
+			Value: value,
+		}
+	}
+
+	return nil
+}
+
+// Returns a proof that each key in [keys] is in or not in trie [t].
+// Assumes [t] doesn't change while this function is running.
+func getMultiProof(t Trie, keys [][]byte) (*MultiProof, error) {
+	switch {
+	case len(keys) == 0:
+		return nil, ErrNoKeys
+	case t.getRoot().IsNothing():
+		return nil, ErrEmptyProof
+	}
+
+	keys = slices.Clone(keys)
+	slices.SortFunc(keys, bytes.Compare)
+	keys = slices.CompactFunc(keys, bytes.Equal)
+
+	var (
+		proof = &MultiProof{
+			KeyValues: make([]KeyChange, len(keys)),
+		}
+		seen = make(map[Key]struct{})
+	)
+	for i, key := range keys {
+		keyProof, err := getProof(t, key)
+		if err != nil {
+			return nil, err
+		}
+		proof.KeyValues[i] = KeyChange{
+			Key:   key,
+			Value: keyProof.Value,
+		}
+		for _, proofNode := range keyProof.Path {
+			if _, ok := seen[proofNode.Key]; ok {
+				continue
+			}
+			seen[proofNode.Key] = struct{}{}
+			proof.Nodes = append(proof.Nodes, proofNode)
+		}
+	}
+
+	slices.SortFunc(proof.Nodes, func(a, b ProofNode) int {
+		return a.Key.Compare(b.Key)
+	})
+	return proof, nil
+}
+
+// hashProofNode returns the ID of the node that [proofNode] represents.
+func hashProofNode(hasher Hasher, proofNode *ProofNode) ids.ID {
+	n := &node{
+		dbNode: dbNode{
+			children: make(map[byte]*child, len(proofNode.Children)),
+		},
+		key:         proofNode.Key,
+		valueDigest: proofNode.ValueOrHash,
+	}
+	for index, childID := range proofNode.Children {
+		n.children[index] = &child{
+			id: childID,
+		}
+	}
+	return hasher.HashNode(n)
+}
@@ -0,0 +1,301 @@
+// Copyright (C) 2019-2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package merkledb
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"math/rand"
+	"slices"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/shubhamdubey02/cryftgo/database"
+	"github.com/shubhamdubey02/cryftgo/ids"
+	"github.com/shubhamdubey02/cryftgo/utils/maybe"
+)
+
+// newMultiProofTestDB returns a database containing [numKeys] random keys and
+// the keys to prove, which contain a mix of keys in and not in the database.
+func newMultiProofTestDB(
+	require *require.Assertions,
+	r *rand.Rand,
+	branchFactor BranchFactor,
+	numKeys int,
+	numProvenKeys int,
+) (*merkleDB, [][]byte) {
+	db, err := getBasicDBWithBranchFactor(branchFactor)
+	require.NoError(err)
+
+	keys := make([][]byte, numKeys)
+	for i := range keys {
+		keys[i] = make([]byte, 1+r.Intn(8))
+		_, _ = r.Read(keys[i])
+
+		value := make([]byte, r.Intn(2*HashLength))
+		_, _ = r.Read(value)
+		require.NoError(db.Put(keys[i], value))
+	}
+
+	provenKeys := make([][]byte, numProvenKeys)
+	for i := range provenKeys {
+		if r.Intn(2) == 0 {
+			provenKeys[i] = keys[r.Intn(len(keys))]
+			continue
+		}
+		provenKeys[i] = make([]byte, 1+r.Intn(8))
+		_, _ = r.Read(provenKeys[i])
+	}
+	return db, provenKeys
+}
+
+func TestMultiProof(t *testing.T) {
+	for _, bf := range validBranchFactors {
+		t.Run(fmt.Sprintf("branch_factor_%d", bf), func(t *testing.T) {
+			require := require.New(t)
+			r := rand.New(rand.NewSource(0)) // #nosec G404
+
+			db, keys := newMultiProofTestDB(require, r, bf, 1_000, 100)
+			// Duplicate keys are only proven once.
+			keys = append(keys, keys[0])
+
+			proof, err := db.GetMultiProof(context.Background(), keys)
+			require.NoError(err)
+
+			rootID, err := db.GetMerkleRoot(context.Background())
+			require.NoError(err)
+			require.NoError(proof.Verify(context.Background(), rootID, db.tokenSize, db.hasher))
+
+			slices.SortFunc(keys, bytes.Compare)
+			keys = slices.CompactFunc(keys, bytes.Equal)
+			require.Len(proof.KeyValues, len(keys))
+
+			numPathNodes := 0
+			for i, key := range keys {
+				require.Equal(key, proof.KeyValues[i].Key)
+
+				value, err := db.Get(key)
+				if errors.Is(err, database.ErrNotFound) {
+					require.True(proof.KeyValues[i].Value.IsNothing())
+				} else {
+					require.NoError(err)
+					require.Equal(maybe.Some(value), proof.KeyValues[i].Value)
+				}
+
+				keyProof, err := db.GetProof(context.Background(), key)
+				require.NoError(err)
+				numPathNodes += len(keyProof.Path)
+			}
+			require.Less(len(proof.Nodes), numPathNodes)
+
+			// The proof is still valid after being serialized.
+			var unmarshaledProof MultiProof
+			require.NoError(unmarshaledProof.UnmarshalProto(proof.ToProto()))
+			require.NoError(unmarshaledProof.Verify(context.Background(), rootID, db.tokenSize, db.hasher))
+		})
+	}
+}
+
+func TestMultiProofSingleNode(t *testing.T) {
+	require := require.New(t)
+
+	db, err := getBasicDB()
+	require.NoError(err)
+	require.NoError(db.Put([]byte{1}, []byte{2}))
+
+	proof, err := db.GetMultiProof(context.Background(), [][]byte{{0}, {1}, {1, 0}})
+	require.NoError(err)
+	require.Len(proof.Nodes, 1)
+	require.Equal(
+		[]KeyChange{
+			{Key: []byte{0}, Value: maybe.Nothing[[]byte]()},
+			{Key: []byte{1}, Value: maybe.Some([]byte{2})},
+			{Key: []byte{1, 0}, Value: maybe.Nothing[[]byte]()},
+		},
+		proof.KeyValues,
+	)
+
+	rootID, err := db.GetMerkleRoot(context.Background())
+	require.NoError(err)
+	require.NoError(proof.Verify(context.Background(), rootID, db.tokenSize, db.hasher))
+}
+
+func TestGetMultiProofErrors(t *testing.T) {
+	require := require.New(t)
+
+	db, err := getBasicDB()
+	require.NoError(err)
+
+	_, err = db.GetMultiProof(context.Background(), [][]byte{{0}})
+	require.ErrorIs(err, ErrEmptyProof)
+
+	require.NoError(db.Put([]byte{0}, []byte{0}))
+	_, err = db.GetMultiProof(context.Background(), nil)
+	require.ErrorIs(err, ErrNoKeys)
+}
+
+func TestMultiProofVerifyInvalid(t *testing.T) {
+	r := rand.New(rand.NewSource(0)) // #nosec G404
+
+	db, keys := newMultiProofTestDB(require.New(t), r, BranchFactor16, 1_000, 20)
+	rootID, err := db.GetMerkleRoot(context.Background())
+	require.NoError(t, err)
+
+	type test struct {
+		name        string
+		modify      func(*MultiProof)
+		rootID      ids.ID
+		expectedErr error
+	}
+
+	tests := []test{
+		{
+			name:        "wrong root",
+			modify:      func(*MultiProof) {},
+			rootID:      ids.GenerateTestID(),
+			expectedErr: ErrInvalidProof,
+		},
+		{
+			name: "no keys",
+			modify: func(proof *MultiProof) {
+				proof.KeyValues = nil
+			},
+			rootID:      rootID,
+			expectedErr: ErrNoKeys,
+		},
+		{
+			name: "no nodes",
+			modify: func(proof *MultiProof) {
+				proof.Nodes = nil
+			},
+			rootID:      rootID,
+			expectedErr: ErrEmptyProof,
+		},
+		{
+			name: "unsorted keys",
+			modify: func(proof *MultiProof) {
+				proof.KeyValues[0], proof.KeyValues[1] = proof.KeyValues[1], proof.KeyValues[0]
+			},
+			rootID:      rootID,
+			expectedErr: ErrNonIncreasingValues,
+		},
+		{
+			name: "unsorted nodes",
+			modify: func(proof *MultiProof) {
+				last := len(proof.Nodes) - 1
+				proof.Nodes[last-1], proof.Nodes[last] = proof.Nodes[last], proof.Nodes[last-1]
+			},
+			rootID:      rootID,
+			expectedErr: ErrNonIncreasingNodes,
+		},
+		{
+			name: "missing node",
+			modify: func(proof *MultiProof) {
+				proof.Nodes = proof.Nodes[:len(proof.Nodes)-1]
+			},
+			rootID:      rootID,
+			expectedErr: ErrMissingProofNode,
+		},
+		{
+			name: "modified node",
+			modify: func(proof *MultiProof) {
+				proof.Nodes[len(proof.Nodes)-1].ValueOrHash = maybe.Some([]byte{1, 2, 3})
+			},
+			rootID:      rootID,
+			expectedErr: ErrInvalidProof,
+		},
+		{
+			name: "unreferenced node",
+			modify: func(proof *MultiProof) {
+				proof.KeyValues = proof.KeyValues[:1]
+			},
+			rootID:      rootID,
+			expectedErr: ErrUnreferencedProofNodes,
+		},
+		{
+			name: "wrong value",
+			modify: func(proof *MultiProof) {
+				for i, kv := range proof.KeyValues {
+					if kv.Value.HasValue() {
+						proof.KeyValues[i].Value = maybe.Some(append(kv.Value.Value(), 0))
+						return
+					}
+				}
+			},
+			rootID:      rootID,
+			expectedErr: ErrProofValueDoesntMatch,
+		},
+		{
+			name: "value for missing key",
+			modify: func(proof *MultiProof) {
+				for i, kv := range proof.KeyValues {
+					if kv.Value.IsNothing() {
+						proof.KeyValues[i].Value = maybe.Some([]byte{})
+						return
+					}
+				}
+			},
+			rootID:      rootID,
+			expectedErr: ErrProofValueDoesntMatch,
+		},
+		{
+			name: "missing value",
+			modify: func(proof *MultiProof) {
+				for i, kv := range proof.KeyValues {
+					if kv.Value.HasValue() {
+						proof.KeyValues[i].Value = maybe.Nothing[[]byte]()
+						return
+					}
+				}
+			},
+			rootID:      rootID,
+			expectedErr: ErrProofValueDoesntMatch,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			require := require.New(t)
+
+			proof, err := db.GetMultiProof(context.Background(), keys)
+			require.NoError(err)
+
+			tt.modify(proof)
+			err = proof.Verify(context.Background(), tt.rootID, db.tokenSize, db.hasher)
+			require.ErrorIs(err, tt.expectedErr)
+		})
+	}
+}
+
+func TestMultiProofUnmarshalProtoNil(t *testing.T) {
+	var proof MultiProof
+	err := proof.UnmarshalProto(nil)
+	require.ErrorIs(t, err, ErrNilMultiProof)
+}
+
+func FuzzMultiProofVerification(f *testing.F) {
+	f.Fuzz(func(
+		t *testing.T,
+		randSeed int64,
+		numKeys uint16,
+		numProvenKeys uint8,
+	) {
+		require := require.New(t)
+		r := rand.New(rand.NewSource(randSeed)) // #nosec G404
+
+		for _, bf := range validBranchFactors {
+			db, keys := newMultiProofTestDB(require, r, bf, 1+int(numKeys%1_000), 1+int(numProvenKeys))
+
+			proof, err := db.GetMultiProof(context.Background(), keys)
+			require.NoError(err)
+
+			rootID, err := db.GetMerkleRoot(context.Background())
+			require.NoError(err)
+			require.NoError(proof.Verify(context.Background(), rootID, db.tokenSize, db.hasher))
+		}
+	})
+}
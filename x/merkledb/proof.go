@@ -48,6 +48,7 @@ var (
 	ErrNilProof                    = errors.New("proof is nil")
 	ErrNilValue                    = errors.New("value is nil")
 	ErrUnexpectedEndProof          = errors.New("end proof should be empty")
+	ErrKeyInTrie                   = errors.New("key is in the trie")
 )
 
 type ProofNode struct {
@@ -194,6 +195,32 @@ func (proof *Proof) Verify(
 	return nil
 }
 
+// VerifyExclusion returns nil if [proof] is a valid proof that [proof.Key]
+// isn't in the trie with root [expectedRootID].
+//
+// If [expectedRootID] is ids.Empty, the trie is empty and [proof.Path] must
+// also be empty.
+// Returns [ErrKeyInTrie] if [proof] claims that [proof.Key] is in the trie.
+func (proof *Proof) VerifyExclusion(
+	ctx context.Context,
+	expectedRootID ids.ID,
+	tokenSize int,
+	hasher Hasher,
+) error {
+	if proof.Value.HasValue() {
+		return ErrKeyInTrie
+	}
+	if expectedRootID == ids.Empty && len(proof.Path) == 0 {
+		// No key is in the empty trie.
+		return nil
+	}
+
+	// Since [proof.Value] is Nothing, a valid proof either ends at a node
+	// that precludes the existence of [proof.Key] or ends at a node with key
+	// [proof.Key] that doesn't have a value.
+	return proof.Verify(ctx, expectedRootID, tokenSize, hasher)
+}
+
 func (proof *Proof) ToProto() *pb.Proof {
 	value := &pb.MaybeBytes{
 		Value:     proof.Value.Value(),
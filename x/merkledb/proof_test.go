@@ -308,6 +308,68 @@ func Test_Proof(t *testing.T) {
 	require.ErrorIs(err, ErrProofNodeNotForKey)
 }
 
+func Test_ExclusionProof(t *testing.T) {
+	require := require.New(t)
+
+	dbTrie, err := getBasicDB()
+	require.NoError(err)
+	trie, err := dbTrie.NewView(
+		context.Background(),
+		ViewChanges{
+			BatchOps: []database.BatchOp{
+				{Key: []byte("key"), Value: []byte("value")},
+				{Key: []byte("key0"), Value: []byte("value0")},
+				{Key: []byte("key1"), Value: []byte("value1")},
+				{Key: []byte("key2"), Value: []byte("value2")},
+			},
+		},
+	)
+	require.NoError(err)
+
+	expectedRootID, err := trie.GetMerkleRoot(context.Background())
+	require.NoError(err)
+
+	for _, key := range []string{"", "k", "kez", "key3", "key00", "key1\x00"} {
+		proof, err := trie.GetExclusionProof(context.Background(), []byte(key))
+		require.NoError(err)
+		require.True(proof.Value.IsNothing())
+		require.NoError(proof.VerifyExclusion(context.Background(), expectedRootID, dbTrie.tokenSize, dbTrie.hasher))
+
+		err = proof.VerifyExclusion(context.Background(), ids.GenerateTestID(), dbTrie.tokenSize, dbTrie.hasher)
+		require.ErrorIs(err, ErrInvalidProof)
+	}
+
+	_, err = trie.GetExclusionProof(context.Background(), []byte("key1"))
+	require.ErrorIs(err, ErrKeyInTrie)
+
+	// An inclusion proof isn't an exclusion proof.
+	proof, err := trie.GetProof(context.Background(), []byte("key1"))
+	require.NoError(err)
+	err = proof.VerifyExclusion(context.Background(), expectedRootID, dbTrie.tokenSize, dbTrie.hasher)
+	require.ErrorIs(err, ErrKeyInTrie)
+
+	// Claiming that a key in the trie is absent is invalid.
+	proof.Value = maybe.Nothing[[]byte]()
+	err = proof.VerifyExclusion(context.Background(), expectedRootID, dbTrie.tokenSize, dbTrie.hasher)
+	require.ErrorIs(err, ErrProofValueDoesntMatch)
+}
+
+func Test_ExclusionProof_EmptyTrie(t *testing.T) {
+	require := require.New(t)
+
+	db, err := getBasicDB()
+	require.NoError(err)
+
+	proof, err := db.GetExclusionProof(context.Background(), []byte("key"))
+	require.NoError(err)
+	require.Empty(proof.Path)
+	require.NoError(proof.VerifyExclusion(context.Background(), ids.Empty, db.tokenSize, db.hasher))
+
+	// The proof isn't valid for a non-empty trie.
+	err = proof.VerifyExclusion(context.Background(), ids.GenerateTestID(), db.tokenSize, db.hasher)
+	require.ErrorIs(err, ErrEmptyProof)
+}
+
 func Test_RangeProof_Syntactic_Verify(t *testing.T) {
 	type test struct {
 		name        string
@@ -33,6 +33,21 @@ type ProofGetter interface {
 	// or a proof of its absence from the trie
 	// Returns ErrEmptyProof if the trie is empty.
 	GetProof(ctx context.Context, keyBytes []byte) (*Proof, error)
+
+	// GetExclusionProof generates a proof that [keyBytes] isn't in the trie.
+	// The proof should be verified with [Proof.VerifyExclusion].
+	// If the trie is empty, the proof doesn't contain any nodes.
+	// Returns ErrKeyInTrie if [keyBytes] is in the trie.
+	GetExclusionProof(ctx context.Context, keyBytes []byte) (*Proof, error)
+}
+
+type MultiProofGetter interface {
+	// GetMultiProof generates a single proof of the values associated with
+	// [keys], or of their absence from the trie.
+	// Nodes shared by the proof paths of multiple keys are only included once.
+	// Returns ErrNoKeys if [keys] is empty.
+	// Returns ErrEmptyProof if the trie is empty.
+	GetMultiProof(ctx context.Context, keys [][]byte) (*MultiProof, error)
 }
 
 type trieInternals interface {
@@ -191,6 +206,26 @@ func getProof(t Trie, key []byte) (*Proof, error) {
 	return proof, nil
 }
 
+// Returns a proof that [key] isn't in trie [t].
+// Assumes [t] doesn't change while this function is running.
+func getExclusionProof(t Trie, key []byte) (*Proof, error) {
+	if t.getRoot().IsNothing() {
+		// No key is in the empty trie.
+		return &Proof{
+			Key: ToKey(key),
+		}, nil
+	}
+
+	proof, err := getProof(t, key)
+	if err != nil {
+		return nil, err
+	}
+	if proof.Value.HasValue() {
+		return nil, ErrKeyInTrie
+	}
+	return proof, nil
+}
+
 // GetRangeProof returns a range proof for (at least part of) the key range [start, end].
 // The returned proof's [KeyValues] has at most [maxLength] values.
 // [maxLength] must be > 0.
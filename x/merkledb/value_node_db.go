@@ -103,7 +103,7 @@ func (db *valueNodeDB) Get(key Key) (*node, error) {
 
 func (db *valueNodeDB) Clear() error {
 	db.nodeCache.Flush()
-	return database.AtomicClearPrefix(db.baseDB, db.baseDB, valueNodePrefix)
+	return clearPrefix(db.baseDB, valueNodePrefix)
 }
 
 type iterator struct {
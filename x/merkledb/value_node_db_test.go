@@ -218,31 +218,35 @@ func TestValueNodeDBIterator(t *testing.T) {
 }
 
 func TestValueNodeDBClear(t *testing.T) {
-	require := require.New(t)
-	cacheSize := 200
-	baseDB := memdb.New()
-	db := newValueNodeDB(
-		baseDB,
-		utils.NewBytesPool(),
-		&mockMetrics{},
-		cacheSize,
-		DefaultHasher,
-	)
-
-	batch := db.baseDB.NewBatch()
-	for _, b := range [][]byte{{1}, {2}, {3}} {
-		require.NoError(db.Write(batch, ToKey(b), newNode(ToKey(b))))
+	for _, test := range clearTests {
+		t.Run(test.name, func(t *testing.T) {
+			require := require.New(t)
+			cacheSize := 200
+			baseDB := test.newBaseDB()
+			db := newValueNodeDB(
+				baseDB,
+				utils.NewBytesPool(),
+				&mockMetrics{},
+				cacheSize,
+				DefaultHasher,
+			)
+
+			batch := db.baseDB.NewBatch()
+			for _, b := range [][]byte{{1}, {2}, {3}} {
+				require.NoError(db.Write(batch, ToKey(b), newNode(ToKey(b))))
+			}
+			require.NoError(batch.Write())
+
+			// Assert the db is not empty
+			iter := baseDB.NewIteratorWithPrefix(valueNodePrefix)
+			require.True(iter.Next())
+			iter.Release()
+
+			require.NoError(db.Clear())
+
+			iter = baseDB.NewIteratorWithPrefix(valueNodePrefix)
+			defer iter.Release()
+			require.False(iter.Next())
+		})
 	}
-	require.NoError(batch.Write())
-
-	// Assert the db is not empty
-	iter := baseDB.NewIteratorWithPrefix(valueNodePrefix)
-	require.True(iter.Next())
-	iter.Release()
-
-	require.NoError(db.Clear())
-
-	iter = baseDB.NewIteratorWithPrefix(valueNodePrefix)
-	defer iter.Release()
-	require.False(iter.Next())
 }
@@ -450,6 +450,25 @@ func (v *view) GetProof(ctx context.Context, key []byte) (*Proof, error) {
 	return result, nil
 }
 
+// GetExclusionProof returns a proof that [key] isn't in the view.
+func (v *view) GetExclusionProof(ctx context.Context, key []byte) (*Proof, error) {
+	_, span := v.db.infoTracer.Start(ctx, "MerkleDB.view.GetExclusionProof")
+	defer span.End()
+
+	if err := v.applyValueChanges(ctx); err != nil {
+		return nil, err
+	}
+
+	result, err := getExclusionProof(v, key)
+	if err != nil {
+		return nil, err
+	}
+	if v.isInvalid() {
+		return nil, ErrInvalid
+	}
+	return result, nil
+}
+
 // GetRangeProof returns a range proof for (at least part of) the key range [start, end].
 // The returned proof's [KeyValues] has at most [maxLength] values.
 // [maxLength] must be > 0.
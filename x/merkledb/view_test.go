@@ -6,6 +6,8 @@ package merkledb
 import (
 	"context"
 	"encoding/binary"
+	"fmt"
+	"runtime"
 	"testing"
 
 	"github.com/stretchr/testify/require"
@@ -52,9 +54,10 @@ var hashChangedNodesTests = []struct {
 	},
 }
 
-func makeViewForHashChangedNodes(t require.TestingT, numKeys uint64, parallelism uint) *view {
+func makeViewForHashChangedNodes(t require.TestingT, numKeys uint64, parallelism uint, branchFactor BranchFactor) *view {
 	config := newDefaultConfig()
 	config.RootGenConcurrency = parallelism
+	config.BranchFactor = branchFactor
 	db, err := newDatabase(
 		context.Background(),
 		memdb.New(),
@@ -84,7 +87,7 @@ func makeViewForHashChangedNodes(t require.TestingT, numKeys uint64, parallelism
 func Test_HashChangedNodes(t *testing.T) {
 	for _, test := range hashChangedNodesTests {
 		t.Run(test.name, func(t *testing.T) {
-			view := makeViewForHashChangedNodes(t, test.numKeys, 16)
+			view := makeViewForHashChangedNodes(t, test.numKeys, 16, BranchFactor16)
 			ctx := context.Background()
 			view.hashChangedNodes(ctx)
 			require.Equal(t, test.expectedRootHash, view.changes.rootID.String())
@@ -92,9 +95,38 @@ func Test_HashChangedNodes(t *testing.T) {
 	}
 }
 
+// Hashing subtries concurrently must result in exactly the same nodes as
+// hashing them serially.
+func Test_HashChangedNodes_Concurrency(t *testing.T) {
+	const numKeys = 10_000
+
+	for _, bf := range validBranchFactors {
+		t.Run(fmt.Sprintf("branch factor %d", bf), func(t *testing.T) {
+			require := require.New(t)
+			ctx := context.Background()
+
+			serialView := makeViewForHashChangedNodes(t, numKeys, 1, bf)
+			serialView.hashChangedNodes(ctx)
+
+			for _, parallelism := range []uint{2, 16} {
+				view := makeViewForHashChangedNodes(t, numKeys, parallelism, bf)
+				view.hashChangedNodes(ctx)
+				require.Equal(serialView.changes.rootID, view.changes.rootID)
+
+				require.Len(view.changes.nodes, len(serialView.changes.nodes))
+				for key, nodeChange := range view.changes.nodes {
+					serialNodeChange, ok := serialView.changes.nodes[key]
+					require.True(ok)
+					require.Equal(serialNodeChange.after.bytes(), nodeChange.after.bytes())
+				}
+			}
+		})
+	}
+}
+
 func Benchmark_HashChangedNodes(b *testing.B) {
 	for _, test := range hashChangedNodesTests {
-		view := makeViewForHashChangedNodes(b, test.numKeys, 1)
+		view := makeViewForHashChangedNodes(b, test.numKeys, 1, BranchFactor16)
 		ctx := context.Background()
 		b.Run(test.name, func(b *testing.B) {
 			for i := 0; i < b.N; i++ {
@@ -103,3 +135,35 @@ func Benchmark_HashChangedNodes(b *testing.B) {
 		})
 	}
 }
+
+// Benchmark_HashChangedNodes_Concurrency compares hashing serially with
+// hashing subtries concurrently for each branch factor. The serial run sets
+// [Config.RootGenConcurrency] to 1 and serves as the baseline.
+func Benchmark_HashChangedNodes_Concurrency(b *testing.B) {
+	benchmark := func(b *testing.B, numKeys uint64, parallelism uint, bf BranchFactor) {
+		view := makeViewForHashChangedNodes(b, numKeys, parallelism, bf)
+		ctx := context.Background()
+		b.ResetTimer()
+		for i := 0; i < b.N; i++ {
+			view.hashChangedNodes(ctx)
+		}
+	}
+
+	parallelisms := []uint{4}
+	if numCPU := uint(runtime.NumCPU()); numCPU > 1 && numCPU != 4 {
+		parallelisms = append(parallelisms, numCPU)
+	}
+	for _, numKeys := range []uint64{1_000, 100_000} {
+		for _, bf := range validBranchFactors {
+			name := fmt.Sprintf("keys=%d/branch_factor=%d", numKeys, bf)
+			b.Run(name+"/serial", func(b *testing.B) {
+				benchmark(b, numKeys, 1, bf)
+			})
+			for _, parallelism := range parallelisms {
+				b.Run(fmt.Sprintf("%s/parallel=%d", name, parallelism), func(b *testing.B) {
+					benchmark(b, numKeys, parallelism, bf)
+				})
+			}
+		}
+	}
+}
@@ -15,6 +15,7 @@ import (
 	"google.golang.org/protobuf/proto"
 
 	"github.com/shubhamdubey02/cryftgo/ids"
+	"github.com/shubhamdubey02/cryftgo/utils/compression"
 	"github.com/shubhamdubey02/cryftgo/utils/logging"
 	"github.com/shubhamdubey02/cryftgo/utils/maybe"
 	"github.com/shubhamdubey02/cryftgo/x/merkledb"
@@ -33,11 +34,16 @@ const (
 var (
 	_ Client = (*client)(nil)
 
-	errInvalidRangeProof             = errors.New("failed to verify range proof")
-	errInvalidChangeProof            = errors.New("failed to verify change proof")
+	errInvalidProof                  = errors.New("invalid proof")
+	errInvalidRangeProof             = fmt.Errorf("%w: failed to verify range proof", errInvalidProof)
+	errInvalidChangeProof            = fmt.Errorf("%w: failed to verify change proof", errInvalidProof)
 	errTooManyKeys                   = errors.New("response contains more than requested keys")
 	errTooManyBytes                  = errors.New("response contains more than requested bytes")
 	errUnexpectedChangeProofResponse = errors.New("unexpected response type")
+	errNoPeers                       = errors.New("no peers available")
+	errUnsupportedCompression        = errors.New("unsupported compression type")
+	errUnexpectedCompression         = errors.New("response compressed with unexpected compression type")
+	errDecompressionFailed           = errors.New("failed to decompress response")
 )
 
 // Client synchronously fetches data from the network
@@ -67,6 +73,9 @@ type Client interface {
 
 type client struct {
 	networkClient    NetworkClient
+	compressionType  pb.CompressionType
+	zstdCompressor   compression.Compressor
+	peerScheduler    *PeerScheduler
 	stateSyncNodes   []ids.NodeID
 	stateSyncNodeIdx uint32
 	log              logging.Logger
@@ -83,6 +92,15 @@ type ClientConfig struct {
 	BranchFactor     merkledb.BranchFactor
 	// If not specified, [merkledb.DefaultHasher] will be used.
 	Hasher merkledb.Hasher
+	// If specified, selects the peer each request is sent to and bans peers
+	// that respond with invalid proofs. Takes precedence over
+	// [StateSyncNodeIDs].
+	// [NetworkClient] notifies [PeerScheduler] when peers connect and
+	// disconnect.
+	PeerScheduler *PeerScheduler
+	// If [compression.TypeZstd], servers are asked to compress their
+	// responses with zstd. If not specified, responses aren't compressed.
+	Compression compression.Type
 }
 
 func NewClient(config *ClientConfig) (Client, error) {
@@ -93,13 +111,35 @@ func NewClient(config *ClientConfig) (Client, error) {
 	if hasher == nil {
 		hasher = merkledb.DefaultHasher
 	}
+
+	var compressionType pb.CompressionType
+	switch config.Compression {
+	case 0, compression.TypeNone:
+		compressionType = pb.CompressionType_COMPRESSION_TYPE_UNSPECIFIED
+	case compression.TypeZstd:
+		compressionType = pb.CompressionType_COMPRESSION_TYPE_ZSTD
+	default:
+		return nil, fmt.Errorf("%w: %s", errUnsupportedCompression, config.Compression)
+	}
+	zstdCompressor, err := compression.NewZstdCompressor(maxByteSizeLimit)
+	if err != nil {
+		return nil, err
+	}
+
+	if config.PeerScheduler != nil {
+		config.NetworkClient.AddPeerScheduler(config.PeerScheduler)
+	}
+
 	return &client{
-		networkClient:  config.NetworkClient,
-		stateSyncNodes: config.StateSyncNodeIDs,
-		log:            config.Log,
-		metrics:        config.Metrics,
-		tokenSize:      merkledb.BranchFactorToTokenSize[config.BranchFactor],
-		hasher:         hasher,
+		networkClient:   config.NetworkClient,
+		compressionType: compressionType,
+		zstdCompressor:  zstdCompressor,
+		peerScheduler:   config.PeerScheduler,
+		stateSyncNodes:  config.StateSyncNodeIDs,
+		log:             config.Log,
+		metrics:         config.Metrics,
+		tokenSize:       merkledb.BranchFactorToTokenSize[config.BranchFactor],
+		hasher:          hasher,
 	}, nil
 }
 
@@ -111,9 +151,11 @@ func (c *client) GetChangeProof(
 	req *pb.SyncGetChangeProofRequest,
 	db DB,
 ) (*merkledb.ChangeOrRangeProof, error) {
+	req.CompressionType = c.compressionType
 	parseFn := func(ctx context.Context, responseBytes []byte) (*merkledb.ChangeOrRangeProof, error) {
-		if len(responseBytes) > int(req.BytesLimit) {
-			return nil, fmt.Errorf("%w: (%d) > %d)", errTooManyBytes, len(responseBytes), req.BytesLimit)
+		responseBytes, err := c.decompress(req.CompressionType, req.BytesLimit, responseBytes)
+		if err != nil {
+			return nil, err
 		}
 
 		var changeProofResp pb.SyncGetChangeProofResponse
@@ -250,12 +292,11 @@ func (c *client) GetRangeProof(
 	ctx context.Context,
 	req *pb.SyncGetRangeProofRequest,
 ) (*merkledb.RangeProof, error) {
+	req.CompressionType = c.compressionType
 	parseFn := func(ctx context.Context, responseBytes []byte) (*merkledb.RangeProof, error) {
-		if len(responseBytes) > int(req.BytesLimit) {
-			return nil, fmt.Errorf(
-				"%w: (%d) > %d)",
-				errTooManyBytes, len(responseBytes), req.BytesLimit,
-			)
+		responseBytes, err := c.decompress(req.CompressionType, req.BytesLimit, responseBytes)
+		if err != nil {
+			return nil, err
 		}
 
 		var rangeProofProto pb.RangeProof
@@ -295,6 +336,73 @@ func (c *client) GetRangeProof(
 	return getAndParse(ctx, c, reqBytes, parseFn)
 }
 
+// decompress returns the uncompressed proof in [response] to a request that
+// accepted [compressionType]. If [compressionType] is specified, servers that
+// support compression respond with a ProofResponse, which records whether the
+// proof was compressed, while older servers respond with the raw proof.
+// Returns [errTooManyBytes] if the proof is larger than [bytesLimit], either
+// before or after being uncompressed.
+func (c *client) decompress(
+	compressionType pb.CompressionType,
+	bytesLimit uint32,
+	response []byte,
+) ([]byte, error) {
+	proofResponse, ok := parseProofResponse(compressionType, response)
+	if !ok {
+		if len(response) > int(bytesLimit) {
+			return nil, fmt.Errorf("%w: (%d) > %d)", errTooManyBytes, len(response), bytesLimit)
+		}
+		return response, nil
+	}
+
+	proof := proofResponse.Proof
+	if len(proof) > int(bytesLimit) {
+		return nil, fmt.Errorf("%w: (%d) > %d)", errTooManyBytes, len(proof), bytesLimit)
+	}
+
+	switch proofResponse.CompressionType {
+	case pb.CompressionType_COMPRESSION_TYPE_UNSPECIFIED:
+		return proof, nil
+	case compressionType:
+	default:
+		return nil, fmt.Errorf("%w: %s", errUnexpectedCompression, proofResponse.CompressionType)
+	}
+
+	decompressedProof, err := c.zstdCompressor.Decompress(proof)
+	if errors.Is(err, compression.ErrDecompressedMsgTooLarge) {
+		return nil, fmt.Errorf("%w: %w", errTooManyBytes, err)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", errDecompressionFailed, err)
+	}
+	if len(decompressedProof) > int(bytesLimit) {
+		return nil, fmt.Errorf("%w: (%d) > %d)", errTooManyBytes, len(decompressedProof), bytesLimit)
+	}
+	return decompressedProof, nil
+}
+
+// parseProofResponse returns the ProofResponse in [response] to a request that
+// accepted [compressionType]. Returns false if [response] isn't a
+// ProofResponse, which is the case if [compressionType] is unspecified or the
+// server doesn't support compression.
+//
+// Proofs never contain the fields of a ProofResponse, so a proof never parses
+// as a ProofResponse that echoes [compressionType] without unknown fields.
+func parseProofResponse(compressionType pb.CompressionType, response []byte) (*pb.ProofResponse, bool) {
+	if compressionType == pb.CompressionType_COMPRESSION_TYPE_UNSPECIFIED {
+		return nil, false
+	}
+
+	var proofResponse pb.ProofResponse
+	if err := proto.Unmarshal(response, &proofResponse); err != nil {
+		return nil, false
+	}
+	if proofResponse.AcceptedCompressionType != compressionType || len(proofResponse.ProtoReflect().GetUnknown()) != 0 {
+		return nil, false
+	}
+	return &proofResponse, true
+}
+
 // getAndParse uses [client] to send [request] to an arbitrary peer.
 // Returns the response to the request.
 // [parseFn] parses the raw response.
@@ -314,9 +422,13 @@ func getAndParse[T any](
 	)
 	// Loop until the context is cancelled or we get a valid response.
 	for attempt := 1; ; attempt++ {
+		startTime := time.Now()
 		nodeID, responseBytes, err := client.get(ctx, request)
 		if err == nil {
-			if response, err = parseFn(ctx, responseBytes); err == nil {
+			latency := time.Since(startTime)
+			response, err = parseFn(ctx, responseBytes)
+			client.registerResponse(ctx, nodeID, latency, err)
+			if err == nil {
 				return response, nil
 			}
 		}
@@ -372,9 +484,12 @@ func (c *client) get(ctx context.Context, request []byte) (ids.NodeID, []byte, e
 
 	c.metrics.RequestMade()
 
-	if len(c.stateSyncNodes) == 0 {
+	switch {
+	case c.peerScheduler != nil:
+		nodeID, response, err = c.requestScheduledPeer(ctx, request)
+	case len(c.stateSyncNodes) == 0:
 		nodeID, response, err = c.networkClient.RequestAny(ctx, request)
-	} else {
+	default:
 		// Get the next nodeID to query using the [nodeIdx] offset.
 		// If we're out of nodes, loop back to 0.
 		// We do this try to query a different node each time if possible.
@@ -390,3 +505,48 @@ func (c *client) get(ctx context.Context, request []byte) (ids.NodeID, []byte, e
 	c.metrics.RequestSucceeded()
 	return nodeID, response, nil
 }
+
+// requestScheduledPeer sends [request] to the peer selected by
+// [c.peerScheduler]. Failed requests are reported to [c.peerScheduler].
+// Responses are reported by [registerResponse] once they have been parsed.
+func (c *client) requestScheduledPeer(ctx context.Context, request []byte) (ids.NodeID, []byte, error) {
+	nodeID, ok := c.peerScheduler.SelectPeer()
+	if !ok {
+		return ids.EmptyNodeID, nil, errNoPeers
+	}
+
+	response, err := c.networkClient.Request(ctx, nodeID, request)
+	if err != nil {
+		c.peerScheduler.RegisterFailure(nodeID)
+		return nodeID, nil, err
+	}
+	return nodeID, response, nil
+}
+
+// registerResponse reports to [c.peerScheduler] the response that [nodeID]
+// sent after [latency]. [parseErr] is the error returned when parsing the
+// response.
+func (c *client) registerResponse(
+	ctx context.Context,
+	nodeID ids.NodeID,
+	latency time.Duration,
+	parseErr error,
+) {
+	if c.peerScheduler == nil {
+		return
+	}
+
+	switch {
+	case parseErr == nil:
+		c.peerScheduler.RegisterResponse(nodeID, latency)
+	case errors.Is(parseErr, errInvalidProof) && ctx.Err() == nil:
+		// The peer sent a proof that failed verification, so don't request
+		// proofs from it again.
+		c.peerScheduler.RegisterInvalidResponse(nodeID)
+	default:
+		// The response couldn't be used, but that doesn't prove that the
+		// peer is misbehaving. For example, it may be running a version that
+		// responds in a format we don't expect.
+		c.peerScheduler.RegisterFailure(nodeID)
+	}
+}
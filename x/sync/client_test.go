@@ -5,6 +5,7 @@ package sync
 
 import (
 	"context"
+	"math"
 	"math/rand"
 	"testing"
 	"time"
@@ -19,8 +20,10 @@ import (
 	"github.com/shubhamdubey02/cryftgo/ids"
 	"github.com/shubhamdubey02/cryftgo/snow/engine/common"
 	"github.com/shubhamdubey02/cryftgo/trace"
+	"github.com/shubhamdubey02/cryftgo/utils/compression"
 	"github.com/shubhamdubey02/cryftgo/utils/logging"
 	"github.com/shubhamdubey02/cryftgo/utils/maybe"
+	"github.com/shubhamdubey02/cryftgo/version"
 	"github.com/shubhamdubey02/cryftgo/x/merkledb"
 
 	pb "github.com/shubhamdubey02/cryftgo/proto/pb/sync"
@@ -775,3 +778,431 @@ func TestAppRequestSendFailed(t *testing.T) {
 	)
 	require.ErrorIs(err, errAppSendFailed)
 }
+
+// Test that a peer that responds with an invalid proof is banned and the
+// request is retried with another peer.
+func TestRangeProofBansInvalidPeer(t *testing.T) {
+	now := time.Now().UnixNano()
+	t.Logf("seed: %d", now)
+	r := rand.New(rand.NewSource(now)) // #nosec G404
+	require := require.New(t)
+	ctrl := gomock.NewController(t)
+
+	db, _, err := generateTrieWithMinKeyLen(t, r, defaultRequestKeyLimit, 1)
+	require.NoError(err)
+	root, err := db.GetMerkleRoot(context.Background())
+	require.NoError(err)
+
+	request := &pb.SyncGetRangeProofRequest{
+		RootHash:   root[:],
+		KeyLimit:   defaultRequestKeyLimit,
+		BytesLimit: defaultRequestByteSizeLimit,
+	}
+	proof, err := db.GetRangeProof(context.Background(), maybe.Nothing[[]byte](), maybe.Nothing[[]byte](), defaultRequestKeyLimit)
+	require.NoError(err)
+	validResponse, err := proto.Marshal(proof.ToProto())
+	require.NoError(err)
+
+	// Remove a key so that the proof no longer matches the root.
+	proof.KeyValues = proof.KeyValues[1:]
+	invalidResponse, err := proto.Marshal(proof.ToProto())
+	require.NoError(err)
+
+	scheduler, err := NewPeerScheduler(logging.NoLog{}, "", prometheus.NewRegistry(), nil, nil, 1)
+	require.NoError(err)
+
+	var (
+		invalidNodeID = ids.GenerateTestNodeID()
+		validNodeID   = ids.GenerateTestNodeID()
+	)
+	scheduler.Connected(invalidNodeID, version.CurrentApp)
+	scheduler.Connected(validNodeID, version.CurrentApp)
+	// Make sure the invalid peer is selected first.
+	scheduler.RegisterResponse(validNodeID, time.Hour)
+
+	networkClient := NewMockNetworkClient(ctrl)
+	networkClient.EXPECT().AddPeerScheduler(scheduler)
+	networkClient.EXPECT().Request(gomock.Any(), invalidNodeID, gomock.Any()).Return(invalidResponse, nil)
+	networkClient.EXPECT().Request(gomock.Any(), validNodeID, gomock.Any()).Return(validResponse, nil)
+
+	client, err := NewClient(&ClientConfig{
+		NetworkClient: networkClient,
+		Log:           logging.NoLog{},
+		Metrics:       &mockMetrics{},
+		BranchFactor:  merkledb.BranchFactor16,
+		PeerScheduler: scheduler,
+	})
+	require.NoError(err)
+
+	gotProof, err := client.GetRangeProof(context.Background(), request)
+	require.NoError(err)
+	require.Len(gotProof.KeyValues, defaultRequestKeyLimit)
+
+	require.True(scheduler.Banned(invalidNodeID))
+	require.False(scheduler.Banned(validNodeID))
+}
+
+// Test that a peer whose response can't be parsed is not banned, since this
+// doesn't prove that the peer sent an invalid proof.
+func TestRangeProofDoesNotBanUnparsableResponse(t *testing.T) {
+	now := time.Now().UnixNano()
+	t.Logf("seed: %d", now)
+	r := rand.New(rand.NewSource(now)) // #nosec G404
+
+	db, _, err := generateTrieWithMinKeyLen(t, r, defaultRequestKeyLimit, 1)
+	require.NoError(t, err)
+	root, err := db.GetMerkleRoot(context.Background())
+	require.NoError(t, err)
+
+	proof, err := db.GetRangeProof(context.Background(), maybe.Nothing[[]byte](), maybe.Nothing[[]byte](), defaultRequestKeyLimit)
+	require.NoError(t, err)
+	validResponse, err := proto.Marshal(proof.ToProto())
+	require.NoError(t, err)
+
+	tests := []struct {
+		name     string
+		response []byte
+	}{
+		{
+			name:     "too many bytes",
+			response: make([]byte, defaultRequestByteSizeLimit+1),
+		},
+		{
+			name:     "undecodable",
+			response: []byte{0xff, 0xff, 0xff},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			require := require.New(t)
+			ctrl := gomock.NewController(t)
+
+			scheduler, err := NewPeerScheduler(logging.NoLog{}, "", prometheus.NewRegistry(), nil, nil, 1)
+			require.NoError(err)
+
+			nodeID := ids.GenerateTestNodeID()
+			scheduler.Connected(nodeID, version.CurrentApp)
+
+			networkClient := NewMockNetworkClient(ctrl)
+			networkClient.EXPECT().AddPeerScheduler(scheduler)
+			gomock.InOrder(
+				networkClient.EXPECT().Request(gomock.Any(), nodeID, gomock.Any()).Return(tt.response, nil),
+				networkClient.EXPECT().Request(gomock.Any(), nodeID, gomock.Any()).Return(validResponse, nil),
+			)
+
+			client, err := NewClient(&ClientConfig{
+				NetworkClient: networkClient,
+				Log:           logging.NoLog{},
+				Metrics:       &mockMetrics{},
+				BranchFactor:  merkledb.BranchFactor16,
+				PeerScheduler: scheduler,
+			})
+			require.NoError(err)
+
+			// The request is retried with the same peer because it wasn't
+			// banned.
+			gotProof, err := client.GetRangeProof(context.Background(), &pb.SyncGetRangeProofRequest{
+				RootHash:   root[:],
+				KeyLimit:   defaultRequestKeyLimit,
+				BytesLimit: defaultRequestByteSizeLimit,
+			})
+			require.NoError(err)
+			require.Len(gotProof.KeyValues, defaultRequestKeyLimit)
+			require.False(scheduler.Banned(nodeID))
+		})
+	}
+}
+
+// Test that range proofs are fetched when the client requests compressed
+// responses, regardless of whether the server compresses them.
+func TestRangeProofCompression(t *testing.T) {
+	const numKeys = 100
+
+	tests := []struct {
+		name                    string
+		value                   func(*rand.Rand) []byte
+		expectedCompressionType pb.CompressionType
+	}{
+		{
+			name: "compressed response",
+			value: func(*rand.Rand) []byte {
+				return make([]byte, 256)
+			},
+			expectedCompressionType: pb.CompressionType_COMPRESSION_TYPE_ZSTD,
+		},
+		{
+			name: "uncompressed response",
+			value: func(r *rand.Rand) []byte {
+				value := make([]byte, 256)
+				_, _ = r.Read(value)
+				return value
+			},
+			expectedCompressionType: pb.CompressionType_COMPRESSION_TYPE_UNSPECIFIED,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			require := require.New(t)
+			ctrl := gomock.NewController(t)
+
+			db, err := merkledb.New(
+				context.Background(),
+				memdb.New(),
+				newDefaultDBConfig(),
+			)
+			require.NoError(err)
+
+			r := rand.New(rand.NewSource(0)) // #nosec G404
+			batch := db.NewBatch()
+			for i := 0; i < numKeys; i++ {
+				require.NoError(batch.Put([]byte{byte(i)}, test.value(r)))
+			}
+			require.NoError(batch.Write())
+			root, err := db.GetMerkleRoot(context.Background())
+			require.NoError(err)
+
+			proof, err := db.GetRangeProof(context.Background(), maybe.Nothing[[]byte](), maybe.Nothing[[]byte](), numKeys)
+			require.NoError(err)
+			uncompressedProof, err := proto.Marshal(proof.ToProto())
+			require.NoError(err)
+
+			var response []byte
+			sender := common.NewMockSender(ctrl)
+			sender.EXPECT().SendAppResponse(
+				gomock.Any(), // ctx
+				gomock.Any(), // nodeID
+				gomock.Any(), // requestID
+				gomock.Any(), // responseBytes
+			).DoAndReturn(
+				func(_ context.Context, _ ids.NodeID, _ uint32, responseBytes []byte) error {
+					response = responseBytes
+					return nil
+				},
+			)
+			server := NewNetworkServer(sender, db, logging.NoLog{})
+
+			networkClient := NewMockNetworkClient(ctrl)
+			networkClient.EXPECT().RequestAny(
+				gomock.Any(), // ctx
+				gomock.Any(), // request
+			).DoAndReturn(
+				func(ctx context.Context, requestBytes []byte) (ids.NodeID, []byte, error) {
+					var request pb.Request
+					require.NoError(proto.Unmarshal(requestBytes, &request))
+					rangeProofRequest := request.GetRangeProofRequest()
+					require.Equal(pb.CompressionType_COMPRESSION_TYPE_ZSTD, rangeProofRequest.CompressionType)
+
+					nodeID := ids.GenerateTestNodeID()
+					require.NoError(server.HandleRangeProofRequest(ctx, nodeID, 0, rangeProofRequest))
+					return nodeID, response, nil
+				},
+			)
+
+			client, err := NewClient(&ClientConfig{
+				NetworkClient: networkClient,
+				Log:           logging.NoLog{},
+				Metrics:       &mockMetrics{},
+				BranchFactor:  merkledb.BranchFactor16,
+				Compression:   compression.TypeZstd,
+			})
+			require.NoError(err)
+
+			gotProof, err := client.GetRangeProof(context.Background(), &pb.SyncGetRangeProofRequest{
+				RootHash:   root[:],
+				KeyLimit:   numKeys,
+				BytesLimit: defaultRequestByteSizeLimit,
+			})
+			require.NoError(err)
+			require.Len(gotProof.KeyValues, numKeys)
+
+			var proofResponse pb.ProofResponse
+			require.NoError(proto.Unmarshal(response, &proofResponse))
+			require.Equal(test.expectedCompressionType, proofResponse.CompressionType)
+			if test.expectedCompressionType == pb.CompressionType_COMPRESSION_TYPE_ZSTD {
+				require.Less(len(proofResponse.Proof), len(uncompressedProof)/2)
+			} else {
+				var rangeProofProto pb.RangeProof
+				require.NoError(proto.Unmarshal(proofResponse.Proof, &rangeProofProto))
+				require.Len(rangeProofProto.KeyValues, numKeys)
+			}
+		})
+	}
+}
+
+// Test that a client requesting compressed responses parses the raw proofs sent
+// by servers that don't support compression.
+func TestCompressingClientWithOldServer(t *testing.T) {
+	require := require.New(t)
+	ctrl := gomock.NewController(t)
+
+	const numKeys = 100
+
+	serverDB, err := merkledb.New(
+		context.Background(),
+		memdb.New(),
+		newDefaultDBConfig(),
+	)
+	require.NoError(err)
+	clientDB, err := merkledb.New(
+		context.Background(),
+		memdb.New(),
+		newDefaultDBConfig(),
+	)
+	require.NoError(err)
+
+	for i := 0; i < numKeys/2; i++ {
+		require.NoError(serverDB.Put([]byte{byte(i)}, []byte{byte(i)}))
+		require.NoError(clientDB.Put([]byte{byte(i)}, []byte{byte(i)}))
+	}
+	startRoot, err := serverDB.GetMerkleRoot(context.Background())
+	require.NoError(err)
+	for i := numKeys / 2; i < numKeys; i++ {
+		require.NoError(serverDB.Put([]byte{byte(i)}, []byte{byte(i)}))
+	}
+	endRoot, err := serverDB.GetMerkleRoot(context.Background())
+	require.NoError(err)
+
+	var response []byte
+	sender := common.NewMockSender(ctrl)
+	sender.EXPECT().SendAppResponse(
+		gomock.Any(), // ctx
+		gomock.Any(), // nodeID
+		gomock.Any(), // requestID
+		gomock.Any(), // responseBytes
+	).DoAndReturn(
+		func(_ context.Context, _ ids.NodeID, _ uint32, responseBytes []byte) error {
+			response = responseBytes
+			return nil
+		},
+	).Times(2)
+	server := NewNetworkServer(sender, serverDB, logging.NoLog{})
+
+	networkClient := NewMockNetworkClient(ctrl)
+	networkClient.EXPECT().RequestAny(
+		gomock.Any(), // ctx
+		gomock.Any(), // request
+	).DoAndReturn(
+		func(ctx context.Context, requestBytes []byte) (ids.NodeID, []byte, error) {
+			var request pb.Request
+			require.NoError(proto.Unmarshal(requestBytes, &request))
+
+			// Servers that don't support compression don't know about the
+			// compression type of the request, so they ignore it.
+			nodeID := ids.GenerateTestNodeID()
+			switch req := request.Message.(type) {
+			case *pb.Request_RangeProofRequest:
+				require.Equal(pb.CompressionType_COMPRESSION_TYPE_ZSTD, req.RangeProofRequest.CompressionType)
+				req.RangeProofRequest.CompressionType = pb.CompressionType_COMPRESSION_TYPE_UNSPECIFIED
+				require.NoError(server.HandleRangeProofRequest(ctx, nodeID, 0, req.RangeProofRequest))
+			case *pb.Request_ChangeProofRequest:
+				require.Equal(pb.CompressionType_COMPRESSION_TYPE_ZSTD, req.ChangeProofRequest.CompressionType)
+				req.ChangeProofRequest.CompressionType = pb.CompressionType_COMPRESSION_TYPE_UNSPECIFIED
+				require.NoError(server.HandleChangeProofRequest(ctx, nodeID, 0, req.ChangeProofRequest))
+			default:
+				require.FailNow("unexpected request", "%T", req)
+			}
+			return nodeID, response, nil
+		},
+	).Times(2)
+
+	client, err := NewClient(&ClientConfig{
+		NetworkClient: networkClient,
+		Log:           logging.NoLog{},
+		Metrics:       &mockMetrics{},
+		BranchFactor:  merkledb.BranchFactor16,
+		Compression:   compression.TypeZstd,
+	})
+	require.NoError(err)
+
+	rangeProof, err := client.GetRangeProof(context.Background(), &pb.SyncGetRangeProofRequest{
+		RootHash:   endRoot[:],
+		KeyLimit:   numKeys,
+		BytesLimit: defaultRequestByteSizeLimit,
+	})
+	require.NoError(err)
+	require.Len(rangeProof.KeyValues, numKeys)
+
+	changeProof, err := client.GetChangeProof(
+		context.Background(),
+		&pb.SyncGetChangeProofRequest{
+			StartRootHash: startRoot[:],
+			EndRootHash:   endRoot[:],
+			KeyLimit:      numKeys,
+			BytesLimit:    defaultRequestByteSizeLimit,
+		},
+		clientDB,
+	)
+	require.NoError(err)
+	require.NotNil(changeProof.ChangeProof)
+	require.Len(changeProof.ChangeProof.KeyChanges, numKeys/2)
+}
+
+func TestDecompressInvalidResponse(t *testing.T) {
+	zstdCompressor, err := compression.NewZstdCompressor(maxByteSizeLimit)
+	require.NoError(t, err)
+	largeProof, err := zstdCompressor.Compress(make([]byte, 1024))
+	require.NoError(t, err)
+
+	tests := []struct {
+		name          string
+		proofResponse *pb.ProofResponse
+		expectedErr   error
+	}{
+		{
+			name: "corrupt compressed proof",
+			proofResponse: &pb.ProofResponse{
+				AcceptedCompressionType: pb.CompressionType_COMPRESSION_TYPE_ZSTD,
+				CompressionType:         pb.CompressionType_COMPRESSION_TYPE_ZSTD,
+				Proof:                   []byte("not compressed"),
+			},
+			expectedErr: errDecompressionFailed,
+		},
+		{
+			name: "unexpected compression type",
+			proofResponse: &pb.ProofResponse{
+				AcceptedCompressionType: pb.CompressionType_COMPRESSION_TYPE_ZSTD,
+				CompressionType:         pb.CompressionType(math.MaxInt32),
+				Proof:                   []byte("proof"),
+			},
+			expectedErr: errUnexpectedCompression,
+		},
+		{
+			name: "decompressed proof too large",
+			proofResponse: &pb.ProofResponse{
+				AcceptedCompressionType: pb.CompressionType_COMPRESSION_TYPE_ZSTD,
+				CompressionType:         pb.CompressionType_COMPRESSION_TYPE_ZSTD,
+				Proof:                   largeProof,
+			},
+			expectedErr: errTooManyBytes,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			require := require.New(t)
+
+			c, err := NewClient(&ClientConfig{
+				Log:          logging.NoLog{},
+				Metrics:      &mockMetrics{},
+				BranchFactor: merkledb.BranchFactor16,
+				Compression:  compression.TypeZstd,
+			})
+			require.NoError(err)
+
+			response, err := proto.Marshal(test.proofResponse)
+			require.NoError(err)
+
+			_, err = c.(*client).decompress(pb.CompressionType_COMPRESSION_TYPE_ZSTD, 512, response)
+			require.ErrorIs(err, test.expectedErr)
+		})
+	}
+}
+
+func TestNewClientUnsupportedCompression(t *testing.T) {
+	_, err := NewClient(&ClientConfig{
+		Log:          logging.NoLog{},
+		Metrics:      &mockMetrics{},
+		BranchFactor: merkledb.BranchFactor16,
+		Compression:  compression.Type(math.MaxUint8),
+	})
+	require.ErrorIs(t, err, errUnsupportedCompression)
+}
@@ -133,6 +133,25 @@ func (c *DBClient) GetProof(ctx context.Context, key []byte) (*merkledb.Proof, e
 	return &proof, nil
 }
 
+func (c *DBClient) GetExclusionProof(ctx context.Context, key []byte) (*merkledb.Proof, error) {
+	resp, err := c.client.GetExclusionProof(ctx, &pb.GetExclusionProofRequest{
+		Key: key,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if resp.GetKeyInTrie() {
+		return nil, merkledb.ErrKeyInTrie
+	}
+
+	var proof merkledb.Proof
+	if err := proof.UnmarshalProto(resp.GetProof()); err != nil {
+		return nil, err
+	}
+	return &proof, nil
+}
+
 func (c *DBClient) GetRangeProofAtRoot(
 	ctx context.Context,
 	rootID ids.ID,
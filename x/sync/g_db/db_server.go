@@ -150,6 +150,29 @@ func (s *DBServer) GetProof(
 	}, nil
 }
 
+func (s *DBServer) GetExclusionProof(
+	ctx context.Context,
+	req *pb.GetExclusionProofRequest,
+) (*pb.GetExclusionProofResponse, error) {
+	proof, err := s.db.GetExclusionProof(ctx, req.Key)
+	if err != nil {
+		if !errors.Is(err, merkledb.ErrKeyInTrie) {
+			return nil, err
+		}
+		return &pb.GetExclusionProofResponse{
+			Response: &pb.GetExclusionProofResponse_KeyInTrie{
+				KeyInTrie: true,
+			},
+		}, nil
+	}
+
+	return &pb.GetExclusionProofResponse{
+		Response: &pb.GetExclusionProofResponse_Proof{
+			Proof: proof.ToProto(),
+		},
+	}, nil
+}
+
 func (s *DBServer) GetRangeProof(
 	ctx context.Context,
 	req *pb.GetRangeProofRequest,
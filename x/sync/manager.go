@@ -10,10 +10,12 @@ import (
 	"fmt"
 	"slices"
 	"sync"
+	"time"
 
 	"go.uber.org/zap"
 	"golang.org/x/exp/maps"
 
+	"github.com/shubhamdubey02/cryftgo/database"
 	"github.com/shubhamdubey02/cryftgo/ids"
 	"github.com/shubhamdubey02/cryftgo/utils/logging"
 	"github.com/shubhamdubey02/cryftgo/utils/maybe"
@@ -26,6 +28,13 @@ import (
 const (
 	defaultRequestKeyLimit      = maxKeyValuesLimit
 	defaultRequestByteSizeLimit = maxByteSizeLimit
+
+	// The progress of the sync is persisted once [progressSaveWorkItems] work
+	// items have been completed or [progressSaveInterval] has passed since it
+	// was last persisted, whichever happens first. It is always persisted when
+	// the sync is closed.
+	progressSaveWorkItems = 256
+	progressSaveInterval  = 10 * time.Second
 )
 
 var (
@@ -74,10 +83,10 @@ type Manager struct {
 	config         ManagerConfig
 
 	workLock sync.Mutex
-	// The number of work items currently being processed.
-	// Namely, the number of goroutines executing [doWork].
-	// [workLock] must be held when accessing [processingWorkItems].
-	processingWorkItems int
+	// The work items currently being processed.
+	// Namely, the work items of the goroutines executing [doWork].
+	// [workLock] must be held when accessing [processingWork].
+	processingWork set.Set[*workItem]
 	// [workLock] must be held while accessing [unprocessedWork].
 	unprocessedWork *workHeap
 	// Signalled when:
@@ -89,6 +98,14 @@ type Manager struct {
 	// [workLock] must be held while accessing [processedWork].
 	processedWork *workHeap
 
+	// The number of work items completed since the progress was last
+	// persisted.
+	// [workLock] must be held while accessing [numUnsavedWork].
+	numUnsavedWork int
+	// The last time the progress was persisted.
+	// [workLock] must be held while accessing [lastProgressSave].
+	lastProgressSave time.Time
+
 	// When this is closed:
 	// - [closed] is true.
 	// - [cancelCtx] was called.
@@ -116,6 +133,10 @@ type ManagerConfig struct {
 	Log                   logging.Logger
 	TargetRoot            ids.ID
 	BranchFactor          merkledb.BranchFactor
+	// If non-nil, the progress of the sync is persisted to [ProgressDB] so
+	// that a Manager created with the same [ProgressDB] after a restart
+	// resumes the sync rather than starting over.
+	ProgressDB database.KeyValueReaderWriterDeleter
 }
 
 func NewManager(config ManagerConfig) (*Manager, error) {
@@ -142,9 +163,48 @@ func NewManager(config ManagerConfig) (*Manager, error) {
 	}
 	m.unprocessedWorkCond.L = &m.workLock
 
+	if config.ProgressDB == nil {
+		return m, nil
+	}
+	if err := m.restoreProgress(); err != nil {
+		return nil, err
+	}
 	return m, nil
 }
 
+// restoreProgress adds the work items persisted by a previous Manager to the
+// work heaps.
+func (m *Manager) restoreProgress() error {
+	progress, err := getProgress(m.config.ProgressDB)
+	if err == database.ErrNotFound {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+
+	for _, item := range progress.unprocessedWork {
+		m.unprocessedWork.Insert(item)
+	}
+	for _, item := range progress.processedWork {
+		if progress.targetRoot != m.config.TargetRoot {
+			// The target changed while we were stopped, so the range needs to
+			// be updated to the new target.
+			item.priority = highPriority
+			m.unprocessedWork.Insert(item)
+			continue
+		}
+		m.processedWork.MergeInsert(item)
+	}
+
+	m.config.Log.Info("resuming sync",
+		zap.Stringer("previous target root", progress.targetRoot),
+		zap.Int("unprocessed work", len(progress.unprocessedWork)),
+		zap.Int("processed work", len(progress.processedWork)),
+	)
+	return nil
+}
+
 func (m *Manager) Start(ctx context.Context) error {
 	m.workLock.Lock()
 	defer m.workLock.Unlock()
@@ -155,11 +215,15 @@ func (m *Manager) Start(ctx context.Context) error {
 
 	m.config.Log.Info("starting sync", zap.Stringer("target root", m.config.TargetRoot))
 
-	// Add work item to fetch the entire key range.
+	// Add work item to fetch the entire key range, unless we are resuming a
+	// previous sync.
 	// Note that this will be the first work item to be processed.
-	m.unprocessedWork.Insert(newWorkItem(ids.Empty, maybe.Nothing[[]byte](), maybe.Nothing[[]byte](), lowPriority))
+	if m.unprocessedWork.Len() == 0 && m.processedWork.Len() == 0 {
+		m.unprocessedWork.Insert(newWorkItem(ids.Empty, maybe.Nothing[[]byte](), maybe.Nothing[[]byte](), lowPriority))
+	}
 
 	m.syncing = true
+	m.lastProgressSave = time.Now()
 	ctx, m.cancelCtx = context.WithCancel(ctx)
 
 	go m.sync(ctx)
@@ -183,12 +247,12 @@ func (m *Manager) sync(ctx context.Context) {
 		switch {
 		case ctx.Err() != nil:
 			return // [m.workLock] released by defer.
-		case m.processingWorkItems >= m.config.SimultaneousWorkLimit:
+		case m.processingWork.Len() >= m.config.SimultaneousWorkLimit:
 			// We're already processing the maximum number of work items.
 			// Wait until one of them finishes.
 			m.unprocessedWorkCond.Wait()
 		case m.unprocessedWork.Len() == 0:
-			if m.processingWorkItems == 0 {
+			if m.processingWork.Len() == 0 {
 				// There's no work to do, and there are no work items being processed
 				// which could cause work to be added, so we're done.
 				return // [m.workLock] released by defer.
@@ -199,8 +263,8 @@ func (m *Manager) sync(ctx context.Context) {
 			// which will cause Wait() to return, and this goroutine to exit.
 			m.unprocessedWorkCond.Wait()
 		default:
-			m.processingWorkItems++
 			work := m.unprocessedWork.GetWork()
+			m.processingWork.Add(work)
 			go m.doWork(ctx, work)
 		}
 	}
@@ -224,6 +288,19 @@ func (m *Manager) close() {
 			m.cancelCtx()
 		}
 
+		if m.syncing && m.config.ProgressDB != nil {
+			var err error
+			if m.unprocessedWork.Len() == 0 && m.processingWork.Len() == 0 {
+				// The sync finished, so there is nothing to resume.
+				err = m.config.ProgressDB.Delete(progressKey)
+			} else {
+				err = m.saveProgress()
+			}
+			if err != nil {
+				m.config.Log.Error("failed to persist sync progress", zap.Error(err))
+			}
+		}
+
 		// ensure any goroutines waiting for work from the heaps gets released
 		m.unprocessedWork.Close()
 		m.unprocessedWorkCond.Signal()
@@ -235,16 +312,11 @@ func (m *Manager) close() {
 }
 
 // Processes [item] by fetching and applying a change or range proof.
+// [work] is removed from [m.processingWork] once it is completed. If it isn't
+// completed, the sync is closing and [work] is left in [m.processingWork] so
+// that it is persisted as unfinished.
 // Assumes [m.workLock] is not held.
 func (m *Manager) doWork(ctx context.Context, work *workItem) {
-	defer func() {
-		m.workLock.Lock()
-		defer m.workLock.Unlock()
-
-		m.processingWorkItems--
-		m.unprocessedWorkCond.Signal()
-	}()
-
 	if work.localRootID == ids.Empty {
 		// the keys in this range have not been downloaded, so get all key/values
 		m.getAndApplyRangeProof(ctx, work)
@@ -640,6 +712,10 @@ func (m *Manager) UpdateSyncTarget(syncTargetRoot ids.ID) error {
 		// waiting on [m.unprocessedWorkCond].
 		m.unprocessedWorkCond.Signal()
 	}
+
+	// The progress isn't persisted here. If the previously persisted target
+	// is restored, the processed ranges are moved to the work heap once the
+	// new target is provided.
 	return nil
 }
 
@@ -676,6 +752,7 @@ func (m *Manager) setError(err error) {
 //
 // Assumes [m.workLock] is not held.
 func (m *Manager) completeWorkItem(ctx context.Context, work *workItem, largestHandledKey maybe.Maybe[[]byte], rootID ids.ID, proofOfLargestKey []merkledb.ProofNode) {
+	var remainingWork *workItem
 	if !maybe.Equal(largestHandledKey, work.end, bytes.Equal) {
 		// The largest handled key isn't equal to the end of the work item.
 		// Find the start of the next key range to fetch.
@@ -695,7 +772,7 @@ func (m *Manager) completeWorkItem(ctx context.Context, work *workItem, largestH
 			largestHandledKey = work.end
 		} else {
 			// the full range wasn't completed, so enqueue a new work item for the range [nextStartKey, workItem.end]
-			m.enqueueWork(newWorkItem(work.localRootID, nextStartKey, work.end, work.priority))
+			remainingWork = newWorkItem(work.localRootID, nextStartKey, work.end, work.priority)
 			largestHandledKey = nextStartKey
 		}
 	}
@@ -705,16 +782,29 @@ func (m *Manager) completeWorkItem(ctx context.Context, work *workItem, largestH
 	m.syncTargetLock.RLock()
 	defer m.syncTargetLock.RUnlock()
 
+	// Move [work] out of [m.processingWork] while holding [workLock] so that
+	// the persisted progress always covers the entire key range.
+	m.workLock.Lock()
+	defer m.workLock.Unlock()
+
+	if remainingWork != nil {
+		m.enqueueWork(remainingWork)
+	}
+
 	stale := m.config.TargetRoot != rootID
 	if stale {
 		// the root has changed, so reinsert with high priority
 		m.enqueueWork(newWorkItem(rootID, work.start, largestHandledKey, highPriority))
 	} else {
-		m.workLock.Lock()
-		defer m.workLock.Unlock()
-
 		m.processedWork.MergeInsert(newWorkItem(rootID, work.start, largestHandledKey, work.priority))
 	}
+	m.processingWork.Remove(work)
+	m.unprocessedWorkCond.Signal()
+
+	if err := m.maybeSaveProgress(); err != nil {
+		m.setError(err)
+		return
+	}
 
 	// completed the range [work.start, lastKey], log and record in the completed work heap
 	m.config.Log.Debug("completed range",
@@ -728,15 +818,9 @@ func (m *Manager) completeWorkItem(ctx context.Context, work *workItem, largestH
 // Queue the given key range to be fetched and applied.
 // If there are sufficiently few unprocessed/processing work items,
 // splits the range into two items and queues them both.
-// Assumes [m.workLock] is not held.
+// Assumes [m.workLock] is held.
 func (m *Manager) enqueueWork(work *workItem) {
-	m.workLock.Lock()
-	defer func() {
-		m.workLock.Unlock()
-		m.unprocessedWorkCond.Signal()
-	}()
-
-	if m.processingWorkItems+m.unprocessedWork.Len() > 2*m.config.SimultaneousWorkLimit {
+	if m.processingWork.Len()+m.unprocessedWork.Len() > 2*m.config.SimultaneousWorkLimit {
 		// There are too many work items already, don't split the range
 		m.unprocessedWork.Insert(work)
 		return
@@ -765,6 +849,50 @@ func (m *Manager) enqueueWork(work *workItem) {
 	m.unprocessedWork.Insert(second)
 }
 
+// maybeSaveProgress records that a work item was completed and persists the
+// progress if enough work items were completed or enough time passed since
+// the progress was last persisted.
+//
+// Assumes [m.workLock] is held.
+func (m *Manager) maybeSaveProgress() error {
+	if m.config.ProgressDB == nil {
+		return nil
+	}
+
+	m.numUnsavedWork++
+	if m.numUnsavedWork < progressSaveWorkItems && time.Since(m.lastProgressSave) < progressSaveInterval {
+		return nil
+	}
+	return m.saveProgress()
+}
+
+// saveProgress persists the target root and the ranges that have and haven't
+// been fetched to [m.config.ProgressDB].
+//
+// The work items being processed are persisted as unprocessed. Because their
+// proofs may have been partially applied, their local roots are discarded so
+// that their ranges are fetched again in full.
+//
+// Assumes [m.workLock] is held.
+func (m *Manager) saveProgress() error {
+	unprocessedWork := m.unprocessedWork.Items()
+	for work := range m.processingWork {
+		unprocessedWork = append(unprocessedWork, newWorkItem(ids.Empty, work.start, work.end, work.priority))
+	}
+	err := putProgress(m.config.ProgressDB, &progress{
+		targetRoot:      m.config.TargetRoot,
+		unprocessedWork: unprocessedWork,
+		processedWork:   m.processedWork.Items(),
+	})
+	if err != nil {
+		return err
+	}
+
+	m.numUnsavedWork = 0
+	m.lastProgressSave = time.Now()
+	return nil
+}
+
 // find the midpoint between two keys
 // start is expected to be less than end
 // Nothing/nil [start] is treated as all 0's
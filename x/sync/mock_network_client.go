@@ -41,6 +41,18 @@ func (m *MockNetworkClient) EXPECT() *MockNetworkClientMockRecorder {
 	return m.recorder
 }
 
+// AddPeerScheduler mocks base method.
+func (m *MockNetworkClient) AddPeerScheduler(arg0 *PeerScheduler) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "AddPeerScheduler", arg0)
+}
+
+// AddPeerScheduler indicates an expected call of AddPeerScheduler.
+func (mr *MockNetworkClientMockRecorder) AddPeerScheduler(arg0 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddPeerScheduler", reflect.TypeOf((*MockNetworkClient)(nil).AddPeerScheduler), arg0)
+}
+
 // AppRequestFailed mocks base method.
 func (m *MockNetworkClient) AppRequestFailed(arg0 context.Context, arg1 ids.NodeID, arg2 uint32) error {
 	m.ctrl.T.Helper()
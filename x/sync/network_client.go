@@ -71,6 +71,10 @@ type NetworkClient interface {
 
 	// Removes given [nodeID] from the peer list.
 	Disconnected(context.Context, ids.NodeID) error
+
+	// Notifies [scheduler] of the peers that are currently connected, and of
+	// the peers that connect and disconnect afterwards.
+	AddPeerScheduler(scheduler *PeerScheduler)
 }
 
 type networkClient struct {
@@ -86,6 +90,13 @@ type networkClient struct {
 	peers *p2p.PeerTracker
 	// For sending messages to peers
 	appSender common.AppSender
+
+	// peersLock protects [connectedPeers] and [peerSchedulers].
+	peersLock sync.Mutex
+	// nodeID => version of the peers that are connected
+	connectedPeers map[ids.NodeID]*version.Application
+	// notified when peers connect and disconnect
+	peerSchedulers []*PeerScheduler
 }
 
 func NewNetworkClient(
@@ -114,6 +125,7 @@ func NewNetworkClient(
 		activeRequests:             semaphore.NewWeighted(maxActiveRequests),
 		peers:                      peerTracker,
 		log:                        log,
+		connectedPeers:             make(map[ids.NodeID]*version.Application),
 	}, nil
 }
 
@@ -358,11 +370,37 @@ func (c *networkClient) Connected(
 ) error {
 	c.log.Debug("adding new peer", zap.Stringer("nodeID", nodeID))
 	c.peers.Connected(nodeID, nodeVersion)
+
+	c.peersLock.Lock()
+	defer c.peersLock.Unlock()
+
+	c.connectedPeers[nodeID] = nodeVersion
+	for _, scheduler := range c.peerSchedulers {
+		scheduler.Connected(nodeID, nodeVersion)
+	}
 	return nil
 }
 
 func (c *networkClient) Disconnected(_ context.Context, nodeID ids.NodeID) error {
 	c.log.Debug("disconnecting peer", zap.Stringer("nodeID", nodeID))
 	c.peers.Disconnected(nodeID)
+
+	c.peersLock.Lock()
+	defer c.peersLock.Unlock()
+
+	delete(c.connectedPeers, nodeID)
+	for _, scheduler := range c.peerSchedulers {
+		scheduler.Disconnected(nodeID)
+	}
 	return nil
 }
+
+func (c *networkClient) AddPeerScheduler(scheduler *PeerScheduler) {
+	c.peersLock.Lock()
+	defer c.peersLock.Unlock()
+
+	for nodeID, nodeVersion := range c.connectedPeers {
+		scheduler.Connected(nodeID, nodeVersion)
+	}
+	c.peerSchedulers = append(c.peerSchedulers, scheduler)
+}
@@ -17,6 +17,7 @@ import (
 
 	"github.com/shubhamdubey02/cryftgo/ids"
 	"github.com/shubhamdubey02/cryftgo/snow/engine/common"
+	"github.com/shubhamdubey02/cryftgo/utils/compression"
 	"github.com/shubhamdubey02/cryftgo/utils/constants"
 	"github.com/shubhamdubey02/cryftgo/utils/hashing"
 	"github.com/shubhamdubey02/cryftgo/utils/logging"
@@ -56,13 +57,18 @@ type NetworkServer struct {
 	appSender common.AppSender // Used to respond to peer requests via AppResponse.
 	db        DB
 	log       logging.Logger
+	// Compresses responses to peers that accept zstd compression.
+	zstdCompressor compression.Compressor
 }
 
 func NewNetworkServer(appSender common.AppSender, db DB, log logging.Logger) *NetworkServer {
+	// Responses are never larger than [maxByteSizeLimit], so this can't error.
+	zstdCompressor, _ := compression.NewZstdCompressor(maxByteSizeLimit)
 	return &NetworkServer{
-		appSender: appSender,
-		db:        db,
-		log:       log,
+		appSender:      appSender,
+		db:             db,
+		log:            log,
+		zstdCompressor: zstdCompressor,
 	}
 }
 
@@ -215,60 +221,45 @@ func (s *NetworkServer) HandleChangeProofRequest(
 					RootHash:   req.EndRootHash,
 					StartKey:   req.StartKey,
 					EndKey:     req.EndKey,
-					KeyLimit:   req.KeyLimit,
-					BytesLimit: req.BytesLimit,
+					KeyLimit:   keyLimit,
+					BytesLimit: uint32(bytesLimit),
 				},
-				func(rangeProof *merkledb.RangeProof) ([]byte, error) {
-					return proto.Marshal(&pb.SyncGetChangeProofResponse{
+				func(rangeProof *pb.RangeProof) proto.Message {
+					return &pb.SyncGetChangeProofResponse{
 						Response: &pb.SyncGetChangeProofResponse_RangeProof{
-							RangeProof: rangeProof.ToProto(),
+							RangeProof: rangeProof,
 						},
-					})
+					}
 				},
 			)
 			if err != nil {
 				return err
 			}
-
-			if err := s.appSender.SendAppResponse(ctx, nodeID, requestID, proofBytes); err != nil {
-				s.log.Fatal(
-					"failed to send app response",
-					zap.Stringer("nodeID", nodeID),
-					zap.Uint32("requestID", requestID),
-					zap.Int("responseLen", len(proofBytes)),
-					zap.Error(err),
-				)
-				return fmt.Errorf("%w: %w", errAppSendFailed, err)
-			}
-			return nil
+			return s.sendResponse(ctx, nodeID, requestID, req.CompressionType, proofBytes)
 		}
 
 		// We generated a change proof. See if it's small enough.
-		proofBytes, err := proto.Marshal(&pb.SyncGetChangeProofResponse{
+		changeProofProto := changeProof.ToProto()
+		response := &pb.SyncGetChangeProofResponse{
 			Response: &pb.SyncGetChangeProofResponse_ChangeProof{
-				ChangeProof: changeProof.ToProto(),
+				ChangeProof: changeProofProto,
 			},
-		})
-		if err != nil {
-			return err
 		}
-
-		if len(proofBytes) < bytesLimit {
-			if err := s.appSender.SendAppResponse(ctx, nodeID, requestID, proofBytes); err != nil {
-				s.log.Fatal(
-					"failed to send app response",
-					zap.Stringer("nodeID", nodeID),
-					zap.Uint32("requestID", requestID),
-					zap.Int("responseLen", len(proofBytes)),
-					zap.Error(err),
-				)
-				return fmt.Errorf("%w: %w", errAppSendFailed, err)
+		size := proto.Size(response)
+		if size < bytesLimit {
+			proofBytes, err := proto.Marshal(response)
+			if err != nil {
+				return err
 			}
-			return nil
+			return s.sendResponse(ctx, nodeID, requestID, req.CompressionType, proofBytes)
 		}
 
-		// The proof was too large. Try to shrink it.
-		keyLimit = uint32(len(changeProof.KeyChanges)) / 2
+		// The proof was too large. Shrink it to the number of key changes that
+		// are estimated to fit, leaving room for the end proof of the smaller
+		// proof to be twice as large as the current one.
+		endProofSize := repeatedFieldSize(changeProofEndProofField, changeProofProto.EndProof)
+		estimate := numEntriesWithinLimit(size, changeProofKeyChangesField, changeProofProto.KeyChanges, bytesLimit-endProofSize)
+		keyLimit = uint32(reducedKeyLimit(len(changeProof.KeyChanges), estimate))
 	}
 	return ErrMinProofSizeIsTooLarge
 }
@@ -300,19 +291,56 @@ func (s *NetworkServer) HandleRangeProofRequest(
 		ctx,
 		s.db,
 		req,
-		func(rangeProof *merkledb.RangeProof) ([]byte, error) {
-			return proto.Marshal(rangeProof.ToProto())
+		func(rangeProof *pb.RangeProof) proto.Message {
+			return rangeProof
 		},
 	)
 	if err != nil {
 		return err
 	}
-	if err := s.appSender.SendAppResponse(ctx, nodeID, requestID, proofBytes); err != nil {
+	return s.sendResponse(ctx, nodeID, requestID, req.CompressionType, proofBytes)
+}
+
+// sendResponse sends [response] to [nodeID]. If [compressionType] is
+// specified, [response] is wrapped in a ProofResponse and compressed with
+// [compressionType] if it's supported and makes the response smaller.
+// If [errAppSendFailed] is returned, this should be considered fatal.
+func (s *NetworkServer) sendResponse(
+	ctx context.Context,
+	nodeID ids.NodeID,
+	requestID uint32,
+	compressionType pb.CompressionType,
+	response []byte,
+) error {
+	if compressionType != pb.CompressionType_COMPRESSION_TYPE_UNSPECIFIED {
+		proofResponse := &pb.ProofResponse{
+			AcceptedCompressionType: compressionType,
+			Proof:                   response,
+		}
+		if compressionType == pb.CompressionType_COMPRESSION_TYPE_ZSTD {
+			compressedProof, err := s.zstdCompressor.Compress(response)
+			if err != nil {
+				return err
+			}
+			if len(compressedProof) < len(response) {
+				proofResponse.CompressionType = compressionType
+				proofResponse.Proof = compressedProof
+			}
+		}
+
+		var err error
+		response, err = proto.Marshal(proofResponse)
+		if err != nil {
+			return err
+		}
+	}
+
+	if err := s.appSender.SendAppResponse(ctx, nodeID, requestID, response); err != nil {
 		s.log.Fatal(
 			"failed to send app response",
 			zap.Stringer("nodeID", nodeID),
 			zap.Uint32("requestID", requestID),
-			zap.Int("responseLen", len(proofBytes)),
+			zap.Int("responseLen", len(response)),
 			zap.Error(err),
 		)
 		return fmt.Errorf("%w: %w", errAppSendFailed, err)
@@ -320,19 +348,19 @@ func (s *NetworkServer) HandleRangeProofRequest(
 	return nil
 }
 
-// Get the range proof specified by [req].
-// If the generated proof is too large, the key limit is reduced
-// and the proof is regenerated. This process is repeated until
-// the proof is smaller than [req.BytesLimit].
+// Get the range proof specified by [req] and returns it serialized in the
+// message returned by [wrapFunc].
+// If the generated proof is too large, the number of keys that are estimated
+// to fit is calculated and the proof is regenerated with that key limit. This
+// is repeated until the proof is smaller than [req.BytesLimit], which usually
+// only requires regenerating the proof once.
 // When a sufficiently small proof is generated, returns it.
 // If no sufficiently small proof can be generated, returns [ErrMinProofSizeIsTooLarge].
-// TODO improve range proof generation so we don't need to iteratively
-// reduce the key limit.
 func getRangeProof(
 	ctx context.Context,
 	db DB,
 	req *pb.SyncGetRangeProofRequest,
-	marshalFunc func(*merkledb.RangeProof) ([]byte, error),
+	wrapFunc func(*pb.RangeProof) proto.Message,
 ) ([]byte, error) {
 	root, err := ids.ToID(req.RootHash)
 	if err != nil {
@@ -356,17 +384,19 @@ func getRangeProof(
 			return nil, err
 		}
 
-		proofBytes, err := marshalFunc(rangeProof)
-		if err != nil {
-			return nil, err
-		}
-
-		if len(proofBytes) < int(req.BytesLimit) {
-			return proofBytes, nil
+		rangeProofProto := rangeProof.ToProto()
+		response := wrapFunc(rangeProofProto)
+		size := proto.Size(response)
+		if size < int(req.BytesLimit) {
+			return proto.Marshal(response)
 		}
 
-		// The proof was too large. Try to shrink it.
-		keyLimit = len(rangeProof.KeyValues) / 2
+		// The proof was too large. Shrink it to the number of keys that are
+		// estimated to fit, leaving room for the end proof of the smaller proof
+		// to be twice as large as the current one.
+		endProofSize := repeatedFieldSize(rangeProofEndProofField, rangeProofProto.EndProof)
+		estimate := numEntriesWithinLimit(size, rangeProofKeyValuesField, rangeProofProto.KeyValues, int(req.BytesLimit)-endProofSize)
+		keyLimit = reducedKeyLimit(len(rangeProof.KeyValues), estimate)
 	}
 	return nil, ErrMinProofSizeIsTooLarge
 }
@@ -0,0 +1,309 @@
+// Copyright (C) 2019-2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package sync
+
+import (
+	"errors"
+	"sync"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"go.uber.org/zap"
+
+	"github.com/shubhamdubey02/cryftgo/ids"
+	"github.com/shubhamdubey02/cryftgo/utils"
+	"github.com/shubhamdubey02/cryftgo/utils/logging"
+	"github.com/shubhamdubey02/cryftgo/utils/set"
+	"github.com/shubhamdubey02/cryftgo/version"
+
+	safemath "github.com/shubhamdubey02/cryftgo/utils/math"
+)
+
+const (
+	// The latency assumed for a peer before it has responded to a request.
+	// Peers that haven't been measured yet are preferred over peers that are
+	// known to be slower than this.
+	initialPeerLatency  = 250 * time.Millisecond
+	peerLatencyHalflife = 5 * time.Minute
+
+	nodeIDLabel = "nodeID"
+)
+
+var ErrZeroRequestsPerPeer = errors.New("max requests per peer must be greater than 0")
+
+// PeerScheduler selects the peers that proof requests are sent to.
+//
+// Requests are spread across the connected peers. Each peer is scored by its
+// average latency, the fraction of requests it has failed, and the number of
+// requests it is currently processing, and the peer with the best score is
+// selected. Peers that respond with an invalid proof are banned for the
+// lifetime of the PeerScheduler.
+type PeerScheduler struct {
+	lock sync.Mutex
+	// Connected peers that haven't been banned.
+	peers map[ids.NodeID]*peerScore
+	// Peers that sent an invalid response.
+	banned set.Set[ids.NodeID]
+
+	// The below fields are assumed to be constant and are not protected by the
+	// lock.
+	log                logging.Logger
+	ignoredNodes       set.Set[ids.NodeID]
+	minVersion         *version.Application
+	maxRequestsPerPeer int
+	metrics            peerSchedulerMetrics
+}
+
+type peerScore struct {
+	// Number of requests sent to the peer that haven't completed.
+	outstandingRequests int
+	numSucceeded        uint64
+	numFailed           uint64
+	// Average latency of the peer's responses in seconds.
+	latency safemath.Averager
+}
+
+// cost returns the expected time until the peer responds with a valid proof.
+// Lower is better.
+func (s *peerScore) cost() float64 {
+	// Laplace smoothing so that peers without any history aren't assumed to
+	// be perfectly reliable or unreliable.
+	reliability := float64(s.numSucceeded+1) / float64(s.numSucceeded+s.numFailed+2)
+	return s.latency.Read() * float64(s.outstandingRequests+1) / reliability
+}
+
+type peerSchedulerMetrics struct {
+	numPeers          prometheus.Gauge
+	numBannedPeers    prometheus.Gauge
+	requestsSucceeded *prometheus.CounterVec
+	requestsFailed    *prometheus.CounterVec
+	invalidResponses  *prometheus.CounterVec
+	latency           *prometheus.GaugeVec
+}
+
+// NewPeerScheduler returns a PeerScheduler that never selects [ignoredNodes]
+// or peers with a version before [minVersion], and sends at most
+// [maxRequestsPerPeer] concurrent requests to each peer.
+func NewPeerScheduler(
+	log logging.Logger,
+	metricsNamespace string,
+	registerer prometheus.Registerer,
+	ignoredNodes set.Set[ids.NodeID],
+	minVersion *version.Application,
+	maxRequestsPerPeer int,
+) (*PeerScheduler, error) {
+	if maxRequestsPerPeer <= 0 {
+		return nil, ErrZeroRequestsPerPeer
+	}
+
+	nodeIDLabels := []string{nodeIDLabel}
+	s := &PeerScheduler{
+		peers:              make(map[ids.NodeID]*peerScore),
+		log:                log,
+		ignoredNodes:       ignoredNodes,
+		minVersion:         minVersion,
+		maxRequestsPerPeer: maxRequestsPerPeer,
+		metrics: peerSchedulerMetrics{
+			numPeers: prometheus.NewGauge(prometheus.GaugeOpts{
+				Namespace: metricsNamespace,
+				Name:      "scheduler_peers",
+				Help:      "number of peers proof requests can be sent to",
+			}),
+			numBannedPeers: prometheus.NewGauge(prometheus.GaugeOpts{
+				Namespace: metricsNamespace,
+				Name:      "scheduler_banned_peers",
+				Help:      "number of peers banned for sending invalid proofs",
+			}),
+			requestsSucceeded: prometheus.NewCounterVec(
+				prometheus.CounterOpts{
+					Namespace: metricsNamespace,
+					Name:      "peer_requests_succeeded",
+					Help:      "cumulative amount of proof requests a peer responded to",
+				},
+				nodeIDLabels,
+			),
+			requestsFailed: prometheus.NewCounterVec(
+				prometheus.CounterOpts{
+					Namespace: metricsNamespace,
+					Name:      "peer_requests_failed",
+					Help:      "cumulative amount of proof requests a peer failed to respond to",
+				},
+				nodeIDLabels,
+			),
+			invalidResponses: prometheus.NewCounterVec(
+				prometheus.CounterOpts{
+					Namespace: metricsNamespace,
+					Name:      "peer_invalid_responses",
+					Help:      "cumulative amount of invalid proofs a peer responded with",
+				},
+				nodeIDLabels,
+			),
+			latency: prometheus.NewGaugeVec(
+				prometheus.GaugeOpts{
+					Namespace: metricsNamespace,
+					Name:      "peer_latency",
+					Help:      "average latency of a peer's responses to proof requests (s)",
+				},
+				nodeIDLabels,
+			),
+		},
+	}
+
+	err := utils.Err(
+		registerer.Register(s.metrics.numPeers),
+		registerer.Register(s.metrics.numBannedPeers),
+		registerer.Register(s.metrics.requestsSucceeded),
+		registerer.Register(s.metrics.requestsFailed),
+		registerer.Register(s.metrics.invalidResponses),
+		registerer.Register(s.metrics.latency),
+	)
+	return s, err
+}
+
+// Connected should be called when [nodeID] connects to this node.
+func (s *PeerScheduler) Connected(nodeID ids.NodeID, nodeVersion *version.Application) {
+	if s.ignoredNodes.Contains(nodeID) {
+		return
+	}
+	if s.minVersion != nil && nodeVersion.Compare(s.minVersion) < 0 {
+		return
+	}
+
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	if s.banned.Contains(nodeID) {
+		return
+	}
+	if _, ok := s.peers[nodeID]; ok {
+		return
+	}
+	s.peers[nodeID] = &peerScore{
+		latency: safemath.NewAverager(initialPeerLatency.Seconds(), peerLatencyHalflife, time.Now()),
+	}
+	s.metrics.numPeers.Set(float64(len(s.peers)))
+}
+
+// Disconnected should be called when [nodeID] disconnects from this node.
+func (s *PeerScheduler) Disconnected(nodeID ids.NodeID) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	if _, ok := s.peers[nodeID]; !ok {
+		return
+	}
+	delete(s.peers, nodeID)
+	s.deletePeerMetrics(nodeID)
+	s.metrics.numPeers.Set(float64(len(s.peers)))
+}
+
+// SelectPeer returns the peer that the next request should be sent to and
+// registers that a request is being sent to it. Returns false if there are no
+// peers, or every peer is processing the maximum number of requests.
+//
+// Every selected peer must be passed to one of RegisterResponse or
+// RegisterFailure once the request completes.
+func (s *PeerScheduler) SelectPeer() (ids.NodeID, bool) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	var (
+		bestNodeID ids.NodeID
+		bestPeer   *peerScore
+		bestCost   float64
+	)
+	for nodeID, peer := range s.peers {
+		if peer.outstandingRequests >= s.maxRequestsPerPeer {
+			continue
+		}
+		if cost := peer.cost(); bestPeer == nil || cost < bestCost {
+			bestNodeID = nodeID
+			bestPeer = peer
+			bestCost = cost
+		}
+	}
+	if bestPeer == nil {
+		return ids.EmptyNodeID, false
+	}
+
+	bestPeer.outstandingRequests++
+	return bestNodeID, true
+}
+
+// RegisterResponse should be called when [nodeID] responds to a request after
+// [latency].
+func (s *PeerScheduler) RegisterResponse(nodeID ids.NodeID, latency time.Duration) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	peer, ok := s.peers[nodeID]
+	if !ok {
+		return
+	}
+	// The peer may have reconnected since the request was sent.
+	peer.outstandingRequests = max(peer.outstandingRequests-1, 0)
+	peer.numSucceeded++
+	peer.latency.Observe(latency.Seconds(), time.Now())
+
+	s.metrics.requestsSucceeded.WithLabelValues(nodeID.String()).Inc()
+	s.metrics.latency.WithLabelValues(nodeID.String()).Set(peer.latency.Read())
+}
+
+// RegisterFailure should be called when a request to [nodeID] fails.
+func (s *PeerScheduler) RegisterFailure(nodeID ids.NodeID) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	peer, ok := s.peers[nodeID]
+	if !ok {
+		return
+	}
+	// The peer may have reconnected since the request was sent.
+	peer.outstandingRequests = max(peer.outstandingRequests-1, 0)
+	peer.numFailed++
+
+	s.metrics.requestsFailed.WithLabelValues(nodeID.String()).Inc()
+}
+
+// RegisterInvalidResponse should be called when [nodeID] responds with a proof
+// that fails verification. [nodeID] won't be selected again.
+func (s *PeerScheduler) RegisterInvalidResponse(nodeID ids.NodeID) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	if s.banned.Contains(nodeID) {
+		return
+	}
+
+	s.log.Info("banning peer for sending an invalid proof",
+		zap.Stringer("nodeID", nodeID),
+	)
+	s.banned.Add(nodeID)
+	delete(s.peers, nodeID)
+	s.deletePeerMetrics(nodeID)
+
+	s.metrics.invalidResponses.WithLabelValues(nodeID.String()).Inc()
+	s.metrics.numPeers.Set(float64(len(s.peers)))
+	s.metrics.numBannedPeers.Set(float64(s.banned.Len()))
+}
+
+// Banned returns true if [nodeID] sent an invalid response.
+func (s *PeerScheduler) Banned(nodeID ids.NodeID) bool {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	return s.banned.Contains(nodeID)
+}
+
+// Removes the metrics of [nodeID] that are only meaningful while it can be
+// selected. The number of invalid responses is kept to record why a peer was
+// banned.
+//
+// Assumes [s.lock] is held.
+func (s *PeerScheduler) deletePeerMetrics(nodeID ids.NodeID) {
+	label := nodeID.String()
+	s.metrics.requestsSucceeded.DeleteLabelValues(label)
+	s.metrics.requestsFailed.DeleteLabelValues(label)
+	s.metrics.latency.DeleteLabelValues(label)
+}
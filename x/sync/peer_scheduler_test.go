@@ -0,0 +1,230 @@
+// Copyright (C) 2019-2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package sync
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/require"
+
+	"github.com/shubhamdubey02/cryftgo/ids"
+	"github.com/shubhamdubey02/cryftgo/utils/logging"
+	"github.com/shubhamdubey02/cryftgo/utils/set"
+	"github.com/shubhamdubey02/cryftgo/version"
+	"github.com/shubhamdubey02/cryftgo/x/merkledb"
+)
+
+func TestNewPeerSchedulerZeroRequestsPerPeer(t *testing.T) {
+	_, err := NewPeerScheduler(logging.NoLog{}, "", prometheus.NewRegistry(), nil, nil, 0)
+	require.ErrorIs(t, err, ErrZeroRequestsPerPeer)
+}
+
+func TestPeerSchedulerConnected(t *testing.T) {
+	require := require.New(t)
+
+	var (
+		ignoredNodeID = ids.GenerateTestNodeID()
+		oldNodeID     = ids.GenerateTestNodeID()
+		nodeID        = ids.GenerateTestNodeID()
+		minVersion    = &version.Application{
+			Name:  version.Client,
+			Major: 1,
+			Minor: 1,
+		}
+		oldVersion = &version.Application{
+			Name:  version.Client,
+			Major: 1,
+		}
+	)
+	s, err := NewPeerScheduler(logging.NoLog{}, "", prometheus.NewRegistry(), set.Of(ignoredNodeID), minVersion, 1)
+	require.NoError(err)
+
+	_, ok := s.SelectPeer()
+	require.False(ok)
+
+	s.Connected(ignoredNodeID, minVersion)
+	s.Connected(oldNodeID, oldVersion)
+	_, ok = s.SelectPeer()
+	require.False(ok)
+
+	s.Connected(nodeID, minVersion)
+	selected, ok := s.SelectPeer()
+	require.True(ok)
+	require.Equal(nodeID, selected)
+
+	s.RegisterFailure(nodeID)
+	s.Disconnected(nodeID)
+	_, ok = s.SelectPeer()
+	require.False(ok)
+}
+
+func TestNetworkClientNotifiesPeerScheduler(t *testing.T) {
+	require := require.New(t)
+
+	networkClient, err := NewNetworkClient(
+		nil,
+		ids.GenerateTestNodeID(),
+		1,
+		logging.NoLog{},
+		"",
+		prometheus.NewRegistry(),
+		nil,
+	)
+	require.NoError(err)
+
+	var (
+		connectedNodeID = ids.GenerateTestNodeID()
+		nodeID          = ids.GenerateTestNodeID()
+	)
+	require.NoError(networkClient.Connected(context.Background(), connectedNodeID, version.CurrentApp))
+
+	s, err := NewPeerScheduler(logging.NoLog{}, "", prometheus.NewRegistry(), nil, nil, 1)
+	require.NoError(err)
+
+	_, err = NewClient(&ClientConfig{
+		NetworkClient: networkClient,
+		Log:           logging.NoLog{},
+		Metrics:       &mockMetrics{},
+		BranchFactor:  merkledb.BranchFactor16,
+		PeerScheduler: s,
+	})
+	require.NoError(err)
+
+	// Peers that connected before the client was created are selectable.
+	selected, ok := s.SelectPeer()
+	require.True(ok)
+	require.Equal(connectedNodeID, selected)
+	s.RegisterFailure(connectedNodeID)
+
+	require.NoError(networkClient.Disconnected(context.Background(), connectedNodeID))
+	_, ok = s.SelectPeer()
+	require.False(ok)
+
+	require.NoError(networkClient.Connected(context.Background(), nodeID, version.CurrentApp))
+	selected, ok = s.SelectPeer()
+	require.True(ok)
+	require.Equal(nodeID, selected)
+}
+
+func TestPeerSchedulerSpreadsRequests(t *testing.T) {
+	require := require.New(t)
+
+	s, err := NewPeerScheduler(logging.NoLog{}, "", prometheus.NewRegistry(), nil, nil, 2)
+	require.NoError(err)
+
+	nodeIDs := set.Of(ids.GenerateTestNodeID(), ids.GenerateTestNodeID())
+	for nodeID := range nodeIDs {
+		s.Connected(nodeID, version.CurrentApp)
+	}
+
+	// Each peer is selected once before either is selected twice.
+	selected := set.Set[ids.NodeID]{}
+	for i := 0; i < nodeIDs.Len(); i++ {
+		nodeID, ok := s.SelectPeer()
+		require.True(ok)
+		selected.Add(nodeID)
+	}
+	require.Equal(nodeIDs, selected)
+
+	for i := 0; i < nodeIDs.Len(); i++ {
+		_, ok := s.SelectPeer()
+		require.True(ok)
+	}
+
+	// Every peer is processing the maximum number of requests.
+	_, ok := s.SelectPeer()
+	require.False(ok)
+
+	for nodeID := range nodeIDs {
+		s.RegisterResponse(nodeID, time.Millisecond)
+	}
+	_, ok = s.SelectPeer()
+	require.True(ok)
+}
+
+func TestPeerSchedulerPrefersFastPeers(t *testing.T) {
+	require := require.New(t)
+
+	s, err := NewPeerScheduler(logging.NoLog{}, "", prometheus.NewRegistry(), nil, nil, 1)
+	require.NoError(err)
+
+	var (
+		fastNodeID = ids.GenerateTestNodeID()
+		slowNodeID = ids.GenerateTestNodeID()
+	)
+	s.Connected(fastNodeID, version.CurrentApp)
+	s.Connected(slowNodeID, version.CurrentApp)
+	s.RegisterResponse(slowNodeID, time.Minute)
+	s.RegisterResponse(fastNodeID, time.Millisecond)
+
+	for i := 0; i < 3; i++ {
+		nodeID, ok := s.SelectPeer()
+		require.True(ok)
+		require.Equal(fastNodeID, nodeID)
+		s.RegisterResponse(nodeID, time.Millisecond)
+	}
+
+	// Once the fast peer is busy, the slow peer is selected.
+	nodeID, ok := s.SelectPeer()
+	require.True(ok)
+	require.Equal(fastNodeID, nodeID)
+
+	nodeID, ok = s.SelectPeer()
+	require.True(ok)
+	require.Equal(slowNodeID, nodeID)
+}
+
+func TestPeerSchedulerPrefersReliablePeers(t *testing.T) {
+	require := require.New(t)
+
+	s, err := NewPeerScheduler(logging.NoLog{}, "", prometheus.NewRegistry(), nil, nil, 1)
+	require.NoError(err)
+
+	var (
+		reliableNodeID   = ids.GenerateTestNodeID()
+		unreliableNodeID = ids.GenerateTestNodeID()
+	)
+	s.Connected(reliableNodeID, version.CurrentApp)
+	s.Connected(unreliableNodeID, version.CurrentApp)
+	for i := 0; i < 3; i++ {
+		s.RegisterFailure(unreliableNodeID)
+	}
+
+	nodeID, ok := s.SelectPeer()
+	require.True(ok)
+	require.Equal(reliableNodeID, nodeID)
+}
+
+func TestPeerSchedulerBansInvalidPeers(t *testing.T) {
+	require := require.New(t)
+
+	s, err := NewPeerScheduler(logging.NoLog{}, "", prometheus.NewRegistry(), nil, nil, 1)
+	require.NoError(err)
+
+	nodeID := ids.GenerateTestNodeID()
+	s.Connected(nodeID, version.CurrentApp)
+
+	selected, ok := s.SelectPeer()
+	require.True(ok)
+	require.Equal(nodeID, selected)
+
+	s.RegisterResponse(nodeID, time.Millisecond)
+	s.RegisterInvalidResponse(nodeID)
+	require.True(s.Banned(nodeID))
+	require.Equal(float64(1), testutil.ToFloat64(s.metrics.numBannedPeers))
+	require.Equal(float64(1), testutil.ToFloat64(s.metrics.invalidResponses.WithLabelValues(nodeID.String())))
+
+	_, ok = s.SelectPeer()
+	require.False(ok)
+
+	// Banned peers aren't selected after reconnecting.
+	s.Disconnected(nodeID)
+	s.Connected(nodeID, version.CurrentApp)
+	_, ok = s.SelectPeer()
+	require.False(ok)
+}
@@ -0,0 +1,114 @@
+// Copyright (C) 2019-2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package sync
+
+import (
+	"errors"
+	"fmt"
+
+	"google.golang.org/protobuf/proto"
+
+	"github.com/shubhamdubey02/cryftgo/database"
+	"github.com/shubhamdubey02/cryftgo/ids"
+
+	pb "github.com/shubhamdubey02/cryftgo/proto/pb/sync"
+)
+
+var (
+	progressKey = []byte("progress")
+
+	errInvalidPriority = errors.New("invalid priority")
+)
+
+// progress is the state of a sync that is persisted so that it can be resumed
+// after a restart.
+type progress struct {
+	targetRoot      ids.ID
+	unprocessedWork []*workItem
+	processedWork   []*workItem
+}
+
+// getProgress returns the progress stored in [db].
+// Returns database.ErrNotFound if no progress is stored.
+func getProgress(db database.KeyValueReader) (*progress, error) {
+	progressBytes, err := db.Get(progressKey)
+	if err != nil {
+		return nil, err
+	}
+
+	var progressProto pb.SyncProgress
+	if err := proto.Unmarshal(progressBytes, &progressProto); err != nil {
+		return nil, err
+	}
+
+	targetRoot, err := ids.ToID(progressProto.TargetRootHash)
+	if err != nil {
+		return nil, err
+	}
+	unprocessedWork, err := workItemsFromProto(progressProto.UnprocessedWork)
+	if err != nil {
+		return nil, err
+	}
+	processedWork, err := workItemsFromProto(progressProto.ProcessedWork)
+	if err != nil {
+		return nil, err
+	}
+	return &progress{
+		targetRoot:      targetRoot,
+		unprocessedWork: unprocessedWork,
+		processedWork:   processedWork,
+	}, nil
+}
+
+// putProgress stores [p] in [db], replacing any previously stored progress.
+func putProgress(db database.KeyValueWriter, p *progress) error {
+	progressBytes, err := proto.Marshal(&pb.SyncProgress{
+		TargetRootHash:  p.targetRoot[:],
+		UnprocessedWork: workItemsToProto(p.unprocessedWork),
+		ProcessedWork:   workItemsToProto(p.processedWork),
+	})
+	if err != nil {
+		return err
+	}
+	return db.Put(progressKey, progressBytes)
+}
+
+func workItemsToProto(items []*workItem) []*pb.SyncWorkItem {
+	pbItems := make([]*pb.SyncWorkItem, len(items))
+	for i, item := range items {
+		pbItems[i] = &pb.SyncWorkItem{
+			StartKey: &pb.MaybeBytes{
+				Value:     item.start.Value(),
+				IsNothing: item.start.IsNothing(),
+			},
+			EndKey: &pb.MaybeBytes{
+				Value:     item.end.Value(),
+				IsNothing: item.end.IsNothing(),
+			},
+			Priority:      uint32(item.priority),
+			LocalRootHash: item.localRootID[:],
+		}
+	}
+	return pbItems
+}
+
+func workItemsFromProto(pbItems []*pb.SyncWorkItem) ([]*workItem, error) {
+	items := make([]*workItem, len(pbItems))
+	for i, pbItem := range pbItems {
+		if pbItem.Priority < uint32(lowPriority) || pbItem.Priority > uint32(highPriority) {
+			return nil, fmt.Errorf("%w: %d", errInvalidPriority, pbItem.Priority)
+		}
+		localRootID, err := ids.ToID(pbItem.LocalRootHash)
+		if err != nil {
+			return nil, err
+		}
+		items[i] = newWorkItem(
+			localRootID,
+			maybeBytesToMaybe(pbItem.StartKey),
+			maybeBytesToMaybe(pbItem.EndKey),
+			priority(pbItem.Priority),
+		)
+	}
+	return items, nil
+}
@@ -0,0 +1,128 @@
+// Copyright (C) 2019-2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package sync
+
+import (
+	"bytes"
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+	"google.golang.org/protobuf/proto"
+
+	"github.com/shubhamdubey02/cryftgo/database"
+	"github.com/shubhamdubey02/cryftgo/database/memdb"
+	"github.com/shubhamdubey02/cryftgo/ids"
+	"github.com/shubhamdubey02/cryftgo/utils/logging"
+	"github.com/shubhamdubey02/cryftgo/utils/maybe"
+	"github.com/shubhamdubey02/cryftgo/x/merkledb"
+
+	pb "github.com/shubhamdubey02/cryftgo/proto/pb/sync"
+)
+
+func TestProgress(t *testing.T) {
+	require := require.New(t)
+
+	db := memdb.New()
+	_, err := getProgress(db)
+	require.ErrorIs(err, database.ErrNotFound)
+
+	expected := &progress{
+		targetRoot: ids.GenerateTestID(),
+		unprocessedWork: []*workItem{
+			newWorkItem(ids.Empty, maybe.Nothing[[]byte](), maybe.Some([]byte{1}), lowPriority),
+			newWorkItem(ids.GenerateTestID(), maybe.Some([]byte{2}), maybe.Nothing[[]byte](), highPriority),
+		},
+		processedWork: []*workItem{
+			newWorkItem(ids.GenerateTestID(), maybe.Some([]byte{1}), maybe.Some([]byte{2}), medPriority),
+		},
+	}
+	require.NoError(putProgress(db, expected))
+
+	got, err := getProgress(db)
+	require.NoError(err)
+	require.Equal(expected.targetRoot, got.targetRoot)
+	requireWorkItemsEqual(t, expected.unprocessedWork, got.unprocessedWork)
+	requireWorkItemsEqual(t, expected.processedWork, got.processedWork)
+}
+
+func requireWorkItemsEqual(t *testing.T, expected, actual []*workItem) {
+	require := require.New(t)
+
+	require.Len(actual, len(expected))
+	for i, expectedItem := range expected {
+		require.True(maybe.Equal(expectedItem.start, actual[i].start, bytes.Equal))
+		require.True(maybe.Equal(expectedItem.end, actual[i].end, bytes.Equal))
+		require.Equal(expectedItem.priority, actual[i].priority)
+		require.Equal(expectedItem.localRootID, actual[i].localRootID)
+	}
+}
+
+func TestProgressInvalidPriority(t *testing.T) {
+	require := require.New(t)
+
+	progressBytes, err := proto.Marshal(&pb.SyncProgress{
+		TargetRootHash: ids.Empty[:],
+		UnprocessedWork: []*pb.SyncWorkItem{
+			{
+				Priority:      uint32(highPriority) + 1,
+				LocalRootHash: ids.Empty[:],
+			},
+		},
+	})
+	require.NoError(err)
+
+	db := memdb.New()
+	require.NoError(db.Put(progressKey, progressBytes))
+
+	_, err = getProgress(db)
+	require.ErrorIs(err, errInvalidPriority)
+}
+
+func TestMaybeSaveProgress(t *testing.T) {
+	require := require.New(t)
+	ctrl := gomock.NewController(t)
+
+	db, err := merkledb.New(
+		context.Background(),
+		memdb.New(),
+		newDefaultDBConfig(),
+	)
+	require.NoError(err)
+	progressDB := memdb.New()
+
+	m, err := NewManager(ManagerConfig{
+		DB:                    db,
+		Client:                NewMockClient(ctrl),
+		TargetRoot:            ids.GenerateTestID(),
+		SimultaneousWorkLimit: 5,
+		Log:                   logging.NoLog{},
+		BranchFactor:          merkledb.BranchFactor16,
+		ProgressDB:            progressDB,
+	})
+	require.NoError(err)
+	m.lastProgressSave = time.Now()
+
+	// The progress isn't persisted after every completed work item.
+	for i := 0; i < progressSaveWorkItems-1; i++ {
+		require.NoError(m.maybeSaveProgress())
+	}
+	_, err = getProgress(progressDB)
+	require.ErrorIs(err, database.ErrNotFound)
+
+	require.NoError(m.maybeSaveProgress())
+	_, err = getProgress(progressDB)
+	require.NoError(err)
+	require.Zero(m.numUnsavedWork)
+
+	// The progress is persisted if enough time passed since it was last
+	// persisted.
+	require.NoError(progressDB.Delete(progressKey))
+	m.lastProgressSave = time.Now().Add(-progressSaveInterval)
+	require.NoError(m.maybeSaveProgress())
+	_, err = getProgress(progressDB)
+	require.NoError(err)
+}
@@ -0,0 +1,70 @@
+// Copyright (C) 2019-2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package sync
+
+import (
+	"google.golang.org/protobuf/encoding/protowire"
+	"google.golang.org/protobuf/proto"
+
+	pb "github.com/shubhamdubey02/cryftgo/proto/pb/sync"
+)
+
+var (
+	rangeProofKeyValuesField   = (&pb.RangeProof{}).ProtoReflect().Descriptor().Fields().ByName("key_values").Number()
+	rangeProofEndProofField    = (&pb.RangeProof{}).ProtoReflect().Descriptor().Fields().ByName("end_proof").Number()
+	changeProofKeyChangesField = (&pb.ChangeProof{}).ProtoReflect().Descriptor().Fields().ByName("key_changes").Number()
+	changeProofEndProofField   = (&pb.ChangeProof{}).ProtoReflect().Descriptor().Fields().ByName("end_proof").Number()
+)
+
+// repeatedFieldSize returns the number of bytes [entries] take up when
+// serialized as the repeated [field] of a message.
+func repeatedFieldSize[T proto.Message](field protowire.Number, entries []T) int {
+	tagSize := protowire.SizeTag(field)
+	size := 0
+	for _, entry := range entries {
+		size += tagSize + protowire.SizeBytes(proto.Size(entry))
+	}
+	return size
+}
+
+// numEntriesWithinLimit returns the largest n such that a proof is estimated
+// to be smaller than [bytesLimit] bytes if only the first n of its [entries]
+// are kept.
+//
+// [size] is the serialized size of the message containing the proof, and
+// [entries] are the repeated [field] of the proof. The rest of the message is
+// assumed to be the same size regardless of the number of entries. Because the
+// end proof of a proof with fewer entries may differ, callers should reserve
+// room for it in [bytesLimit]; the estimate isn't guaranteed to be correct.
+func numEntriesWithinLimit[T proto.Message](
+	size int,
+	field protowire.Number,
+	entries []T,
+	bytesLimit int,
+) int {
+	tagSize := protowire.SizeTag(field)
+	entrySizes := make([]int, len(entries))
+	for i, entry := range entries {
+		entrySizes[i] = tagSize + protowire.SizeBytes(proto.Size(entry))
+		size -= entrySizes[i]
+	}
+
+	numEntries := 0
+	for numEntries < len(entrySizes) && size+entrySizes[numEntries] < bytesLimit {
+		size += entrySizes[numEntries]
+		numEntries++
+	}
+	return numEntries
+}
+
+// reducedKeyLimit returns the key limit to regenerate a proof with after a
+// proof with [numKeys] keys was too large, and [estimate] of its keys were
+// estimated to fit. If the estimate can't be used, the number of keys is
+// halved instead. The returned limit is always less than [numKeys].
+func reducedKeyLimit(numKeys int, estimate int) int {
+	if 0 < estimate && estimate < numKeys {
+		return estimate
+	}
+	return numKeys / 2
+}
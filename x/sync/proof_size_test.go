@@ -0,0 +1,139 @@
+// Copyright (C) 2019-2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package sync
+
+import (
+	"context"
+	"math/rand"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/proto"
+
+	"github.com/shubhamdubey02/cryftgo/ids"
+	"github.com/shubhamdubey02/cryftgo/utils/maybe"
+	"github.com/shubhamdubey02/cryftgo/x/merkledb"
+
+	pb "github.com/shubhamdubey02/cryftgo/proto/pb/sync"
+)
+
+func TestNumEntriesWithinLimit(t *testing.T) {
+	require := require.New(t)
+
+	proof := &pb.RangeProof{
+		StartProof: []*pb.ProofNode{{Key: &pb.Key{Length: 8, Value: []byte{1}}}},
+		EndProof:   []*pb.ProofNode{{Key: &pb.Key{Length: 8, Value: []byte{2}}}},
+	}
+	for i := 0; i < 10; i++ {
+		proof.KeyValues = append(proof.KeyValues, &pb.KeyValue{
+			Key:   []byte{byte(i)},
+			Value: make([]byte, 10*i),
+		})
+	}
+	size := proto.Size(proof)
+
+	for numEntries := 0; numEntries <= len(proof.KeyValues); numEntries++ {
+		truncatedProof := &pb.RangeProof{
+			StartProof: proof.StartProof,
+			EndProof:   proof.EndProof,
+			KeyValues:  proof.KeyValues[:numEntries],
+		}
+		truncatedSize := proto.Size(truncatedProof)
+
+		// The proof must be strictly smaller than the limit.
+		require.Equal(
+			numEntries,
+			numEntriesWithinLimit(size, rangeProofKeyValuesField, proof.KeyValues, truncatedSize+1),
+		)
+		require.Equal(
+			max(numEntries-1, 0),
+			numEntriesWithinLimit(size, rangeProofKeyValuesField, proof.KeyValues, truncatedSize),
+		)
+	}
+}
+
+func TestReducedKeyLimit(t *testing.T) {
+	tests := []struct {
+		name     string
+		numKeys  int
+		estimate int
+		expected int
+	}{
+		{
+			name:     "estimate used",
+			numKeys:  10,
+			estimate: 7,
+			expected: 7,
+		},
+		{
+			name:     "estimate too large",
+			numKeys:  10,
+			estimate: 10,
+			expected: 5,
+		},
+		{
+			name:     "estimate is 0",
+			numKeys:  10,
+			estimate: 0,
+			expected: 5,
+		},
+		{
+			name:     "single key",
+			numKeys:  1,
+			estimate: 0,
+			expected: 0,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			require.Equal(t, test.expected, reducedKeyLimit(test.numKeys, test.estimate))
+		})
+	}
+}
+
+type rangeProofCountingDB struct {
+	DB
+	numRangeProofs int
+}
+
+func (db *rangeProofCountingDB) GetRangeProofAtRoot(
+	ctx context.Context,
+	rootID ids.ID,
+	start maybe.Maybe[[]byte],
+	end maybe.Maybe[[]byte],
+	maxLength int,
+) (*merkledb.RangeProof, error) {
+	db.numRangeProofs++
+	return db.DB.GetRangeProofAtRoot(ctx, rootID, start, end, maxLength)
+}
+
+// Test that a range proof that is too large is only regenerated once.
+func TestGetRangeProofRegeneratedOnce(t *testing.T) {
+	require := require.New(t)
+	r := rand.New(rand.NewSource(0)) // #nosec G404
+
+	trieDB, _, err := generateTrieWithMinKeyLen(t, r, defaultRequestKeyLimit, 1)
+	require.NoError(err)
+	root, err := trieDB.GetMerkleRoot(context.Background())
+	require.NoError(err)
+
+	for _, bytesLimit := range []uint32{5 * 1024, 10 * 1024, 20 * 1024, 50 * 1024} {
+		db := &rangeProofCountingDB{DB: trieDB}
+		proofBytes, err := getRangeProof(
+			context.Background(),
+			db,
+			&pb.SyncGetRangeProofRequest{
+				RootHash:   root[:],
+				KeyLimit:   defaultRequestKeyLimit,
+				BytesLimit: bytesLimit,
+			},
+			func(rangeProof *pb.RangeProof) proto.Message {
+				return rangeProof
+			},
+		)
+		require.NoError(err)
+		require.Less(len(proofBytes), int(bytesLimit))
+		require.Equal(2, db.numRangeProofs)
+	}
+}
@@ -917,6 +917,147 @@ func Test_Sync_Result_Correct_Root_With_Sync_Restart(t *testing.T) {
 	require.Equal(syncRoot, newRoot)
 }
 
+func Test_Sync_Resume_From_Progress(t *testing.T) {
+	tests := []struct {
+		name         string
+		changeTarget bool
+	}{
+		{
+			name:         "same target",
+			changeTarget: false,
+		},
+		{
+			name:         "new target",
+			changeTarget: true,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			require := require.New(t)
+			ctrl := gomock.NewController(t)
+			now := time.Now().UnixNano()
+			t.Logf("seed: %d", now)
+			r := rand.New(rand.NewSource(now)) // #nosec G404
+
+			dbToSync, err := generateTrie(t, r, 3*maxKeyValuesLimit)
+			require.NoError(err)
+			firstSyncRoot, err := dbToSync.GetMerkleRoot(context.Background())
+			require.NoError(err)
+
+			client := NewMockClient(ctrl)
+			client.EXPECT().GetRangeProof(gomock.Any(), gomock.Any()).DoAndReturn(
+				func(ctx context.Context, request *pb.SyncGetRangeProofRequest) (*merkledb.RangeProof, error) {
+					root, err := ids.ToID(request.RootHash)
+					if err != nil {
+						return nil, err
+					}
+					return dbToSync.GetRangeProofAtRoot(ctx, root, maybeBytesToMaybe(request.StartKey), maybeBytesToMaybe(request.EndKey), int(request.KeyLimit))
+				},
+			).AnyTimes()
+			client.EXPECT().GetChangeProof(gomock.Any(), gomock.Any(), gomock.Any()).DoAndReturn(
+				func(ctx context.Context, request *pb.SyncGetChangeProofRequest, _ DB) (*merkledb.ChangeOrRangeProof, error) {
+					startRoot, err := ids.ToID(request.StartRootHash)
+					if err != nil {
+						return nil, err
+					}
+					endRoot, err := ids.ToID(request.EndRootHash)
+					if err != nil {
+						return nil, err
+					}
+					changeProof, err := dbToSync.GetChangeProof(ctx, startRoot, endRoot, maybeBytesToMaybe(request.StartKey), maybeBytesToMaybe(request.EndKey), int(request.KeyLimit))
+					if err != nil {
+						return nil, err
+					}
+					return &merkledb.ChangeOrRangeProof{
+						ChangeProof: changeProof,
+					}, nil
+				},
+			).AnyTimes()
+
+			db, err := merkledb.New(
+				context.Background(),
+				memdb.New(),
+				newDefaultDBConfig(),
+			)
+			require.NoError(err)
+			progressDB := memdb.New()
+
+			syncer, err := NewManager(ManagerConfig{
+				DB:                    db,
+				Client:                client,
+				TargetRoot:            firstSyncRoot,
+				SimultaneousWorkLimit: 5,
+				Log:                   logging.NoLog{},
+				BranchFactor:          merkledb.BranchFactor16,
+				ProgressDB:            progressDB,
+			})
+			require.NoError(err)
+			require.NoError(syncer.Start(context.Background()))
+
+			// Wait until we've processed some work before stopping.
+			require.Eventually(
+				func() bool {
+					syncer.workLock.Lock()
+					defer syncer.workLock.Unlock()
+
+					return syncer.processedWork.Len() > 0
+				},
+				5*time.Second,
+				5*time.Millisecond,
+			)
+			syncer.Close()
+
+			progress, err := getProgress(progressDB)
+			require.NoError(err)
+			require.Equal(firstSyncRoot, progress.targetRoot)
+			require.NotEmpty(progress.processedWork)
+
+			secondSyncRoot := firstSyncRoot
+			if test.changeTarget {
+				for i := 0; i < 100; i++ {
+					key := make([]byte, r.Intn(50))
+					_, _ = r.Read(key)
+					val := make([]byte, r.Intn(50))
+					_, _ = r.Read(val)
+					require.NoError(dbToSync.Put(key, val))
+				}
+				secondSyncRoot, err = dbToSync.GetMerkleRoot(context.Background())
+				require.NoError(err)
+			}
+
+			newSyncer, err := NewManager(ManagerConfig{
+				DB:                    db,
+				Client:                client,
+				TargetRoot:            secondSyncRoot,
+				SimultaneousWorkLimit: 5,
+				Log:                   logging.NoLog{},
+				BranchFactor:          merkledb.BranchFactor16,
+				ProgressDB:            progressDB,
+			})
+			require.NoError(err)
+
+			// The work done by the first syncer isn't redone unless the
+			// target changed.
+			if test.changeTarget {
+				require.Zero(newSyncer.processedWork.Len())
+			} else {
+				require.Equal(len(progress.processedWork), newSyncer.processedWork.Len())
+			}
+
+			require.NoError(newSyncer.Start(context.Background()))
+			require.NoError(newSyncer.Wait(context.Background()))
+
+			newRoot, err := db.GetMerkleRoot(context.Background())
+			require.NoError(err)
+			require.Equal(secondSyncRoot, newRoot)
+
+			// The progress is removed once the sync completes.
+			_, err = getProgress(progressDB)
+			require.ErrorIs(err, database.ErrNotFound)
+		})
+	}
+}
+
 func Test_Sync_Error_During_Sync(t *testing.T) {
 	require := require.New(t)
 	ctrl := gomock.NewController(t)
@@ -157,3 +157,13 @@ func (wh *workHeap) remove(item *workItem) {
 func (wh *workHeap) Len() int {
 	return wh.innerHeap.Len()
 }
+
+// Items returns the items in the heap sorted by range start.
+func (wh *workHeap) Items() []*workItem {
+	items := make([]*workItem, 0, wh.Len())
+	wh.sortedItems.Ascend(func(item *workItem) bool {
+		items = append(items, item)
+		return true
+	})
+	return items
+}